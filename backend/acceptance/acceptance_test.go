@@ -16,12 +16,29 @@ func TestUserSenarioes(t *testing.T) {
 	t.Cleanup(server.Close)
 	gin.SetMode(gin.TestMode)
 	
+	contract, err := loadContract(server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("Failed to load OpenAPI contract.\n%v", err)
+	}
+
 	apiDriver := apiDriverImpl{
-		client:  server.Client(),
-		baseURL: server.URL,
+		client:   server.Client(),
+		baseURL:  server.URL,
+		contract: contract,
 	}
 
 	userDsl := userDslImpl{apiDriver: apiDriver}
 	signUpSenario(t, userDsl)
 	loginSenario(t, userDsl)
+
+	t.Run("todos", func(t *testing.T) { todoSenario(t, apiDriver) })
+	t.Run("projects", func(t *testing.T) { projectSenario(t, apiDriver) })
+	t.Run("tags", func(t *testing.T) { tagSenario(t, apiDriver) })
+	t.Run("clarify", func(t *testing.T) { clarifySenario(t, apiDriver) })
+	t.Run("reviews", func(t *testing.T) { reviewSenario(t, apiDriver) })
+	t.Run("attention", func(t *testing.T) { attentionSenario(t, apiDriver) })
+	t.Run("next", func(t *testing.T) { nextSenario(t, apiDriver) })
+	t.Run("search", func(t *testing.T) { searchSenario(t, apiDriver) })
+	t.Run("activity", func(t *testing.T) { activitySenario(t, apiDriver) })
+	t.Run("sync", func(t *testing.T) { syncSenario(t, apiDriver) })
 }
@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+
+	"yangdongju/gtd_todo/internal/openapi"
 )
 type apiDriver interface {
 	call(method string, path string, payload any, headers map[string]string) (apiResponse, error)
 }
 type apiDriverImpl struct {
-	client  *http.Client
-	baseURL string
+	client   *http.Client
+	baseURL  string
+	contract *openapi.Validator
 }
 
 type apiResponse struct {
@@ -28,19 +33,26 @@ func (d apiDriverImpl) call(method string, path string, payload any, headers map
 		return apiResponse{}, err
 	}
 
-	json, err := parseToJSON(payload)
+	requestBody, err := parseToJSON(payload)
 	if err != nil {
 		return apiResponse{}, err
 	}
 
 	fullURL := buildFullURL(base, path)
+	parsedURL, err := url.Parse(fullURL)
+	if err != nil {
+		return apiResponse{}, err
+	}
+	if err := d.checkRequest(method, parsedURL, requestBody); err != nil {
+		return apiResponse{}, err
+	}
 
 	client := d.client
 	if client == nil {
 		client = http.DefaultClient
 	}
 
-	req, err := http.NewRequest(method, fullURL, json)
+	req, err := http.NewRequest(method, fullURL, bytes.NewReader(requestBody))
 	if err != nil {
 		return apiResponse{}, err
 	}
@@ -59,6 +71,10 @@ func (d apiDriverImpl) call(method string, path string, payload any, headers map
 		return apiResponse{}, err
 	}
 
+	if err := d.checkResponse(method, parsedURL, resp, respBody); err != nil {
+		return apiResponse{}, err
+	}
+
 	return apiResponse{
 		StatusCode: resp.StatusCode,
 		Body:       respBody,
@@ -66,6 +82,49 @@ func (d apiDriverImpl) call(method string, path string, payload any, headers map
 	}, nil
 }
 
+// checkRequest fails the call when the request itself breaks the OpenAPI contract,
+// so scenarios cannot rely on undocumented fields or parameters.
+func (d apiDriverImpl) checkRequest(method string, target *url.URL, body []byte) error {
+	if d.contract == nil {
+		return nil
+	}
+	violations := d.contract.ValidateRequest(method, target.Path, target.Query(), body)
+	return contractError("request", method, target.Path, violations)
+}
+
+func (d apiDriverImpl) checkResponse(method string, target *url.URL, resp *http.Response, body []byte) error {
+	if d.contract == nil {
+		return nil
+	}
+	violations := d.contract.ValidateResponse(method, target.Path, resp.StatusCode, resp.Header.Get("Content-Type"), body)
+	return contractError("response", method, target.Path, violations)
+}
+
+func contractError(kind string, method string, path string, violations []openapi.Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	return fmt.Errorf("%s of %s %s violates the API contract: %s", kind, method, path, strings.Join(messages, ", "))
+}
+
+func loadContract(client *http.Client, baseURL string) (*openapi.Validator, error) {
+	resp, err := client.Get(buildFullURL(baseURL, "/api/openapi.json"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var document openapi.Document
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return nil, err
+	}
+	return openapi.NewValidator(&document), nil
+}
+
 func requireBaseURL(baseURL string) (string, error) {
 	if baseURL == "" {
 		return "", errors.New("api driver base URL is empty")
@@ -73,17 +132,12 @@ func requireBaseURL(baseURL string) (string, error) {
 	return strings.TrimRight(baseURL, "/"), nil
 }
 
-func parseToJSON(payload any)  (io.Reader, error) {
+func parseToJSON(payload any) ([]byte, error) {
 	if payload == nil {
 		return nil, nil
 	}
 
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	return bytes.NewReader(data), nil
+	return json.Marshal(payload)
 }
 
 func buildFullURL(base, route string) string {
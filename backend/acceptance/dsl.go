@@ -50,3 +50,35 @@ func unmarshal[T any](apiResponse apiResponse, target *T) (T, error) {
 	}
 	return *target, nil
 }
+
+// sessionDsl calls the API as a signed-in user. ifMatch is sent as If-Match when set.
+type sessionDsl interface {
+	send(method string, path string, payload any, ifMatch string) (apiResponse, error)
+}
+
+type sessionDslImpl struct {
+	apiDriver apiDriver
+	token     string
+}
+
+func (s sessionDslImpl) send(method string, path string, payload any, ifMatch string) (apiResponse, error) {
+	headers := map[string]string{"Authorization": "Bearer " + s.token}
+	if ifMatch != "" {
+		headers["If-Match"] = ifMatch
+	}
+	return s.apiDriver.call(method, path, payload, headers)
+}
+
+// signIn signs a new user up and logs in as them.
+func signIn(apiDriver apiDriver, email string) (sessionDsl, error) {
+	users := userDslImpl{apiDriver: apiDriver}
+	credentials := user.SignUpRequest{Email: email, Password: "examplePasswords"}
+	if _, err := users.signUp(credentials); err != nil {
+		return nil, err
+	}
+	login, err := users.login(user.LoginRequest{Email: credentials.Email, Password: credentials.Password})
+	if err != nil {
+		return nil, err
+	}
+	return sessionDslImpl{apiDriver: apiDriver, token: login.Token}, nil
+}
@@ -0,0 +1,249 @@
+package acceptance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/attention"
+	"yangdongju/gtd_todo/internal/clarify"
+	"yangdongju/gtd_todo/internal/delta"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/next"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/review"
+	"yangdongju/gtd_todo/internal/search"
+	"yangdongju/gtd_todo/internal/tag"
+	"yangdongju/gtd_todo/internal/todo"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Each scenario below goes through one resource. The driver checks every request and
+// response against the OpenAPI contract, so a scenario fails on any undocumented field,
+// parameter or status as well as on its own assertions.
+
+func todoSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	report := expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Write report", Status: ptr(todo.StatusNextActions)}, "")
+	numbers := expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Gather numbers"}, "")
+	expect[todo.TodoResponse](t, session, http.StatusOK, http.MethodGet, todoPath(report.ID), nil, "")
+	list := expect[todo.TodoListResponse](t, session, http.StatusOK, http.MethodGet, "/api/todos?status=next_actions", nil, "")
+	renamed := expect[todo.TodoResponse](t, session, http.StatusOK, http.MethodPatch, todoPath(report.ID),
+		todo.UpdateTodoRequest{Title: ptr("Write the report")}, report.ETag())
+	withItem := expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, todoPath(report.ID)+"/checklist",
+		todo.AddChecklistItemRequest{Title: "Outline"}, "")
+	blocked := expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, todoPath(report.ID)+"/dependencies",
+		todo.AddDependencyRequest{BlockerID: numbers.ID}, "")
+	expect[todo.DependencyGraphResponse](t, session, http.StatusOK, http.MethodGet, todoPath(report.ID)+"/dependencies", nil, "")
+	done := expect[todo.StatusChangeResponse](t, session, http.StatusOK, http.MethodPatch, todoPath(numbers.ID)+"/status",
+		todo.ChangeStatusRequest{Status: todo.StatusDone}, numbers.ETag())
+	expect[todo.TodosByContextResponse](t, session, http.StatusOK, http.MethodGet, "/api/todos/by-context", nil, "")
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Quote for the roof", Status: ptr(todo.StatusWaitingFor), DelegatedTo: ptr("Sam")}, "")
+	waiting := expect[todo.WaitingListResponse](t, session, http.StatusOK, http.MethodGet, "/api/waiting", nil, "")
+	current := expect[todo.TodoResponse](t, session, http.StatusOK, http.MethodGet, todoPath(report.ID), nil, "")
+	expect[todo.DeleteTodoResponse](t, session, http.StatusOK, http.MethodDelete, todoPath(report.ID), nil, current.ETag())
+
+	assert.Equal(t, 1, list.Total)
+	assert.Equal(t, "Write the report", renamed.Title)
+	assert.Len(t, withItem.Checklist, 1)
+	assert.True(t, blocked.IsBlocked)
+	assert.Equal(t, todo.StatusDone, done.Todo.Status)
+	assert.False(t, current.IsBlocked)
+	assert.Equal(t, 1, waiting.Total)
+}
+
+func projectSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	work := expect[project.AreaResponse](t, session, http.StatusCreated, http.MethodPost, "/api/areas",
+		project.CreateAreaRequest{Name: "Work"}, "")
+	launch := expect[project.ProjectResponse](t, session, http.StatusCreated, http.MethodPost, "/api/projects",
+		project.CreateProjectRequest{Name: "Launch", AreaID: &work.ID}, "")
+	docs := expect[project.ProjectResponse](t, session, http.StatusCreated, http.MethodPost, "/api/projects",
+		project.CreateProjectRequest{Name: "Docs", ParentProjectID: &launch.ID}, "")
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Announce", ProjectID: &launch.ID}, "")
+	tree := expect[project.ProjectTreeResponse](t, session, http.StatusOK, http.MethodGet, "/api/projects/tree", nil, "")
+	expect[project.AreaTreeResponse](t, session, http.StatusOK, http.MethodGet, areaPath(work.ID), nil, "")
+	expect[project.AreaListResponse](t, session, http.StatusOK, http.MethodGet, "/api/areas", nil, "")
+	renamedArea := expect[project.AreaResponse](t, session, http.StatusOK, http.MethodPatch, areaPath(work.ID),
+		project.UpdateAreaRequest{Name: ptr("Job")}, work.ETag())
+	moved := expect[project.ProjectResponse](t, session, http.StatusOK, http.MethodPost, projectPath(docs.ID)+"/move",
+		project.MoveProjectRequest{}, docs.ETag())
+	expect[project.ProjectResponse](t, session, http.StatusOK, http.MethodGet, projectPath(launch.ID), nil, "")
+	refused := expect[project.ErrorResponse](t, session, http.StatusConflict, http.MethodPost, projectPath(launch.ID)+"/status",
+		project.ChangeProjectStatusRequest{Status: project.StatusDone}, launch.ETag())
+	completed := expect[project.ProjectStatusChangeResponse](t, session, http.StatusOK, http.MethodPost, projectPath(launch.ID)+"/status",
+		project.ChangeProjectStatusRequest{Status: project.StatusDone, Force: true}, launch.ETag())
+	expect[project.ProjectStatusChangeResponse](t, session, http.StatusOK, http.MethodPost, projectPath(launch.ID)+"/status",
+		project.ChangeProjectStatusRequest{Status: project.StatusArchived}, completed.ETag())
+	active := expect[project.ProjectListResponse](t, session, http.StatusOK, http.MethodGet, "/api/projects", nil, "")
+	archived := expect[project.ProjectListResponse](t, session, http.StatusOK, http.MethodGet, "/api/projects?status=archived", nil, "")
+	archive := expect[project.ArchivedProjectListResponse](t, session, http.StatusOK, http.MethodGet, "/api/projects/archive", nil, "")
+	expect[project.DeleteAreaResponse](t, session, http.StatusOK, http.MethodDelete, areaPath(work.ID), nil, renamedArea.ETag())
+	expect[project.DeleteProjectResponse](t, session, http.StatusOK, http.MethodDelete, projectPath(docs.ID), nil, moved.ETag())
+
+	assert.Len(t, tree.Areas, 1)
+	assert.Len(t, tree.Areas[0].Projects[0].Children, 1)
+	assert.Nil(t, moved.AreaID)
+	assert.Contains(t, refused.Error, "1 open todos")
+	assert.Len(t, completed.Completed, 1)
+	assert.Equal(t, 1, active.Total)
+	assert.Equal(t, 1, archived.Total)
+	assert.Len(t, archive.Projects, 1)
+	assert.NotNil(t, archive.Projects[0].Project.CompletedAt)
+}
+
+func tagSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	home := expect[tag.TagResponse](t, session, http.StatusCreated, http.MethodPost, "/api/tags",
+		tag.CreateTagRequest{Name: "@home", Kind: ptr("context")}, "")
+	house := expect[tag.TagResponse](t, session, http.StatusCreated, http.MethodPost, "/api/tags",
+		tag.CreateTagRequest{Name: "@house", Kind: ptr("context")}, "")
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Fix the tap", TagIDs: []int{house.ID}}, "")
+	expect[tag.TagListResponse](t, session, http.StatusOK, http.MethodGet, "/api/tags?kind=context", nil, "")
+	recolored := expect[tag.TagResponse](t, session, http.StatusOK, http.MethodPatch, tagPath(home.ID),
+		tag.UpdateTagRequest{Color: ptr("#10B981")}, home.ETag())
+	merged := expect[tag.TagResponse](t, session, http.StatusOK, http.MethodPost, tagPath(house.ID)+"/merge",
+		tag.MergeTagRequest{IntoID: home.ID}, house.ETag())
+	expect[tag.DeleteTagResponse](t, session, http.StatusOK, http.MethodDelete, tagPath(home.ID), nil, merged.ETag())
+
+	assert.Equal(t, "#10B981", recolored.Color)
+	assert.Equal(t, 1, merged.TodoCount)
+}
+
+func clarifySenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	idea := expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Learn to sail"}, "")
+	clarified := expect[clarify.ClarifyResponse](t, session, http.StatusOK, http.MethodPost, todoPath(idea.ID)+"/clarify",
+		clarify.ClarifyRequest{Decision: "someday"}, idea.ETag())
+
+	assert.Equal(t, todo.StatusSomeday, clarified.Todo.Status)
+}
+
+func reviewSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	idea := expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Call the bank"}, "")
+	started := expect[review.ReviewResponse](t, session, http.StatusCreated, http.MethodPost, "/api/reviews",
+		review.StartReviewRequest{Kind: ptr("weekly")}, "")
+	step := expect[review.StepResponse](t, session, http.StatusOK, http.MethodGet, reviewPath(started.ID)+"/steps/inbox", nil, "")
+	expect[review.DecideResponse](t, session, http.StatusOK, http.MethodPost, reviewPath(started.ID)+"/steps/inbox/decisions",
+		review.DecideRequest{ItemType: "todo", ItemID: idea.ID, Decision: "keep"}, "")
+	advanced := expect[review.ReviewResponse](t, session, http.StatusOK, http.MethodPost, reviewPath(started.ID)+"/advance", nil, "")
+	detail := expect[review.ReviewDetailResponse](t, session, http.StatusOK, http.MethodGet, reviewPath(started.ID), nil, "")
+	expect[review.ReviewListResponse](t, session, http.StatusOK, http.MethodGet, "/api/reviews", nil, "")
+
+	assert.Len(t, step.Items, 1)
+	assert.Equal(t, "next_actions", advanced.Step)
+	assert.Len(t, detail.Decisions, 1)
+}
+
+func attentionSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Sort the mail"}, "")
+	expect[attention.AttentionResponse](t, session, http.StatusOK, http.MethodGet, "/api/attention", nil, "")
+	expect[attention.SettingsResponse](t, session, http.StatusOK, http.MethodGet, "/api/attention/settings", nil, "")
+	settings := expect[attention.SettingsResponse](t, session, http.StatusOK, http.MethodPatch, "/api/attention/settings",
+		attention.UpdateSettingsRequest{InboxDays: ptr(3), Digest: ptr("daily")}, "")
+
+	assert.Equal(t, 3, settings.InboxDays)
+}
+
+func nextSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Reply to Ana", Status: ptr(todo.StatusNextActions), EstimateMinutes: ptr(10), Energy: ptr("low")}, "")
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Plan the quarter", Status: ptr(todo.StatusNextActions), EstimateMinutes: ptr(120)}, "")
+	suggestions := expect[next.NextResponse](t, session, http.StatusOK, http.MethodGet, "/api/next?minutes=30&energy=low", nil, "")
+
+	assert.Equal(t, 1, suggestions.Total)
+}
+
+func searchSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Renew passport"}, "")
+	found := expect[search.SearchResponse](t, session, http.StatusOK, http.MethodGet, "/api/search?q=passport", nil, "")
+
+	assert.Len(t, found.Results, 1)
+}
+
+func activitySenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	expect[todo.TodoResponse](t, session, http.StatusCreated, http.MethodPost, "/api/todos",
+		todo.CreateTodoRequest{Title: "Water plants"}, "")
+	activity := expect[event.ActivityListResponse](t, session, http.StatusOK, http.MethodGet, "/api/activity?type=todo.created", nil, "")
+
+	assert.Equal(t, 1, activity.Total)
+}
+
+func syncSenario(t *testing.T, apiDriver apiDriver) {
+	testhelper.CleanUp()
+	session := newSession(t, apiDriver)
+
+	pushed := expect[delta.PushResponse](t, session, http.StatusOK, http.MethodPost, "/api/sync",
+		delta.PushRequest{Mutations: []delta.Mutation{{
+			Entity: "todo", Op: "create", ClientID: ptr("3f6c2a9e-8d41-4b7a-9c55-1e2f3a4b5c6d"),
+			Data: json.RawMessage(`{"title":"Written offline"}`),
+		}}}, "")
+	pulled := expect[delta.PullResponse](t, session, http.StatusOK, http.MethodGet, "/api/sync?since=0", nil, "")
+
+	assert.Len(t, pushed.Results, 1)
+	assert.Len(t, pulled.Todos, 1)
+}
+
+// expect sends the request, requires the status and decodes the body.
+func expect[T any](t *testing.T, session sessionDsl, status int, method string, path string, payload any, ifMatch string) T {
+	t.Helper()
+	res, err := session.send(method, path, payload, ifMatch)
+	require.NoError(t, err)
+	require.Equal(t, status, res.StatusCode, "%s %s: %s", method, path, res.Body)
+	body, err := unmarshal(res, new(T))
+	require.NoError(t, err)
+	return body
+}
+
+func newSession(t *testing.T, apiDriver apiDriver) sessionDsl {
+	t.Helper()
+	session, err := signIn(apiDriver, "example@test.com")
+	require.NoError(t, err)
+	return session
+}
+
+func todoPath(id int) string    { return fmt.Sprintf("/api/todos/%d", id) }
+func projectPath(id int) string { return fmt.Sprintf("/api/projects/%d", id) }
+func areaPath(id int) string    { return fmt.Sprintf("/api/areas/%d", id) }
+func tagPath(id int) string     { return fmt.Sprintf("/api/tags/%d", id) }
+func reviewPath(id int) string  { return fmt.Sprintf("/api/reviews/%d", id) }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -29,7 +29,7 @@ func loginSenario(t *testing.T, userDsl userDsl) {
 	}
 	_,_ = userDsl.signUp(signUpRequest)
 
-	loginRequest := user.LoginRequest(signUpRequest)
+	loginRequest := user.LoginRequest{Email: signUpRequest.Email, Password: signUpRequest.Password}
 	loginResponse, err := userDsl.login(loginRequest)
 
 	assert.NoError(t, err)
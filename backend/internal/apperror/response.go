@@ -0,0 +1,17 @@
+package apperror
+
+// ErrorResponse is the error envelope shared by every endpoint.
+type ErrorResponse struct {
+	Error      string      `json:"error"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Violation points at the part of a request that broke the API contract.
+type Violation struct {
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return v.Location + ": " + v.Message
+}
@@ -0,0 +1,152 @@
+package attention
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+)
+
+const (
+	DefaultDigestInterval = 5 * time.Minute
+	digestBatchSize       = 20
+	notifyTimeout         = 10 * time.Second
+)
+
+// Notifier delivers one digest. Key is the same every time the same digest is handed
+// over, so receivers can drop a resend after a crash between delivery and bookkeeping.
+type Notifier interface {
+	Notify(ctx context.Context, digest Digest) error
+}
+
+// Digest is what needed a user's attention when their digest came due.
+type Digest struct {
+	UserID      int
+	Email       string
+	Timezone    string
+	Items       []Item
+	ScheduledAt time.Time
+	GeneratedAt time.Time
+}
+
+func (d Digest) Key() string {
+	return fmt.Sprintf("digest-%d-%d", d.UserID, d.ScheduledAt.Unix())
+}
+
+// Digester sends due digests through a Notifier. Every instance of the server runs one;
+// row locks decide which instance sends a digest.
+type Digester struct {
+	repository AttentionRepository
+	txManager  database.TxManager
+	notifier   Notifier
+	now        func() time.Time
+	interval   time.Duration
+}
+
+func NewDigester(repository AttentionRepository, txManager database.TxManager, notifier Notifier, now func() time.Time, interval time.Duration) *Digester {
+	return &Digester{
+		repository: repository,
+		txManager:  txManager,
+		notifier:   notifier,
+		now:        now,
+		interval:   interval,
+	}
+}
+
+// Run sends due digests every interval until ctx is done.
+func (d *Digester) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.RunOnce(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Attention digest failed. %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce sends the digests due now and reports how many were sent. Each batch is
+// claimed, built and moved on to the next digest in one short transaction, and delivered
+// only after it commits, so no lock is held while a notifier waits. A digest is a
+// snapshot, so unlike a reminder one that fails is not retried: the next one carries
+// whatever still needs attention. A digest with nothing in it is not sent at all.
+func (d *Digester) RunOnce(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		var claimed int
+		var digests []Digest
+		err := d.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			now := d.now()
+			recipients, err := d.repository.ClaimDueDigests(ctx, now, digestBatchSize)
+			if err != nil {
+				return err
+			}
+			claimed, digests = len(recipients), nil
+			for _, recipient := range recipients {
+				digest, err := d.build(ctx, recipient, now)
+				if err != nil {
+					return err
+				}
+				if digest != nil {
+					digests = append(digests, *digest)
+				}
+				next := NextDigestAt(recipient.Digest, recipient.DigestHour, location(recipient.Timezone), now)
+				if err := d.repository.ScheduleNextDigest(ctx, recipient.UserID, next); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return sent, err
+		}
+
+		for _, digest := range digests {
+			if !d.deliver(ctx, digest) {
+				continue
+			}
+			if err := d.repository.MarkDigestSent(ctx, digest.UserID, digest.GeneratedAt); err != nil {
+				return sent, err
+			}
+			sent++
+		}
+		if claimed < digestBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// build returns the recipient's digest, or nil when nothing needs their attention.
+func (d *Digester) build(ctx context.Context, recipient Recipient, now time.Time) (*Digest, error) {
+	candidates, err := d.repository.FindCandidates(ctx, recipient.UserID, recipient.Cutoffs(now))
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	return &Digest{
+		UserID:      recipient.UserID,
+		Email:       recipient.Email,
+		Timezone:    recipient.Timezone,
+		Items:       Score(candidates, recipient.Settings, now),
+		ScheduledAt: *recipient.NextDigestAt,
+		GeneratedAt: now,
+	}, nil
+}
+
+// deliver reports whether the digest went out. A failed delivery is logged and skipped.
+func (d *Digester) deliver(ctx context.Context, digest Digest) bool {
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	if err := d.notifier.Notify(ctx, digest); err != nil {
+		log.Printf("Attention digest not delivered. user_id=%v err=%v", digest.UserID, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package attention_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/attention"
+	attentionmocks "yangdongju/gtd_todo/internal/attention/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func dailyRecipient(userID int) attention.Recipient {
+	settings := attention.DefaultSettings(userID)
+	settings.Digest = attention.DigestDaily
+	settings.NextDigestAt = ptr(now.Add(-time.Hour))
+	return attention.Recipient{Settings: settings, Email: "hello@example.com", Timezone: "UTC"}
+}
+
+// ============ Test Cases ============
+
+func TestDigesterRunOnce_SendsScoredItemsAndSchedulesTheNextDigest(t *testing.T) {
+	// given
+	recipient := dailyRecipient(7)
+	mockRepo := attentionmocks.NewAttentionRepository(t)
+	mockRepo.EXPECT().ClaimDueDigests(mock.Anything, now, mock.Anything).Return([]attention.Recipient{recipient}, nil)
+	mockRepo.EXPECT().FindCandidates(mock.Anything, 7, recipient.Cutoffs(now)).Return([]attention.Candidate{
+		{Reason: attention.ReasonAgingInbox, ItemType: attention.ItemTodo, ItemID: 4, Title: "Call the plumber", IdleSince: daysAgo(3)},
+	}, nil)
+	next := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().ScheduleNextDigest(mock.Anything, 7, &next).Return(nil)
+	mockRepo.EXPECT().MarkDigestSent(mock.Anything, 7, now).Return(nil)
+
+	notifier := attention.NewMemoryNotifier()
+	digester := attention.NewDigester(mockRepo, passThroughTxManager{}, notifier, fixedNow, time.Minute)
+
+	// when
+	sent, err := digester.RunOnce(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	digests := notifier.Sent()
+	assert.Len(t, digests, 1)
+	assert.Equal(t, "hello@example.com", digests[0].Email)
+	assert.Equal(t, 4, digests[0].Items[0].ItemID)
+	assert.Equal(t, "digest-7-1792396800", digests[0].Key())
+}
+
+func TestDigesterRunOnce_NothingToSayStillSchedulesTheNextDigest(t *testing.T) {
+	// given
+	mockRepo := attentionmocks.NewAttentionRepository(t)
+	mockRepo.EXPECT().ClaimDueDigests(mock.Anything, now, mock.Anything).Return([]attention.Recipient{dailyRecipient(7)}, nil)
+	mockRepo.EXPECT().FindCandidates(mock.Anything, 7, mock.Anything).Return([]attention.Candidate{}, nil)
+	mockRepo.EXPECT().ScheduleNextDigest(mock.Anything, 7, mock.Anything).Return(nil)
+
+	notifier := attention.NewMemoryNotifier()
+	digester := attention.NewDigester(mockRepo, passThroughTxManager{}, notifier, fixedNow, time.Minute)
+
+	// when
+	sent, err := digester.RunOnce(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, notifier.Sent())
+}
+
+func TestDigesterRunOnce_FailedDeliveryIsSkippedNotRetried(t *testing.T) {
+	// given
+	mockRepo := attentionmocks.NewAttentionRepository(t)
+	mockRepo.EXPECT().ClaimDueDigests(mock.Anything, now, mock.Anything).Return([]attention.Recipient{dailyRecipient(7)}, nil).Once()
+	mockRepo.EXPECT().FindCandidates(mock.Anything, 7, mock.Anything).Return([]attention.Candidate{
+		{Reason: attention.ReasonAgingInbox, ItemType: attention.ItemTodo, ItemID: 4, IdleSince: daysAgo(3)},
+	}, nil)
+	mockRepo.EXPECT().ScheduleNextDigest(mock.Anything, 7, mock.Anything).Return(nil)
+
+	notifier := attention.NewMemoryNotifier()
+	notifier.FailWith(errors.New("webhook answered 503"))
+	digester := attention.NewDigester(mockRepo, passThroughTxManager{}, notifier, fixedNow, time.Minute)
+
+	// when
+	sent, err := digester.RunOnce(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
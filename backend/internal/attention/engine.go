@@ -0,0 +1,134 @@
+package attention
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	ReasonAgingInbox               = "aging_inbox"
+	ReasonStalledInProgress        = "stalled_in_progress"
+	ReasonWaitingWithoutFollowUp   = "waiting_without_follow_up"
+	ReasonProjectWithoutNextAction = "project_without_next_action"
+)
+
+const (
+	ItemTodo    = "todo"
+	ItemProject = "project"
+)
+
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+const day = 24 * time.Hour
+
+// DefaultSettings are what a user gets until they change something. They match the
+// column defaults of attention_settings.
+func DefaultSettings(userID int) Settings {
+	return Settings{
+		UserID:         userID,
+		InboxDays:      2,
+		InProgressDays: 7,
+		WaitingForDays: 7,
+		ProjectDays:    7,
+		Digest:         DigestOff,
+		DigestHour:     8,
+	}
+}
+
+// Cutoffs places the settings' thresholds before now.
+func (s Settings) Cutoffs(now time.Time) Cutoffs {
+	return Cutoffs{
+		Inbox:      now.Add(-time.Duration(s.InboxDays) * day),
+		InProgress: now.Add(-time.Duration(s.InProgressDays) * day),
+		WaitingFor: now.Add(-time.Duration(s.WaitingForDays) * day),
+		Project:    now.Add(-time.Duration(s.ProjectDays) * day),
+	}
+}
+
+func (s Settings) threshold(reason string) int {
+	switch reason {
+	case ReasonAgingInbox:
+		return s.InboxDays
+	case ReasonStalledInProgress:
+		return s.InProgressDays
+	case ReasonWaitingWithoutFollowUp:
+		return s.WaitingForDays
+	default:
+		return s.ProjectDays
+	}
+}
+
+// Item is a scored candidate. Score is how many thresholds the item has been idle for,
+// so anything listed scores at least 1 and an item idle for twice its threshold scores 2.
+type Item struct {
+	Reason        string    `json:"reason"`
+	ItemType      string    `json:"item_type"`
+	ItemID        int       `json:"item_id"`
+	Title         string    `json:"title"`
+	ProjectID     *int      `json:"project_id"`
+	IdleSince     time.Time `json:"idle_since"`
+	IdleDays      int       `json:"idle_days"`
+	ThresholdDays int       `json:"threshold_days"`
+	Score         float64   `json:"score"`
+}
+
+// Score ranks candidates by score, highest first. Ties go to the item idle the longest.
+// Scores are compared across reasons, which is what lets one threshold per reason set
+// how urgent its items are relative to the others.
+func Score(candidates []Candidate, settings Settings, now time.Time) []Item {
+	items := make([]Item, 0, len(candidates))
+	for _, c := range candidates {
+		threshold := settings.threshold(c.Reason)
+		idle := now.Sub(c.IdleSince)
+		items = append(items, Item{
+			Reason:        c.Reason,
+			ItemType:      c.ItemType,
+			ItemID:        c.ItemID,
+			Title:         c.Title,
+			ProjectID:     c.ProjectID,
+			IdleSince:     c.IdleSince,
+			IdleDays:      int(idle / day),
+			ThresholdDays: threshold,
+			Score:         math.Round(idle.Hours()/float64(threshold*24)*100) / 100,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.IdleSince.Equal(b.IdleSince) {
+			return a.IdleSince.Before(b.IdleSince)
+		}
+		if a.ItemType != b.ItemType {
+			return a.ItemType < b.ItemType
+		}
+		return a.ItemID < b.ItemID
+	})
+	return items
+}
+
+// NextDigestAt returns the first digest time after now: the digest hour of the next day,
+// or of the next Monday for a weekly digest, on the user's clock. It is nil when the
+// digest is off.
+func NextDigestAt(digest string, hour int, loc *time.Location, now time.Time) *time.Time {
+	if digest == DigestOff {
+		return nil
+	}
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, loc)
+	step := 1
+	if digest == DigestWeekly {
+		step = 7
+		next = next.AddDate(0, 0, (int(time.Monday)-int(next.Weekday())+7)%7)
+	}
+	for !next.After(now) {
+		next = next.AddDate(0, 0, step)
+	}
+	return &next
+}
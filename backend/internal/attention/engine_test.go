@@ -0,0 +1,119 @@
+package attention_test
+
+import (
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/attention"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+func fixedNow() time.Time {
+	return now
+}
+
+func daysAgo(days float64) time.Time {
+	return now.Add(-time.Duration(days * float64(24*time.Hour)))
+}
+
+// ============ Test Cases ============
+
+func TestScore_RanksByHowManyThresholdsAnItemIsPast(t *testing.T) {
+	// given
+	settings := attention.DefaultSettings(7)
+	candidates := []attention.Candidate{
+		{Reason: attention.ReasonStalledInProgress, ItemType: attention.ItemTodo, ItemID: 1, Title: "Draft report", IdleSince: daysAgo(10.5)},
+		{Reason: attention.ReasonAgingInbox, ItemType: attention.ItemTodo, ItemID: 2, Title: "Call the plumber", IdleSince: daysAgo(4)},
+		{Reason: attention.ReasonProjectWithoutNextAction, ItemType: attention.ItemProject, ItemID: 3, Title: "Moving", IdleSince: daysAgo(14)},
+	}
+
+	// when
+	items := attention.Score(candidates, settings, now)
+
+	// then
+	assert.Equal(t, []int{3, 2, 1}, []int{items[0].ItemID, items[1].ItemID, items[2].ItemID})
+	assert.Equal(t, 2.0, items[0].Score)
+	assert.Equal(t, 2.0, items[1].Score)
+	assert.Equal(t, 1.5, items[2].Score)
+	assert.Equal(t, 10, items[2].IdleDays)
+	assert.Equal(t, 7, items[2].ThresholdDays)
+}
+
+func TestScore_UsesTheUsersThresholds(t *testing.T) {
+	// given
+	settings := attention.DefaultSettings(7)
+	settings.WaitingForDays = 2
+	candidates := []attention.Candidate{
+		{Reason: attention.ReasonStalledInProgress, ItemType: attention.ItemTodo, ItemID: 1, IdleSince: daysAgo(8)},
+		{Reason: attention.ReasonWaitingWithoutFollowUp, ItemType: attention.ItemTodo, ItemID: 2, IdleSince: daysAgo(3)},
+	}
+
+	// when
+	items := attention.Score(candidates, settings, now)
+
+	// then
+	assert.Equal(t, 2, items[0].ItemID)
+	assert.Equal(t, 1.5, items[0].Score)
+	assert.Equal(t, 1.14, items[1].Score)
+}
+
+func TestNextDigestAt(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	tests := []struct {
+		name     string
+		digest   string
+		hour     int
+		loc      *time.Location
+		now      time.Time
+		expected *time.Time
+	}{
+		{
+			name: "off", digest: attention.DigestOff, hour: 8, loc: time.UTC, now: now,
+			expected: nil,
+		},
+		{
+			name: "daily, later today", digest: attention.DigestDaily, hour: 18, loc: time.UTC, now: now,
+			expected: ptr(time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "daily, on the user's clock", digest: attention.DigestDaily, hour: 8, loc: seoul, now: now,
+			expected: ptr(time.Date(2026, 10, 20, 8, 0, 0, 0, seoul)),
+		},
+		{
+			name: "daily, across the end of daylight saving time", digest: attention.DigestDaily, hour: 8, loc: newYork,
+			now:      time.Date(2026, 10, 31, 15, 0, 0, 0, time.UTC),
+			expected: ptr(time.Date(2026, 11, 1, 13, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "weekly, later this Monday", digest: attention.DigestWeekly, hour: 10, loc: time.UTC, now: now,
+			expected: ptr(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "weekly, this Monday's hour has passed", digest: attention.DigestWeekly, hour: 8, loc: time.UTC, now: now,
+			expected: ptr(time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "weekly, from midweek", digest: attention.DigestWeekly, hour: 8, loc: time.UTC,
+			now:      time.Date(2026, 10, 22, 12, 0, 0, 0, time.UTC),
+			expected: ptr(time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			next := attention.NextDigestAt(tt.digest, tt.hour, tt.loc, tt.now)
+
+			// then
+			if tt.expected == nil {
+				assert.Nil(t, next)
+				return
+			}
+			assert.True(t, tt.expected.Equal(*next), "expected %v, got %v", tt.expected, next)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package attention
+
+import (
+	"context"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+)
+
+type AttentionHandler struct {
+	attentionUsecase AttentionUsecase
+}
+
+func NewAttentionHandler(attentionUsecase AttentionUsecase) *AttentionHandler {
+	return &AttentionHandler{attentionUsecase: attentionUsecase}
+}
+
+func (h *AttentionHandler) HandleList(ctx context.Context, req ListAttentionRequest) (int, any) {
+	res, err := h.attentionUsecase.List(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *AttentionHandler) HandleGetSettings(ctx context.Context, req GetSettingsRequest) (int, any) {
+	res, err := h.attentionUsecase.GetSettings(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *AttentionHandler) HandleUpdateSettings(ctx context.Context, req UpdateSettingsRequest) (int, any) {
+	res, err := h.attentionUsecase.UpdateSettings(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError has nothing to map: requests are validated on binding and every user has
+// settings, saved or default.
+func handleError(err error) (int, any) {
+	return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+}
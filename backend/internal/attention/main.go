@@ -0,0 +1,21 @@
+//go:generate mockery
+package attention
+
+import (
+	"database/sql"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func InitializeHandler(pool *sqlx.DB) *AttentionHandler {
+	return NewAttentionHandler(NewAttentionService(NewAttentionRepository(pool), database.NewTxManager(pool), time.Now))
+}
+
+// InitializeDigester claims digests under read committed, where SKIP LOCKED keeps instances
+// apart. Digests are delivered after the claim commits, so a retried claim sends nothing twice.
+func InitializeDigester(pool *sqlx.DB, notifier Notifier, interval time.Duration) *Digester {
+	txManager := database.NewTxManager(pool, database.WithIsolation(sql.LevelReadCommitted))
+	return NewDigester(NewAttentionRepository(pool), txManager, notifier, time.Now, interval)
+}
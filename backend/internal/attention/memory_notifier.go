@@ -0,0 +1,41 @@
+package attention
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryNotifier records digests instead of delivering them. Tests use it to see what
+// would have been sent.
+type MemoryNotifier struct {
+	mu   sync.Mutex
+	sent []Digest
+	err  error
+}
+
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) Notify(ctx context.Context, digest Digest) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, digest)
+	return nil
+}
+
+// FailWith makes every following Notify return err, or succeed again when err is nil.
+func (n *MemoryNotifier) FailWith(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.err = err
+}
+
+func (n *MemoryNotifier) Sent() []Digest {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Digest(nil), n.sent...)
+}
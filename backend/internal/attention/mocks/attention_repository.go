@@ -0,0 +1,430 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package attentionmocks
+
+import (
+	context "context"
+	attention "yangdongju/gtd_todo/internal/attention"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AttentionRepository is an autogenerated mock type for the AttentionRepository type
+type AttentionRepository struct {
+	mock.Mock
+}
+
+type AttentionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AttentionRepository) EXPECT() *AttentionRepository_Expecter {
+	return &AttentionRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDueDigests provides a mock function with given fields: ctx, now, limit
+func (_m *AttentionRepository) ClaimDueDigests(ctx context.Context, now time.Time, limit int) ([]attention.Recipient, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDigests")
+	}
+
+	var r0 []attention.Recipient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]attention.Recipient, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []attention.Recipient); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]attention.Recipient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionRepository_ClaimDueDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueDigests'
+type AttentionRepository_ClaimDueDigests_Call struct {
+	*mock.Call
+}
+
+// ClaimDueDigests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *AttentionRepository_Expecter) ClaimDueDigests(ctx interface{}, now interface{}, limit interface{}) *AttentionRepository_ClaimDueDigests_Call {
+	return &AttentionRepository_ClaimDueDigests_Call{Call: _e.mock.On("ClaimDueDigests", ctx, now, limit)}
+}
+
+func (_c *AttentionRepository_ClaimDueDigests_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *AttentionRepository_ClaimDueDigests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *AttentionRepository_ClaimDueDigests_Call) Return(_a0 []attention.Recipient, _a1 error) *AttentionRepository_ClaimDueDigests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionRepository_ClaimDueDigests_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]attention.Recipient, error)) *AttentionRepository_ClaimDueDigests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindCandidates provides a mock function with given fields: ctx, userID, cutoffs
+func (_m *AttentionRepository) FindCandidates(ctx context.Context, userID int, cutoffs attention.Cutoffs) ([]attention.Candidate, error) {
+	ret := _m.Called(ctx, userID, cutoffs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCandidates")
+	}
+
+	var r0 []attention.Candidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, attention.Cutoffs) ([]attention.Candidate, error)); ok {
+		return rf(ctx, userID, cutoffs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, attention.Cutoffs) []attention.Candidate); ok {
+		r0 = rf(ctx, userID, cutoffs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]attention.Candidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, attention.Cutoffs) error); ok {
+		r1 = rf(ctx, userID, cutoffs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionRepository_FindCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindCandidates'
+type AttentionRepository_FindCandidates_Call struct {
+	*mock.Call
+}
+
+// FindCandidates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - cutoffs attention.Cutoffs
+func (_e *AttentionRepository_Expecter) FindCandidates(ctx interface{}, userID interface{}, cutoffs interface{}) *AttentionRepository_FindCandidates_Call {
+	return &AttentionRepository_FindCandidates_Call{Call: _e.mock.On("FindCandidates", ctx, userID, cutoffs)}
+}
+
+func (_c *AttentionRepository_FindCandidates_Call) Run(run func(ctx context.Context, userID int, cutoffs attention.Cutoffs)) *AttentionRepository_FindCandidates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(attention.Cutoffs))
+	})
+	return _c
+}
+
+func (_c *AttentionRepository_FindCandidates_Call) Return(_a0 []attention.Candidate, _a1 error) *AttentionRepository_FindCandidates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionRepository_FindCandidates_Call) RunAndReturn(run func(context.Context, int, attention.Cutoffs) ([]attention.Candidate, error)) *AttentionRepository_FindCandidates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSettings provides a mock function with given fields: ctx, userID
+func (_m *AttentionRepository) FindSettings(ctx context.Context, userID int) (*attention.Settings, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSettings")
+	}
+
+	var r0 *attention.Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*attention.Settings, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *attention.Settings); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attention.Settings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionRepository_FindSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSettings'
+type AttentionRepository_FindSettings_Call struct {
+	*mock.Call
+}
+
+// FindSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *AttentionRepository_Expecter) FindSettings(ctx interface{}, userID interface{}) *AttentionRepository_FindSettings_Call {
+	return &AttentionRepository_FindSettings_Call{Call: _e.mock.On("FindSettings", ctx, userID)}
+}
+
+func (_c *AttentionRepository_FindSettings_Call) Run(run func(ctx context.Context, userID int)) *AttentionRepository_FindSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *AttentionRepository_FindSettings_Call) Return(_a0 *attention.Settings, _a1 error) *AttentionRepository_FindSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionRepository_FindSettings_Call) RunAndReturn(run func(context.Context, int) (*attention.Settings, error)) *AttentionRepository_FindSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDigestSent provides a mock function with given fields: ctx, userID, sentAt
+func (_m *AttentionRepository) MarkDigestSent(ctx context.Context, userID int, sentAt time.Time) error {
+	ret := _m.Called(ctx, userID, sentAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDigestSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, userID, sentAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttentionRepository_MarkDigestSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDigestSent'
+type AttentionRepository_MarkDigestSent_Call struct {
+	*mock.Call
+}
+
+// MarkDigestSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - sentAt time.Time
+func (_e *AttentionRepository_Expecter) MarkDigestSent(ctx interface{}, userID interface{}, sentAt interface{}) *AttentionRepository_MarkDigestSent_Call {
+	return &AttentionRepository_MarkDigestSent_Call{Call: _e.mock.On("MarkDigestSent", ctx, userID, sentAt)}
+}
+
+func (_c *AttentionRepository_MarkDigestSent_Call) Run(run func(ctx context.Context, userID int, sentAt time.Time)) *AttentionRepository_MarkDigestSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AttentionRepository_MarkDigestSent_Call) Return(_a0 error) *AttentionRepository_MarkDigestSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttentionRepository_MarkDigestSent_Call) RunAndReturn(run func(context.Context, int, time.Time) error) *AttentionRepository_MarkDigestSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSettings provides a mock function with given fields: ctx, settings
+func (_m *AttentionRepository) SaveSettings(ctx context.Context, settings *attention.Settings) (*attention.Settings, error) {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSettings")
+	}
+
+	var r0 *attention.Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *attention.Settings) (*attention.Settings, error)); ok {
+		return rf(ctx, settings)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *attention.Settings) *attention.Settings); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attention.Settings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *attention.Settings) error); ok {
+		r1 = rf(ctx, settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionRepository_SaveSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSettings'
+type AttentionRepository_SaveSettings_Call struct {
+	*mock.Call
+}
+
+// SaveSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - settings *attention.Settings
+func (_e *AttentionRepository_Expecter) SaveSettings(ctx interface{}, settings interface{}) *AttentionRepository_SaveSettings_Call {
+	return &AttentionRepository_SaveSettings_Call{Call: _e.mock.On("SaveSettings", ctx, settings)}
+}
+
+func (_c *AttentionRepository_SaveSettings_Call) Run(run func(ctx context.Context, settings *attention.Settings)) *AttentionRepository_SaveSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*attention.Settings))
+	})
+	return _c
+}
+
+func (_c *AttentionRepository_SaveSettings_Call) Return(_a0 *attention.Settings, _a1 error) *AttentionRepository_SaveSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionRepository_SaveSettings_Call) RunAndReturn(run func(context.Context, *attention.Settings) (*attention.Settings, error)) *AttentionRepository_SaveSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleNextDigest provides a mock function with given fields: ctx, userID, next
+func (_m *AttentionRepository) ScheduleNextDigest(ctx context.Context, userID int, next *time.Time) error {
+	ret := _m.Called(ctx, userID, next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleNextDigest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time) error); ok {
+		r0 = rf(ctx, userID, next)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttentionRepository_ScheduleNextDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleNextDigest'
+type AttentionRepository_ScheduleNextDigest_Call struct {
+	*mock.Call
+}
+
+// ScheduleNextDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - next *time.Time
+func (_e *AttentionRepository_Expecter) ScheduleNextDigest(ctx interface{}, userID interface{}, next interface{}) *AttentionRepository_ScheduleNextDigest_Call {
+	return &AttentionRepository_ScheduleNextDigest_Call{Call: _e.mock.On("ScheduleNextDigest", ctx, userID, next)}
+}
+
+func (_c *AttentionRepository_ScheduleNextDigest_Call) Run(run func(ctx context.Context, userID int, next *time.Time)) *AttentionRepository_ScheduleNextDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *AttentionRepository_ScheduleNextDigest_Call) Return(_a0 error) *AttentionRepository_ScheduleNextDigest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttentionRepository_ScheduleNextDigest_Call) RunAndReturn(run func(context.Context, int, *time.Time) error) *AttentionRepository_ScheduleNextDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserTimezone provides a mock function with given fields: ctx, userID
+func (_m *AttentionRepository) UserTimezone(ctx context.Context, userID int) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserTimezone")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionRepository_UserTimezone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserTimezone'
+type AttentionRepository_UserTimezone_Call struct {
+	*mock.Call
+}
+
+// UserTimezone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *AttentionRepository_Expecter) UserTimezone(ctx interface{}, userID interface{}) *AttentionRepository_UserTimezone_Call {
+	return &AttentionRepository_UserTimezone_Call{Call: _e.mock.On("UserTimezone", ctx, userID)}
+}
+
+func (_c *AttentionRepository_UserTimezone_Call) Run(run func(ctx context.Context, userID int)) *AttentionRepository_UserTimezone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *AttentionRepository_UserTimezone_Call) Return(_a0 string, _a1 error) *AttentionRepository_UserTimezone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionRepository_UserTimezone_Call) RunAndReturn(run func(context.Context, int) (string, error)) *AttentionRepository_UserTimezone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAttentionRepository creates a new instance of AttentionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttentionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttentionRepository {
+	mock := &AttentionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,214 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package attentionmocks
+
+import (
+	context "context"
+	attention "yangdongju/gtd_todo/internal/attention"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttentionUsecase is an autogenerated mock type for the AttentionUsecase type
+type AttentionUsecase struct {
+	mock.Mock
+}
+
+type AttentionUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AttentionUsecase) EXPECT() *AttentionUsecase_Expecter {
+	return &AttentionUsecase_Expecter{mock: &_m.Mock}
+}
+
+// GetSettings provides a mock function with given fields: ctx, request
+func (_m *AttentionUsecase) GetSettings(ctx context.Context, request attention.GetSettingsRequest) (*attention.SettingsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *attention.SettingsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, attention.GetSettingsRequest) (*attention.SettingsResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, attention.GetSettingsRequest) *attention.SettingsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attention.SettingsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, attention.GetSettingsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionUsecase_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
+type AttentionUsecase_GetSettings_Call struct {
+	*mock.Call
+}
+
+// GetSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request attention.GetSettingsRequest
+func (_e *AttentionUsecase_Expecter) GetSettings(ctx interface{}, request interface{}) *AttentionUsecase_GetSettings_Call {
+	return &AttentionUsecase_GetSettings_Call{Call: _e.mock.On("GetSettings", ctx, request)}
+}
+
+func (_c *AttentionUsecase_GetSettings_Call) Run(run func(ctx context.Context, request attention.GetSettingsRequest)) *AttentionUsecase_GetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(attention.GetSettingsRequest))
+	})
+	return _c
+}
+
+func (_c *AttentionUsecase_GetSettings_Call) Return(_a0 *attention.SettingsResponse, _a1 error) *AttentionUsecase_GetSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionUsecase_GetSettings_Call) RunAndReturn(run func(context.Context, attention.GetSettingsRequest) (*attention.SettingsResponse, error)) *AttentionUsecase_GetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *AttentionUsecase) List(ctx context.Context, request attention.ListAttentionRequest) (*attention.AttentionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *attention.AttentionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, attention.ListAttentionRequest) (*attention.AttentionResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, attention.ListAttentionRequest) *attention.AttentionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attention.AttentionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, attention.ListAttentionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AttentionUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request attention.ListAttentionRequest
+func (_e *AttentionUsecase_Expecter) List(ctx interface{}, request interface{}) *AttentionUsecase_List_Call {
+	return &AttentionUsecase_List_Call{Call: _e.mock.On("List", ctx, request)}
+}
+
+func (_c *AttentionUsecase_List_Call) Run(run func(ctx context.Context, request attention.ListAttentionRequest)) *AttentionUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(attention.ListAttentionRequest))
+	})
+	return _c
+}
+
+func (_c *AttentionUsecase_List_Call) Return(_a0 *attention.AttentionResponse, _a1 error) *AttentionUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionUsecase_List_Call) RunAndReturn(run func(context.Context, attention.ListAttentionRequest) (*attention.AttentionResponse, error)) *AttentionUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSettings provides a mock function with given fields: ctx, request
+func (_m *AttentionUsecase) UpdateSettings(ctx context.Context, request attention.UpdateSettingsRequest) (*attention.SettingsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSettings")
+	}
+
+	var r0 *attention.SettingsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, attention.UpdateSettingsRequest) (*attention.SettingsResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, attention.UpdateSettingsRequest) *attention.SettingsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*attention.SettingsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, attention.UpdateSettingsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttentionUsecase_UpdateSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSettings'
+type AttentionUsecase_UpdateSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request attention.UpdateSettingsRequest
+func (_e *AttentionUsecase_Expecter) UpdateSettings(ctx interface{}, request interface{}) *AttentionUsecase_UpdateSettings_Call {
+	return &AttentionUsecase_UpdateSettings_Call{Call: _e.mock.On("UpdateSettings", ctx, request)}
+}
+
+func (_c *AttentionUsecase_UpdateSettings_Call) Run(run func(ctx context.Context, request attention.UpdateSettingsRequest)) *AttentionUsecase_UpdateSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(attention.UpdateSettingsRequest))
+	})
+	return _c
+}
+
+func (_c *AttentionUsecase_UpdateSettings_Call) Return(_a0 *attention.SettingsResponse, _a1 error) *AttentionUsecase_UpdateSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttentionUsecase_UpdateSettings_Call) RunAndReturn(run func(context.Context, attention.UpdateSettingsRequest) (*attention.SettingsResponse, error)) *AttentionUsecase_UpdateSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAttentionUsecase creates a new instance of AttentionUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttentionUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttentionUsecase {
+	mock := &AttentionUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
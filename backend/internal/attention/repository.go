@@ -0,0 +1,192 @@
+package attention
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AttentionRepository interface {
+	FindSettings(ctx context.Context, userID int) (*Settings, error)
+	SaveSettings(ctx context.Context, settings *Settings) (*Settings, error)
+	UserTimezone(ctx context.Context, userID int) (string, error)
+	FindCandidates(ctx context.Context, userID int, cutoffs Cutoffs) ([]Candidate, error)
+	ClaimDueDigests(ctx context.Context, now time.Time, limit int) ([]Recipient, error)
+	ScheduleNextDigest(ctx context.Context, userID int, next *time.Time) error
+	MarkDigestSent(ctx context.Context, userID int, sentAt time.Time) error
+}
+
+type attentionRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// Settings are one user's thresholds, in days, and digest schedule.
+type Settings struct {
+	UserID         int        `db:"user_id"`
+	InboxDays      int        `db:"inbox_days"`
+	InProgressDays int        `db:"in_progress_days"`
+	WaitingForDays int        `db:"waiting_for_days"`
+	ProjectDays    int        `db:"project_days"`
+	Digest         string     `db:"digest"`
+	DigestHour     int        `db:"digest_hour"`
+	NextDigestAt   *time.Time `db:"next_digest_at"`
+	DigestSentAt   *time.Time `db:"digest_sent_at"`
+}
+
+// Cutoffs are the instants before which an item counts as drifting, one per reason.
+type Cutoffs struct {
+	Inbox      time.Time
+	InProgress time.Time
+	WaitingFor time.Time
+	Project    time.Time
+}
+
+// Candidate is an item past its threshold. IdleSince is when it last changed, or for an
+// inbox item when it was captured.
+type Candidate struct {
+	Reason    string    `db:"reason"`
+	ItemType  string    `db:"item_type"`
+	ItemID    int       `db:"item_id"`
+	Title     string    `db:"title"`
+	ProjectID *int      `db:"project_id"`
+	IdleSince time.Time `db:"idle_since"`
+}
+
+// Recipient is a user whose digest is due, with the settings it is built from.
+type Recipient struct {
+	Settings
+	Email    string `db:"email"`
+	Timezone string `db:"timezone"`
+}
+
+const settingsColumns = "user_id, inbox_days, in_progress_days, waiting_for_days, project_days, " +
+	"digest, digest_hour, next_digest_at, digest_sent_at"
+
+func NewAttentionRepository(db *sqlx.DB) *attentionRepositoryImpl {
+	return &attentionRepositoryImpl{db: db}
+}
+
+// FindSettings returns nil for a user who never changed the defaults.
+func (r *attentionRepositoryImpl) FindSettings(ctx context.Context, userID int) (*Settings, error) {
+	var settings Settings
+	err := database.Conn(ctx, r.db).GetContext(ctx, &settings,
+		"SELECT "+settingsColumns+" FROM attention_settings WHERE user_id = $1", userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *attentionRepositoryImpl) SaveSettings(ctx context.Context, s *Settings) (*Settings, error) {
+	var saved Settings
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO attention_settings (user_id, inbox_days, in_progress_days, waiting_for_days, project_days,
+			digest, digest_hour, next_digest_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			inbox_days = EXCLUDED.inbox_days,
+			in_progress_days = EXCLUDED.in_progress_days,
+			waiting_for_days = EXCLUDED.waiting_for_days,
+			project_days = EXCLUDED.project_days,
+			digest = EXCLUDED.digest,
+			digest_hour = EXCLUDED.digest_hour,
+			next_digest_at = EXCLUDED.next_digest_at,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING `+settingsColumns,
+		s.UserID, s.InboxDays, s.InProgressDays, s.WaitingForDays, s.ProjectDays,
+		s.Digest, s.DigestHour, s.NextDigestAt)
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// UserTimezone returns the IANA timezone the digest hour is read in.
+func (r *attentionRepositoryImpl) UserTimezone(ctx context.Context, userID int) (string, error) {
+	var timezone string
+	err := database.Conn(ctx, r.db).GetContext(ctx, &timezone, "SELECT timezone FROM users WHERE id = $1", userID)
+	return timezone, err
+}
+
+// FindCandidates returns the user's items past their cutoff. A waiting_for todo whose
+// follow-up date is still ahead is not drifting, and only projects still being worked on,
+// todo or in_progress, can drift. A project counts as idle since the last change to it or
+// to any of its todos.
+func (r *attentionRepositoryImpl) FindCandidates(ctx context.Context, userID int, cutoffs Cutoffs) ([]Candidate, error) {
+	candidates := []Candidate{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &candidates, `
+		SELECT 'aging_inbox' AS reason, 'todo' AS item_type, id AS item_id, title, project_id,
+			created_at AS idle_since
+		FROM todos
+		WHERE user_id = $1 AND status = 'inbox' AND created_at < $2
+		UNION ALL
+		SELECT 'stalled_in_progress', 'todo', id, title, project_id, updated_at
+		FROM todos
+		WHERE user_id = $1 AND status = 'in_progress' AND updated_at < $3
+		UNION ALL
+		SELECT 'waiting_without_follow_up', 'todo', id, title, project_id, updated_at
+		FROM todos
+		WHERE user_id = $1 AND status = 'waiting_for' AND updated_at < $4
+			AND (follow_up_date IS NULL OR follow_up_date < (
+				SELECT (CURRENT_TIMESTAMP AT TIME ZONE timezone)::date FROM users WHERE id = $1
+			))
+		UNION ALL
+		SELECT 'project_without_next_action', 'project', p.id, p.name, NULL, activity.at
+		FROM projects p
+		CROSS JOIN LATERAL (
+			SELECT GREATEST(p.updated_at, MAX(t.updated_at)) AS at FROM todos t WHERE t.project_id = p.id
+		) activity
+		WHERE p.user_id = $1 AND p.status IN ('todo', 'in_progress') AND activity.at < $5
+			AND NOT EXISTS (
+				SELECT 1 FROM todos t
+				WHERE t.project_id = p.id AND t.status IN ('next_actions', 'in_progress')
+			)`,
+		userID, cutoffs.Inbox, cutoffs.InProgress, cutoffs.WaitingFor, cutoffs.Project)
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// ClaimDueDigests locks up to limit users whose digest is due, earliest first, skipping
+// rows another instance holds. The locks last until the surrounding transaction ends.
+func (r *attentionRepositoryImpl) ClaimDueDigests(ctx context.Context, now time.Time, limit int) ([]Recipient, error) {
+	recipients := []Recipient{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &recipients, `
+		SELECT s.user_id, s.inbox_days, s.in_progress_days, s.waiting_for_days, s.project_days,
+			s.digest, s.digest_hour, s.next_digest_at, s.digest_sent_at, u.email, u.timezone
+		FROM attention_settings s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.next_digest_at <= $1
+		ORDER BY s.next_digest_at, s.user_id
+		LIMIT $2
+		FOR UPDATE OF s SKIP LOCKED`,
+		now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// ScheduleNextDigest moves the user's digest to next, so it is not claimed again.
+func (r *attentionRepositoryImpl) ScheduleNextDigest(ctx context.Context, userID int, next *time.Time) error {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE attention_settings SET next_digest_at = $2 WHERE user_id = $1",
+		userID, next)
+	return err
+}
+
+// MarkDigestSent records that a digest went out.
+func (r *attentionRepositoryImpl) MarkDigestSent(ctx context.Context, userID int, sentAt time.Time) error {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE attention_settings SET digest_sent_at = $2 WHERE user_id = $1",
+		userID, sentAt)
+	return err
+}
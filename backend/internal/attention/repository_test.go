@@ -0,0 +1,86 @@
+package attention_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/attention"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttentionRepository_FindsItemsPastTheirCutoff(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID, movingID, taxesID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	old, recent := time.Now().Add(-30*24*time.Hour), time.Now()
+	_ = db.Get(&movingID, "INSERT INTO projects (user_id, name, updated_at) VALUES ($1, 'Moving', $2) RETURNING id", userID, old)
+	_ = db.Get(&taxesID, "INSERT INTO projects (user_id, name, updated_at) VALUES ($1, 'Taxes', $2) RETURNING id", userID, old)
+	for _, row := range []struct {
+		title, status string
+		projectID     *int
+		at            time.Time
+	}{
+		{"Call the plumber", "inbox", nil, old},
+		{"Buy stamps", "inbox", nil, recent},
+		{"Draft report", "in_progress", nil, old},
+		{"Hear back from landlord", "waiting_for", &movingID, old},
+		{"Collect receipts", "next_actions", &taxesID, old},
+	} {
+		_, _ = db.Exec("INSERT INTO todos (user_id, title, status, project_id, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $5)",
+			userID, row.title, row.status, row.projectID, row.at)
+	}
+	repository := attention.NewAttentionRepository(db)
+
+	// when
+	candidates, err := repository.FindCandidates(context.Background(), userID, attention.DefaultSettings(userID).Cutoffs(time.Now()))
+
+	// then
+	assert.NoError(t, err)
+	reasons := map[string]string{}
+	for _, c := range candidates {
+		reasons[c.Title] = c.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"Call the plumber":        attention.ReasonAgingInbox,
+		"Draft report":            attention.ReasonStalledInProgress,
+		"Hear back from landlord": attention.ReasonWaitingWithoutFollowUp,
+		"Moving":                  attention.ReasonProjectWithoutNextAction,
+	}, reasons)
+}
+
+func TestAttentionRepository_ClaimsDueDigestsOnly(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var dueID, laterID int
+	_ = db.Get(&dueID, "INSERT INTO users (email, password_hash) VALUES ('due@example.com', 'hash') RETURNING id")
+	_ = db.Get(&laterID, "INSERT INTO users (email, password_hash) VALUES ('later@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	repository := attention.NewAttentionRepository(db)
+	now := time.Now()
+	for userID, next := range map[int]time.Time{dueID: now.Add(-time.Minute), laterID: now.Add(time.Hour)} {
+		settings := attention.DefaultSettings(userID)
+		settings.Digest, settings.NextDigestAt = attention.DigestDaily, &next
+		_, _ = repository.SaveSettings(ctx, &settings)
+	}
+
+	// when
+	recipients, err := repository.ClaimDueDigests(ctx, now, 10)
+	sentAt, next := now, now.Add(24*time.Hour)
+	scheduleErr := repository.ScheduleNextDigest(ctx, dueID, &next)
+	markErr := repository.MarkDigestSent(ctx, dueID, sentAt)
+	saved, _ := repository.FindSettings(ctx, dueID)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, scheduleErr)
+	assert.NoError(t, markErr)
+	assert.Len(t, recipients, 1)
+	assert.Equal(t, "due@example.com", recipients[0].Email)
+	assert.WithinDuration(t, next, *saved.NextDigestAt, time.Millisecond)
+	assert.NotNil(t, saved.DigestSentAt)
+}
@@ -0,0 +1,165 @@
+package attention
+
+import (
+	"context"
+	"slices"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+)
+
+type AttentionUsecase interface {
+	List(ctx context.Context, request ListAttentionRequest) (*AttentionResponse, error)
+	GetSettings(ctx context.Context, request GetSettingsRequest) (*SettingsResponse, error)
+	UpdateSettings(ctx context.Context, request UpdateSettingsRequest) (*SettingsResponse, error)
+}
+
+type attentionService struct {
+	attentionRepository AttentionRepository
+	txManager           database.TxManager
+	now                 func() time.Time
+}
+
+func NewAttentionService(repository AttentionRepository, txManager database.TxManager, now func() time.Time) *attentionService {
+	return &attentionService{
+		attentionRepository: repository,
+		txManager:           txManager,
+		now:                 now,
+	}
+}
+
+// List scores what has drifted past the user's thresholds, most overdue first.
+func (s *attentionService) List(ctx context.Context, req ListAttentionRequest) (*AttentionResponse, error) {
+	settings, err := s.settings(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	candidates, err := s.attentionRepository.FindCandidates(ctx, req.UserID, settings.Cutoffs(now))
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Reason) > 0 {
+		candidates = slices.DeleteFunc(candidates, func(c Candidate) bool {
+			return !slices.Contains(req.Reason, c.Reason)
+		})
+	}
+	items := Score(candidates, *settings, now)
+	return &AttentionResponse{Items: items, Total: len(items), GeneratedAt: now}, nil
+}
+
+func (s *attentionService) GetSettings(ctx context.Context, req GetSettingsRequest) (*SettingsResponse, error) {
+	settings, err := s.settings(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return toSettingsResponse(settings), nil
+}
+
+// UpdateSettings changes the fields given. Any change to the digest reschedules it from
+// now, on the user's clock.
+func (s *attentionService) UpdateSettings(ctx context.Context, req UpdateSettingsRequest) (*SettingsResponse, error) {
+	var saved *Settings
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		settings, err := s.settings(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		setIfGiven(&settings.InboxDays, req.InboxDays)
+		setIfGiven(&settings.InProgressDays, req.InProgressDays)
+		setIfGiven(&settings.WaitingForDays, req.WaitingForDays)
+		setIfGiven(&settings.ProjectDays, req.ProjectDays)
+		if req.Digest != nil || req.DigestHour != nil {
+			setIfGiven(&settings.Digest, req.Digest)
+			setIfGiven(&settings.DigestHour, req.DigestHour)
+			timezone, err := s.attentionRepository.UserTimezone(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+			settings.NextDigestAt = NextDigestAt(settings.Digest, settings.DigestHour, location(timezone), s.now())
+		}
+		saved, err = s.attentionRepository.SaveSettings(ctx, settings)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toSettingsResponse(saved), nil
+}
+
+// settings returns the user's saved settings, or the defaults when there are none.
+func (s *attentionService) settings(ctx context.Context, userID int) (*Settings, error) {
+	settings, err := s.attentionRepository.FindSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		defaults := DefaultSettings(userID)
+		return &defaults, nil
+	}
+	return settings, nil
+}
+
+// location falls back to UTC for a timezone the runtime does not know, as due dates do.
+func location(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func setIfGiven[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+func toSettingsResponse(settings *Settings) *SettingsResponse {
+	return &SettingsResponse{
+		InboxDays:      settings.InboxDays,
+		InProgressDays: settings.InProgressDays,
+		WaitingForDays: settings.WaitingForDays,
+		ProjectDays:    settings.ProjectDays,
+		Digest:         settings.Digest,
+		DigestHour:     settings.DigestHour,
+		NextDigestAt:   settings.NextDigestAt,
+		DigestSentAt:   settings.DigestSentAt,
+	}
+}
+
+type ListAttentionRequest struct {
+	UserID int      `json:"-" auth:"user_id"`
+	Reason []string `json:"-" form:"reason" binding:"omitempty,max=4,dive,oneof=aging_inbox stalled_in_progress waiting_without_follow_up project_without_next_action"`
+}
+
+type GetSettingsRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+}
+
+type UpdateSettingsRequest struct {
+	UserID         int     `json:"-" auth:"user_id"`
+	InboxDays      *int    `json:"inbox_days" binding:"omitempty,min=1,max=365"`
+	InProgressDays *int    `json:"in_progress_days" binding:"omitempty,min=1,max=365"`
+	WaitingForDays *int    `json:"waiting_for_days" binding:"omitempty,min=1,max=365"`
+	ProjectDays    *int    `json:"project_days" binding:"omitempty,min=1,max=365"`
+	Digest         *string `json:"digest" binding:"omitempty,oneof=off daily weekly"`
+	DigestHour     *int    `json:"digest_hour" binding:"omitempty,min=0,max=23"`
+}
+
+// AttentionResponse lists drifting items, highest score first.
+type AttentionResponse struct {
+	Items       []Item    `json:"items"`
+	Total       int       `json:"total"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+type SettingsResponse struct {
+	InboxDays      int        `json:"inbox_days"`
+	InProgressDays int        `json:"in_progress_days"`
+	WaitingForDays int        `json:"waiting_for_days"`
+	ProjectDays    int        `json:"project_days"`
+	Digest         string     `json:"digest"`
+	DigestHour     int        `json:"digest_hour"`
+	NextDigestAt   *time.Time `json:"next_digest_at"`
+	DigestSentAt   *time.Time `json:"digest_sent_at"`
+}
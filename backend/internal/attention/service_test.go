@@ -0,0 +1,81 @@
+package attention_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/attention"
+	attentionmocks "yangdongju/gtd_todo/internal/attention/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestList_UsesDefaultThresholdsAndFiltersByReason(t *testing.T) {
+	// given
+	mockRepo := attentionmocks.NewAttentionRepository(t)
+	mockRepo.EXPECT().FindSettings(mock.Anything, 7).Return(nil, nil)
+	mockRepo.EXPECT().FindCandidates(mock.Anything, 7, attention.DefaultSettings(7).Cutoffs(now)).Return([]attention.Candidate{
+		{Reason: attention.ReasonAgingInbox, ItemType: attention.ItemTodo, ItemID: 4, IdleSince: daysAgo(3)},
+		{Reason: attention.ReasonStalledInProgress, ItemType: attention.ItemTodo, ItemID: 5, IdleSince: daysAgo(9)},
+	}, nil)
+	service := attention.NewAttentionService(mockRepo, passThroughTxManager{}, fixedNow)
+
+	// when
+	res, err := service.List(context.Background(), attention.ListAttentionRequest{
+		UserID: 7, Reason: []string{attention.ReasonStalledInProgress},
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, 5, res.Items[0].ItemID)
+	assert.Equal(t, now, res.GeneratedAt)
+}
+
+func TestUpdateSettings_TurningTheDigestOnSchedulesItOnTheUsersClock(t *testing.T) {
+	// given
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	mockRepo := attentionmocks.NewAttentionRepository(t)
+	mockRepo.EXPECT().FindSettings(mock.Anything, 7).Return(nil, nil)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("Asia/Seoul", nil)
+	mockRepo.EXPECT().SaveSettings(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, settings *attention.Settings) (*attention.Settings, error) {
+			return settings, nil
+		})
+	service := attention.NewAttentionService(mockRepo, passThroughTxManager{}, fixedNow)
+
+	// when
+	res, err := service.UpdateSettings(context.Background(), attention.UpdateSettingsRequest{
+		UserID: 7, InboxDays: ptr(3), Digest: ptr(attention.DigestWeekly),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res.InboxDays)
+	assert.Equal(t, 7, res.InProgressDays)
+	assert.Equal(t, attention.DigestWeekly, res.Digest)
+	assert.True(t, time.Date(2026, 10, 26, 8, 0, 0, 0, seoul).Equal(*res.NextDigestAt))
+}
+
+func TestUpdateSettings_ThresholdsAloneKeepTheDigestSchedule(t *testing.T) {
+	// given
+	scheduled := time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)
+	saved := attention.DefaultSettings(7)
+	saved.Digest, saved.NextDigestAt = attention.DigestDaily, &scheduled
+	mockRepo := attentionmocks.NewAttentionRepository(t)
+	mockRepo.EXPECT().FindSettings(mock.Anything, 7).Return(&saved, nil)
+	mockRepo.EXPECT().SaveSettings(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, settings *attention.Settings) (*attention.Settings, error) {
+			return settings, nil
+		})
+	service := attention.NewAttentionService(mockRepo, passThroughTxManager{}, fixedNow)
+
+	// when
+	res, err := service.UpdateSettings(context.Background(), attention.UpdateSettingsRequest{UserID: 7, ProjectDays: ptr(14)})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 14, res.ProjectDays)
+	assert.Equal(t, &scheduled, res.NextDigestAt)
+}
@@ -0,0 +1,77 @@
+package attention
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	signatureHeader   = "X-Signature-256"
+)
+
+type webhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookNotifier posts digests as JSON to url, signed the same way as reminder
+// webhooks when a secret is set.
+func NewWebhookNotifier(url string, secret string, client *http.Client) *webhookNotifier {
+	return &webhookNotifier{url: url, secret: secret, client: client}
+}
+
+type webhookPayload struct {
+	Key         string    `json:"key"`
+	UserID      int       `json:"user_id"`
+	Timezone    string    `json:"timezone"`
+	Items       []Item    `json:"items"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Notify treats any status outside 2xx as a failed delivery. The digest key goes in the
+// Idempotency-Key header.
+func (n *webhookNotifier) Notify(ctx context.Context, digest Digest) error {
+	body, err := json.Marshal(webhookPayload{
+		Key:         digest.Key(),
+		UserID:      digest.UserID,
+		Timezone:    digest.Timezone,
+		Items:       digest.Items,
+		GeneratedAt: digest.GeneratedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotencyHeader, digest.Key())
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package clarify
+
+import (
+	"time"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+// ClarifyRequest carries a decision and the details it needs. Title, description, due
+// date and time, project and context shape the todo an action becomes; for a project they
+// name the project instead, and next_action titles its first action.
+type ClarifyRequest struct {
+	UserID          int     `json:"-" auth:"user_id"`
+	ID              int     `json:"-" uri:"id"`
+	IfMatch         string  `json:"-" header:"If-Match"`
+	Decision        string  `json:"decision" binding:"required,oneof=action project someday delegate trash do_now"`
+	Title           *string `json:"title" binding:"omitempty,min=1,max=500"`
+	Description     *string `json:"description"`
+	Priority        *string `json:"priority" binding:"omitempty,oneof=high normal low"`
+	Context         *string `json:"context" binding:"omitempty,min=1,max=50"`
+	ProjectID       *int    `json:"project_id"`
+	DueDate         *string `json:"due_date" binding:"omitempty,datetime=2006-01-02|eq="`
+	DueTime         *string `json:"due_time" binding:"omitempty,datetime=15:04|eq="`
+	NextAction      *string `json:"next_action" binding:"omitempty,min=1,max=500"`
+	DelegatedTo     *string `json:"delegated_to" binding:"omitempty,max=255"`
+	DelegateContact *string `json:"delegate_contact" binding:"omitempty,max=255"`
+	FollowUpDate    *string `json:"follow_up_date" binding:"omitempty,datetime=2006-01-02"`
+	Minutes         *int    `json:"minutes" binding:"omitempty,gte=0"`
+}
+
+// ClarifyResponse is the recorded decision with the todo and project it left behind. Todo
+// is null when the item was deleted, and project is set only for a project decision.
+type ClarifyResponse struct {
+	Clarification ClarificationResponse    `json:"clarification"`
+	Todo          *todo.TodoResponse       `json:"todo"`
+	Project       *project.ProjectResponse `json:"project"`
+}
+
+type ClarificationResponse struct {
+	ID                int       `json:"id"`
+	SourceTodoID      int       `json:"source_todo_id"`
+	SourceTitle       string    `json:"source_title"`
+	SourceDescription *string   `json:"source_description"`
+	Decision          string    `json:"decision"`
+	TodoID            *int      `json:"todo_id"`
+	ProjectID         *int      `json:"project_id"`
+	Context           *string   `json:"context"`
+	DelegatedTo       *string   `json:"delegated_to"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func toClarificationResponse(c *Clarification) ClarificationResponse {
+	return ClarificationResponse{
+		ID:                c.ID,
+		SourceTodoID:      c.SourceTodoID,
+		SourceTitle:       c.SourceTitle,
+		SourceDescription: c.SourceDescription,
+		Decision:          c.Decision,
+		TodoID:            c.TodoID,
+		ProjectID:         c.ProjectID,
+		Context:           c.Context,
+		DelegatedTo:       c.DelegatedTo,
+		CreatedAt:         c.CreatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package clarify
+
+import (
+	"fmt"
+	"net/http"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+type NotInInboxError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e NotInInboxError) Error() string {
+	return e.Message
+}
+
+func NewNotInInboxError(id int, status string) *NotInInboxError {
+	return &NotInInboxError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Only inbox items can be clarified. id=%v & status=%v", id, status),
+		NestedErr: nil,
+	}
+}
+
+type InvalidDecisionError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidDecisionError) Error() string {
+	return e.Message
+}
+
+func NewInvalidDecisionError(decision string, reason string) *InvalidDecisionError {
+	return &InvalidDecisionError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid %v decision. %v", decision, reason),
+		NestedErr: nil,
+	}
+}
+
+// VersionConflictError carries the current inbox item so the client can look again and
+// retry.
+type VersionConflictError struct {
+	Code      int
+	Message   string
+	Current   *todo.TodoResponse
+	NestedErr error
+}
+
+func (e VersionConflictError) Error() string {
+	return e.Message
+}
+
+func NewVersionConflictError(current *todo.TodoResponse, expectedVersion int) *VersionConflictError {
+	return &VersionConflictError{
+		Code:      http.StatusPreconditionFailed,
+		Message:   fmt.Sprintf("Todo was modified. id=%v & version=%v & expected=%v", current.ID, current.Version, expectedVersion),
+		Current:   current,
+		NestedErr: nil,
+	}
+}
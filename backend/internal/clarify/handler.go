@@ -0,0 +1,65 @@
+package clarify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/tag"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+type ClarifyHandler struct {
+	clarifyUsecase ClarifyUsecase
+}
+
+func NewClarifyHandler(clarifyUsecase ClarifyUsecase) *ClarifyHandler {
+	return &ClarifyHandler{clarifyUsecase: clarifyUsecase}
+}
+
+func (h *ClarifyHandler) HandleClarify(ctx context.Context, req ClarifyRequest) (int, any) {
+	res, err := h.clarifyUsecase.Clarify(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError answers a version conflict with the current inbox item, so the body of a
+// 412 is a TodoResponse rather than an ErrorResponse.
+func handleError(err error) (int, any) {
+	var notFoundError *todo.TodoNotFoundError
+	var invalidProjectError *todo.InvalidProjectError
+	var invalidTagError *todo.InvalidTagError
+	var invalidDueDateError *todo.InvalidDueDateError
+	var invalidDecisionError *InvalidDecisionError
+	var duplicateTagError *tag.TagNameTakenError
+	var notInInboxError *NotInInboxError
+	var versionConflictError *VersionConflictError
+	var todoConflictError *todo.VersionConflictError
+	var projectConflictError *project.VersionConflictError
+
+	switch {
+	case errors.As(err, &notFoundError):
+		return http.StatusNotFound, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &invalidProjectError), errors.As(err, &invalidTagError),
+		errors.As(err, &invalidDueDateError), errors.As(err, &invalidDecisionError):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &notInInboxError), errors.As(err, &duplicateTagError):
+		return http.StatusConflict, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &versionConflictError):
+		return http.StatusPreconditionFailed, versionConflictError.Current
+	case errors.As(err, &todoConflictError), errors.As(err, &projectConflictError):
+		return http.StatusPreconditionFailed, ErrorResponse{Error: err.Error()}
+	case errors.Is(err, etag.ErrMissingIfMatch):
+		return http.StatusPreconditionRequired, ErrorResponse{Error: err.Error()}
+	case errors.Is(err, etag.ErrInvalidVersion):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	default:
+		return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+	}
+}
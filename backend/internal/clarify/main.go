@@ -0,0 +1,25 @@
+//go:generate mockery
+package clarify
+
+import (
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/tag"
+	"yangdongju/gtd_todo/internal/todo"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func InitializeHandler(pool *sqlx.DB, publisher event.Publisher) *ClarifyHandler {
+	txManager := database.NewTxManager(pool)
+	todoService := todo.NewTodoService(todo.NewTodoRepository(pool), txManager, publisher, time.Now)
+	return NewClarifyHandler(NewClarifyService(
+		NewClarificationRepository(pool),
+		txManager,
+		todoService,
+		project.NewProjectService(project.NewProjectRepository(pool), txManager, publisher, todoService),
+		tag.NewTagService(tag.NewTagRepository(pool), txManager, publisher, todoService),
+	))
+}
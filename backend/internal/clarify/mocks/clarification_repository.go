@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package clarifymocks
+
+import (
+	context "context"
+	clarify "yangdongju/gtd_todo/internal/clarify"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ClarificationRepository is an autogenerated mock type for the ClarificationRepository type
+type ClarificationRepository struct {
+	mock.Mock
+}
+
+type ClarificationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ClarificationRepository) EXPECT() *ClarificationRepository_Expecter {
+	return &ClarificationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Save provides a mock function with given fields: ctx, clarification
+func (_m *ClarificationRepository) Save(ctx context.Context, clarification *clarify.Clarification) (*clarify.Clarification, error) {
+	ret := _m.Called(ctx, clarification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *clarify.Clarification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *clarify.Clarification) (*clarify.Clarification, error)); ok {
+		return rf(ctx, clarification)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *clarify.Clarification) *clarify.Clarification); ok {
+		r0 = rf(ctx, clarification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*clarify.Clarification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *clarify.Clarification) error); ok {
+		r1 = rf(ctx, clarification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClarificationRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type ClarificationRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clarification *clarify.Clarification
+func (_e *ClarificationRepository_Expecter) Save(ctx interface{}, clarification interface{}) *ClarificationRepository_Save_Call {
+	return &ClarificationRepository_Save_Call{Call: _e.mock.On("Save", ctx, clarification)}
+}
+
+func (_c *ClarificationRepository_Save_Call) Run(run func(ctx context.Context, clarification *clarify.Clarification)) *ClarificationRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*clarify.Clarification))
+	})
+	return _c
+}
+
+func (_c *ClarificationRepository_Save_Call) Return(_a0 *clarify.Clarification, _a1 error) *ClarificationRepository_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClarificationRepository_Save_Call) RunAndReturn(run func(context.Context, *clarify.Clarification) (*clarify.Clarification, error)) *ClarificationRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewClarificationRepository creates a new instance of ClarificationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClarificationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClarificationRepository {
+	mock := &ClarificationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package clarifymocks
+
+import (
+	context "context"
+	clarify "yangdongju/gtd_todo/internal/clarify"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ClarifyUsecase is an autogenerated mock type for the ClarifyUsecase type
+type ClarifyUsecase struct {
+	mock.Mock
+}
+
+type ClarifyUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ClarifyUsecase) EXPECT() *ClarifyUsecase_Expecter {
+	return &ClarifyUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Clarify provides a mock function with given fields: ctx, request
+func (_m *ClarifyUsecase) Clarify(ctx context.Context, request clarify.ClarifyRequest) (*clarify.ClarifyResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clarify")
+	}
+
+	var r0 *clarify.ClarifyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clarify.ClarifyRequest) (*clarify.ClarifyResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clarify.ClarifyRequest) *clarify.ClarifyResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*clarify.ClarifyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clarify.ClarifyRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClarifyUsecase_Clarify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clarify'
+type ClarifyUsecase_Clarify_Call struct {
+	*mock.Call
+}
+
+// Clarify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request clarify.ClarifyRequest
+func (_e *ClarifyUsecase_Expecter) Clarify(ctx interface{}, request interface{}) *ClarifyUsecase_Clarify_Call {
+	return &ClarifyUsecase_Clarify_Call{Call: _e.mock.On("Clarify", ctx, request)}
+}
+
+func (_c *ClarifyUsecase_Clarify_Call) Run(run func(ctx context.Context, request clarify.ClarifyRequest)) *ClarifyUsecase_Clarify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(clarify.ClarifyRequest))
+	})
+	return _c
+}
+
+func (_c *ClarifyUsecase_Clarify_Call) Return(_a0 *clarify.ClarifyResponse, _a1 error) *ClarifyUsecase_Clarify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClarifyUsecase_Clarify_Call) RunAndReturn(run func(context.Context, clarify.ClarifyRequest) (*clarify.ClarifyResponse, error)) *ClarifyUsecase_Clarify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewClarifyUsecase creates a new instance of ClarifyUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClarifyUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClarifyUsecase {
+	mock := &ClarifyUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
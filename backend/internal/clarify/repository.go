@@ -0,0 +1,64 @@
+package clarify
+
+import (
+	"context"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	DecisionAction   = "action"
+	DecisionProject  = "project"
+	DecisionSomeday  = "someday"
+	DecisionDelegate = "delegate"
+	DecisionTrash    = "trash"
+	DecisionDoNow    = "do_now"
+)
+
+type ClarificationRepository interface {
+	Save(ctx context.Context, clarification *Clarification) (*Clarification, error)
+}
+
+type clarificationRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// Clarification records what an inbox item was turned into. The source is a copy, since
+// the todo may be gone.
+type Clarification struct {
+	ID                int       `db:"id"`
+	UserID            int       `db:"user_id"`
+	SourceTodoID      int       `db:"source_todo_id"`
+	SourceTitle       string    `db:"source_title"`
+	SourceDescription *string   `db:"source_description"`
+	Decision          string    `db:"decision"`
+	TodoID            *int      `db:"todo_id"`
+	ProjectID         *int      `db:"project_id"`
+	Context           *string   `db:"context"`
+	DelegatedTo       *string   `db:"delegated_to"`
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+const clarificationColumns = "id, user_id, source_todo_id, source_title, source_description, decision, " +
+	"todo_id, project_id, context, delegated_to, created_at"
+
+func NewClarificationRepository(db *sqlx.DB) *clarificationRepositoryImpl {
+	return &clarificationRepositoryImpl{db: db}
+}
+
+func (r *clarificationRepositoryImpl) Save(ctx context.Context, c *Clarification) (*Clarification, error) {
+	var saved Clarification
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO clarifications (user_id, source_todo_id, source_title, source_description, decision,
+			todo_id, project_id, context, delegated_to)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+clarificationColumns,
+		c.UserID, c.SourceTodoID, c.SourceTitle, c.SourceDescription, c.Decision,
+		c.TodoID, c.ProjectID, c.Context, c.DelegatedTo)
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
@@ -0,0 +1,39 @@
+package clarify_test
+
+import (
+	"context"
+	"testing"
+	"yangdongju/gtd_todo/internal/clarify"
+	"yangdongju/gtd_todo/internal/todo"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClarificationRepository_OutlivesTheTodoItMade(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	saved, _ := todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Call the plumber", Status: todo.StatusNextActions})
+	repository := clarify.NewClarificationRepository(testhelper.GetTestDB())
+
+	// when
+	clarification, err := repository.Save(ctx, &clarify.Clarification{
+		UserID: userID, SourceTodoID: saved.ID, SourceTitle: "plumber?", Decision: clarify.DecisionAction,
+		TodoID: &saved.ID, Context: ptr("@phone"),
+	})
+	_, _ = todos.Delete(ctx, saved)
+	var todoID *int
+	_ = testhelper.GetTestDB().Get(&todoID, "SELECT todo_id FROM clarifications WHERE id = $1", clarification.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, saved.ID, clarification.SourceTodoID)
+	assert.Equal(t, "@phone", *clarification.Context)
+	assert.False(t, clarification.CreatedAt.IsZero())
+	assert.Nil(t, todoID)
+}
@@ -0,0 +1,247 @@
+package clarify
+
+import (
+	"context"
+	"strings"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/tag"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+// doNowMinutes is the two-minute rule: anything quicker is done on the spot instead of
+// being tracked.
+const doNowMinutes = 2
+
+// priorityContexts carries over the legacy workflow rule that maps a clarified priority
+// to a context. Other priorities keep the context the user chose.
+var priorityContexts = map[string]string{
+	"high": "urgent",
+	"low":  "someday",
+}
+
+type ClarifyUsecase interface {
+	Clarify(ctx context.Context, request ClarifyRequest) (*ClarifyResponse, error)
+}
+
+type clarifyService struct {
+	clarificationRepository ClarificationRepository
+	txManager               database.TxManager
+	todoUsecase             todo.TodoUsecase
+	projectUsecase          project.ProjectUsecase
+	tagUsecase              tag.TagUsecase
+}
+
+// NewClarifyService applies decisions through the todo, project and tag use cases, so they
+// get the same validation, version checks and events as the REST endpoints.
+func NewClarifyService(repository ClarificationRepository, txManager database.TxManager, todoUsecase todo.TodoUsecase, projectUsecase project.ProjectUsecase, tagUsecase tag.TagUsecase) *clarifyService {
+	return &clarifyService{
+		clarificationRepository: repository,
+		txManager:               txManager,
+		todoUsecase:             todoUsecase,
+		projectUsecase:          projectUsecase,
+		tagUsecase:              tagUsecase,
+	}
+}
+
+// Clarify decides what an inbox item is and applies the decision in one transaction:
+//
+//   - action: a next action, optionally in a project and with a context
+//   - project: a new project, with the item as its first next action when next_action is
+//     given and deleted otherwise
+//   - someday: parked in someday
+//   - delegate: waiting for someone, recorded on the todo along with when to follow up
+//   - trash: deleted
+//   - do_now: done on the spot, for work of two minutes or less
+//
+// Every decision is recorded with a copy of the item it came from.
+func (s *clarifyService) Clarify(ctx context.Context, req ClarifyRequest) (*ClarifyResponse, error) {
+	if err := checkDecision(req); err != nil {
+		return nil, err
+	}
+
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ClarifyResponse{}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		source, err := s.todoUsecase.Get(ctx, todo.GetTodoRequest{UserID: req.UserID, ID: req.ID})
+		if err != nil {
+			return err
+		}
+		// Checked up front, since a project decision creates the project before it touches
+		// the item.
+		if source.Version != expectedVersion {
+			return NewVersionConflictError(source, expectedVersion)
+		}
+		if source.Status != todo.StatusInbox {
+			return NewNotInInboxError(source.ID, source.Status)
+		}
+
+		record := &Clarification{
+			UserID:            req.UserID,
+			SourceTodoID:      source.ID,
+			SourceTitle:       source.Title,
+			SourceDescription: source.Description,
+			Decision:          req.Decision,
+			DelegatedTo:       req.DelegatedTo,
+		}
+		res.Todo, res.Project, err = s.apply(ctx, req, source, record)
+		if err != nil {
+			return err
+		}
+		if res.Todo != nil {
+			record.TodoID = &res.Todo.ID
+		}
+		if res.Project != nil {
+			record.ProjectID = &res.Project.ID
+		}
+
+		saved, err := s.clarificationRepository.Save(ctx, record)
+		if err != nil {
+			return err
+		}
+		res.Clarification = toClarificationResponse(saved)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *clarifyService) apply(ctx context.Context, req ClarifyRequest, source *todo.TodoResponse, record *Clarification) (*todo.TodoResponse, *project.ProjectResponse, error) {
+	update := todo.UpdateTodoRequest{
+		UserID: req.UserID, ID: req.ID, IfMatch: req.IfMatch,
+		Title: req.Title, Description: req.Description, DueDate: req.DueDate, DueTime: req.DueTime,
+	}
+
+	switch req.Decision {
+	case DecisionAction:
+		tagIDs, err := s.withContext(ctx, req, source, record)
+		if err != nil {
+			return nil, nil, err
+		}
+		update.Status, update.ProjectID, update.TagIDs = ptr(todo.StatusNextActions), req.ProjectID, tagIDs
+		updated, err := s.todoUsecase.Update(ctx, update)
+		return updated, nil, err
+
+	case DecisionProject:
+		name := source.Title
+		if req.Title != nil {
+			name = *req.Title
+		}
+		description := source.Description
+		if req.Description != nil {
+			description = req.Description
+		}
+		if len([]rune(name)) > 255 {
+			return nil, nil, NewInvalidDecisionError(req.Decision, "a project name is at most 255 characters")
+		}
+		created, err := s.projectUsecase.Create(ctx, project.CreateProjectRequest{UserID: req.UserID, Name: name, Description: description})
+		if err != nil {
+			return nil, nil, err
+		}
+		if req.NextAction == nil {
+			_, err = s.todoUsecase.Delete(ctx, todo.DeleteTodoRequest{UserID: req.UserID, ID: req.ID, IfMatch: req.IfMatch})
+			return nil, created, err
+		}
+		tagIDs, err := s.withContext(ctx, req, source, record)
+		if err != nil {
+			return nil, nil, err
+		}
+		update.Title, update.Description = req.NextAction, nil
+		update.Status, update.ProjectID, update.TagIDs = ptr(todo.StatusNextActions), &created.ID, tagIDs
+		updated, err := s.todoUsecase.Update(ctx, update)
+		return updated, created, err
+
+	case DecisionSomeday:
+		update.Status = ptr(todo.StatusSomeday)
+		updated, err := s.todoUsecase.Update(ctx, update)
+		return updated, nil, err
+
+	case DecisionDelegate:
+		update.Status = ptr(todo.StatusWaitingFor)
+		update.DelegatedTo, update.DelegateContact, update.FollowUpDate = req.DelegatedTo, req.DelegateContact, req.FollowUpDate
+		updated, err := s.todoUsecase.Update(ctx, update)
+		return updated, nil, err
+
+	case DecisionDoNow:
+		moved, err := s.todoUsecase.ChangeStatus(ctx, todo.ChangeStatusRequest{
+			UserID: req.UserID, ID: req.ID, IfMatch: req.IfMatch, Status: todo.StatusDone,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return moved.Todo, nil, nil
+
+	default:
+		_, err := s.todoUsecase.Delete(ctx, todo.DeleteTodoRequest{UserID: req.UserID, ID: req.ID, IfMatch: req.IfMatch})
+		return nil, nil, err
+	}
+}
+
+// withContext returns the item's tags plus the context the request names, creating the
+// context tag when the user has no tag of that name yet. A priority with a mapped context
+// overrides the one named.
+func (s *clarifyService) withContext(ctx context.Context, req ClarifyRequest, source *todo.TodoResponse, record *Clarification) (*[]int, error) {
+	name := req.Context
+	if req.Priority != nil {
+		if mapped, ok := priorityContexts[*req.Priority]; ok {
+			name = &mapped
+		}
+	}
+	if name == nil {
+		return nil, nil
+	}
+
+	contextID, err := s.findOrCreateContext(ctx, req.UserID, *name)
+	if err != nil {
+		return nil, err
+	}
+	record.Context = name
+
+	tagIDs := []int{contextID}
+	for _, existing := range source.Tags {
+		if existing.ID != contextID {
+			tagIDs = append(tagIDs, existing.ID)
+		}
+	}
+	return &tagIDs, nil
+}
+
+// findOrCreateContext looks the name up among all of the user's tags, since tag names are
+// unique regardless of kind.
+func (s *clarifyService) findOrCreateContext(ctx context.Context, userID int, name string) (int, error) {
+	tags, err := s.tagUsecase.List(ctx, tag.ListTagsRequest{UserID: userID})
+	if err != nil {
+		return 0, err
+	}
+	for _, existing := range tags.Tags {
+		if strings.EqualFold(existing.Name, name) {
+			return existing.ID, nil
+		}
+	}
+	created, err := s.tagUsecase.Create(ctx, tag.CreateTagRequest{UserID: userID, Name: name, Kind: ptr(tag.KindContext)})
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+func checkDecision(req ClarifyRequest) error {
+	switch {
+	case req.Decision == DecisionDelegate && (req.DelegatedTo == nil || strings.TrimSpace(*req.DelegatedTo) == ""):
+		return NewInvalidDecisionError(req.Decision, "delegated_to names who it was handed to")
+	case req.Decision == DecisionDoNow && req.Minutes != nil && *req.Minutes > doNowMinutes:
+		return NewInvalidDecisionError(req.Decision, "anything over two minutes should become an action")
+	}
+	return nil
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,257 @@
+package clarify_test
+
+import (
+	"context"
+	"testing"
+	"yangdongju/gtd_todo/internal/clarify"
+	clarifymocks "yangdongju/gtd_todo/internal/clarify/mocks"
+	"yangdongju/gtd_todo/internal/project"
+	projectmocks "yangdongju/gtd_todo/internal/project/mocks"
+	"yangdongju/gtd_todo/internal/tag"
+	tagmocks "yangdongju/gtd_todo/internal/tag/mocks"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func inboxItem() *todo.TodoResponse {
+	return &todo.TodoResponse{
+		ID: 4, Title: "plumber?", Description: ptr("sink drips"), Status: todo.StatusInbox, Version: 2,
+		Tags: []todo.TodoTagResponse{{ID: 30, Name: "home", Kind: tag.KindTag}},
+	}
+}
+
+func savedAs(id int) func(ctx context.Context, c *clarify.Clarification) (*clarify.Clarification, error) {
+	return func(ctx context.Context, c *clarify.Clarification) (*clarify.Clarification, error) {
+		saved := *c
+		saved.ID = id
+		return &saved, nil
+	}
+}
+
+func TestClarify_ActionGetsAnExistingContextAndKeepsItsTags(t *testing.T) {
+	// given
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 4}).Return(inboxItem(), nil)
+	mockTodos.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req todo.UpdateTodoRequest) bool {
+		return req.IfMatch == `"2"` && *req.Status == todo.StatusNextActions && *req.Title == "Call the plumber" &&
+			*req.ProjectID == 3 && assert.ObjectsAreEqual([]int{11, 30}, *req.TagIDs)
+	})).Return(&todo.TodoResponse{ID: 4, Title: "Call the plumber", Status: todo.StatusNextActions, Version: 3}, nil)
+	mockTags := tagmocks.NewTagUsecase(t)
+	mockTags.EXPECT().List(mock.Anything, tag.ListTagsRequest{UserID: 7}).
+		Return(&tag.TagListResponse{Tags: []tag.TagResponse{{ID: 11, Name: "@Phone", Kind: tag.KindContext}}}, nil)
+	mockRepo := clarifymocks.NewClarificationRepository(t)
+	mockRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(c *clarify.Clarification) bool {
+		return c.SourceTitle == "plumber?" && *c.SourceDescription == "sink drips" &&
+			c.Decision == clarify.DecisionAction && *c.TodoID == 4 && *c.Context == "@phone"
+	})).RunAndReturn(savedAs(1))
+
+	service := clarify.NewClarifyService(mockRepo, passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t), mockTags)
+
+	// when
+	res, err := service.Clarify(context.Background(), clarify.ClarifyRequest{
+		UserID: 7, ID: 4, IfMatch: `"2"`, Decision: clarify.DecisionAction,
+		Title: ptr("Call the plumber"), Context: ptr("@phone"), ProjectID: ptr(3),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Clarification.ID)
+	assert.Equal(t, todo.StatusNextActions, res.Todo.Status)
+	assert.Nil(t, res.Project)
+}
+
+func TestClarify_PriorityPicksTheContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority string
+		context  string
+	}{
+		{"high is urgent", "high", "urgent"},
+		{"low is someday", "low", "someday"},
+		{"normal keeps the chosen context", "normal", "@desk"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockTodos := todomocks.NewTodoUsecase(t)
+			mockTodos.EXPECT().Get(mock.Anything, mock.Anything).Return(inboxItem(), nil)
+			mockTodos.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req todo.UpdateTodoRequest) bool {
+				return assert.ObjectsAreEqual([]int{50, 30}, *req.TagIDs)
+			})).Return(&todo.TodoResponse{ID: 4}, nil)
+			mockTags := tagmocks.NewTagUsecase(t)
+			mockTags.EXPECT().List(mock.Anything, mock.Anything).Return(&tag.TagListResponse{}, nil)
+			mockTags.EXPECT().Create(mock.Anything, tag.CreateTagRequest{UserID: 7, Name: tt.context, Kind: ptr(tag.KindContext)}).
+				Return(&tag.TagResponse{ID: 50, Name: tt.context, Kind: tag.KindContext}, nil)
+			mockRepo := clarifymocks.NewClarificationRepository(t)
+			mockRepo.EXPECT().Save(mock.Anything, mock.Anything).RunAndReturn(savedAs(1))
+
+			service := clarify.NewClarifyService(mockRepo, passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t), mockTags)
+
+			// when
+			res, err := service.Clarify(context.Background(), clarify.ClarifyRequest{
+				UserID: 7, ID: 4, IfMatch: `"2"`, Decision: clarify.DecisionAction, Priority: ptr(tt.priority), Context: ptr("@desk"),
+			})
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.context, *res.Clarification.Context)
+		})
+	}
+}
+
+func TestClarify_ProjectWithNextActionKeepsTheItemAsItsFirstAction(t *testing.T) {
+	// given
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Get(mock.Anything, mock.Anything).Return(inboxItem(), nil)
+	mockTodos.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req todo.UpdateTodoRequest) bool {
+		return *req.Title == "Find a plumber" && req.Description == nil && *req.ProjectID == 9 &&
+			*req.Status == todo.StatusNextActions && req.TagIDs == nil
+	})).Return(&todo.TodoResponse{ID: 4, ProjectID: ptr(9)}, nil)
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().Create(mock.Anything, project.CreateProjectRequest{UserID: 7, Name: "Fix the sink", Description: ptr("sink drips")}).
+		Return(&project.ProjectResponse{ID: 9, Name: "Fix the sink"}, nil)
+	mockRepo := clarifymocks.NewClarificationRepository(t)
+	mockRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(c *clarify.Clarification) bool {
+		return *c.TodoID == 4 && *c.ProjectID == 9
+	})).RunAndReturn(savedAs(1))
+
+	service := clarify.NewClarifyService(mockRepo, passThroughTxManager{}, mockTodos, mockProjects, tagmocks.NewTagUsecase(t))
+
+	// when
+	res, err := service.Clarify(context.Background(), clarify.ClarifyRequest{
+		UserID: 7, ID: 4, IfMatch: `"2"`, Decision: clarify.DecisionProject,
+		Title: ptr("Fix the sink"), NextAction: ptr("Find a plumber"),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 9, res.Project.ID)
+	assert.Equal(t, 9, *res.Todo.ProjectID)
+}
+
+func TestClarify_ProjectWithoutNextActionDeletesTheItem(t *testing.T) {
+	// given
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Get(mock.Anything, mock.Anything).Return(inboxItem(), nil)
+	mockTodos.EXPECT().Delete(mock.Anything, todo.DeleteTodoRequest{UserID: 7, ID: 4, IfMatch: `"2"`}).
+		Return(&todo.DeleteTodoResponse{}, nil)
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().Create(mock.Anything, mock.MatchedBy(func(req project.CreateProjectRequest) bool {
+		return req.Name == "plumber?"
+	})).Return(&project.ProjectResponse{ID: 9}, nil)
+	mockRepo := clarifymocks.NewClarificationRepository(t)
+	mockRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(c *clarify.Clarification) bool {
+		return c.TodoID == nil && *c.ProjectID == 9
+	})).RunAndReturn(savedAs(1))
+
+	service := clarify.NewClarifyService(mockRepo, passThroughTxManager{}, mockTodos, mockProjects, tagmocks.NewTagUsecase(t))
+
+	// when
+	res, err := service.Clarify(context.Background(), clarify.ClarifyRequest{
+		UserID: 7, ID: 4, IfMatch: `"2"`, Decision: clarify.DecisionProject,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, res.Todo)
+	assert.Equal(t, 9, res.Project.ID)
+}
+
+func TestClarify_DoNowCompletesTheItem(t *testing.T) {
+	// given
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Get(mock.Anything, mock.Anything).Return(inboxItem(), nil)
+	mockTodos.EXPECT().ChangeStatus(mock.Anything, todo.ChangeStatusRequest{UserID: 7, ID: 4, IfMatch: `"2"`, Status: todo.StatusDone}).
+		Return(&todo.StatusChangeResponse{Todo: &todo.TodoResponse{ID: 4, Status: todo.StatusDone}}, nil)
+	mockRepo := clarifymocks.NewClarificationRepository(t)
+	mockRepo.EXPECT().Save(mock.Anything, mock.Anything).RunAndReturn(savedAs(1))
+
+	service := clarify.NewClarifyService(mockRepo, passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t), tagmocks.NewTagUsecase(t))
+
+	// when
+	res, err := service.Clarify(context.Background(), clarify.ClarifyRequest{
+		UserID: 7, ID: 4, IfMatch: `"2"`, Decision: clarify.DecisionDoNow, Minutes: ptr(2),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, todo.StatusDone, res.Todo.Status)
+}
+
+func TestClarify_RejectsWithoutTouchingAnything(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     clarify.ClarifyRequest
+		current *todo.TodoResponse
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name: "delegating to nobody",
+			req:  clarify.ClarifyRequest{Decision: clarify.DecisionDelegate, DelegatedTo: ptr(" ")},
+			check: func(t *testing.T, err error) {
+				var target *clarify.InvalidDecisionError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name: "doing now what takes longer than two minutes",
+			req:  clarify.ClarifyRequest{Decision: clarify.DecisionDoNow, Minutes: ptr(15)},
+			check: func(t *testing.T, err error) {
+				var target *clarify.InvalidDecisionError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name:    "an item already clarified",
+			req:     clarify.ClarifyRequest{Decision: clarify.DecisionSomeday},
+			current: &todo.TodoResponse{ID: 4, Status: todo.StatusNextActions, Version: 2},
+			check: func(t *testing.T, err error) {
+				var target *clarify.NotInInboxError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name:    "a stale version",
+			req:     clarify.ClarifyRequest{Decision: clarify.DecisionProject},
+			current: &todo.TodoResponse{ID: 4, Status: todo.StatusInbox, Version: 5},
+			check: func(t *testing.T, err error) {
+				var target *clarify.VersionConflictError
+				assert.ErrorAs(t, err, &target)
+				assert.Equal(t, 5, target.Current.Version)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockTodos := todomocks.NewTodoUsecase(t)
+			if tt.current != nil {
+				mockTodos.EXPECT().Get(mock.Anything, mock.Anything).Return(tt.current, nil)
+			}
+			service := clarify.NewClarifyService(clarifymocks.NewClarificationRepository(t), passThroughTxManager{},
+				mockTodos, projectmocks.NewProjectUsecase(t), tagmocks.NewTagUsecase(t))
+			req := tt.req
+			req.UserID, req.ID, req.IfMatch = 7, 4, `"2"`
+
+			// when
+			res, err := service.Clarify(context.Background(), req)
+
+			// then
+			assert.Nil(t, res)
+			tt.check(t, err)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package collab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	sendBufferSize     = 64
+	maxRoomsPerSession = 16
+)
+
+// Hub keeps the per-project rooms of connected sessions. Every session has a bounded
+// outbox; a session whose outbox is full is dropped instead of slowing down the sender,
+// so one slow client never holds up a room.
+type Hub struct {
+	access    AccessRepository
+	heartbeat time.Duration
+
+	mu    sync.Mutex
+	rooms map[int]map[*Session]struct{}
+}
+
+func NewHub(access AccessRepository, heartbeat time.Duration) *Hub {
+	return &Hub{
+		access:    access,
+		heartbeat: heartbeat,
+		rooms:     map[int]map[*Session]struct{}{},
+	}
+}
+
+// Viewers returns the sessions in the project's room ordered by session id.
+func (h *Hub) Viewers(projectID int) []Viewer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.viewersLocked(projectID)
+}
+
+func (h *Hub) handle(ctx context.Context, s *Session, raw []byte) {
+	var envelope Envelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		h.sendError(s, CodeBadMessage, err.Error())
+		return
+	}
+	if envelope.V > s.version {
+		h.sendError(s, CodeUnsupportedVersion, fmt.Sprintf("Session speaks protocol %d. v=%v", s.version, envelope.V))
+		return
+	}
+
+	switch envelope.Type {
+	case TypeJoin, TypeLeave:
+		var data RoomData
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			h.sendError(s, CodeBadMessage, err.Error())
+			return
+		}
+		if envelope.Type == TypeJoin {
+			h.join(ctx, s, data.ProjectID)
+		} else {
+			h.leave(s, data.ProjectID)
+		}
+	case TypeDrag, TypeDragEnd:
+		var data DragData
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			h.sendError(s, CodeBadMessage, err.Error())
+			return
+		}
+		if !h.inRoom(s, data.ProjectID) {
+			h.sendError(s, CodeNotJoined, fmt.Sprintf("Join the project first. project_id=%v", data.ProjectID))
+			return
+		}
+		preview := DragPreview{DragData: data, SessionID: s.ID, UserID: s.UserID}
+		h.broadcast(data.ProjectID, s, func(version int) []byte { return encode(version, envelope.Type, preview) })
+	default:
+		h.sendError(s, CodeUnknownType, fmt.Sprintf("Unknown message type. type=%v", envelope.Type))
+	}
+}
+
+func (h *Hub) join(ctx context.Context, s *Session, projectID int) {
+	allowed, err := h.access.CanViewProject(ctx, s.UserID, projectID)
+	if err != nil {
+		h.sendError(s, CodeInternal, err.Error())
+		return
+	}
+	if !allowed {
+		h.sendError(s, CodeForbidden, fmt.Sprintf("Project not found. project_id=%v", projectID))
+		return
+	}
+
+	h.mu.Lock()
+	if _, joined := s.rooms[projectID]; !joined && len(s.rooms) >= maxRoomsPerSession {
+		h.mu.Unlock()
+		h.sendError(s, CodeTooManyRooms, fmt.Sprintf("A session can join at most %d projects", maxRoomsPerSession))
+		return
+	}
+	if h.rooms[projectID] == nil {
+		h.rooms[projectID] = map[*Session]struct{}{}
+	}
+	h.rooms[projectID][s] = struct{}{}
+	s.rooms[projectID] = struct{}{}
+	h.mu.Unlock()
+
+	h.broadcastPresence(projectID)
+}
+
+func (h *Hub) leave(s *Session, projectID int) {
+	h.mu.Lock()
+	_, joined := s.rooms[projectID]
+	h.removeLocked(s, projectID)
+	h.mu.Unlock()
+
+	if joined {
+		h.broadcastPresence(projectID)
+	}
+}
+
+// disconnect drops the session and tells the rooms it was in. It is safe to call more
+// than once.
+func (h *Hub) disconnect(s *Session) {
+	s.drop(websocket.CloseNormalClosure, "")
+
+	h.mu.Lock()
+	rooms := make([]int, 0, len(s.rooms))
+	for projectID := range s.rooms {
+		rooms = append(rooms, projectID)
+		h.removeLocked(s, projectID)
+	}
+	h.mu.Unlock()
+
+	for _, projectID := range rooms {
+		h.broadcastPresence(projectID)
+	}
+}
+
+func (h *Hub) removeLocked(s *Session, projectID int) {
+	delete(s.rooms, projectID)
+	delete(h.rooms[projectID], s)
+	if len(h.rooms[projectID]) == 0 {
+		delete(h.rooms, projectID)
+	}
+}
+
+func (h *Hub) inRoom(s *Session, projectID int) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, joined := s.rooms[projectID]
+	return joined
+}
+
+func (h *Hub) broadcastPresence(projectID int) {
+	presence := PresenceData{ProjectID: projectID, Viewers: h.Viewers(projectID)}
+	h.broadcast(projectID, nil, func(version int) []byte { return encode(version, TypePresence, presence) })
+}
+
+// broadcast queues a message for everyone in the room except the sender. The message is
+// encoded once per protocol version in use.
+func (h *Hub) broadcast(projectID int, except *Session, message func(version int) []byte) {
+	encoded := map[int][]byte{}
+	var slow []*Session
+
+	h.mu.Lock()
+	for s := range h.rooms[projectID] {
+		if s == except {
+			continue
+		}
+		if encoded[s.version] == nil {
+			encoded[s.version] = message(s.version)
+		}
+		if !s.enqueue(encoded[s.version]) {
+			slow = append(slow, s)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, s := range slow {
+		h.dropSlow(s)
+	}
+}
+
+func (h *Hub) send(s *Session, messageType string, data any) {
+	if !s.enqueue(encode(s.version, messageType, data)) {
+		h.dropSlow(s)
+	}
+}
+
+func (h *Hub) dropSlow(s *Session) {
+	s.drop(websocket.CloseTryAgainLater, "client is not keeping up")
+	h.disconnect(s)
+}
+
+func (h *Hub) sendError(s *Session, code string, message string) {
+	h.send(s, TypeError, ErrorData{Code: code, Message: message})
+}
+
+func (h *Hub) viewersLocked(projectID int) []Viewer {
+	viewers := make([]Viewer, 0, len(h.rooms[projectID]))
+	for s := range h.rooms[projectID] {
+		viewers = append(viewers, Viewer{SessionID: s.ID, UserID: s.UserID})
+	}
+	sort.Slice(viewers, func(i, j int) bool { return viewers[i].SessionID < viewers[j].SessionID })
+	return viewers
+}
@@ -0,0 +1,180 @@
+package collab_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/collab"
+	collabmocks "yangdongju/gtd_todo/internal/collab/mocks"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeConn feeds messages to a session and records what it writes. A stalled conn never
+// finishes a write, like a client that stopped reading.
+type fakeConn struct {
+	incoming  chan []byte
+	written   chan collab.Envelope
+	stalled   bool
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newFakeConn(stalled bool) *fakeConn {
+	return &fakeConn{
+		incoming: make(chan []byte),
+		written:  make(chan collab.Envelope, 256),
+		stalled:  stalled,
+		closed:   make(chan struct{}),
+	}
+}
+
+func (c *fakeConn) Subprotocol() string                       { return "gtd.v1" }
+func (c *fakeConn) SetReadLimit(int64)                        {}
+func (c *fakeConn) SetReadDeadline(time.Time) error           { return nil }
+func (c *fakeConn) SetWriteDeadline(time.Time) error          { return nil }
+func (c *fakeConn) SetPongHandler(func(string) error)         {}
+func (c *fakeConn) WriteControl(int, []byte, time.Time) error { return nil }
+func (c *fakeConn) Close() error                              { c.closeOnce.Do(func() { close(c.closed) }); return nil }
+
+func (c *fakeConn) ReadMessage() (int, []byte, error) {
+	select {
+	case message := <-c.incoming:
+		return websocket.TextMessage, message, nil
+	case <-c.closed:
+		return 0, nil, &websocket.CloseError{Code: websocket.CloseNormalClosure}
+	}
+}
+
+func (c *fakeConn) WriteMessage(_ int, data []byte) error {
+	if c.stalled {
+		<-c.closed
+		return errors.New("closed")
+	}
+	var envelope collab.Envelope
+	_ = json.Unmarshal(data, &envelope)
+	select {
+	case c.written <- envelope:
+		return nil
+	case <-c.closed:
+		return errors.New("closed")
+	}
+}
+
+func (c *fakeConn) send(t *testing.T, messageType string, data any) {
+	payload, _ := json.Marshal(data)
+	message, _ := json.Marshal(collab.Envelope{V: 1, Type: messageType, Data: payload})
+	select {
+	case c.incoming <- message:
+	case <-time.After(time.Second):
+		t.Fatalf("session did not read %s", messageType)
+	}
+}
+
+// next returns the data of the next written message of the given type.
+func next[T any](t *testing.T, c *fakeConn, messageType string) T {
+	t.Helper()
+	for {
+		select {
+		case envelope := <-c.written:
+			if envelope.Type != messageType {
+				continue
+			}
+			var data T
+			assert.NoError(t, json.Unmarshal(envelope.Data, &data))
+			return data
+		case <-time.After(time.Second):
+			t.Fatalf("no %s message", messageType)
+		}
+	}
+}
+
+func serve(hub *collab.Hub, conn *fakeConn, userID int) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- hub.Serve(context.Background(), conn, userID) }()
+	return done
+}
+
+func TestHub_PresenceAndDragPreview(t *testing.T) {
+	// given
+	access := collabmocks.NewAccessRepository(t)
+	access.EXPECT().CanViewProject(mock.Anything, 7, 3).Return(true, nil)
+	hub := collab.NewHub(access, time.Minute)
+	alice, bob := newFakeConn(false), newFakeConn(false)
+	aliceDone := serve(hub, alice, 7)
+	serve(hub, bob, 7)
+	aliceSession := next[collab.WelcomeData](t, alice, collab.TypeWelcome).SessionID
+
+	// when
+	alice.send(t, collab.TypeJoin, collab.RoomData{ProjectID: 3})
+	next[collab.PresenceData](t, alice, collab.TypePresence)
+	bob.send(t, collab.TypeJoin, collab.RoomData{ProjectID: 3})
+	presence := next[collab.PresenceData](t, alice, collab.TypePresence)
+	alice.send(t, collab.TypeDrag, collab.DragData{ProjectID: 3, TodoID: 5, Status: "done", Position: 2})
+	preview := next[collab.DragPreview](t, bob, collab.TypeDrag)
+	alice.Close()
+	afterLeave := next[collab.PresenceData](t, bob, collab.TypePresence)
+	for len(afterLeave.Viewers) != 1 {
+		afterLeave = next[collab.PresenceData](t, bob, collab.TypePresence)
+	}
+
+	// then
+	assert.Len(t, presence.Viewers, 2)
+	assert.Equal(t, 5, preview.TodoID)
+	assert.Equal(t, aliceSession, preview.SessionID)
+	assert.NoError(t, <-aliceDone)
+	assert.NotEqual(t, aliceSession, afterLeave.Viewers[0].SessionID)
+}
+
+func TestHub_DropsClientThatStopsReading(t *testing.T) {
+	// given
+	access := collabmocks.NewAccessRepository(t)
+	access.EXPECT().CanViewProject(mock.Anything, 7, 3).Return(true, nil)
+	hub := collab.NewHub(access, time.Minute)
+	fast, slow := newFakeConn(false), newFakeConn(true)
+	serve(hub, fast, 7)
+	slowDone := serve(hub, slow, 7)
+	slow.send(t, collab.TypeJoin, collab.RoomData{ProjectID: 3})
+	fast.send(t, collab.TypeJoin, collab.RoomData{ProjectID: 3})
+
+	// when
+	for i := 0; i < 200 && len(hub.Viewers(3)) == 2; i++ {
+		fast.send(t, collab.TypeDrag, collab.DragData{ProjectID: 3, TodoID: 5, Position: i})
+	}
+
+	// then
+	assert.Len(t, hub.Viewers(3), 1)
+	slow.Close()
+	assert.NoError(t, <-slowDone)
+}
+
+func TestHub_RejectsInvalidMessages(t *testing.T) {
+	// given
+	access := collabmocks.NewAccessRepository(t)
+	access.EXPECT().CanViewProject(mock.Anything, 7, 4).Return(false, nil)
+	hub := collab.NewHub(access, time.Minute)
+	conn := newFakeConn(false)
+	serve(hub, conn, 7)
+
+	// when
+	conn.send(t, collab.TypeJoin, collab.RoomData{ProjectID: 4})
+	forbidden := next[collab.ErrorData](t, conn, collab.TypeError)
+	conn.send(t, collab.TypeDrag, collab.DragData{ProjectID: 4, TodoID: 1})
+	notJoined := next[collab.ErrorData](t, conn, collab.TypeError)
+	conn.incoming <- []byte(`{"v":2,"type":"join","data":{"project_id":4}}`)
+	newer := next[collab.ErrorData](t, conn, collab.TypeError)
+	conn.send(t, "cursor", nil)
+	unknown := next[collab.ErrorData](t, conn, collab.TypeError)
+	conn.Close()
+
+	// then
+	assert.Equal(t, collab.CodeForbidden, forbidden.Code)
+	assert.Equal(t, collab.CodeNotJoined, notJoined.Code)
+	assert.Equal(t, collab.CodeUnsupportedVersion, newer.Code)
+	assert.Equal(t, collab.CodeUnknownType, unknown.Code)
+}
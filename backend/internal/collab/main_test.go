@@ -0,0 +1,10 @@
+package collab_test
+
+import (
+	"testing"
+	"yangdongju/gtd_todo/testhelper"
+)
+
+func TestMain(m *testing.M) {
+	testhelper.TestMain(m)
+}
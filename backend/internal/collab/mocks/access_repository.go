@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package collabmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessRepository is an autogenerated mock type for the AccessRepository type
+type AccessRepository struct {
+	mock.Mock
+}
+
+type AccessRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccessRepository) EXPECT() *AccessRepository_Expecter {
+	return &AccessRepository_Expecter{mock: &_m.Mock}
+}
+
+// CanViewProject provides a mock function with given fields: ctx, userID, projectID
+func (_m *AccessRepository) CanViewProject(ctx context.Context, userID int, projectID int) (bool, error) {
+	ret := _m.Called(ctx, userID, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanViewProject")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (bool, error)); ok {
+		return rf(ctx, userID, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, userID, projectID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessRepository_CanViewProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CanViewProject'
+type AccessRepository_CanViewProject_Call struct {
+	*mock.Call
+}
+
+// CanViewProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - projectID int
+func (_e *AccessRepository_Expecter) CanViewProject(ctx interface{}, userID interface{}, projectID interface{}) *AccessRepository_CanViewProject_Call {
+	return &AccessRepository_CanViewProject_Call{Call: _e.mock.On("CanViewProject", ctx, userID, projectID)}
+}
+
+func (_c *AccessRepository_CanViewProject_Call) Run(run func(ctx context.Context, userID int, projectID int)) *AccessRepository_CanViewProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *AccessRepository_CanViewProject_Call) Return(_a0 bool, _a1 error) *AccessRepository_CanViewProject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccessRepository_CanViewProject_Call) RunAndReturn(run func(context.Context, int, int) (bool, error)) *AccessRepository_CanViewProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccessRepository creates a new instance of AccessRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccessRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccessRepository {
+	mock := &AccessRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,443 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package collabmocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Conn is an autogenerated mock type for the Conn type
+type Conn struct {
+	mock.Mock
+}
+
+type Conn_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Conn) EXPECT() *Conn_Expecter {
+	return &Conn_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with no fields
+func (_m *Conn) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Conn_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type Conn_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *Conn_Expecter) Close() *Conn_Close_Call {
+	return &Conn_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *Conn_Close_Call) Run(run func()) *Conn_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Conn_Close_Call) Return(_a0 error) *Conn_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Conn_Close_Call) RunAndReturn(run func() error) *Conn_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadMessage provides a mock function with no fields
+func (_m *Conn) ReadMessage() (int, []byte, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadMessage")
+	}
+
+	var r0 int
+	var r1 []byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (int, []byte, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() []byte); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Conn_ReadMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadMessage'
+type Conn_ReadMessage_Call struct {
+	*mock.Call
+}
+
+// ReadMessage is a helper method to define mock.On call
+func (_e *Conn_Expecter) ReadMessage() *Conn_ReadMessage_Call {
+	return &Conn_ReadMessage_Call{Call: _e.mock.On("ReadMessage")}
+}
+
+func (_c *Conn_ReadMessage_Call) Run(run func()) *Conn_ReadMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Conn_ReadMessage_Call) Return(messageType int, p []byte, err error) *Conn_ReadMessage_Call {
+	_c.Call.Return(messageType, p, err)
+	return _c
+}
+
+func (_c *Conn_ReadMessage_Call) RunAndReturn(run func() (int, []byte, error)) *Conn_ReadMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPongHandler provides a mock function with given fields: h
+func (_m *Conn) SetPongHandler(h func(string) error) {
+	_m.Called(h)
+}
+
+// Conn_SetPongHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPongHandler'
+type Conn_SetPongHandler_Call struct {
+	*mock.Call
+}
+
+// SetPongHandler is a helper method to define mock.On call
+//   - h func(string) error
+func (_e *Conn_Expecter) SetPongHandler(h interface{}) *Conn_SetPongHandler_Call {
+	return &Conn_SetPongHandler_Call{Call: _e.mock.On("SetPongHandler", h)}
+}
+
+func (_c *Conn_SetPongHandler_Call) Run(run func(h func(string) error)) *Conn_SetPongHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(func(string) error))
+	})
+	return _c
+}
+
+func (_c *Conn_SetPongHandler_Call) Return() *Conn_SetPongHandler_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Conn_SetPongHandler_Call) RunAndReturn(run func(func(string) error)) *Conn_SetPongHandler_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetReadDeadline provides a mock function with given fields: t
+func (_m *Conn) SetReadDeadline(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReadDeadline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Conn_SetReadDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReadDeadline'
+type Conn_SetReadDeadline_Call struct {
+	*mock.Call
+}
+
+// SetReadDeadline is a helper method to define mock.On call
+//   - t time.Time
+func (_e *Conn_Expecter) SetReadDeadline(t interface{}) *Conn_SetReadDeadline_Call {
+	return &Conn_SetReadDeadline_Call{Call: _e.mock.On("SetReadDeadline", t)}
+}
+
+func (_c *Conn_SetReadDeadline_Call) Run(run func(t time.Time)) *Conn_SetReadDeadline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Conn_SetReadDeadline_Call) Return(_a0 error) *Conn_SetReadDeadline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Conn_SetReadDeadline_Call) RunAndReturn(run func(time.Time) error) *Conn_SetReadDeadline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetReadLimit provides a mock function with given fields: limit
+func (_m *Conn) SetReadLimit(limit int64) {
+	_m.Called(limit)
+}
+
+// Conn_SetReadLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReadLimit'
+type Conn_SetReadLimit_Call struct {
+	*mock.Call
+}
+
+// SetReadLimit is a helper method to define mock.On call
+//   - limit int64
+func (_e *Conn_Expecter) SetReadLimit(limit interface{}) *Conn_SetReadLimit_Call {
+	return &Conn_SetReadLimit_Call{Call: _e.mock.On("SetReadLimit", limit)}
+}
+
+func (_c *Conn_SetReadLimit_Call) Run(run func(limit int64)) *Conn_SetReadLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Conn_SetReadLimit_Call) Return() *Conn_SetReadLimit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Conn_SetReadLimit_Call) RunAndReturn(run func(int64)) *Conn_SetReadLimit_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SetWriteDeadline provides a mock function with given fields: t
+func (_m *Conn) SetWriteDeadline(t time.Time) error {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWriteDeadline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Conn_SetWriteDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWriteDeadline'
+type Conn_SetWriteDeadline_Call struct {
+	*mock.Call
+}
+
+// SetWriteDeadline is a helper method to define mock.On call
+//   - t time.Time
+func (_e *Conn_Expecter) SetWriteDeadline(t interface{}) *Conn_SetWriteDeadline_Call {
+	return &Conn_SetWriteDeadline_Call{Call: _e.mock.On("SetWriteDeadline", t)}
+}
+
+func (_c *Conn_SetWriteDeadline_Call) Run(run func(t time.Time)) *Conn_SetWriteDeadline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Conn_SetWriteDeadline_Call) Return(_a0 error) *Conn_SetWriteDeadline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Conn_SetWriteDeadline_Call) RunAndReturn(run func(time.Time) error) *Conn_SetWriteDeadline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subprotocol provides a mock function with no fields
+func (_m *Conn) Subprotocol() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subprotocol")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Conn_Subprotocol_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subprotocol'
+type Conn_Subprotocol_Call struct {
+	*mock.Call
+}
+
+// Subprotocol is a helper method to define mock.On call
+func (_e *Conn_Expecter) Subprotocol() *Conn_Subprotocol_Call {
+	return &Conn_Subprotocol_Call{Call: _e.mock.On("Subprotocol")}
+}
+
+func (_c *Conn_Subprotocol_Call) Run(run func()) *Conn_Subprotocol_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Conn_Subprotocol_Call) Return(_a0 string) *Conn_Subprotocol_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Conn_Subprotocol_Call) RunAndReturn(run func() string) *Conn_Subprotocol_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteControl provides a mock function with given fields: messageType, data, deadline
+func (_m *Conn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	ret := _m.Called(messageType, data, deadline)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteControl")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []byte, time.Time) error); ok {
+		r0 = rf(messageType, data, deadline)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Conn_WriteControl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteControl'
+type Conn_WriteControl_Call struct {
+	*mock.Call
+}
+
+// WriteControl is a helper method to define mock.On call
+//   - messageType int
+//   - data []byte
+//   - deadline time.Time
+func (_e *Conn_Expecter) WriteControl(messageType interface{}, data interface{}, deadline interface{}) *Conn_WriteControl_Call {
+	return &Conn_WriteControl_Call{Call: _e.mock.On("WriteControl", messageType, data, deadline)}
+}
+
+func (_c *Conn_WriteControl_Call) Run(run func(messageType int, data []byte, deadline time.Time)) *Conn_WriteControl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].([]byte), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Conn_WriteControl_Call) Return(_a0 error) *Conn_WriteControl_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Conn_WriteControl_Call) RunAndReturn(run func(int, []byte, time.Time) error) *Conn_WriteControl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteMessage provides a mock function with given fields: messageType, data
+func (_m *Conn) WriteMessage(messageType int, data []byte) error {
+	ret := _m.Called(messageType, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []byte) error); ok {
+		r0 = rf(messageType, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Conn_WriteMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteMessage'
+type Conn_WriteMessage_Call struct {
+	*mock.Call
+}
+
+// WriteMessage is a helper method to define mock.On call
+//   - messageType int
+//   - data []byte
+func (_e *Conn_Expecter) WriteMessage(messageType interface{}, data interface{}) *Conn_WriteMessage_Call {
+	return &Conn_WriteMessage_Call{Call: _e.mock.On("WriteMessage", messageType, data)}
+}
+
+func (_c *Conn_WriteMessage_Call) Run(run func(messageType int, data []byte)) *Conn_WriteMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *Conn_WriteMessage_Call) Return(_a0 error) *Conn_WriteMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Conn_WriteMessage_Call) RunAndReturn(run func(int, []byte) error) *Conn_WriteMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewConn creates a new instance of Conn. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewConn(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Conn {
+	mock := &Conn{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
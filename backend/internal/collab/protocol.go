@@ -0,0 +1,111 @@
+package collab
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ProtocolVersion is the newest message protocol this server speaks. Clients pick a version
+// with the Sec-WebSocket-Protocol "gtd.v<N>"; clients that send none get version 1, and
+// each session is answered in the version it negotiated.
+const ProtocolVersion = 1
+
+const subprotocolPrefix = "gtd.v"
+
+// Client to server.
+const (
+	TypeJoin    = "join"
+	TypeLeave   = "leave"
+	TypeDrag    = "drag"
+	TypeDragEnd = "drag_end"
+)
+
+// Server to client. Drag and drag_end are relayed to the other viewers of the project.
+const (
+	TypeWelcome  = "welcome"
+	TypePresence = "presence"
+	TypeError    = "error"
+)
+
+const (
+	CodeBadMessage         = "bad_message"
+	CodeUnsupportedVersion = "unsupported_version"
+	CodeUnknownType        = "unknown_type"
+	CodeForbidden          = "forbidden"
+	CodeNotJoined          = "not_joined"
+	CodeTooManyRooms       = "too_many_rooms"
+	CodeInternal           = "internal"
+)
+
+// Envelope wraps every message. Unknown fields are ignored, so newer clients can send
+// additions an older server does not understand yet.
+type Envelope struct {
+	V    int             `json:"v"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+type RoomData struct {
+	ProjectID int `json:"project_id"`
+}
+
+// DragData is a preview of a card being dragged. It is relayed as is and never stored;
+// the move itself is still made through the REST API.
+type DragData struct {
+	ProjectID int    `json:"project_id"`
+	TodoID    int    `json:"todo_id"`
+	Status    string `json:"status,omitempty"`
+	Position  int    `json:"position"`
+}
+
+type DragPreview struct {
+	DragData
+	SessionID string `json:"session_id"`
+	UserID    int    `json:"user_id"`
+}
+
+type WelcomeData struct {
+	SessionID        string `json:"session_id"`
+	Protocol         int    `json:"protocol"`
+	HeartbeatSeconds int    `json:"heartbeat_seconds"`
+}
+
+type PresenceData struct {
+	ProjectID int      `json:"project_id"`
+	Viewers   []Viewer `json:"viewers"`
+}
+
+type Viewer struct {
+	SessionID string `json:"session_id"`
+	UserID    int    `json:"user_id"`
+}
+
+type ErrorData struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Subprotocols lists the protocols offered during the handshake, newest first.
+func Subprotocols() []string {
+	protocols := make([]string, 0, ProtocolVersion)
+	for v := ProtocolVersion; v >= 1; v-- {
+		protocols = append(protocols, subprotocolPrefix+strconv.Itoa(v))
+	}
+	return protocols
+}
+
+// VersionOf returns the protocol version of a negotiated subprotocol.
+func VersionOf(subprotocol string) int {
+	for v := ProtocolVersion; v >= 1; v-- {
+		if subprotocol == subprotocolPrefix+strconv.Itoa(v) {
+			return v
+		}
+	}
+	return 1
+}
+
+func encode(version int, messageType string, data any) []byte {
+	payload, _ := json.Marshal(data)
+	message, _ := json.Marshal(Envelope{V: version, Type: messageType, Data: payload})
+	return message
+}
@@ -0,0 +1,31 @@
+package collab
+
+import (
+	"context"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AccessRepository interface {
+	CanViewProject(ctx context.Context, userID int, projectID int) (bool, error)
+}
+
+type accessRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewAccessRepository(db *sqlx.DB) *accessRepositoryImpl {
+	return &accessRepositoryImpl{db: db}
+}
+
+// CanViewProject allows the owner of the project and nobody else. Projects have no members
+// yet, so a room only ever holds the owner's own sessions, e.g. a laptop and a phone.
+// Sharing a board with other users is out of scope until project membership exists; this
+// is the one check to extend then.
+func (r *accessRepositoryImpl) CanViewProject(ctx context.Context, userID int, projectID int) (bool, error) {
+	var exists bool
+	err := database.Conn(ctx, r.db).GetContext(ctx, &exists,
+		"SELECT EXISTS (SELECT 1 FROM projects WHERE id = $1 AND user_id = $2)", projectID, userID)
+	return exists, err
+}
@@ -0,0 +1,30 @@
+package collab_test
+
+import (
+	"context"
+	"testing"
+	"yangdongju/gtd_todo/internal/collab"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessRepository_OnlyOwnerCanViewProject(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID, projectID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	_ = testhelper.GetTestDB().Get(&projectID,
+		"INSERT INTO projects (user_id, name) VALUES ($1, 'Home') RETURNING id", userID)
+	repository := collab.NewAccessRepository(testhelper.GetTestDB())
+
+	// when
+	owner, err := repository.CanViewProject(context.Background(), userID, projectID)
+	stranger, _ := repository.CanViewProject(context.Background(), userID+1, projectID)
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, owner)
+	assert.False(t, stranger)
+}
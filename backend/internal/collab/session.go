@@ -0,0 +1,162 @@
+package collab
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	maxMessageSize = 4096
+)
+
+// Conn is the part of *websocket.Conn a session uses.
+type Conn interface {
+	Subprotocol() string
+	SetReadLimit(limit int64)
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+	SetPongHandler(h func(appData string) error)
+	ReadMessage() (messageType int, p []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	Close() error
+}
+
+// Session is one connected socket. rooms is guarded by the hub's mutex.
+type Session struct {
+	ID      string
+	UserID  int
+	version int
+
+	outbox      chan []byte
+	dropped     chan struct{}
+	dropOnce    sync.Once
+	closeCode   int
+	closeReason string
+	rooms       map[int]struct{}
+}
+
+func newSession(userID int, version int) *Session {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return &Session{
+		ID:      hex.EncodeToString(id),
+		UserID:  userID,
+		version: version,
+		outbox:  make(chan []byte, sendBufferSize),
+		dropped: make(chan struct{}),
+		rooms:   map[int]struct{}{},
+	}
+}
+
+// enqueue never blocks. It reports false when the outbox is full or the session is gone.
+func (s *Session) enqueue(message []byte) bool {
+	select {
+	case <-s.dropped:
+		return false
+	default:
+	}
+	select {
+	case s.outbox <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+// drop ends the session. The first call decides the close frame sent to the client.
+func (s *Session) drop(code int, reason string) {
+	s.dropOnce.Do(func() {
+		s.closeCode, s.closeReason = code, reason
+		close(s.dropped)
+	})
+}
+
+// Serve runs the session on an upgraded connection until the client goes away, stops
+// answering pings, falls behind, or ctx is done. The connection is closed on return.
+func (h *Hub) Serve(ctx context.Context, conn Conn, userID int) error {
+	s := newSession(userID, VersionOf(conn.Subprotocol()))
+	defer h.disconnect(s)
+
+	h.send(s, TypeWelcome, WelcomeData{SessionID: s.ID, Protocol: s.version, HeartbeatSeconds: int(h.heartbeat / time.Second)})
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		h.write(ctx, conn, s)
+	}()
+
+	err := h.read(ctx, conn, s)
+	h.disconnect(s)
+	<-written
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) {
+		return nil
+	}
+	return err
+}
+
+// read handles incoming messages. A client that misses two heartbeats times out.
+func (h *Hub) read(ctx context.Context, conn Conn, s *Session) error {
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	})
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-s.dropped:
+				return nil
+			case <-ctx.Done():
+				return nil
+			default:
+				return err
+			}
+		}
+		h.handle(ctx, s, raw)
+	}
+}
+
+// write is the only writer of the connection. It closes the connection when the session
+// is dropped, which also ends read.
+func (h *Hub) write(ctx context.Context, conn Conn, s *Session) {
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	defer conn.Close()
+
+	for {
+		select {
+		case message := <-s.outbox:
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				s.drop(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				s.drop(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ctx.Done():
+			closeWith(conn, websocket.CloseGoingAway, "server shutting down")
+			return
+		case <-s.dropped:
+			if s.closeCode != websocket.CloseAbnormalClosure {
+				closeWith(conn, s.closeCode, s.closeReason)
+			}
+			return
+		}
+	}
+}
+
+func closeWith(conn Conn, code int, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+}
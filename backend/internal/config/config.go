@@ -2,8 +2,10 @@ package config
 
 import (
 	"log"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +20,46 @@ type Config struct {
 	DBMaxOpenConnection     int
 	DBMaxIdleConnection     int
 	DBConnectionMaxLifeTime int
+
+	ServerPort             string
+	ReadinessTimeoutMillis int
+	ShutdownDrainSeconds   int
+	ShutdownTimeoutSeconds int
+
+	RequestValidationEnabled bool
+
+	TrustedProxies []string
+
+	RateLimitStore          string
+	RateLimitAuthPerMinute  int
+	RateLimitReadPerMinute  int
+	RateLimitWritePerMinute int
+
+	IdempotencyTTLHours int
+
+	EventRetentionHours int
+
+	TombstoneRetentionHours int
+
+	ReminderNotifier      string
+	ReminderPollSeconds   int
+	SMTPHost              string
+	SMTPPort              string
+	SMTPUsername          string
+	SMTPPassword          string
+	SMTPFrom              string
+	ReminderWebhookURL    string
+	ReminderWebhookSecret string
+
+	AttentionDigestNotifier    string
+	AttentionDigestPollSeconds int
+	AttentionWebhookURL        string
+	AttentionWebhookSecret     string
+
+	NextWeightDue     float64
+	NextWeightAge     float64
+	NextWeightProject float64
+	NextWeightBlocked float64
 }
 
 func Load() *Config {
@@ -34,6 +76,45 @@ func Load() *Config {
 		DBMaxOpenConnection:     mustAtoi(os.Getenv("DB_MAX_OPEN_CONNECTION")),
 		DBMaxIdleConnection:     mustAtoi(os.Getenv("DB_MAX_IDLE_CONNECTION")),
 		DBConnectionMaxLifeTime: mustAtoi(os.Getenv("DB_CONNECTION_MAX_LIFE_TIME")),
+		ServerPort:              getEnvOrDefault("SERVER_PORT", "8080"),
+		ReadinessTimeoutMillis:  atoiOrDefault("READINESS_TIMEOUT_MILLIS", 1000),
+		ShutdownDrainSeconds:    atoiOrDefault("SHUTDOWN_DRAIN_SECONDS", 5),
+		ShutdownTimeoutSeconds:  atoiOrDefault("SHUTDOWN_TIMEOUT_SECONDS", 10),
+
+		RequestValidationEnabled: boolOrDefault("REQUEST_VALIDATION_ENABLED", false),
+
+		TrustedProxies: listOrDefault("TRUSTED_PROXIES", nil),
+
+		RateLimitStore:          getEnvOrDefault("RATE_LIMIT_STORE", "memory"),
+		RateLimitAuthPerMinute:  atoiOrDefault("RATE_LIMIT_AUTH_PER_MINUTE", 10),
+		RateLimitReadPerMinute:  atoiOrDefault("RATE_LIMIT_READ_PER_MINUTE", 300),
+		RateLimitWritePerMinute: atoiOrDefault("RATE_LIMIT_WRITE_PER_MINUTE", 60),
+
+		IdempotencyTTLHours: atoiOrDefault("IDEMPOTENCY_TTL_HOURS", 24),
+
+		EventRetentionHours: atoiOrDefault("EVENT_RETENTION_HOURS", 24),
+
+		TombstoneRetentionHours: atoiOrDefault("TOMBSTONE_RETENTION_HOURS", 30*24),
+
+		ReminderNotifier:      os.Getenv("REMINDER_NOTIFIER"),
+		ReminderPollSeconds:   atoiOrDefault("REMINDER_POLL_SECONDS", 30),
+		SMTPHost:              os.Getenv("SMTP_HOST"),
+		SMTPPort:              getEnvOrDefault("SMTP_PORT", "587"),
+		SMTPUsername:          os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:          os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:              os.Getenv("SMTP_FROM"),
+		ReminderWebhookURL:    os.Getenv("REMINDER_WEBHOOK_URL"),
+		ReminderWebhookSecret: os.Getenv("REMINDER_WEBHOOK_SECRET"),
+
+		AttentionDigestNotifier:    os.Getenv("ATTENTION_DIGEST_NOTIFIER"),
+		AttentionDigestPollSeconds: atoiOrDefault("ATTENTION_DIGEST_POLL_SECONDS", 300),
+		AttentionWebhookURL:        os.Getenv("ATTENTION_WEBHOOK_URL"),
+		AttentionWebhookSecret:     os.Getenv("ATTENTION_WEBHOOK_SECRET"),
+
+		NextWeightDue:     floatOrDefault("NEXT_WEIGHT_DUE", 4),
+		NextWeightAge:     floatOrDefault("NEXT_WEIGHT_AGE", 1),
+		NextWeightProject: floatOrDefault("NEXT_WEIGHT_PROJECT", 2),
+		NextWeightBlocked: floatOrDefault("NEXT_WEIGHT_BLOCKED", 10),
 	}
 }
 
@@ -44,3 +125,63 @@ func mustAtoi(value string) int {
 	}
 	return i
 }
+
+func getEnvOrDefault(key string, defaultValue string) string {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func atoiOrDefault(key string, defaultValue int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	return mustAtoi(value)
+}
+
+func boolOrDefault(key string, defaultValue bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("%s must be a valid boolean: %v", key, err)
+	}
+	return b
+}
+
+// floatOrDefault reads a weight. NaN and infinities break the ordering of suggestions and a
+// negative weight turns it around, so they stop the server at startup instead.
+func floatOrDefault(key string, defaultValue float64) float64 {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Fatalf("%s must be a valid number: %v", key, err)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) || f < 0 {
+		log.Fatalf("%s must be a finite number of zero or more: %v", key, value)
+	}
+	return f
+}
+
+// listOrDefault splits a comma separated value, dropping blanks around and between items.
+func listOrDefault(key string, defaultValue []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(value) == "" {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
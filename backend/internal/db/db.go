@@ -9,13 +9,16 @@ import (
 	_ "github.com/lib/pq"
 )
 
-func NewConnectionPool(cfg *config.Config) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf(
+// DSN은 lib/pq 연결 문자열을 만든다. LISTEN 전용 연결도 같은 값을 사용한다.
+func DSN(cfg *config.Config) string {
+	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
 	)
+}
 
-	db, err := sqlx.Connect("postgres", dsn)
+func NewConnectionPool(cfg *config.Config) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("postgres", DSN(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+	uniqueViolationCode      = "23505"
+)
+
+// Executor is the query surface shared by *sqlx.DB and *sqlx.Tx.
+type Executor interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type txManager struct {
+	db         *sqlx.DB
+	isolation  sql.IsolationLevel
+	maxAttempt int
+}
+
+type TxOption func(*txManager)
+
+// WithIsolation replaces the default serializable isolation level.
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(m *txManager) {
+		m.isolation = level
+	}
+}
+
+// WithMaxAttempts bounds how often a failed closure runs. One disables retries, which
+// closures with effects outside the database need.
+func WithMaxAttempts(attempts int) TxOption {
+	return func(m *txManager) {
+		m.maxAttempt = attempts
+	}
+}
+
+func NewTxManager(db *sqlx.DB, opts ...TxOption) *txManager {
+	m := &txManager{
+		db:         db,
+		isolation:  sql.LevelSerializable,
+		maxAttempt: 3,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// WithinTx runs fn in one transaction and retries the whole closure on serialization failures.
+// Calls nested inside an open transaction join it instead of starting a new one.
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= m.maxAttempt; attempt++ {
+		err = m.runOnce(ctx, fn)
+		if !isRetryable(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("transaction failed after %d attempts: %w", m.maxAttempt, err)
+}
+
+func (m *txManager) runOnce(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := m.db.BeginTxx(ctx, &sql.TxOptions{Isolation: m.isolation})
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Conn returns the transaction bound to ctx, or the pool when no transaction is open.
+func Conn(ctx context.Context, pool *sqlx.DB) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+func IsUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == uniqueViolationCode && pqErr.Constraint == constraint
+}
+
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailureCode || pqErr.Code == deadlockDetectedCode
+}
@@ -0,0 +1,150 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func countUsers(t *testing.T) int {
+	var count int
+	err := testhelper.GetTestDB().Get(&count, "SELECT COUNT(*) FROM users")
+	assert.NoError(t, err)
+	return count
+}
+
+func insertUser(ctx context.Context, email string) error {
+	_, err := database.Conn(ctx, testhelper.GetTestDB()).ExecContext(ctx,
+		"INSERT INTO users (email, password_hash) VALUES ($1, 'hash')", email)
+	return err
+}
+
+func TestWithinTx_Commit(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	txManager := database.NewTxManager(testhelper.GetTestDB())
+
+	// when
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := insertUser(ctx, "first@example.com"); err != nil {
+			return err
+		}
+		return insertUser(ctx, "second@example.com")
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, countUsers(t))
+}
+
+func TestWithinTx_RollbackOnError(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	txManager := database.NewTxManager(testhelper.GetTestDB())
+	failure := errors.New("business rule violated")
+
+	// when
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := insertUser(ctx, "first@example.com"); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	// then
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 0, countUsers(t))
+}
+
+func TestWithinTx_NestedCallJoinsOuterTx(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	txManager := database.NewTxManager(testhelper.GetTestDB())
+
+	// when
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		innerErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return insertUser(ctx, "inner@example.com")
+		})
+		if innerErr != nil {
+			return innerErr
+		}
+		return errors.New("outer failed")
+	})
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, 0, countUsers(t))
+}
+
+func TestWithinTx_RetryOnSerializationFailure(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	txManager := database.NewTxManager(testhelper.GetTestDB())
+	attempts := 0
+
+	// when
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return &pq.Error{Code: "40001"}
+		}
+		return insertUser(ctx, "retried@example.com")
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, countUsers(t))
+}
+
+func TestWithinTx_GiveUpAfterMaxAttempts(t *testing.T) {
+	// given
+	txManager := database.NewTxManager(testhelper.GetTestDB())
+	attempts := 0
+
+	// when
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return &pq.Error{Code: "40001"}
+	})
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWithinTx_SingleAttemptDoesNotRetry(t *testing.T) {
+	// given
+	txManager := database.NewTxManager(testhelper.GetTestDB(), database.WithMaxAttempts(1))
+	attempts := 0
+
+	// when
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return &pq.Error{Code: "40001"}
+	})
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	_ = insertUser(context.Background(), "dup@example.com")
+
+	// when
+	err := insertUser(context.Background(), "dup@example.com")
+
+	// then
+	assert.True(t, database.IsUniqueViolation(err, "users_email_key"))
+	assert.False(t, database.IsUniqueViolation(err, "other_constraint"))
+	assert.False(t, database.IsUniqueViolation(errors.New("plain"), "users_email_key"))
+}
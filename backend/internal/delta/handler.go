@@ -0,0 +1,39 @@
+package delta
+
+import (
+	"context"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+)
+
+type SyncHandler struct {
+	syncUsecase SyncUsecase
+}
+
+func NewSyncHandler(syncUsecase SyncUsecase) *SyncHandler {
+	return &SyncHandler{syncUsecase: syncUsecase}
+}
+
+func (h *SyncHandler) HandlePull(ctx context.Context, req PullRequest) (int, any) {
+	res, err := h.syncUsecase.Pull(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+// HandlePush answers 200 even when some mutations were not applied; the outcome of each
+// one is in its result.
+func (h *SyncHandler) HandlePush(ctx context.Context, req PushRequest) (int, any) {
+	res, err := h.syncUsecase.Push(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+func handleError(err error) (int, any) {
+	return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+}
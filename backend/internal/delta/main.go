@@ -0,0 +1,23 @@
+//go:generate mockery
+package delta
+
+import (
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func InitializeHandler(pool *sqlx.DB, publisher event.Publisher) *SyncHandler {
+	txManager := database.NewTxManager(pool)
+	todoService := todo.NewTodoService(todo.NewTodoRepository(pool), txManager, publisher, time.Now)
+	return NewSyncHandler(NewSyncService(
+		NewSyncRepository(pool),
+		txManager,
+		todoService,
+		project.NewProjectService(project.NewProjectRepository(pool), txManager, publisher, todoService),
+	))
+}
@@ -0,0 +1,270 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package deltamocks
+
+import (
+	context "context"
+	delta "yangdongju/gtd_todo/internal/delta"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SyncRepository is an autogenerated mock type for the SyncRepository type
+type SyncRepository struct {
+	mock.Mock
+}
+
+type SyncRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SyncRepository) EXPECT() *SyncRepository_Expecter {
+	return &SyncRepository_Expecter{mock: &_m.Mock}
+}
+
+// Cursor provides a mock function with given fields: ctx, userID
+func (_m *SyncRepository) Cursor(ctx context.Context, userID int) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cursor")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncRepository_Cursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cursor'
+type SyncRepository_Cursor_Call struct {
+	*mock.Call
+}
+
+// Cursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *SyncRepository_Expecter) Cursor(ctx interface{}, userID interface{}) *SyncRepository_Cursor_Call {
+	return &SyncRepository_Cursor_Call{Call: _e.mock.On("Cursor", ctx, userID)}
+}
+
+func (_c *SyncRepository_Cursor_Call) Run(run func(ctx context.Context, userID int)) *SyncRepository_Cursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *SyncRepository_Cursor_Call) Return(_a0 int64, _a1 error) *SyncRepository_Cursor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncRepository_Cursor_Call) RunAndReturn(run func(context.Context, int) (int64, error)) *SyncRepository_Cursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTombstonesSince provides a mock function with given fields: ctx, userID, cursor
+func (_m *SyncRepository) FindTombstonesSince(ctx context.Context, userID int, cursor int64) ([]delta.Tombstone, error) {
+	ret := _m.Called(ctx, userID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTombstonesSince")
+	}
+
+	var r0 []delta.Tombstone
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) ([]delta.Tombstone, error)); ok {
+		return rf(ctx, userID, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) []delta.Tombstone); ok {
+		r0 = rf(ctx, userID, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]delta.Tombstone)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncRepository_FindTombstonesSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTombstonesSince'
+type SyncRepository_FindTombstonesSince_Call struct {
+	*mock.Call
+}
+
+// FindTombstonesSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - cursor int64
+func (_e *SyncRepository_Expecter) FindTombstonesSince(ctx interface{}, userID interface{}, cursor interface{}) *SyncRepository_FindTombstonesSince_Call {
+	return &SyncRepository_FindTombstonesSince_Call{Call: _e.mock.On("FindTombstonesSince", ctx, userID, cursor)}
+}
+
+func (_c *SyncRepository_FindTombstonesSince_Call) Run(run func(ctx context.Context, userID int, cursor int64)) *SyncRepository_FindTombstonesSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *SyncRepository_FindTombstonesSince_Call) Return(_a0 []delta.Tombstone, _a1 error) *SyncRepository_FindTombstonesSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncRepository_FindTombstonesSince_Call) RunAndReturn(run func(context.Context, int, int64) ([]delta.Tombstone, error)) *SyncRepository_FindTombstonesSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveClientID provides a mock function with given fields: ctx, userID, entity, clientID
+func (_m *SyncRepository) ResolveClientID(ctx context.Context, userID int, entity string, clientID string) (int, error) {
+	ret := _m.Called(ctx, userID, entity, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveClientID")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (int, error)); ok {
+		return rf(ctx, userID, entity, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) int); ok {
+		r0 = rf(ctx, userID, entity, clientID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, userID, entity, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncRepository_ResolveClientID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveClientID'
+type SyncRepository_ResolveClientID_Call struct {
+	*mock.Call
+}
+
+// ResolveClientID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - entity string
+//   - clientID string
+func (_e *SyncRepository_Expecter) ResolveClientID(ctx interface{}, userID interface{}, entity interface{}, clientID interface{}) *SyncRepository_ResolveClientID_Call {
+	return &SyncRepository_ResolveClientID_Call{Call: _e.mock.On("ResolveClientID", ctx, userID, entity, clientID)}
+}
+
+func (_c *SyncRepository_ResolveClientID_Call) Run(run func(ctx context.Context, userID int, entity string, clientID string)) *SyncRepository_ResolveClientID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SyncRepository_ResolveClientID_Call) Return(_a0 int, _a1 error) *SyncRepository_ResolveClientID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncRepository_ResolveClientID_Call) RunAndReturn(run func(context.Context, int, string, string) (int, error)) *SyncRepository_ResolveClientID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TombstonesPrunedThrough provides a mock function with given fields: ctx, userID
+func (_m *SyncRepository) TombstonesPrunedThrough(ctx context.Context, userID int) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TombstonesPrunedThrough")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncRepository_TombstonesPrunedThrough_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TombstonesPrunedThrough'
+type SyncRepository_TombstonesPrunedThrough_Call struct {
+	*mock.Call
+}
+
+// TombstonesPrunedThrough is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *SyncRepository_Expecter) TombstonesPrunedThrough(ctx interface{}, userID interface{}) *SyncRepository_TombstonesPrunedThrough_Call {
+	return &SyncRepository_TombstonesPrunedThrough_Call{Call: _e.mock.On("TombstonesPrunedThrough", ctx, userID)}
+}
+
+func (_c *SyncRepository_TombstonesPrunedThrough_Call) Run(run func(ctx context.Context, userID int)) *SyncRepository_TombstonesPrunedThrough_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *SyncRepository_TombstonesPrunedThrough_Call) Return(_a0 int64, _a1 error) *SyncRepository_TombstonesPrunedThrough_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncRepository_TombstonesPrunedThrough_Call) RunAndReturn(run func(context.Context, int) (int64, error)) *SyncRepository_TombstonesPrunedThrough_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSyncRepository creates a new instance of SyncRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSyncRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SyncRepository {
+	mock := &SyncRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
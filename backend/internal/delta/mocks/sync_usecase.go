@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package deltamocks
+
+import (
+	context "context"
+	delta "yangdongju/gtd_todo/internal/delta"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SyncUsecase is an autogenerated mock type for the SyncUsecase type
+type SyncUsecase struct {
+	mock.Mock
+}
+
+type SyncUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SyncUsecase) EXPECT() *SyncUsecase_Expecter {
+	return &SyncUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Pull provides a mock function with given fields: ctx, request
+func (_m *SyncUsecase) Pull(ctx context.Context, request delta.PullRequest) (*delta.PullResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pull")
+	}
+
+	var r0 *delta.PullResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, delta.PullRequest) (*delta.PullResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, delta.PullRequest) *delta.PullResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*delta.PullResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, delta.PullRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncUsecase_Pull_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pull'
+type SyncUsecase_Pull_Call struct {
+	*mock.Call
+}
+
+// Pull is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request delta.PullRequest
+func (_e *SyncUsecase_Expecter) Pull(ctx interface{}, request interface{}) *SyncUsecase_Pull_Call {
+	return &SyncUsecase_Pull_Call{Call: _e.mock.On("Pull", ctx, request)}
+}
+
+func (_c *SyncUsecase_Pull_Call) Run(run func(ctx context.Context, request delta.PullRequest)) *SyncUsecase_Pull_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(delta.PullRequest))
+	})
+	return _c
+}
+
+func (_c *SyncUsecase_Pull_Call) Return(_a0 *delta.PullResponse, _a1 error) *SyncUsecase_Pull_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncUsecase_Pull_Call) RunAndReturn(run func(context.Context, delta.PullRequest) (*delta.PullResponse, error)) *SyncUsecase_Pull_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Push provides a mock function with given fields: ctx, request
+func (_m *SyncUsecase) Push(ctx context.Context, request delta.PushRequest) (*delta.PushResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Push")
+	}
+
+	var r0 *delta.PushResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, delta.PushRequest) (*delta.PushResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, delta.PushRequest) *delta.PushResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*delta.PushResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, delta.PushRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncUsecase_Push_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Push'
+type SyncUsecase_Push_Call struct {
+	*mock.Call
+}
+
+// Push is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request delta.PushRequest
+func (_e *SyncUsecase_Expecter) Push(ctx interface{}, request interface{}) *SyncUsecase_Push_Call {
+	return &SyncUsecase_Push_Call{Call: _e.mock.On("Push", ctx, request)}
+}
+
+func (_c *SyncUsecase_Push_Call) Run(run func(ctx context.Context, request delta.PushRequest)) *SyncUsecase_Push_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(delta.PushRequest))
+	})
+	return _c
+}
+
+func (_c *SyncUsecase_Push_Call) Return(_a0 *delta.PushResponse, _a1 error) *SyncUsecase_Push_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncUsecase_Push_Call) RunAndReturn(run func(context.Context, delta.PushRequest) (*delta.PushResponse, error)) *SyncUsecase_Push_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSyncUsecase creates a new instance of SyncUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSyncUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SyncUsecase {
+	mock := &SyncUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
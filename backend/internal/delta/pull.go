@@ -0,0 +1,77 @@
+package delta
+
+import (
+	"context"
+	"time"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+// Pull returns everything written after the cursor in Since, read from one snapshot so the
+// returned cursor covers exactly the changes in the response.
+func (s *syncService) Pull(ctx context.Context, req PullRequest) (*PullResponse, error) {
+	res := &PullResponse{}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if res.Cursor, err = s.syncRepository.Cursor(ctx, req.UserID); err != nil {
+			return err
+		}
+		prunedThrough, err := s.syncRepository.TombstonesPrunedThrough(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if req.Since > 0 && req.Since < prunedThrough {
+			res.Reset = true
+			req.Since = 0
+		}
+		res.Todos, err = s.todoUsecase.ListChanged(ctx, todo.ListChangedTodosRequest{UserID: req.UserID, Since: req.Since})
+		if err != nil {
+			return err
+		}
+		res.Projects, err = s.projectUsecase.ListChanged(ctx, project.ListChangedProjectsRequest{UserID: req.UserID, Since: req.Since})
+		if err != nil {
+			return err
+		}
+
+		tombstones, err := s.syncRepository.FindTombstonesSince(ctx, req.UserID, req.Since)
+		if err != nil {
+			return err
+		}
+		res.Tombstones = make([]TombstoneResponse, 0, len(tombstones))
+		for _, tombstone := range tombstones {
+			res.Tombstones = append(res.Tombstones, TombstoneResponse{
+				Entity:    tombstone.EntityType,
+				ID:        tombstone.EntityID,
+				ClientID:  tombstone.ClientID,
+				DeletedAt: tombstone.DeletedAt,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type PullRequest struct {
+	UserID int   `json:"-" auth:"user_id"`
+	Since  int64 `json:"-" form:"since" binding:"gte=0"`
+}
+
+// PullResponse is what changed after the cursor. Reset means the cursor was older than
+// tombstone retention: everything is sent again and the client replaces what it holds.
+type PullResponse struct {
+	Cursor     int64                     `json:"cursor"`
+	Reset      bool                      `json:"reset"`
+	Todos      []todo.TodoResponse       `json:"todos"`
+	Projects   []project.ProjectResponse `json:"projects"`
+	Tombstones []TombstoneResponse       `json:"tombstones"`
+}
+
+type TombstoneResponse struct {
+	Entity    string    `json:"entity"`
+	ID        int       `json:"id"`
+	ClientID  *string   `json:"client_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
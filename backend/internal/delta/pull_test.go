@@ -0,0 +1,70 @@
+package delta_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/delta"
+	deltamocks "yangdongju/gtd_todo/internal/delta/mocks"
+	"yangdongju/gtd_todo/internal/project"
+	projectmocks "yangdongju/gtd_todo/internal/project/mocks"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPull_ReturnsChangesAndTombstonesSinceCursor(t *testing.T) {
+	// given
+	deletedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	mockRepo := deltamocks.NewSyncRepository(t)
+	mockRepo.EXPECT().Cursor(mock.Anything, 7).Return(int64(12), nil)
+	mockRepo.EXPECT().TombstonesPrunedThrough(mock.Anything, 7).Return(int64(3), nil)
+	mockRepo.EXPECT().FindTombstonesSince(mock.Anything, 7, int64(10)).Return([]delta.Tombstone{
+		{EntityType: delta.EntityTodo, EntityID: 2, ChangeSeq: 11, DeletedAt: deletedAt},
+	}, nil)
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().ListChanged(mock.Anything, todo.ListChangedTodosRequest{UserID: 7, Since: 10}).
+		Return([]todo.TodoResponse{{ID: 4, Title: "Buy milk", Version: 2}}, nil)
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().ListChanged(mock.Anything, project.ListChangedProjectsRequest{UserID: 7, Since: 10}).
+		Return([]project.ProjectResponse{}, nil)
+
+	service := delta.NewSyncService(mockRepo, passThroughTxManager{}, mockTodos, mockProjects)
+
+	// when
+	res, err := service.Pull(context.Background(), delta.PullRequest{UserID: 7, Since: 10})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), res.Cursor)
+	assert.Equal(t, 4, res.Todos[0].ID)
+	assert.Empty(t, res.Projects)
+	assert.Equal(t, []delta.TombstoneResponse{{Entity: delta.EntityTodo, ID: 2, DeletedAt: deletedAt}}, res.Tombstones)
+}
+
+func TestPull_CursorOlderThanRetentionResyncsEverything(t *testing.T) {
+	// given
+	mockRepo := deltamocks.NewSyncRepository(t)
+	mockRepo.EXPECT().Cursor(mock.Anything, 7).Return(int64(40), nil)
+	mockRepo.EXPECT().TombstonesPrunedThrough(mock.Anything, 7).Return(int64(20), nil)
+	mockRepo.EXPECT().FindTombstonesSince(mock.Anything, 7, int64(0)).Return([]delta.Tombstone{}, nil)
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().ListChanged(mock.Anything, todo.ListChangedTodosRequest{UserID: 7, Since: 0}).
+		Return([]todo.TodoResponse{{ID: 4, Title: "Buy milk", Version: 2}}, nil)
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().ListChanged(mock.Anything, project.ListChangedProjectsRequest{UserID: 7, Since: 0}).
+		Return([]project.ProjectResponse{}, nil)
+
+	service := delta.NewSyncService(mockRepo, passThroughTxManager{}, mockTodos, mockProjects)
+
+	// when
+	res, err := service.Pull(context.Background(), delta.PullRequest{UserID: 7, Since: 10})
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, res.Reset)
+	assert.Equal(t, int64(40), res.Cursor)
+	assert.Len(t, res.Todos, 1)
+}
@@ -0,0 +1,296 @@
+package delta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+const (
+	StatusApplied  = "applied"
+	StatusConflict = "conflict"
+	StatusNotFound = "not_found"
+	StatusRejected = "rejected"
+	StatusFailed   = "failed"
+)
+
+// Push applies the mutations in order, each in its own transaction, and reports one result
+// per mutation. A failing mutation does not stop the ones after it.
+func (s *syncService) Push(ctx context.Context, req PushRequest) (*PushResponse, error) {
+	res := &PushResponse{Results: make([]MutationResult, 0, len(req.Mutations))}
+	for i, mutation := range req.Mutations {
+		var result MutationResult
+		switch mutation.Entity {
+		case EntityTodo:
+			result = s.applyTodo(ctx, req.UserID, mutation)
+		case EntityProject:
+			result = s.applyProject(ctx, req.UserID, mutation)
+		default:
+			result = rejected(fmt.Errorf("unknown entity %q", mutation.Entity))
+		}
+		result.Index = i
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// todoReferences holds the fields of todo data that point at other entities by client id.
+type todoReferences struct {
+	ProjectClientID *string `json:"project_client_id"`
+}
+
+func (s *syncService) applyTodo(ctx context.Context, userID int, m Mutation) MutationResult {
+	var refs todoReferences
+	if err := decode(m.Data, &refs); err != nil {
+		return rejected(err)
+	}
+
+	switch m.Op {
+	case OpCreate:
+		if m.ClientID == nil {
+			return rejected(errors.New("client_id is required to create a todo"))
+		}
+		req := todo.CreateTodoRequest{}
+		if err := s.decodeValid(m.Data, &req); err != nil {
+			return rejected(err)
+		}
+		req.UserID, req.ClientID = userID, m.ClientID
+		if result, ok := s.resolveProject(ctx, userID, refs, &req.ProjectID); !ok {
+			return result
+		}
+		res, err := s.todoUsecase.Create(ctx, req)
+		return s.todoResult(ctx, userID, res, err)
+	case OpUpdate:
+		req := todo.UpdateTodoRequest{}
+		if err := s.decodeValid(m.Data, &req); err != nil {
+			return rejected(err)
+		}
+		id, result, ok := s.target(ctx, userID, m)
+		if !ok {
+			return result
+		}
+		req.UserID, req.ID, req.IfMatch = userID, id, etag.FromVersion(*m.Version)
+		if result, ok := s.resolveProject(ctx, userID, refs, &req.ProjectID); !ok {
+			return result
+		}
+		res, err := s.todoUsecase.Update(ctx, req)
+		return s.todoResult(ctx, userID, res, err)
+	default:
+		id, result, ok := s.target(ctx, userID, m)
+		if !ok {
+			return result
+		}
+		_, err := s.todoUsecase.Delete(ctx, todo.DeleteTodoRequest{UserID: userID, ID: id, IfMatch: etag.FromVersion(*m.Version)})
+		if err == nil {
+			return MutationResult{Status: StatusApplied, ID: &id}
+		}
+		return s.todoResult(ctx, userID, nil, err)
+	}
+}
+
+func (s *syncService) applyProject(ctx context.Context, userID int, m Mutation) MutationResult {
+	switch m.Op {
+	case OpCreate:
+		if m.ClientID == nil {
+			return rejected(errors.New("client_id is required to create a project"))
+		}
+		req := project.CreateProjectRequest{}
+		if err := s.decodeValid(m.Data, &req); err != nil {
+			return rejected(err)
+		}
+		req.UserID, req.ClientID = userID, m.ClientID
+		res, err := s.projectUsecase.Create(ctx, req)
+		return s.projectResult(ctx, userID, res, err)
+	case OpUpdate:
+		req := project.UpdateProjectRequest{}
+		if err := s.decodeValid(m.Data, &req); err != nil {
+			return rejected(err)
+		}
+		id, result, ok := s.target(ctx, userID, m)
+		if !ok {
+			return result
+		}
+		req.UserID, req.ID, req.IfMatch = userID, id, etag.FromVersion(*m.Version)
+		res, err := s.projectUsecase.Update(ctx, req)
+		return s.projectResult(ctx, userID, res, err)
+	default:
+		id, result, ok := s.target(ctx, userID, m)
+		if !ok {
+			return result
+		}
+		_, err := s.projectUsecase.Delete(ctx, project.DeleteProjectRequest{UserID: userID, ID: id, IfMatch: etag.FromVersion(*m.Version)})
+		if err == nil {
+			return MutationResult{Status: StatusApplied, ID: &id}
+		}
+		return s.projectResult(ctx, userID, nil, err)
+	}
+}
+
+// target finds the server id an update or delete points at, by id or by client_id.
+func (s *syncService) target(ctx context.Context, userID int, m Mutation) (int, MutationResult, bool) {
+	if m.Version == nil {
+		return 0, rejected(fmt.Errorf("version is required to %s a %s", m.Op, m.Entity)), false
+	}
+	if m.ID != nil {
+		return *m.ID, MutationResult{}, true
+	}
+	if m.ClientID == nil {
+		return 0, rejected(fmt.Errorf("id or client_id is required to %s a %s", m.Op, m.Entity)), false
+	}
+
+	id, err := s.syncRepository.ResolveClientID(ctx, userID, m.Entity, *m.ClientID)
+	if err != nil {
+		return 0, failed(err), false
+	}
+	if id == 0 {
+		return 0, MutationResult{Status: StatusNotFound, Error: fmt.Sprintf("No %s with client_id=%v", m.Entity, *m.ClientID)}, false
+	}
+	return id, MutationResult{}, true
+}
+
+// resolveProject replaces project_client_id with the project id it was created as, so a
+// todo can join a project created earlier in the same batch.
+func (s *syncService) resolveProject(ctx context.Context, userID int, refs todoReferences, projectID **int) (MutationResult, bool) {
+	if refs.ProjectClientID == nil {
+		return MutationResult{}, true
+	}
+	id, err := s.syncRepository.ResolveClientID(ctx, userID, EntityProject, *refs.ProjectClientID)
+	if err != nil {
+		return failed(err), false
+	}
+	if id == 0 {
+		return rejected(fmt.Errorf("Project does not exist. project_client_id=%v", *refs.ProjectClientID)), false
+	}
+	*projectID = &id
+	return MutationResult{}, true
+}
+
+// todoResult turns the outcome of a todo use case into a result. A conflict carries the
+// current todo so the client can merge and retry.
+func (s *syncService) todoResult(ctx context.Context, userID int, res *todo.TodoResponse, err error) MutationResult {
+	var notFoundError *todo.TodoNotFoundError
+	var invalidProjectError *todo.InvalidProjectError
+	var invalidTagError *todo.InvalidTagError
+	var invalidDueDateError *todo.InvalidDueDateError
+	var invalidDelegationError *todo.InvalidDelegationError
+	var invalidRecurrenceError *todo.InvalidRecurrenceError
+	var invalidChecklistError *todo.InvalidChecklistError
+	var checklistItemNotFoundError *todo.ChecklistItemNotFoundError
+	var versionConflictError *todo.VersionConflictError
+
+	switch {
+	case err == nil:
+		return MutationResult{Status: StatusApplied, ID: &res.ID, Todo: res}
+	case errors.As(err, &notFoundError):
+		return MutationResult{Status: StatusNotFound, Error: err.Error()}
+	case errors.As(err, &invalidProjectError), errors.As(err, &invalidTagError),
+		errors.As(err, &invalidDueDateError), errors.As(err, &invalidDelegationError),
+		errors.As(err, &invalidRecurrenceError), errors.As(err, &invalidChecklistError),
+		errors.As(err, &checklistItemNotFoundError):
+		return rejected(err)
+	case errors.As(err, &versionConflictError):
+		id := versionConflictError.Current.ID
+		current, getErr := s.todoUsecase.Get(ctx, todo.GetTodoRequest{UserID: userID, ID: id})
+		if getErr != nil {
+			return s.todoResult(ctx, userID, nil, getErr)
+		}
+		return MutationResult{Status: StatusConflict, ID: &id, Todo: current, Error: err.Error()}
+	default:
+		return failed(err)
+	}
+}
+
+// projectResult turns the outcome of a project use case into a result. An area or parent
+// that does not exist or does not fit, or a status the project cannot take, is the
+// client's to fix, so it is rejected.
+func (s *syncService) projectResult(ctx context.Context, userID int, res *project.ProjectResponse, err error) MutationResult {
+	var notFoundError *project.ProjectNotFoundError
+	var areaNotFoundError *project.AreaNotFoundError
+	var invalidPlacementError *project.InvalidPlacementError
+	var hierarchyError *project.HierarchyError
+	var statusTransitionError *project.StatusTransitionError
+	var openTodosError *project.OpenTodosError
+	var versionConflictError *project.VersionConflictError
+
+	switch {
+	case err == nil:
+		return MutationResult{Status: StatusApplied, ID: &res.ID, Project: res}
+	case errors.As(err, &notFoundError):
+		return MutationResult{Status: StatusNotFound, Error: err.Error()}
+	case errors.As(err, &areaNotFoundError), errors.As(err, &invalidPlacementError), errors.As(err, &hierarchyError),
+		errors.As(err, &statusTransitionError), errors.As(err, &openTodosError):
+		return rejected(err)
+	case errors.As(err, &versionConflictError):
+		id := versionConflictError.Current.ID
+		current, getErr := s.projectUsecase.Get(ctx, project.GetProjectRequest{UserID: userID, ID: id})
+		if getErr != nil {
+			return s.projectResult(ctx, userID, nil, getErr)
+		}
+		return MutationResult{Status: StatusConflict, ID: &id, Project: current, Error: err.Error()}
+	default:
+		return failed(err)
+	}
+}
+
+// decodeValid reads mutation data into a use case request and checks the same binding
+// rules the REST endpoints enforce.
+func (s *syncService) decodeValid(data json.RawMessage, req any) error {
+	if err := decode(data, req); err != nil {
+		return err
+	}
+	return s.validate.Struct(req)
+}
+
+func decode(data json.RawMessage, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+func rejected(err error) MutationResult {
+	return MutationResult{Status: StatusRejected, Error: err.Error()}
+}
+
+func failed(err error) MutationResult {
+	return MutationResult{Status: StatusFailed, Error: err.Error()}
+}
+
+type PushRequest struct {
+	UserID    int        `json:"-" auth:"user_id"`
+	Mutations []Mutation `json:"mutations" binding:"required,max=100,dive"`
+}
+
+// Mutation is one change made on the client. Updates and deletes name the version they
+// were based on and target the entity by id, or by client_id when it was created offline.
+type Mutation struct {
+	Entity   string          `json:"entity" binding:"required,oneof=todo project"`
+	Op       string          `json:"op" binding:"required,oneof=create update delete"`
+	ID       *int            `json:"id"`
+	ClientID *string         `json:"client_id" binding:"omitempty,uuid"`
+	Version  *int            `json:"version" binding:"omitempty,gte=1"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type PushResponse struct {
+	Results []MutationResult `json:"results"`
+}
+
+type MutationResult struct {
+	Index   int                      `json:"index"`
+	Status  string                   `json:"status"`
+	ID      *int                     `json:"id,omitempty"`
+	Todo    *todo.TodoResponse       `json:"todo,omitempty"`
+	Project *project.ProjectResponse `json:"project,omitempty"`
+	Error   string                   `json:"error,omitempty"`
+}
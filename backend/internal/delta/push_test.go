@@ -0,0 +1,195 @@
+package delta_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"yangdongju/gtd_todo/internal/delta"
+	deltamocks "yangdongju/gtd_todo/internal/delta/mocks"
+	"yangdongju/gtd_todo/internal/project"
+	projectmocks "yangdongju/gtd_todo/internal/project/mocks"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+const (
+	projectClientID = "0b6f7a1e-52c4-4d3b-9a8e-1f2d3c4b5a69"
+	todoClientID    = "6f1c2b9e-3d4a-4e5f-8a7b-9c0d1e2f3a4b"
+)
+
+func TestPush_CreatesTodoInProjectCreatedEarlierInBatch(t *testing.T) {
+	// given
+	mockRepo := deltamocks.NewSyncRepository(t)
+	mockRepo.EXPECT().ResolveClientID(mock.Anything, 7, delta.EntityProject, projectClientID).Return(3, nil)
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().Create(mock.Anything, mock.MatchedBy(func(req project.CreateProjectRequest) bool {
+		return req.UserID == 7 && *req.ClientID == projectClientID && req.Name == "Home"
+	})).Return(&project.ProjectResponse{ID: 3, Name: "Home", Version: 1}, nil)
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Create(mock.Anything, mock.MatchedBy(func(req todo.CreateTodoRequest) bool {
+		return req.UserID == 7 && *req.ClientID == todoClientID && *req.ProjectID == 3
+	})).Return(&todo.TodoResponse{ID: 9, Title: "Fix sink", Version: 1}, nil)
+
+	service := delta.NewSyncService(mockRepo, passThroughTxManager{}, mockTodos, mockProjects)
+
+	// when
+	res, err := service.Push(context.Background(), delta.PushRequest{UserID: 7, Mutations: []delta.Mutation{
+		{Entity: delta.EntityProject, Op: delta.OpCreate, ClientID: ptr(projectClientID), Data: json.RawMessage(`{"name":"Home"}`)},
+		{Entity: delta.EntityTodo, Op: delta.OpCreate, ClientID: ptr(todoClientID),
+			Data: json.RawMessage(`{"title":"Fix sink","project_client_id":"` + projectClientID + `"}`)},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 2)
+	assert.Equal(t, delta.StatusApplied, res.Results[0].Status)
+	assert.Equal(t, 3, *res.Results[0].ID)
+	assert.Equal(t, 1, res.Results[1].Index)
+	assert.Equal(t, delta.StatusApplied, res.Results[1].Status)
+	assert.Equal(t, 9, res.Results[1].Todo.ID)
+}
+
+func TestPush_StaleVersionReportsConflictWithCurrentTodo(t *testing.T) {
+	// given
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req todo.UpdateTodoRequest) bool {
+		return req.UserID == 7 && req.ID == 4 && req.IfMatch == `"2"` && *req.Title == "new"
+	})).Return(nil, todo.NewVersionConflictError(&todo.Todo{ID: 4, Version: 3}, 2))
+	mockTodos.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 4}).
+		Return(&todo.TodoResponse{ID: 4, Title: "theirs", Version: 3}, nil)
+
+	service := delta.NewSyncService(deltamocks.NewSyncRepository(t), passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t))
+
+	// when
+	res, err := service.Push(context.Background(), delta.PushRequest{UserID: 7, Mutations: []delta.Mutation{
+		{Entity: delta.EntityTodo, Op: delta.OpUpdate, ID: ptr(4), Version: ptr(2), Data: json.RawMessage(`{"title":"new"}`)},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, delta.StatusConflict, res.Results[0].Status)
+	assert.Equal(t, 3, res.Results[0].Todo.Version)
+	assert.Equal(t, "theirs", res.Results[0].Todo.Title)
+}
+
+func TestPush_InvalidMutationDoesNotStopTheBatch(t *testing.T) {
+	// given
+	mockRepo := deltamocks.NewSyncRepository(t)
+	mockRepo.EXPECT().ResolveClientID(mock.Anything, 7, delta.EntityTodo, todoClientID).Return(0, nil)
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().Delete(mock.Anything, project.DeleteProjectRequest{UserID: 7, ID: 5, IfMatch: `"1"`}).
+		Return(&project.DeleteProjectResponse{Message: "Project deleted"}, nil)
+
+	service := delta.NewSyncService(mockRepo, passThroughTxManager{}, todomocks.NewTodoUsecase(t), mockProjects)
+
+	// when
+	res, err := service.Push(context.Background(), delta.PushRequest{UserID: 7, Mutations: []delta.Mutation{
+		{Entity: delta.EntityTodo, Op: delta.OpCreate, ClientID: ptr(todoClientID), Data: json.RawMessage(`{"title":""}`)},
+		{Entity: delta.EntityTodo, Op: delta.OpUpdate, ID: ptr(4), Data: json.RawMessage(`{"title":"no version"}`)},
+		{Entity: delta.EntityTodo, Op: delta.OpDelete, ClientID: ptr(todoClientID), Version: ptr(1)},
+		{Entity: delta.EntityProject, Op: delta.OpDelete, ID: ptr(5), Version: ptr(1)},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, delta.StatusRejected, res.Results[0].Status)
+	assert.Equal(t, delta.StatusRejected, res.Results[1].Status)
+	assert.Equal(t, delta.StatusNotFound, res.Results[2].Status)
+	assert.Equal(t, delta.StatusApplied, res.Results[3].Status)
+	assert.Equal(t, 5, *res.Results[3].ID)
+}
+
+func TestPush_InvalidDelegationIsRejected(t *testing.T) {
+	// given
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Create(mock.Anything, mock.Anything).
+		Return(nil, todo.NewInvalidDelegationError("follow_up_date is before delegated_date"))
+
+	service := delta.NewSyncService(deltamocks.NewSyncRepository(t), passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t))
+
+	// when
+	res, err := service.Push(context.Background(), delta.PushRequest{UserID: 7, Mutations: []delta.Mutation{
+		{Entity: delta.EntityTodo, Op: delta.OpCreate, ClientID: ptr(todoClientID),
+			Data: json.RawMessage(`{"title":"Hear back","status":"waiting_for","follow_up_date":"2026-10-01","delegated_date":"2026-10-19"}`)},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, delta.StatusRejected, res.Results[0].Status)
+}
+
+func TestPush_ChecklistErrorsAreRejected(t *testing.T) {
+	// given
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req todo.UpdateTodoRequest) bool { return req.ID == 4 })).
+		Return(nil, todo.NewInvalidChecklistError("a todo holds at most 100 items"))
+	mockTodos.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req todo.UpdateTodoRequest) bool { return req.ID == 5 })).
+		Return(nil, todo.NewChecklistItemNotFoundError(5, 12))
+
+	service := delta.NewSyncService(deltamocks.NewSyncRepository(t), passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t))
+
+	// when
+	res, err := service.Push(context.Background(), delta.PushRequest{UserID: 7, Mutations: []delta.Mutation{
+		{Entity: delta.EntityTodo, Op: delta.OpUpdate, ID: ptr(4), Version: ptr(1), Data: json.RawMessage(`{"title":"old"}`)},
+		{Entity: delta.EntityTodo, Op: delta.OpUpdate, ID: ptr(5), Version: ptr(1), Data: json.RawMessage(`{"title":"new"}`)},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, delta.StatusRejected, res.Results[0].Status)
+	assert.Equal(t, delta.StatusRejected, res.Results[1].Status)
+}
+
+func TestPush_ProjectUnderAMissingParentIsRejected(t *testing.T) {
+	// given
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().Create(mock.Anything, mock.MatchedBy(func(req project.CreateProjectRequest) bool {
+		return *req.ParentProjectID == 99
+	})).Return(nil, project.NewInvalidPlacementError("parent project does not exist. parent_project_id=99"))
+
+	service := delta.NewSyncService(deltamocks.NewSyncRepository(t), passThroughTxManager{}, todomocks.NewTodoUsecase(t), mockProjects)
+
+	// when
+	res, err := service.Push(context.Background(), delta.PushRequest{UserID: 7, Mutations: []delta.Mutation{
+		{Entity: delta.EntityProject, Op: delta.OpCreate, ClientID: ptr(projectClientID), Data: json.RawMessage(`{"name":"Docs","parent_project_id":99}`)},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, delta.StatusRejected, res.Results[0].Status)
+}
+
+func TestPush_ProjectStatusErrorsAreRejected(t *testing.T) {
+	// given
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req project.UpdateProjectRequest) bool { return req.ID == 3 })).
+		Return(nil, project.NewStatusTransitionError(project.StatusArchived, project.StatusDone))
+	mockProjects.EXPECT().Update(mock.Anything, mock.MatchedBy(func(req project.UpdateProjectRequest) bool { return req.ID == 4 })).
+		Return(nil, project.NewOpenTodosError(2))
+
+	service := delta.NewSyncService(deltamocks.NewSyncRepository(t), passThroughTxManager{}, todomocks.NewTodoUsecase(t), mockProjects)
+
+	// when
+	res, err := service.Push(context.Background(), delta.PushRequest{UserID: 7, Mutations: []delta.Mutation{
+		{Entity: delta.EntityProject, Op: delta.OpUpdate, ID: ptr(3), Version: ptr(1), Data: json.RawMessage(`{"name":"Launch"}`)},
+		{Entity: delta.EntityProject, Op: delta.OpUpdate, ID: ptr(4), Version: ptr(1), Data: json.RawMessage(`{"name":"Move"}`)},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, delta.StatusRejected, res.Results[0].Status)
+	assert.Equal(t, delta.StatusRejected, res.Results[1].Status)
+}
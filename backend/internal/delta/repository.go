@@ -0,0 +1,105 @@
+package delta
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	EntityTodo    = "todo"
+	EntityProject = "project"
+)
+
+var entityTables = map[string]string{
+	EntityTodo:    "todos",
+	EntityProject: "projects",
+}
+
+type SyncRepository interface {
+	Cursor(ctx context.Context, userID int) (int64, error)
+	// TombstonesPrunedThrough returns the highest cursor whose tombstones retention removed.
+	TombstonesPrunedThrough(ctx context.Context, userID int) (int64, error)
+	FindTombstonesSince(ctx context.Context, userID int, cursor int64) ([]Tombstone, error)
+	ResolveClientID(ctx context.Context, userID int, entity string, clientID string) (int, error)
+}
+
+type syncRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// Tombstone records a deleted todo or project so clients that synced it can drop it.
+// Rows are written by a trigger on delete.
+type Tombstone struct {
+	EntityType string    `db:"entity_type"`
+	EntityID   int       `db:"entity_id"`
+	ClientID   *string   `db:"client_id"`
+	ChangeSeq  int64     `db:"change_seq"`
+	DeletedAt  time.Time `db:"deleted_at"`
+}
+
+func NewSyncRepository(db *sqlx.DB) *syncRepositoryImpl {
+	return &syncRepositoryImpl{db: db}
+}
+
+// Cursor returns the user's latest sync cursor. Every write to a todo or project takes the
+// next value, so a client that has seen everything up to it can ask for what came after.
+func (r *syncRepositoryImpl) Cursor(ctx context.Context, userID int) (int64, error) {
+	var cursor int64
+	err := database.Conn(ctx, r.db).GetContext(ctx, &cursor,
+		"SELECT sync_cursor FROM users WHERE id = $1", userID)
+	return cursor, err
+}
+
+func (r *syncRepositoryImpl) TombstonesPrunedThrough(ctx context.Context, userID int) (int64, error) {
+	var cursor int64
+	err := database.Conn(ctx, r.db).GetContext(ctx, &cursor,
+		"SELECT tombstones_pruned_through FROM users WHERE id = $1", userID)
+	return cursor, err
+}
+
+// PruneTombstones drops tombstones older than the retention and moves each owner's
+// horizon past them.
+func (r *syncRepositoryImpl) PruneTombstones(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.GetContext(ctx, &deleted, `
+		WITH deleted AS (
+			DELETE FROM tombstones WHERE deleted_at < $1 RETURNING user_id, change_seq
+		), horizon AS (
+			UPDATE users SET tombstones_pruned_through = GREATEST(users.tombstones_pruned_through, pruned.change_seq)
+			FROM (SELECT user_id, MAX(change_seq) AS change_seq FROM deleted GROUP BY user_id) pruned
+			WHERE users.id = pruned.user_id
+		)
+		SELECT COUNT(*) FROM deleted`,
+		before)
+	return deleted, err
+}
+
+func (r *syncRepositoryImpl) FindTombstonesSince(ctx context.Context, userID int, cursor int64) ([]Tombstone, error) {
+	tombstones := []Tombstone{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &tombstones, `
+		SELECT entity_type, entity_id, client_id, change_seq, deleted_at
+		FROM tombstones
+		WHERE user_id = $1 AND change_seq > $2
+		ORDER BY change_seq`, userID, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return tombstones, nil
+}
+
+// ResolveClientID returns the server id of the entity created with clientID, or 0 when
+// there is none.
+func (r *syncRepositoryImpl) ResolveClientID(ctx context.Context, userID int, entity string, clientID string) (int, error) {
+	var id int
+	err := database.Conn(ctx, r.db).GetContext(ctx, &id,
+		"SELECT id FROM "+entityTables[entity]+" WHERE client_id = $1 AND user_id = $2", clientID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
@@ -0,0 +1,118 @@
+package delta_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/delta"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncRepository_DeletesLeaveTombstones(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	clientID := "6f1c2b9e-3d4a-4e5f-8a7b-9c0d1e2f3a4b"
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	saved, _ := todos.Save(ctx, &todo.Todo{UserID: userID, ClientID: &clientID, Title: "Buy milk", Status: todo.StatusInbox})
+	repository := delta.NewSyncRepository(testhelper.GetTestDB())
+	before, _ := repository.Cursor(ctx, userID)
+
+	// when
+	_, err := todos.Delete(ctx, saved)
+	after, _ := repository.Cursor(ctx, userID)
+	tombstones, _ := repository.FindTombstonesSince(ctx, userID, before)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, before+1, after)
+	assert.Len(t, tombstones, 1)
+	assert.Equal(t, delta.EntityTodo, tombstones[0].EntityType)
+	assert.Equal(t, saved.ID, tombstones[0].EntityID)
+	assert.Equal(t, &clientID, tombstones[0].ClientID)
+	assert.Equal(t, after, tombstones[0].ChangeSeq)
+}
+
+func TestSyncRepository_ProjectDeleteStampsDetachedTodos(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	projects := project.NewProjectRepository(testhelper.GetTestDB())
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	home, _ := projects.Save(ctx, &project.Project{UserID: userID, Name: "Home", Color: "#3B82F6"})
+	saved, _ := todos.Save(ctx, &todo.Todo{UserID: userID, ProjectID: &home.ID, Title: "Fix sink", Status: todo.StatusInbox})
+	cursor, _ := delta.NewSyncRepository(testhelper.GetTestDB()).Cursor(ctx, userID)
+
+	// when
+	_, err := projects.Delete(ctx, home)
+	changed, _ := todos.FindChangedSince(ctx, userID, cursor)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, changed, 1)
+	assert.Equal(t, saved.ID, changed[0].ID)
+	assert.Nil(t, changed[0].ProjectID)
+	assert.Equal(t, saved.Version+1, changed[0].Version)
+}
+
+func TestSyncRepository_ResolveClientID(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	clientID := "0b6f7a1e-52c4-4d3b-9a8e-1f2d3c4b5a69"
+	saved, _ := project.NewProjectRepository(testhelper.GetTestDB()).
+		Save(ctx, &project.Project{UserID: userID, ClientID: &clientID, Name: "Home", Color: "#3B82F6"})
+	repository := delta.NewSyncRepository(testhelper.GetTestDB())
+
+	// when
+	own, err := repository.ResolveClientID(ctx, userID, delta.EntityProject, clientID)
+	other, _ := repository.ResolveClientID(ctx, userID+1, delta.EntityProject, clientID)
+	wrongEntity, _ := repository.ResolveClientID(ctx, userID, delta.EntityTodo, clientID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, saved.ID, own)
+	assert.Zero(t, other)
+	assert.Zero(t, wrongEntity)
+}
+
+func TestSyncRepository_PruneTombstonesMovesHorizon(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	old, _ := todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Old", Status: todo.StatusInbox})
+	recent, _ := todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Recent", Status: todo.StatusInbox})
+	_, _ = todos.Delete(ctx, old)
+	_, _ = todos.Delete(ctx, recent)
+	_, _ = testhelper.GetTestDB().Exec("UPDATE tombstones SET deleted_at = deleted_at - INTERVAL '40 days' WHERE entity_id = $1", old.ID)
+	repository := delta.NewSyncRepository(testhelper.GetTestDB())
+
+	// when
+	deleted, err := repository.PruneTombstones(ctx, time.Now().Add(-30*24*time.Hour))
+	prunedThrough, _ := repository.TombstonesPrunedThrough(ctx, userID)
+	remaining, _ := repository.FindTombstonesSince(ctx, userID, 0)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Equal(t, int64(3), prunedThrough)
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, recent.ID, remaining[0].EntityID)
+}
@@ -0,0 +1,37 @@
+package delta
+
+import (
+	"context"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type SyncUsecase interface {
+	Pull(ctx context.Context, request PullRequest) (*PullResponse, error)
+	Push(ctx context.Context, request PushRequest) (*PushResponse, error)
+}
+
+type syncService struct {
+	syncRepository SyncRepository
+	txManager      database.TxManager
+	todoUsecase    todo.TodoUsecase
+	projectUsecase project.ProjectUsecase
+	validate       *validator.Validate
+}
+
+// NewSyncService applies pushed mutations through the todo and project use cases, so they
+// get the same validation, version checks and events as the REST endpoints.
+func NewSyncService(repository SyncRepository, txManager database.TxManager, todoUsecase todo.TodoUsecase, projectUsecase project.ProjectUsecase) *syncService {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	return &syncService{
+		syncRepository: repository,
+		txManager:      txManager,
+		todoUsecase:    todoUsecase,
+		projectUsecase: projectUsecase,
+		validate:       validate,
+	}
+}
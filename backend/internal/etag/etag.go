@@ -0,0 +1,67 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Tagged is implemented by responses that carry their own validator, usually a row version.
+type Tagged interface {
+	ETag() string
+}
+
+var ErrInvalidVersion = errors.New("If-Match must be a version ETag such as \"3\"")
+
+func FromVersion(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// FromContent returns a weak validator for representations without a version, such as lists.
+func FromContent(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ParseVersion reads the version out of an If-Match header produced by FromVersion.
+func ParseVersion(header string) (int, error) {
+	value := strings.TrimSpace(header)
+	if !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) || len(value) < 3 {
+		return 0, ErrInvalidVersion
+	}
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil {
+		return 0, ErrInvalidVersion
+	}
+	return version, nil
+}
+
+// MatchesNoneMatch reports whether an If-None-Match header matches tag using the weak
+// comparison RFC 9110 requires for that header.
+func MatchesNoneMatch(header string, tag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return tag != ""
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		if weak(strings.TrimSpace(candidate)) == weak(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func weak(tag string) string {
+	return strings.TrimPrefix(tag, "W/")
+}
+
+var ErrMissingIfMatch = errors.New("If-Match header is required")
+
+// ExpectedVersion reads the version a conditional write was based on.
+func ExpectedVersion(ifMatch string) (int, error) {
+	if strings.TrimSpace(ifMatch) == "" {
+		return 0, ErrMissingIfMatch
+	}
+	return ParseVersion(ifMatch)
+}
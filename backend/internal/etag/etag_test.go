@@ -0,0 +1,62 @@
+package etag_test
+
+import (
+	"testing"
+	"yangdongju/gtd_todo/internal/etag"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int
+		wantErr bool
+	}{
+		{header: `"3"`, version: 3},
+		{header: ` "12" `, version: 12},
+		{header: `3`, wantErr: true},
+		{header: `W/"3"`, wantErr: true},
+		{header: `""`, wantErr: true},
+		{header: `"abc"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			// when
+			version, err := etag.ParseVersion(tt.header)
+
+			// then
+			if tt.wantErr {
+				assert.ErrorIs(t, err, etag.ErrInvalidVersion)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestMatchesNoneMatch(t *testing.T) {
+	// given
+	tag := etag.FromVersion(2)
+
+	// then
+	assert.True(t, etag.MatchesNoneMatch(`"2"`, tag))
+	assert.True(t, etag.MatchesNoneMatch(`"1", W/"2"`, tag))
+	assert.True(t, etag.MatchesNoneMatch(`*`, tag))
+	assert.False(t, etag.MatchesNoneMatch(`"1"`, tag))
+	assert.False(t, etag.MatchesNoneMatch(``, tag))
+}
+
+func TestFromContent_IsWeakAndStable(t *testing.T) {
+	// when
+	first := etag.FromContent([]byte(`{"todos":[]}`))
+	second := etag.FromContent([]byte(`{"todos":[]}`))
+	other := etag.FromContent([]byte(`{"todos":[{}]}`))
+
+	// then
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	assert.Contains(t, first, `W/"`)
+}
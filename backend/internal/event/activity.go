@@ -0,0 +1,85 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/query"
+)
+
+// activitySorts orders the feed by event id, which follows commit order and is served by
+// the (user_id, id) index. Newest first unless the client asks otherwise.
+var activitySorts = query.Sorts{
+	{Name: "created_at", Order: query.Desc},
+}
+
+// History is the part of the event log the activity feed reads.
+type History interface {
+	FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Event, int, error)
+}
+
+// ActivityHandler serves the user's recent events as a feed. It only reaches back as far
+// as the event log's retention.
+type ActivityHandler struct {
+	history History
+}
+
+func NewActivityHandler(history History) *ActivityHandler {
+	return &ActivityHandler{history: history}
+}
+
+func (h *ActivityHandler) HandleList(ctx context.Context, req ListActivityRequest) (int, any) {
+	spec, err := query.NewSpec(activitySorts, req.Sort, req.Order, req.Cursor, req.Limit)
+	if err != nil {
+		return http.StatusBadRequest, apperror.ErrorResponse{Error: err.Error()}
+	}
+	spec.Filters = []query.Filter{
+		query.In("type", req.Type),
+		query.Between("created_at", req.CreatedAfter, req.CreatedBefore),
+	}
+
+	events, total, err := h.history.FindPage(ctx, req.UserID, spec)
+	if err != nil {
+		return http.StatusInternalServerError, apperror.ErrorResponse{Error: err.Error()}
+	}
+	events, next := query.Page(spec, events, func(event Event, _ string) any { return event.ID })
+
+	res := ActivityListResponse{Activity: make([]ActivityResponse, 0, len(events)), Total: total, NextCursor: next}
+	for _, event := range events {
+		res.Activity = append(res.Activity, ActivityResponse{
+			ID:        event.ID,
+			Type:      event.Type,
+			EntityID:  event.EntityID,
+			Payload:   event.Payload,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return http.StatusOK, res
+}
+
+type ListActivityRequest struct {
+	UserID        int        `json:"-" auth:"user_id"`
+	Type          []string   `json:"-" form:"type" binding:"omitempty,dive,oneof=todo.created todo.updated todo.moved todo.deleted project.created project.updated project.deleted tag.created tag.updated tag.merged tag.deleted area.created area.updated area.deleted"`
+	CreatedAfter  *time.Time `json:"-" form:"created_after"`
+	CreatedBefore *time.Time `json:"-" form:"created_before"`
+	Sort          string     `json:"-" form:"sort" binding:"omitempty,oneof=created_at"`
+	Order         string     `json:"-" form:"order" binding:"omitempty,oneof=asc desc"`
+	Cursor        string     `json:"-" form:"cursor"`
+	Limit         int        `json:"-" form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+type ActivityResponse struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	EntityID  int             `json:"entity_id"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type ActivityListResponse struct {
+	Activity   []ActivityResponse `json:"activity"`
+	Total      int                `json:"total"`
+	NextCursor *string            `json:"next_cursor"`
+}
@@ -0,0 +1,46 @@
+package event_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/query"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pagedHistory struct {
+	events []event.Event
+	sql    []string
+}
+
+func (h *pagedHistory) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]event.Event, int, error) {
+	page, _ := spec.Build("id", "events", query.Equal("user_id", userID))
+	h.sql = append(h.sql, page.SQL)
+	return h.events, len(h.events), nil
+}
+
+func TestActivity_NewestFirstWithCursor(t *testing.T) {
+	// given
+	history := &pagedHistory{events: []event.Event{
+		{ID: 9, Type: event.TodoDeleted, Payload: []byte(`{"id":1}`)},
+		{ID: 8, Type: event.TodoCreated, Payload: []byte(`{}`)},
+	}}
+	handler := event.NewActivityHandler(history)
+
+	// when
+	status, body := handler.HandleList(context.Background(), event.ListActivityRequest{
+		UserID: 7, Type: []string{event.TodoCreated, event.TodoDeleted}, Limit: 1,
+	})
+	badStatus, _ := handler.HandleList(context.Background(), event.ListActivityRequest{UserID: 7, Cursor: "bogus"})
+
+	// then
+	res := body.(event.ActivityListResponse)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "SELECT id FROM events WHERE user_id = $1 AND type IN ($2, $3) ORDER BY id DESC LIMIT 2", history.sql[0])
+	assert.Len(t, res.Activity, 1)
+	assert.Equal(t, int64(9), res.Activity[0].ID)
+	assert.NotNil(t, res.NextCursor)
+	assert.Equal(t, http.StatusBadRequest, badStatus)
+}
@@ -0,0 +1,62 @@
+package event
+
+import "sync"
+
+// Broker wakes the streams of a user when new events may exist. Wake-ups coalesce: a
+// stream that is still writing sees one pending signal and then reads everything new,
+// so a slow client never blocks publishers.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscribers: map[int]map[chan struct{}]struct{}{}}
+}
+
+func (b *Broker) Subscribe(userID int) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = map[chan struct{}]struct{}{}
+	}
+	b.subscribers[userID][wake] = struct{}{}
+	b.mu.Unlock()
+
+	return wake, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers[userID], wake)
+		if len(b.subscribers[userID]) == 0 {
+			delete(b.subscribers, userID)
+		}
+	}
+}
+
+func (b *Broker) Notify(userID int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for wake := range b.subscribers[userID] {
+		signal(wake)
+	}
+}
+
+// NotifyAll wakes every stream, e.g. after the listener reconnected and may have missed
+// notifications.
+func (b *Broker) NotifyAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, subscribers := range b.subscribers {
+		for wake := range subscribers {
+			signal(wake)
+		}
+	}
+}
+
+func signal(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
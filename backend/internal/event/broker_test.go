@@ -0,0 +1,52 @@
+package event_test
+
+import (
+	"testing"
+	"yangdongju/gtd_todo/internal/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_NotifiesOnlyTheUser(t *testing.T) {
+	// given
+	broker := event.NewBroker()
+	mine, unsubscribeMine := broker.Subscribe(1)
+	other, unsubscribeOther := broker.Subscribe(2)
+	defer unsubscribeMine()
+	defer unsubscribeOther()
+
+	// when
+	broker.Notify(1)
+
+	// then
+	assert.Len(t, mine, 1)
+	assert.Len(t, other, 0)
+}
+
+func TestBroker_CoalescesPendingWakeUps(t *testing.T) {
+	// given
+	broker := event.NewBroker()
+	wake, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+
+	// when
+	broker.Notify(1)
+	broker.Notify(1)
+	broker.NotifyAll()
+
+	// then
+	assert.Len(t, wake, 1)
+}
+
+func TestBroker_UnsubscribedStreamIsNotWoken(t *testing.T) {
+	// given
+	broker := event.NewBroker()
+	wake, unsubscribe := broker.Subscribe(1)
+
+	// when
+	unsubscribe()
+	broker.Notify(1)
+
+	// then
+	assert.Len(t, wake, 0)
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	TodoCreated    = "todo.created"
+	TodoUpdated    = "todo.updated"
+	TodoMoved      = "todo.moved"
+	TodoDeleted    = "todo.deleted"
+	ProjectCreated = "project.created"
+	ProjectUpdated = "project.updated"
+	ProjectDeleted = "project.deleted"
+	TagCreated     = "tag.created"
+	TagUpdated     = "tag.updated"
+	TagMerged      = "tag.merged"
+	TagDeleted     = "tag.deleted"
+	AreaCreated    = "area.created"
+	AreaUpdated    = "area.updated"
+	AreaDeleted    = "area.deleted"
+
+	// Reset tells a resuming client that events it missed were pruned, so it must refetch.
+	Reset = "reset"
+)
+
+type Event struct {
+	ID        int64           `db:"id"`
+	UserID    int             `db:"user_id"`
+	Type      string          `db:"type"`
+	EntityID  int             `db:"entity_id"`
+	Payload   json.RawMessage `db:"payload"`
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// Publisher records an event for the user. Called inside a transaction, the event is only
+// visible and announced once the change it describes commits.
+type Publisher interface {
+	Publish(ctx context.Context, userID int, eventType string, entityID int, payload any) error
+}
+
+// Log is the bounded, ordered history of events that streams replay from.
+type Log interface {
+	Since(ctx context.Context, userID int, afterID int64, limit int) ([]Event, error)
+	LatestID(ctx context.Context, userID int) (int64, error)
+	// PrunedThrough returns the highest event id already removed by retention.
+	PrunedThrough(ctx context.Context) (int64, error)
+}
+
+// Deleted is the payload of delete events.
+type Deleted struct {
+	ID int `json:"id"`
+}
@@ -0,0 +1,43 @@
+package event
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Listen forwards NOTIFY messages from every instance to the broker until ctx is done.
+func Listen(ctx context.Context, dsn string, broker *Broker) error {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Event listener connection event=%v err=%v", ev, err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(Channel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// A nil notification means the connection was re-established.
+			if notification == nil {
+				broker.NotifyAll()
+				continue
+			}
+			userID, err := strconv.Atoi(notification.Extra)
+			if err != nil {
+				log.Printf("Ignoring malformed event notification. payload=%v", notification.Extra)
+				continue
+			}
+			broker.Notify(userID)
+		}
+	}
+}
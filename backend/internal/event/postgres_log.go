@@ -0,0 +1,101 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/query"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Channel is the LISTEN/NOTIFY channel. Notifications carry only the user id; listeners
+// read the events themselves, so payload size limits never apply.
+const Channel = "gtd_events"
+
+type postgresLog struct {
+	db *sqlx.DB
+}
+
+func NewPostgresLog(db *sqlx.DB) *postgresLog {
+	return &postgresLog{db: db}
+}
+
+// Publish takes the id while holding the user row until the surrounding transaction ends.
+// Ids are drawn before commit, so without the lock a later id of the same user could
+// commit first and a reader resuming after it would skip the earlier one for good. With
+// it, each user's events become visible in id order, which is all Since relies on.
+func (l *postgresLog) Publish(ctx context.Context, userID int, eventType string, entityID int, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	conn := database.Conn(ctx, l.db)
+	_, err = conn.ExecContext(ctx, `
+		WITH owner AS (SELECT id FROM users WHERE id = $1 FOR NO KEY UPDATE)
+		INSERT INTO events (user_id, type, entity_id, payload)
+		SELECT id, $2, $3, $4 FROM owner`,
+		userID, eventType, entityID, string(body))
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, "SELECT pg_notify($1, $2)", Channel, strconv.Itoa(userID))
+	return err
+}
+
+func (l *postgresLog) Since(ctx context.Context, userID int, afterID int64, limit int) ([]Event, error) {
+	events := []Event{}
+	err := l.db.SelectContext(ctx, &events, `
+		SELECT id, user_id, type, entity_id, payload, created_at FROM events
+		WHERE user_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3`,
+		userID, afterID, limit)
+	return events, err
+}
+
+// FindPage returns the user's events matching spec, one row past its limit when there are
+// more, and how many match in total.
+func (l *postgresLog) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Event, int, error) {
+	page, count := spec.Build("id, user_id, type, entity_id, payload, created_at", "events", query.Equal("user_id", userID))
+
+	events := []Event{}
+	if err := l.db.SelectContext(ctx, &events, page.SQL, page.Args...); err != nil {
+		return nil, 0, err
+	}
+	var total int
+	if err := l.db.GetContext(ctx, &total, count.SQL, count.Args...); err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+func (l *postgresLog) LatestID(ctx context.Context, userID int) (int64, error) {
+	var id int64
+	err := l.db.GetContext(ctx, &id, "SELECT COALESCE(MAX(id), 0) FROM events WHERE user_id = $1", userID)
+	return id, err
+}
+
+func (l *postgresLog) PrunedThrough(ctx context.Context) (int64, error) {
+	var id int64
+	err := l.db.GetContext(ctx, &id, "SELECT pruned_through FROM event_log_horizon")
+	return id, err
+}
+
+// Prune drops events older than the retention and moves the horizon past them.
+func (l *postgresLog) Prune(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := l.db.GetContext(ctx, &deleted, `
+		WITH deleted AS (
+			DELETE FROM events WHERE created_at < $1 RETURNING id
+		), horizon AS (
+			UPDATE event_log_horizon
+			SET pruned_through = GREATEST(pruned_through, (SELECT COALESCE(MAX(id), 0) FROM deleted))
+		)
+		SELECT COUNT(*) FROM deleted`,
+		before)
+	return deleted, err
+}
@@ -0,0 +1,100 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func insertUser(t *testing.T, email string) int {
+	var id int
+	err := testhelper.GetTestDB().Get(&id,
+		"INSERT INTO users (email, password_hash) VALUES ($1, 'hash') RETURNING id", email)
+	assert.NoError(t, err)
+	return id
+}
+
+func TestPostgresLog_PublishAndReadSince(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t, "hello@example.com")
+	otherID := insertUser(t, "other@example.com")
+	log := event.NewPostgresLog(testhelper.GetTestDB())
+	ctx := context.Background()
+	assert.NoError(t, log.Publish(ctx, userID, event.TodoCreated, 1, map[string]any{"id": 1}))
+	assert.NoError(t, log.Publish(ctx, otherID, event.TodoCreated, 2, map[string]any{"id": 2}))
+	assert.NoError(t, log.Publish(ctx, userID, event.TodoDeleted, 1, event.Deleted{ID: 1}))
+
+	// when
+	events, err := log.Since(ctx, userID, 1, 10)
+	latest, _ := log.LatestID(ctx, userID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, event.TodoDeleted, events[0].Type)
+	assert.JSONEq(t, `{"id":1}`, string(events[0].Payload))
+	assert.Equal(t, int64(3), latest)
+}
+
+func TestPostgresLog_PruneMovesHorizon(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t, "hello@example.com")
+	log := event.NewPostgresLog(testhelper.GetTestDB())
+	ctx := context.Background()
+	_ = log.Publish(ctx, userID, event.TodoCreated, 1, event.Deleted{ID: 1})
+	_ = log.Publish(ctx, userID, event.TodoCreated, 2, event.Deleted{ID: 2})
+	_, _ = testhelper.GetTestDB().Exec("UPDATE events SET created_at = created_at - INTERVAL '2 days' WHERE id = 1")
+
+	// when
+	deleted, err := log.Prune(ctx, time.Now().Add(-24*time.Hour))
+	prunedThrough, _ := log.PrunedThrough(ctx)
+	remaining, _ := log.Since(ctx, userID, 0, 10)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Equal(t, int64(1), prunedThrough)
+	assert.Len(t, remaining, 1)
+}
+
+func TestPostgresLog_LaterEventWaitsForEarlierCommit(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t, "hello@example.com")
+	log := event.NewPostgresLog(testhelper.GetTestDB())
+	ctx := context.Background()
+	published, commit, committed := make(chan struct{}), make(chan struct{}), make(chan error)
+	go func() {
+		committed <- database.NewTxManager(testhelper.GetTestDB()).WithinTx(ctx, func(ctx context.Context) error {
+			if err := log.Publish(ctx, userID, event.TodoCreated, 1, event.Deleted{ID: 1}); err != nil {
+				return err
+			}
+			close(published)
+			<-commit
+			return nil
+		})
+	}()
+	<-published
+
+	// when
+	second := make(chan error)
+	go func() { second <- log.Publish(ctx, userID, event.TodoCreated, 2, event.Deleted{ID: 2}) }()
+	time.Sleep(100 * time.Millisecond)
+	beforeCommit, _ := log.Since(ctx, userID, 0, 10)
+	close(commit)
+	assert.NoError(t, <-committed)
+	assert.NoError(t, <-second)
+	afterCommit, _ := log.Since(ctx, userID, 0, 10)
+
+	// then
+	assert.Empty(t, beforeCommit)
+	assert.Len(t, afterCommit, 2)
+	assert.Equal(t, 1, afterCommit[0].EntityID)
+}
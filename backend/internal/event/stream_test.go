@@ -0,0 +1,161 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryLog struct {
+	mu            sync.Mutex
+	events        []event.Event
+	prunedThrough int64
+}
+
+func (l *memoryLog) append(userID int, eventType string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event.Event{ID: int64(len(l.events) + 1), UserID: userID, Type: eventType})
+}
+
+func (l *memoryLog) Since(ctx context.Context, userID int, afterID int64, limit int) ([]event.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []event.Event
+	for _, e := range l.events {
+		if e.UserID == userID && e.ID > afterID && len(found) < limit {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func (l *memoryLog) LatestID(ctx context.Context, userID int) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var latest int64
+	for _, e := range l.events {
+		if e.UserID == userID {
+			latest = e.ID
+		}
+	}
+	return latest, nil
+}
+
+func (l *memoryLog) PrunedThrough(ctx context.Context) (int64, error) {
+	return l.prunedThrough, nil
+}
+
+var errClientGone = errors.New("client gone")
+
+// recordingEmitter stops the stream once it has seen want events.
+type recordingEmitter struct {
+	want       int
+	events     []event.Event
+	heartbeats int
+}
+
+func (e *recordingEmitter) Emit(ev event.Event) error {
+	e.events = append(e.events, ev)
+	if len(e.events) >= e.want {
+		return errClientGone
+	}
+	return nil
+}
+
+func (e *recordingEmitter) Heartbeat() error {
+	e.heartbeats++
+	return nil
+}
+
+func (e *recordingEmitter) types() []string {
+	var types []string
+	for _, ev := range e.events {
+		types = append(types, ev.Type)
+	}
+	return types
+}
+
+func TestStream_ResumesAfterLastEventID(t *testing.T) {
+	// given
+	log := &memoryLog{}
+	log.append(1, event.TodoCreated)
+	log.append(2, event.TodoCreated)
+	log.append(1, event.TodoUpdated)
+	log.append(1, event.TodoDeleted)
+	streamer := event.NewStreamer(log, event.NewBroker(), time.Hour)
+	emitter := &recordingEmitter{want: 2}
+	lastEventID := int64(1)
+
+	// when
+	err := streamer.Stream(context.Background(), 1, &lastEventID, emitter)
+
+	// then
+	assert.ErrorIs(t, err, errClientGone)
+	assert.Equal(t, []string{event.TodoUpdated, event.TodoDeleted}, emitter.types())
+	assert.Equal(t, int64(4), emitter.events[1].ID)
+}
+
+func TestStream_ResetsWhenResumePointWasPruned(t *testing.T) {
+	// given
+	log := &memoryLog{prunedThrough: 5}
+	for i := 0; i < 6; i++ {
+		log.append(1, event.TodoUpdated)
+	}
+	streamer := event.NewStreamer(log, event.NewBroker(), time.Hour)
+	emitter := &recordingEmitter{want: 1}
+	lastEventID := int64(2)
+
+	// when
+	_ = streamer.Stream(context.Background(), 1, &lastEventID, emitter)
+
+	// then
+	assert.Equal(t, event.Reset, emitter.events[0].Type)
+	assert.Equal(t, int64(6), emitter.events[0].ID)
+}
+
+func TestStream_NewConnectionOnlyGetsNewEvents(t *testing.T) {
+	// given
+	log := &memoryLog{}
+	log.append(1, event.TodoCreated)
+	broker := event.NewBroker()
+	streamer := event.NewStreamer(log, broker, time.Hour)
+	emitter := &recordingEmitter{want: 1}
+	done := make(chan error)
+
+	// when
+	go func() { done <- streamer.Stream(context.Background(), 1, nil, emitter) }()
+	assert.Eventually(t, func() bool {
+		log.append(1, event.ProjectCreated)
+		broker.Notify(1)
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	// then
+	assert.Equal(t, event.ProjectCreated, emitter.events[0].Type)
+}
+
+func TestStream_StopsWhenContextIsDone(t *testing.T) {
+	// given
+	streamer := event.NewStreamer(&memoryLog{}, event.NewBroker(), time.Millisecond)
+	emitter := &recordingEmitter{want: 1}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// when
+	err := streamer.Stream(ctx, 1, nil, emitter)
+
+	// then
+	assert.NoError(t, err)
+	assert.Positive(t, emitter.heartbeats)
+}
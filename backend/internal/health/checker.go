@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type dbChecker struct {
+	db *sqlx.DB
+}
+
+func NewDBChecker(db *sqlx.DB) *dbChecker {
+	return &dbChecker{db: db}
+}
+
+func (c *dbChecker) Name() string {
+	return "database"
+}
+
+func (c *dbChecker) Check(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+type VersionSource interface {
+	CurrentVersion(ctx context.Context) (int, error)
+	LatestVersion() int
+}
+
+type migrationChecker struct {
+	source VersionSource
+}
+
+func NewMigrationChecker(source VersionSource) *migrationChecker {
+	return &migrationChecker{source: source}
+}
+
+func (c *migrationChecker) Name() string {
+	return "migrations"
+}
+
+func (c *migrationChecker) Check(ctx context.Context) error {
+	current, err := c.source.CurrentVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := c.source.LatestVersion()
+	if current != expected {
+		return fmt.Errorf("migration version mismatch. current=%d expected=%d", current, expected)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package health
+
+import (
+	"context"
+	"net/http"
+)
+
+type HealthHandler struct {
+	probe *Probe
+}
+
+func NewHealthHandler(probe *Probe) *HealthHandler {
+	return &HealthHandler{probe: probe}
+}
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+func (h *HealthHandler) HandleLiveness() (int, any) {
+	return http.StatusOK, LivenessResponse{Status: "ok"}
+}
+
+func (h *HealthHandler) HandleReadiness(ctx context.Context) (int, any) {
+	report := h.probe.Readiness(ctx)
+	if !report.Ready {
+		return http.StatusServiceUnavailable, report
+	}
+	return http.StatusOK, report
+}
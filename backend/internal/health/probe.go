@@ -0,0 +1,106 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type ReadinessReport struct {
+	Ready        bool                   `json:"-"`
+	Status       string                 `json:"status"`
+	ShuttingDown bool                   `json:"shutting_down"`
+	Checks       map[string]CheckResult `json:"checks"`
+}
+
+type Probe struct {
+	timeout      time.Duration
+	checkers     []Checker
+	shuttingDown atomic.Bool
+}
+
+func NewProbe(timeout time.Duration, checkers ...Checker) *Probe {
+	return &Probe{
+		timeout:  timeout,
+		checkers: checkers,
+	}
+}
+
+// MarkShuttingDown makes every following readiness check fail so that load balancers
+// stop routing new traffic before the server actually stops.
+func (p *Probe) MarkShuttingDown() {
+	p.shuttingDown.Store(true)
+}
+
+func (p *Probe) IsShuttingDown() bool {
+	return p.shuttingDown.Load()
+}
+
+func (p *Probe) Readiness(ctx context.Context) ReadinessReport {
+	results := make(map[string]CheckResult, len(p.checkers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, checker := range p.checkers {
+		wg.Add(1)
+		go func(checker Checker) {
+			defer wg.Done()
+			result := p.runCheck(ctx, checker)
+			mu.Lock()
+			results[checker.Name()] = result
+			mu.Unlock()
+		}(checker)
+	}
+	wg.Wait()
+
+	shuttingDown := p.IsShuttingDown()
+	ready := !shuttingDown
+	for _, result := range results {
+		if result.Status != StatusUp {
+			ready = false
+		}
+	}
+
+	status := "ready"
+	if !ready {
+		status = "not ready"
+	}
+
+	return ReadinessReport{
+		Ready:        ready,
+		Status:       status,
+		ShuttingDown: shuttingDown,
+		Checks:       results,
+	}
+}
+
+func (p *Probe) runCheck(ctx context.Context, checker Checker) CheckResult {
+	checkCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	err := checker.Check(checkCtx)
+	latency := float64(time.Since(startedAt).Microseconds()) / 1000
+
+	if err != nil {
+		return CheckResult{Status: StatusDown, LatencyMs: latency, Error: err.Error()}
+	}
+	return CheckResult{Status: StatusUp, LatencyMs: latency}
+}
@@ -0,0 +1,154 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/health"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubChecker struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (s stubChecker) Name() string {
+	return s.name
+}
+
+func (s stubChecker) Check(ctx context.Context) error {
+	return s.check(ctx)
+}
+
+func healthyChecker(name string) stubChecker {
+	return stubChecker{name: name, check: func(ctx context.Context) error { return nil }}
+}
+
+func TestReadiness_AllChecksUp(t *testing.T) {
+	// given
+	probe := health.NewProbe(time.Second, healthyChecker("database"), healthyChecker("migrations"))
+
+	// when
+	report := probe.Readiness(context.Background())
+
+	// then
+	assert.True(t, report.Ready)
+	assert.Equal(t, "ready", report.Status)
+	assert.Len(t, report.Checks, 2)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+	assert.Equal(t, health.StatusUp, report.Checks["migrations"].Status)
+}
+
+func TestReadiness_CheckFailed(t *testing.T) {
+	// given
+	failing := stubChecker{name: "database", check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}}
+	probe := health.NewProbe(time.Second, failing, healthyChecker("migrations"))
+
+	// when
+	report := probe.Readiness(context.Background())
+
+	// then
+	assert.False(t, report.Ready)
+	assert.Equal(t, "not ready", report.Status)
+	assert.Equal(t, health.StatusDown, report.Checks["database"].Status)
+	assert.Equal(t, "connection refused", report.Checks["database"].Error)
+	assert.Equal(t, health.StatusUp, report.Checks["migrations"].Status)
+}
+
+func TestReadiness_CheckTimedOut(t *testing.T) {
+	// given
+	slow := stubChecker{name: "database", check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	probe := health.NewProbe(10*time.Millisecond, slow)
+
+	// when
+	report := probe.Readiness(context.Background())
+
+	// then
+	assert.False(t, report.Ready)
+	assert.Contains(t, report.Checks["database"].Error, "deadline exceeded")
+}
+
+func TestReadiness_ShuttingDown(t *testing.T) {
+	// given
+	probe := health.NewProbe(time.Second, healthyChecker("database"))
+
+	// when
+	probe.MarkShuttingDown()
+	report := probe.Readiness(context.Background())
+
+	// then
+	assert.False(t, report.Ready)
+	assert.True(t, report.ShuttingDown)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+}
+
+func TestHandleReadiness_NotReady(t *testing.T) {
+	// given
+	probe := health.NewProbe(time.Second, healthyChecker("database"))
+	probe.MarkShuttingDown()
+	handler := health.NewHealthHandler(probe)
+
+	// when
+	code, res := handler.HandleReadiness(context.Background())
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.IsType(t, health.ReadinessReport{}, res)
+}
+
+func TestHandleLiveness(t *testing.T) {
+	// given
+	handler := health.NewHealthHandler(health.NewProbe(time.Second))
+
+	// when
+	code, res := handler.HandleLiveness()
+
+	// then
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.LivenessResponse{Status: "ok"}, res)
+}
+
+type stubVersionSource struct {
+	current int
+	latest  int
+}
+
+func (s stubVersionSource) CurrentVersion(ctx context.Context) (int, error) {
+	return s.current, nil
+}
+
+func (s stubVersionSource) LatestVersion() int {
+	return s.latest
+}
+
+func TestMigrationChecker_UpToDate(t *testing.T) {
+	// given
+	checker := health.NewMigrationChecker(stubVersionSource{current: 3, latest: 3})
+
+	// when
+	err := checker.Check(context.Background())
+
+	// then
+	assert.NoError(t, err)
+}
+
+func TestMigrationChecker_VersionMismatch(t *testing.T) {
+	// given
+	checker := health.NewMigrationChecker(stubVersionSource{current: 2, latest: 3})
+
+	// when
+	err := checker.Check(context.Background())
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "current=2 expected=3")
+}
@@ -0,0 +1,73 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	requestHash string
+	response    *Response
+	expiresAt   time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func NewMemoryStore(ttl time.Duration, now func() time.Time) *memoryStore {
+	return &memoryStore{entries: map[string]*entry{}, ttl: ttl, now: now}
+}
+
+func (s *memoryStore) Begin(ctx context.Context, scope string, key string, requestHash string) (Outcome, *Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	e, ok := s.entries[scope+"/"+key]
+	switch {
+	case !ok:
+		s.entries[scope+"/"+key] = &entry{requestHash: requestHash, expiresAt: now.Add(s.ttl)}
+		return Started, nil, nil
+	case e.requestHash != requestHash:
+		return Mismatch, nil, nil
+	case e.response == nil:
+		return InProgress, nil, nil
+	default:
+		return Replay, e.response, nil
+	}
+}
+
+func (s *memoryStore) Complete(ctx context.Context, scope string, key string, response Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[scope+"/"+key]; ok {
+		e.response = &response
+	}
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, scope string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[scope+"/"+key]; ok && e.response == nil {
+		delete(s.entries, scope+"/"+key)
+	}
+	return nil
+}
+
+func (s *memoryStore) sweep(now time.Time) {
+	for key, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
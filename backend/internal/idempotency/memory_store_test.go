@@ -0,0 +1,96 @@
+package idempotency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/idempotency"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestHashRequest_DependsOnMethodPathAndBody(t *testing.T) {
+	// given
+	base := idempotency.HashRequest("POST", "/api/todos", []byte(`{"title":"a"}`))
+
+	// when
+	same := idempotency.HashRequest("POST", "/api/todos", []byte(`{"title":"a"}`))
+	otherBody := idempotency.HashRequest("POST", "/api/todos", []byte(`{"title":"b"}`))
+	otherPath := idempotency.HashRequest("POST", "/api/projects", []byte(`{"title":"a"}`))
+
+	// then
+	assert.Equal(t, base, same)
+	assert.NotEqual(t, base, otherBody)
+	assert.NotEqual(t, base, otherPath)
+}
+
+func TestMemoryStore_ReplaysCompletedResponse(t *testing.T) {
+	// given
+	store := idempotency.NewMemoryStore(time.Hour, newClock().Now)
+	ctx := context.Background()
+	first, _, _ := store.Begin(ctx, "user:1", "key-1", "hash")
+	_ = store.Complete(ctx, "user:1", "key-1", idempotency.Response{StatusCode: 201, Body: []byte(`{"id":1}`)})
+
+	// when
+	outcome, response, err := store.Begin(ctx, "user:1", "key-1", "hash")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, idempotency.Started, first)
+	assert.Equal(t, idempotency.Replay, outcome)
+	assert.Equal(t, 201, response.StatusCode)
+	assert.Equal(t, `{"id":1}`, string(response.Body))
+}
+
+func TestMemoryStore_DetectsMismatchAndInProgress(t *testing.T) {
+	// given
+	store := idempotency.NewMemoryStore(time.Hour, newClock().Now)
+	ctx := context.Background()
+	_, _, _ = store.Begin(ctx, "user:1", "key-1", "hash")
+
+	// when
+	inProgress, _, _ := store.Begin(ctx, "user:1", "key-1", "hash")
+	mismatch, _, _ := store.Begin(ctx, "user:1", "key-1", "other-hash")
+	otherScope, _, _ := store.Begin(ctx, "user:2", "key-1", "other-hash")
+
+	// then
+	assert.Equal(t, idempotency.InProgress, inProgress)
+	assert.Equal(t, idempotency.Mismatch, mismatch)
+	assert.Equal(t, idempotency.Started, otherScope)
+}
+
+func TestMemoryStore_ReleaseAndExpiryFreeTheKey(t *testing.T) {
+	// given
+	clock := newClock()
+	store := idempotency.NewMemoryStore(time.Hour, clock.Now)
+	ctx := context.Background()
+	_, _, _ = store.Begin(ctx, "user:1", "released", "hash")
+	_, _, _ = store.Begin(ctx, "user:1", "expired", "hash")
+	_ = store.Complete(ctx, "user:1", "expired", idempotency.Response{StatusCode: 201, Body: []byte(`{}`)})
+
+	// when
+	_ = store.Release(ctx, "user:1", "released")
+	afterRelease, _, _ := store.Begin(ctx, "user:1", "released", "other-hash")
+	clock.Advance(time.Hour)
+	afterExpiry, _, _ := store.Begin(ctx, "user:1", "expired", "other-hash")
+
+	// then
+	assert.Equal(t, idempotency.Started, afterRelease)
+	assert.Equal(t, idempotency.Started, afterExpiry)
+}
@@ -0,0 +1,113 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresStore struct {
+	db  *sqlx.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewPostgresStore shares keys between every instance connected to the same database.
+func NewPostgresStore(db *sqlx.DB, ttl time.Duration, now func() time.Time) *postgresStore {
+	return &postgresStore{db: db, ttl: ttl, now: now}
+}
+
+// Begin claims the key with an insert, so of two concurrent requests only one starts.
+// An expired record is replaced as if it never existed, and an unfinished one whose lock
+// ran out is taken over by the same request.
+func (s *postgresStore) Begin(ctx context.Context, scope string, key string, requestHash string) (Outcome, *Response, error) {
+	now := s.now()
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (scope, key, request_hash, created_at, expires_at, locked_until)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			response_body = NULL,
+			response_headers = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at,
+			locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at <= $4
+			OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until <= $4
+				AND idempotency_keys.request_hash = EXCLUDED.request_hash)`,
+		scope, key, requestHash, now, now.Add(s.ttl), now.Add(LockTimeout))
+	if err != nil {
+		return 0, nil, err
+	}
+	if claimed, _ := result.RowsAffected(); claimed == 1 {
+		return Started, nil, nil
+	}
+
+	var record struct {
+		RequestHash     string        `db:"request_hash"`
+		StatusCode      sql.NullInt64 `db:"status_code"`
+		ResponseBody    []byte        `db:"response_body"`
+		ResponseHeaders []byte        `db:"response_headers"`
+	}
+	err = s.db.GetContext(ctx, &record, `
+		SELECT request_hash, status_code, response_body, response_headers
+		FROM idempotency_keys WHERE scope = $1 AND key = $2`,
+		scope, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released by the owner between the insert and the select.
+		return s.Begin(ctx, scope, key, requestHash)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	switch {
+	case record.RequestHash != requestHash:
+		return Mismatch, nil, nil
+	case !record.StatusCode.Valid:
+		return InProgress, nil, nil
+	default:
+		response := &Response{StatusCode: int(record.StatusCode.Int64), Body: record.ResponseBody}
+		if record.ResponseHeaders != nil {
+			if err := json.Unmarshal(record.ResponseHeaders, &response.Header); err != nil {
+				return 0, nil, err
+			}
+		}
+		return Replay, response, nil
+	}
+}
+
+func (s *postgresStore) Complete(ctx context.Context, scope string, key string, response Response) error {
+	var headers []byte
+	if len(response.Header) > 0 {
+		var err error
+		if headers, err = json.Marshal(response.Header); err != nil {
+			return err
+		}
+	}
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE idempotency_keys SET status_code = $3, response_body = $4, response_headers = $5, locked_until = NULL
+		WHERE scope = $1 AND key = $2`,
+		scope, key, response.StatusCode, response.Body, headers)
+	return err
+}
+
+func (s *postgresStore) Release(ctx context.Context, scope string, key string) error {
+	_, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND status_code IS NULL", scope, key)
+	return err
+}
+
+// DeleteExpired removes keys past their TTL. Begin already ignores them; this only reclaims space.
+func (s *postgresStore) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= $1", s.now())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,110 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/idempotency"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresStore_ReplaysAndDetectsMismatch(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	store := idempotency.NewPostgresStore(testhelper.GetTestDB(), time.Hour, newClock().Now)
+	ctx := context.Background()
+	started, _, err := store.Begin(ctx, "user:1", "key-1", "hash")
+	assert.NoError(t, err)
+	inProgress, _, _ := store.Begin(ctx, "user:1", "key-1", "hash")
+	assert.NoError(t, store.Complete(ctx, "user:1", "key-1", idempotency.Response{
+		StatusCode: 201, Body: []byte(`{"id": 1}`), Header: http.Header{"Etag": {`"1"`}},
+	}))
+
+	// when
+	replay, response, err := store.Begin(ctx, "user:1", "key-1", "hash")
+	mismatch, _, _ := store.Begin(ctx, "user:1", "key-1", "other-hash")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, idempotency.Started, started)
+	assert.Equal(t, idempotency.InProgress, inProgress)
+	assert.Equal(t, idempotency.Replay, replay)
+	assert.Equal(t, 201, response.StatusCode)
+	assert.Equal(t, `{"id": 1}`, string(response.Body))
+	assert.Equal(t, `"1"`, response.Header.Get("ETag"))
+	assert.Equal(t, idempotency.Mismatch, mismatch)
+}
+
+func TestPostgresStore_ExpiredKeyIsClaimedAgain(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	clock := newClock()
+	store := idempotency.NewPostgresStore(testhelper.GetTestDB(), time.Hour, clock.Now)
+	ctx := context.Background()
+	_, _, _ = store.Begin(ctx, "user:1", "key-1", "hash")
+	_ = store.Complete(ctx, "user:1", "key-1", idempotency.Response{StatusCode: 201, Body: []byte(`{}`)})
+	_, _, _ = store.Begin(ctx, "user:1", "key-2", "hash")
+	clock.Advance(time.Hour)
+
+	// when
+	outcome, _, err := store.Begin(ctx, "user:1", "key-1", "other-hash")
+	deleted, purgeErr := store.DeleteExpired(ctx)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, purgeErr)
+	assert.Equal(t, idempotency.Started, outcome)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestPostgresStore_ConcurrentBeginStartsOnce(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	store := idempotency.NewPostgresStore(testhelper.GetTestDB(), time.Hour, newClock().Now)
+
+	// when
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	started := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outcome, _, err := store.Begin(context.Background(), "user:1", "key-1", "hash")
+			if err == nil && outcome == idempotency.Started {
+				mu.Lock()
+				started++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// then
+	assert.Equal(t, 1, started)
+}
+
+func TestPostgresStore_StaleLockIsTakenOverBySameRequest(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	clock := newClock()
+	store := idempotency.NewPostgresStore(testhelper.GetTestDB(), time.Hour, clock.Now)
+	ctx := context.Background()
+	_, _, _ = store.Begin(ctx, "user:1", "key-1", "hash")
+	stillLocked, _, _ := store.Begin(ctx, "user:1", "key-1", "hash")
+	clock.Advance(idempotency.LockTimeout)
+
+	// when
+	other, _, _ := store.Begin(ctx, "user:1", "key-1", "other-hash")
+	takenOver, _, err := store.Begin(ctx, "user:1", "key-1", "hash")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, idempotency.InProgress, stillLocked)
+	assert.Equal(t, idempotency.Mismatch, other)
+	assert.Equal(t, idempotency.Started, takenOver)
+}
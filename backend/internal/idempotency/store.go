@@ -0,0 +1,57 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+const (
+	HeaderName         = "Idempotency-Key"
+	ReplayedHeaderName = "Idempotent-Replayed"
+	DefaultTTL         = 24 * time.Hour
+	// LockTimeout is how long a started request keeps its key. A retry after it takes the
+	// key over, so a crashed owner does not leave the key in progress for the whole TTL.
+	LockTimeout = time.Minute
+)
+
+type Outcome int
+
+const (
+	// Started means the caller owns the key and must Complete or Release it.
+	Started Outcome = iota
+	// Replay means a response was already stored for the same request.
+	Replay
+	// Mismatch means the key was used before with a different request.
+	Mismatch
+	// InProgress means the first request with this key has not finished yet.
+	InProgress
+)
+
+// ReplayedHeaders are the response headers stored with the body. A retried create gets
+// the same ETag and Location as the first attempt, so it can go on with a conditional write.
+var ReplayedHeaders = []string{"ETag", "Location"}
+
+type Response struct {
+	StatusCode int
+	Body       []byte
+	Header     http.Header
+}
+
+// Store remembers the response of a request per scope and key until the TTL passes.
+// Scope separates clients so keys chosen by one user never collide with another's.
+type Store interface {
+	Begin(ctx context.Context, scope string, key string, requestHash string) (Outcome, *Response, error)
+	Complete(ctx context.Context, scope string, key string, response Response) error
+	// Release forgets an unfinished key so the client can retry after a server error.
+	Release(ctx context.Context, scope string, key string) error
+}
+
+func HashRequest(method string, path string, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(method + " " + path + "\n"))
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil))
+}
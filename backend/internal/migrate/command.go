@@ -0,0 +1,79 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const usage = "usage: migrate up|down|status|goto <version>"
+
+type Runner interface {
+	Up(ctx context.Context) ([]Migration, error)
+	Down(ctx context.Context) ([]Migration, error)
+	Goto(ctx context.Context, target int) ([]Migration, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+// RunCommand executes the "migrate" subcommand described by args and reports to out.
+func RunCommand(ctx context.Context, runner Runner, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch args[0] {
+	case "up":
+		executed, err := runner.Up(ctx)
+		printExecuted(out, "applied", executed)
+		return err
+	case "down":
+		executed, err := runner.Down(ctx)
+		printExecuted(out, "rolled back", executed)
+		return err
+	case "goto":
+		if len(args) != 2 {
+			return errors.New(usage)
+		}
+		target, err := strconv.Atoi(args[1])
+		if err != nil || target < 0 {
+			return fmt.Errorf("invalid target version %q", args[1])
+		}
+		executed, err := runner.Goto(ctx, target)
+		printExecuted(out, "executed", executed)
+		return err
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatuses(out, statuses)
+		return nil
+	default:
+		return errors.New(usage)
+	}
+}
+
+func printExecuted(out io.Writer, verb string, executed []Migration) {
+	if len(executed) == 0 {
+		fmt.Fprintln(out, "no change")
+		return
+	}
+	for _, migration := range executed {
+		fmt.Fprintf(out, "✓ %s: %06d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
+
+func printStatuses(out io.Writer, statuses []MigrationStatus) {
+	for _, status := range statuses {
+		state := "pending"
+		if status.Applied {
+			state = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if status.ChecksumMismatch {
+			state += " (checksum mismatch)"
+		}
+		fmt.Fprintf(out, "%06d_%s\t%s\n", status.Version, status.Name, state)
+	}
+}
@@ -0,0 +1,107 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/migrate"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRunner struct {
+	gotoTarget int
+	executed   []migrate.Migration
+	statuses   []migrate.MigrationStatus
+}
+
+func (s *stubRunner) Up(ctx context.Context) ([]migrate.Migration, error) {
+	return s.executed, nil
+}
+
+func (s *stubRunner) Down(ctx context.Context) ([]migrate.Migration, error) {
+	return s.executed, nil
+}
+
+func (s *stubRunner) Goto(ctx context.Context, target int) ([]migrate.Migration, error) {
+	s.gotoTarget = target
+	return s.executed, nil
+}
+
+func (s *stubRunner) Status(ctx context.Context) ([]migrate.MigrationStatus, error) {
+	return s.statuses, nil
+}
+
+func TestRunCommand_Up(t *testing.T) {
+	// given
+	runner := &stubRunner{executed: []migrate.Migration{{Version: 1, Name: "create_users"}}}
+	out := &bytes.Buffer{}
+
+	// when
+	err := migrate.RunCommand(context.Background(), runner, []string{"up"}, out)
+
+	// then
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "applied: 000001_create_users")
+}
+
+func TestRunCommand_NoChange(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+
+	// when
+	err := migrate.RunCommand(context.Background(), &stubRunner{}, []string{"down"}, out)
+
+	// then
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "no change")
+}
+
+func TestRunCommand_Goto(t *testing.T) {
+	// given
+	runner := &stubRunner{}
+
+	// when
+	err := migrate.RunCommand(context.Background(), runner, []string{"goto", "2"}, &bytes.Buffer{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runner.gotoTarget)
+}
+
+func TestRunCommand_GotoInvalidVersion(t *testing.T) {
+	// when
+	err := migrate.RunCommand(context.Background(), &stubRunner{}, []string{"goto", "latest"}, &bytes.Buffer{})
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid target version")
+}
+
+func TestRunCommand_Status(t *testing.T) {
+	// given
+	appliedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	runner := &stubRunner{statuses: []migrate.MigrationStatus{
+		{Version: 1, Name: "create_users", Applied: true, AppliedAt: &appliedAt},
+		{Version: 2, Name: "create_projects"},
+	}}
+	out := &bytes.Buffer{}
+
+	// when
+	err := migrate.RunCommand(context.Background(), runner, []string{"status"}, out)
+
+	// then
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "000001_create_users\tapplied at 2025-01-02 03:04:05")
+	assert.Contains(t, out.String(), "000002_create_projects\tpending")
+}
+
+func TestRunCommand_UnknownSubcommand(t *testing.T) {
+	// when
+	err := migrate.RunCommand(context.Background(), &stubRunner{}, []string{"redo"}, &bytes.Buffer{})
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "usage")
+}
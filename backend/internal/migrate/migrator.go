@@ -0,0 +1,260 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// advisoryLockKey serializes migration runs across every process sharing the database.
+const advisoryLockKey int64 = 7_275_183_901
+
+type AppliedMigration struct {
+	Version   int       `db:"version"`
+	Name      string    `db:"name"`
+	Checksum  string    `db:"checksum"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+type MigrationStatus struct {
+	Version          int
+	Name             string
+	Applied          bool
+	AppliedAt        *time.Time
+	ChecksumMismatch bool
+}
+
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sqlx.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func (m *Migrator) LatestVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// CurrentVersion returns the highest applied version, or 0 when nothing was applied yet.
+func (m *Migrator) CurrentVersion(ctx context.Context) (int, error) {
+	var exists bool
+	err := m.db.GetContext(ctx, &exists, "SELECT to_regclass('schema_migrations') IS NOT NULL")
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var version int
+	err = m.db.GetContext(ctx, &version, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations")
+	return version, err
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.Goto(ctx, m.LatestVersion())
+}
+
+// Down rolls back the latest applied migration only.
+func (m *Migrator) Down(ctx context.Context) ([]Migration, error) {
+	var rolledBack []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			return nil
+		}
+
+		migration, err := m.find(applied[len(applied)-1].Version)
+		if err != nil {
+			return err
+		}
+		if err := m.rollback(ctx, conn, migration); err != nil {
+			return err
+		}
+		rolledBack = append(rolledBack, migration)
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Goto applies or rolls back migrations until target is the latest applied version.
+// A target of 0 rolls back every migration.
+func (m *Migrator) Goto(ctx context.Context, target int) ([]Migration, error) {
+	if target != 0 {
+		if _, err := m.find(target); err != nil {
+			return nil, err
+		}
+	}
+
+	var executed []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		appliedVersions := map[int]bool{}
+		for _, a := range applied {
+			appliedVersions[a.Version] = true
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version <= target || !appliedVersions[migration.Version] {
+				continue
+			}
+			if err := m.rollback(ctx, conn, migration); err != nil {
+				return err
+			}
+			executed = append(executed, migration)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > target || appliedVersions[migration.Version] {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			executed = append(executed, migration)
+		}
+		return nil
+	})
+	return executed, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		appliedByVersion := map[int]AppliedMigration{}
+		for _, a := range applied {
+			appliedByVersion[a.Version] = a
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if a, ok := appliedByVersion[migration.Version]; ok {
+				appliedAt := a.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.ChecksumMismatch = a.Checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sqlx.Conn) ([]AppliedMigration, error) {
+	var applied []AppliedMigration
+	err := conn.SelectContext(ctx, &applied,
+		"SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version")
+	return applied, err
+}
+
+// verifiedApplied refuses to go on when an applied script was edited after it ran.
+func (m *Migrator) verifiedApplied(ctx context.Context, conn *sqlx.Conn) ([]AppliedMigration, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range applied {
+		migration, err := m.find(a.Version)
+		if err != nil {
+			return nil, fmt.Errorf("applied migration %d is missing from the binary", a.Version)
+		}
+		if migration.Checksum != a.Checksum {
+			return nil, fmt.Errorf("checksum mismatch on migration %d_%s", a.Version, a.Name)
+		}
+	}
+	return applied, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, migration Migration) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+		return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+		migration.Version, migration.Name, migration.Checksum)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) rollback(ctx context.Context, conn *sqlx.Conn, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+	}
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) find(version int) (Migration, error) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, nil
+		}
+	}
+	return Migration{}, fmt.Errorf("unknown migration version %d", version)
+}
@@ -0,0 +1,109 @@
+package migrate_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"yangdongju/gtd_todo/internal/migrate"
+	"yangdongju/gtd_todo/migrations"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrator_AlreadyUpToDate(t *testing.T) {
+	// given
+	ctx := context.Background()
+	migrator, _ := migrate.NewMigrator(testhelper.GetTestDB(), migrations.FS)
+
+	// when
+	executed, err := migrator.Up(ctx)
+	current, _ := migrator.CurrentVersion(ctx)
+
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, executed)
+	assert.Equal(t, migrator.LatestVersion(), current)
+}
+
+func TestMigrator_GotoAndBack(t *testing.T) {
+	// given
+	ctx := context.Background()
+	migrator, _ := migrate.NewMigrator(testhelper.GetTestDB(), migrations.FS)
+	latest := migrator.LatestVersion()
+
+	// when
+	rolledBack, rollbackErr := migrator.Goto(ctx, 1)
+	versionAfterRollback, _ := migrator.CurrentVersion(ctx)
+	reapplied, upErr := migrator.Up(ctx)
+	versionAfterUp, _ := migrator.CurrentVersion(ctx)
+
+	// then
+	assert.NoError(t, rollbackErr)
+	assert.Len(t, rolledBack, latest-1)
+	assert.Equal(t, 1, versionAfterRollback)
+	assert.NoError(t, upErr)
+	assert.Len(t, reapplied, latest-1)
+	assert.Equal(t, latest, versionAfterUp)
+}
+
+func TestMigrator_Down(t *testing.T) {
+	// given
+	ctx := context.Background()
+	migrator, _ := migrate.NewMigrator(testhelper.GetTestDB(), migrations.FS)
+	latest := migrator.LatestVersion()
+
+	// when
+	rolledBack, err := migrator.Down(ctx)
+	current, _ := migrator.CurrentVersion(ctx)
+	_, _ = migrator.Up(ctx)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, rolledBack, 1)
+	assert.Equal(t, latest, rolledBack[0].Version)
+	assert.Equal(t, latest-1, current)
+}
+
+func TestMigrator_Status(t *testing.T) {
+	// given
+	migrator, _ := migrate.NewMigrator(testhelper.GetTestDB(), migrations.FS)
+
+	// when
+	statuses, err := migrator.Status(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, statuses, migrator.LatestVersion())
+	for _, status := range statuses {
+		assert.True(t, status.Applied)
+		assert.False(t, status.ChecksumMismatch)
+	}
+}
+
+func TestMigrator_ChecksumMismatch(t *testing.T) {
+	// given
+	edited := fstest.MapFS{
+		"000001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id SERIAL PRIMARY KEY);")},
+	}
+	migrator, _ := migrate.NewMigrator(testhelper.GetTestDB(), edited)
+
+	// when
+	_, err := migrator.Up(context.Background())
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+}
+
+func TestMigrator_GotoUnknownVersion(t *testing.T) {
+	// given
+	migrator, _ := migrate.NewMigrator(testhelper.GetTestDB(), migrations.FS)
+
+	// when
+	_, err := migrator.Goto(context.Background(), 999)
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown migration version")
+}
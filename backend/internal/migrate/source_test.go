@@ -0,0 +1,73 @@
+package migrate_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"yangdongju/gtd_todo/internal/migrate"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_SortedByVersion(t *testing.T) {
+	// given
+	fsys := fstest.MapFS{
+		"000002_create_projects.up.sql":   {Data: []byte("CREATE TABLE projects ();")},
+		"000002_create_projects.down.sql": {Data: []byte("DROP TABLE projects;")},
+		"000001_create_users.up.sql":      {Data: []byte("CREATE TABLE users ();")},
+		"000001_create_users.down.sql":    {Data: []byte("DROP TABLE users;")},
+		"embed.go":                        {Data: []byte("package migrations")},
+	}
+
+	// when
+	migrations, err := migrate.Load(fsys)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_users", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE users ();", migrations[0].Up)
+	assert.Equal(t, "DROP TABLE users;", migrations[0].Down)
+	assert.Len(t, migrations[0].Checksum, 64)
+	assert.Equal(t, 2, migrations[1].Version)
+}
+
+func TestLoad_ChecksumChangesWithScript(t *testing.T) {
+	// given
+	before := fstest.MapFS{"000001_create_users.up.sql": {Data: []byte("CREATE TABLE users ();")}}
+	after := fstest.MapFS{"000001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id INT);")}}
+
+	// when
+	beforeMigrations, _ := migrate.Load(before)
+	afterMigrations, _ := migrate.Load(after)
+
+	// then
+	assert.NotEqual(t, beforeMigrations[0].Checksum, afterMigrations[0].Checksum)
+}
+
+func TestLoad_MissingUpScript(t *testing.T) {
+	// given
+	fsys := fstest.MapFS{"000001_create_users.down.sql": {Data: []byte("DROP TABLE users;")}}
+
+	// when
+	_, err := migrate.Load(fsys)
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no up script")
+}
+
+func TestLoad_ConflictingNames(t *testing.T) {
+	// given
+	fsys := fstest.MapFS{
+		"000001_create_users.up.sql": {Data: []byte("CREATE TABLE users ();")},
+		"000001_create_todos.up.sql": {Data: []byte("CREATE TABLE todos ();")},
+	}
+
+	// when
+	_, err := migrate.Load(fsys)
+
+	// then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicting names")
+}
@@ -0,0 +1,212 @@
+package next
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"yangdongju/gtd_todo/internal/project"
+)
+
+const (
+	EnergyLow  = "low"
+	EnergyMed  = "med"
+	EnergyHigh = "high"
+)
+
+const (
+	day = 24 * time.Hour
+	// dueHorizonDays is how far ahead a due date starts to pull a todo up.
+	dueHorizonDays = 14
+	// ageCapDays is the age past which a todo stops gaining on younger ones.
+	ageCapDays = 30
+)
+
+const dueDateLayout = "2006-01-02"
+
+// Weights scale the components of a score. Only their ratios matter to the order; the
+// defaults let a due date outweigh everything but a blocker.
+type Weights struct {
+	Due     float64 `json:"due"`
+	Age     float64 `json:"age"`
+	Project float64 `json:"project"`
+	Blocked float64 `json:"blocked"`
+}
+
+func DefaultWeights() Weights {
+	return Weights{Due: 4, Age: 1, Project: 2, Blocked: 10}
+}
+
+// Filter is the situation the user is in. A zero field does not filter.
+type Filter struct {
+	Context string
+	Minutes int
+	Energy  string
+}
+
+// Breakdown is what each component added to a score, after weighting. Blocked is zero or
+// negative.
+type Breakdown struct {
+	Due     float64 `json:"due"`
+	Age     float64 `json:"age"`
+	Project float64 `json:"project"`
+	Blocked float64 `json:"blocked"`
+}
+
+type Suggestion struct {
+	TodoID          int       `json:"todo_id"`
+	Title           string    `json:"title"`
+	ProjectID       *int      `json:"project_id"`
+	ProjectPriority *string   `json:"project_priority"`
+	DueDate         *string   `json:"due_date"`
+	EstimateMinutes *int      `json:"estimate_minutes"`
+	Energy          *string   `json:"energy"`
+	Contexts        []string  `json:"contexts"`
+	IsBlocked       bool      `json:"is_blocked"`
+	CreatedAt       time.Time `json:"created_at"`
+	Score           float64   `json:"score"`
+	Breakdown       Breakdown `json:"breakdown"`
+}
+
+// Rank drops the candidates that do not fit filter and orders the rest by score, highest
+// first. today is the user's date and now the instant ages are measured to.
+//
+// A candidate fits when it carries the context, compared without case or a leading @,
+// when its estimate is at most the minutes available and when its energy is at most the
+// energy available. A candidate without an estimate or energy fits either way.
+//
+// The score is
+//
+//	due·Weights.Due + age·Weights.Age + project·Weights.Project − blocked·Weights.Blocked
+//
+// where each component runs from 0 to 1:
+//
+//   - due is 1 for a todo due today or overdue, falls linearly to 0 at 14 days ahead
+//     and is 0 without a due date
+//   - age is the days since the todo was created over 30, capped at 1
+//   - project is 1 for a high priority project, 0.5 for a normal one or no project and
+//     0 for a low one
+//   - blocked is 1 while the todo waits on a todo that is not done
+//
+// Each weighted component is rounded to two decimals and the score is their sum. Ties go
+// to the earlier due date, dates before none, then to the older todo, then to the lower id.
+func Rank(candidates []Candidate, filter Filter, weights Weights, today time.Time, now time.Time) []Suggestion {
+	suggestions := make([]Suggestion, 0, len(candidates))
+	for _, c := range candidates {
+		if !fits(c, filter) {
+			continue
+		}
+		breakdown := Breakdown{
+			Due:     round(dueComponent(c.DueDate, today) * weights.Due),
+			Age:     round(ageComponent(c.CreatedAt, now) * weights.Age),
+			Project: round(projectComponent(c.ProjectPriority) * weights.Project),
+		}
+		if c.IsBlocked {
+			breakdown.Blocked = -round(weights.Blocked)
+		}
+		contexts := []string{}
+		contexts = append(contexts, c.Contexts...)
+		suggestions = append(suggestions, Suggestion{
+			TodoID:          c.ID,
+			Title:           c.Title,
+			ProjectID:       c.ProjectID,
+			ProjectPriority: c.ProjectPriority,
+			DueDate:         formatDate(c.DueDate),
+			EstimateMinutes: c.EstimateMinutes,
+			Energy:          c.Energy,
+			Contexts:        contexts,
+			IsBlocked:       c.IsBlocked,
+			CreatedAt:       c.CreatedAt,
+			Score:           round(breakdown.Due + breakdown.Age + breakdown.Project + breakdown.Blocked),
+			Breakdown:       breakdown,
+		})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if a.DueDate != nil && *a.DueDate != *b.DueDate {
+			return *a.DueDate < *b.DueDate
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.TodoID < b.TodoID
+	})
+	return suggestions
+}
+
+func fits(c Candidate, filter Filter) bool {
+	if filter.Context != "" && !slices.ContainsFunc(c.Contexts, func(name string) bool {
+		return contextKey(name) == contextKey(filter.Context)
+	}) {
+		return false
+	}
+	if filter.Minutes > 0 && c.EstimateMinutes != nil && *c.EstimateMinutes > filter.Minutes {
+		return false
+	}
+	if filter.Energy != "" && c.Energy != nil && energyLevel(*c.Energy) > energyLevel(filter.Energy) {
+		return false
+	}
+	return true
+}
+
+// contextKey lets "@Office", "@office" and "office" name the same context.
+func contextKey(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+}
+
+func energyLevel(energy string) int {
+	switch energy {
+	case EnergyLow:
+		return 1
+	case EnergyMed:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func dueComponent(dueDate *time.Time, today time.Time) float64 {
+	if dueDate == nil {
+		return 0
+	}
+	days := math.Round(dueDate.Sub(today).Hours() / 24)
+	return math.Max(0, math.Min(1, 1-days/dueHorizonDays))
+}
+
+func ageComponent(createdAt time.Time, now time.Time) float64 {
+	days := now.Sub(createdAt).Hours() / 24
+	return math.Max(0, math.Min(1, days/ageCapDays))
+}
+
+func projectComponent(priority *string) float64 {
+	if priority == nil {
+		return 0.5
+	}
+	switch *priority {
+	case project.PriorityHigh:
+		return 1
+	case project.PriorityLow:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(dueDateLayout)
+	return &formatted
+}
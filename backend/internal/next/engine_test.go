@@ -0,0 +1,124 @@
+package next_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/next"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// fixture is one ranking scenario in testdata. Weights default to next.DefaultWeights.
+type fixture struct {
+	Today      string        `json:"today"`
+	Now        time.Time     `json:"now"`
+	Filter     fixtureFilter `json:"filter"`
+	Weights    *next.Weights `json:"weights"`
+	Candidates []struct {
+		ID              int       `json:"id"`
+		Title           string    `json:"title"`
+		ProjectID       *int      `json:"project_id"`
+		ProjectPriority *string   `json:"project_priority"`
+		DueDate         *string   `json:"due_date"`
+		EstimateMinutes *int      `json:"estimate_minutes"`
+		Energy          *string   `json:"energy"`
+		Contexts        []string  `json:"contexts"`
+		IsBlocked       bool      `json:"is_blocked"`
+		CreatedAt       time.Time `json:"created_at"`
+	} `json:"candidates"`
+}
+
+type fixtureFilter struct {
+	Context string `json:"context"`
+	Minutes int    `json:"minutes"`
+	Energy  string `json:"energy"`
+}
+
+// ============ Test Cases ============
+
+// TestRank_Golden ranks every fixture in testdata and compares the result with its
+// .golden file. Run with -update after an intended change to the scoring.
+func TestRank_Golden(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t.Run(name, func(t *testing.T) {
+			// given
+			f := loadFixture(t, path)
+			weights := next.DefaultWeights()
+			if f.Weights != nil {
+				weights = *f.Weights
+			}
+			today, err := time.Parse("2006-01-02", f.Today)
+			require.NoError(t, err)
+			candidates := make([]next.Candidate, 0, len(f.Candidates))
+			for _, c := range f.Candidates {
+				candidate := next.Candidate{
+					ID: c.ID, Title: c.Title, ProjectID: c.ProjectID, ProjectPriority: c.ProjectPriority,
+					EstimateMinutes: c.EstimateMinutes, Energy: c.Energy, Contexts: c.Contexts,
+					IsBlocked: c.IsBlocked, CreatedAt: c.CreatedAt,
+				}
+				if c.DueDate != nil {
+					due, err := time.Parse("2006-01-02", *c.DueDate)
+					require.NoError(t, err)
+					candidate.DueDate = &due
+				}
+				candidates = append(candidates, candidate)
+			}
+
+			// when
+			suggestions := next.Rank(candidates, next.Filter(f.Filter), weights, today, f.Now)
+
+			// then
+			actual, err := json.MarshalIndent(suggestions, "", "  ")
+			require.NoError(t, err)
+			actual = append(actual, '\n')
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual, 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestRank_SameInputSameOrder(t *testing.T) {
+	// given
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	created := now.Add(-48 * time.Hour)
+	candidates := []next.Candidate{
+		{ID: 3, Title: "c", CreatedAt: created},
+		{ID: 1, Title: "a", CreatedAt: created},
+		{ID: 2, Title: "b", CreatedAt: created},
+	}
+
+	// when
+	first := next.Rank(candidates, next.Filter{}, next.DefaultWeights(), now, now)
+	second := next.Rank([]next.Candidate{candidates[2], candidates[0], candidates[1]}, next.Filter{}, next.DefaultWeights(), now, now)
+
+	// then
+	assert.Equal(t, first, second)
+	assert.Equal(t, []int{1, 2, 3}, []int{first[0].TodoID, first[1].TodoID, first[2].TodoID})
+}
+
+func loadFixture(t *testing.T, path string) fixture {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var f fixture
+	require.NoError(t, json.Unmarshal(data, &f))
+	return f
+}
@@ -0,0 +1,31 @@
+package next
+
+import (
+	"context"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+)
+
+type NextHandler struct {
+	nextUsecase NextUsecase
+}
+
+func NewNextHandler(nextUsecase NextUsecase) *NextHandler {
+	return &NextHandler{nextUsecase: nextUsecase}
+}
+
+func (h *NextHandler) HandleNext(ctx context.Context, req NextRequest) (int, any) {
+	res, err := h.nextUsecase.Next(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError has nothing to map: the filters are validated on binding and an empty
+// ranking is still a ranking.
+func handleError(err error) (int, any) {
+	return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+}
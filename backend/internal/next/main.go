@@ -0,0 +1,12 @@
+//go:generate mockery
+package next
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func InitializeHandler(pool *sqlx.DB, weights Weights) *NextHandler {
+	return NewNextHandler(NewNextService(NewNextRepository(pool), weights, time.Now))
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package nextmocks
+
+import (
+	context "context"
+	next "yangdongju/gtd_todo/internal/next"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NextRepository is an autogenerated mock type for the NextRepository type
+type NextRepository struct {
+	mock.Mock
+}
+
+type NextRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NextRepository) EXPECT() *NextRepository_Expecter {
+	return &NextRepository_Expecter{mock: &_m.Mock}
+}
+
+// FindCandidates provides a mock function with given fields: ctx, userID
+func (_m *NextRepository) FindCandidates(ctx context.Context, userID int) ([]next.Candidate, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCandidates")
+	}
+
+	var r0 []next.Candidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]next.Candidate, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []next.Candidate); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]next.Candidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextRepository_FindCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindCandidates'
+type NextRepository_FindCandidates_Call struct {
+	*mock.Call
+}
+
+// FindCandidates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *NextRepository_Expecter) FindCandidates(ctx interface{}, userID interface{}) *NextRepository_FindCandidates_Call {
+	return &NextRepository_FindCandidates_Call{Call: _e.mock.On("FindCandidates", ctx, userID)}
+}
+
+func (_c *NextRepository_FindCandidates_Call) Run(run func(ctx context.Context, userID int)) *NextRepository_FindCandidates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *NextRepository_FindCandidates_Call) Return(_a0 []next.Candidate, _a1 error) *NextRepository_FindCandidates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NextRepository_FindCandidates_Call) RunAndReturn(run func(context.Context, int) ([]next.Candidate, error)) *NextRepository_FindCandidates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserTimezone provides a mock function with given fields: ctx, userID
+func (_m *NextRepository) UserTimezone(ctx context.Context, userID int) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserTimezone")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextRepository_UserTimezone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserTimezone'
+type NextRepository_UserTimezone_Call struct {
+	*mock.Call
+}
+
+// UserTimezone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *NextRepository_Expecter) UserTimezone(ctx interface{}, userID interface{}) *NextRepository_UserTimezone_Call {
+	return &NextRepository_UserTimezone_Call{Call: _e.mock.On("UserTimezone", ctx, userID)}
+}
+
+func (_c *NextRepository_UserTimezone_Call) Run(run func(ctx context.Context, userID int)) *NextRepository_UserTimezone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *NextRepository_UserTimezone_Call) Return(_a0 string, _a1 error) *NextRepository_UserTimezone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NextRepository_UserTimezone_Call) RunAndReturn(run func(context.Context, int) (string, error)) *NextRepository_UserTimezone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNextRepository creates a new instance of NextRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNextRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NextRepository {
+	mock := &NextRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package nextmocks
+
+import (
+	context "context"
+	next "yangdongju/gtd_todo/internal/next"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NextUsecase is an autogenerated mock type for the NextUsecase type
+type NextUsecase struct {
+	mock.Mock
+}
+
+type NextUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NextUsecase) EXPECT() *NextUsecase_Expecter {
+	return &NextUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Next provides a mock function with given fields: ctx, request
+func (_m *NextUsecase) Next(ctx context.Context, request next.NextRequest) (*next.NextResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Next")
+	}
+
+	var r0 *next.NextResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, next.NextRequest) (*next.NextResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, next.NextRequest) *next.NextResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*next.NextResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, next.NextRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextUsecase_Next_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Next'
+type NextUsecase_Next_Call struct {
+	*mock.Call
+}
+
+// Next is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request next.NextRequest
+func (_e *NextUsecase_Expecter) Next(ctx interface{}, request interface{}) *NextUsecase_Next_Call {
+	return &NextUsecase_Next_Call{Call: _e.mock.On("Next", ctx, request)}
+}
+
+func (_c *NextUsecase_Next_Call) Run(run func(ctx context.Context, request next.NextRequest)) *NextUsecase_Next_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(next.NextRequest))
+	})
+	return _c
+}
+
+func (_c *NextUsecase_Next_Call) Return(_a0 *next.NextResponse, _a1 error) *NextUsecase_Next_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NextUsecase_Next_Call) RunAndReturn(run func(context.Context, next.NextRequest) (*next.NextResponse, error)) *NextUsecase_Next_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNextUsecase creates a new instance of NextUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNextUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NextUsecase {
+	mock := &NextUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,72 @@
+package next
+
+import (
+	"context"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type NextRepository interface {
+	UserTimezone(ctx context.Context, userID int) (string, error)
+	FindCandidates(ctx context.Context, userID int) ([]Candidate, error)
+}
+
+type nextRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// Candidate is a next action with what ranking it takes: its project's priority, whether
+// it waits on an open todo and the names of its context tags.
+type Candidate struct {
+	ID              int            `db:"id"`
+	Title           string         `db:"title"`
+	ProjectID       *int           `db:"project_id"`
+	ProjectPriority *string        `db:"project_priority"`
+	DueDate         *time.Time     `db:"due_date"`
+	EstimateMinutes *int           `db:"estimate_minutes"`
+	Energy          *string        `db:"energy"`
+	Contexts        pq.StringArray `db:"contexts"`
+	IsBlocked       bool           `db:"is_blocked"`
+	CreatedAt       time.Time      `db:"created_at"`
+}
+
+func NewNextRepository(db *sqlx.DB) *nextRepositoryImpl {
+	return &nextRepositoryImpl{db: db}
+}
+
+// UserTimezone returns the IANA timezone due dates are read in.
+func (r *nextRepositoryImpl) UserTimezone(ctx context.Context, userID int) (string, error) {
+	var timezone string
+	err := database.Conn(ctx, r.db).GetContext(ctx, &timezone, "SELECT timezone FROM users WHERE id = $1", userID)
+	return timezone, err
+}
+
+// FindCandidates returns every next action of the user outside archived projects.
+// Filtering and ranking are left to Rank so that they stay in one place.
+func (r *nextRepositoryImpl) FindCandidates(ctx context.Context, userID int) ([]Candidate, error) {
+	candidates := []Candidate{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &candidates, `
+		SELECT t.id, t.title, t.project_id, p.priority AS project_priority, t.due_date,
+			t.estimate_minutes, t.energy, t.created_at,
+			ARRAY(
+				SELECT g.name FROM todo_tags tt JOIN tags g ON g.id = tt.tag_id
+				WHERE tt.todo_id = t.id AND g.kind = 'context'
+				ORDER BY g.name
+			) AS contexts,
+			EXISTS (
+				SELECT 1 FROM todo_dependencies d JOIN todos b ON b.id = d.blocker_id
+				WHERE d.todo_id = t.id AND b.status <> 'done'
+			) AS is_blocked
+		FROM todos t
+		LEFT JOIN projects p ON p.id = t.project_id
+		WHERE t.user_id = $1 AND t.status = 'next_actions' AND p.status IS DISTINCT FROM 'archived'
+		ORDER BY t.id`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
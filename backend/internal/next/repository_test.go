@@ -0,0 +1,40 @@
+package next_test
+
+import (
+	"context"
+	"testing"
+	"yangdongju/gtd_todo/internal/next"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextRepository_FindsNextActionsWithContextsAndBlockers(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID, projectID, officeID, reportID, reviewID, blockerID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	_ = db.Get(&projectID, "INSERT INTO projects (user_id, name, priority) VALUES ($1, 'Launch', 'high') RETURNING id", userID)
+	_ = db.Get(&officeID, "INSERT INTO tags (user_id, name, kind) VALUES ($1, '@office', 'context') RETURNING id", userID)
+	_ = db.Get(&reportID, `INSERT INTO todos (user_id, title, status, project_id, estimate_minutes, energy)
+		VALUES ($1, 'Draft report', 'next_actions', $2, 30, 'med') RETURNING id`, userID, projectID)
+	_ = db.Get(&reviewID, "INSERT INTO todos (user_id, title, status) VALUES ($1, 'Review draft', 'next_actions') RETURNING id", userID)
+	_ = db.Get(&blockerID, "INSERT INTO todos (user_id, title, status) VALUES ($1, 'Collect numbers', 'in_progress') RETURNING id", userID)
+	_, _ = db.Exec("INSERT INTO todo_tags (todo_id, tag_id) VALUES ($1, $2)", reportID, officeID)
+	_, _ = db.Exec("INSERT INTO todo_dependencies (todo_id, blocker_id) VALUES ($1, $2)", reviewID, blockerID)
+	repository := next.NewNextRepository(db)
+
+	// when
+	candidates, err := repository.FindCandidates(context.Background(), userID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, candidates, 2, "only next actions are candidates")
+	assert.Equal(t, "high", *candidates[0].ProjectPriority)
+	assert.Equal(t, 30, *candidates[0].EstimateMinutes)
+	assert.Equal(t, []string{"@office"}, []string(candidates[0].Contexts))
+	assert.False(t, candidates[0].IsBlocked)
+	assert.True(t, candidates[1].IsBlocked)
+	assert.Empty(t, candidates[1].Contexts)
+}
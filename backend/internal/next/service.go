@@ -0,0 +1,80 @@
+package next
+
+import (
+	"context"
+	"time"
+)
+
+const defaultLimit = 20
+
+type NextUsecase interface {
+	Next(ctx context.Context, request NextRequest) (*NextResponse, error)
+}
+
+type nextService struct {
+	nextRepository NextRepository
+	weights        Weights
+	now            func() time.Time
+}
+
+func NewNextService(repository NextRepository, weights Weights, now func() time.Time) *nextService {
+	return &nextService{
+		nextRepository: repository,
+		weights:        weights,
+		now:            now,
+	}
+}
+
+// Next ranks the user's next actions that fit the context, time and energy at hand. Due
+// dates are compared with the user's today.
+func (s *nextService) Next(ctx context.Context, req NextRequest) (*NextResponse, error) {
+	timezone, err := s.nextRepository.UserTimezone(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.nextRepository.FindCandidates(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	filter := Filter{Context: req.Context, Minutes: req.Minutes, Energy: req.Energy}
+	suggestions := Rank(candidates, filter, s.weights, today(now, timezone), now)
+	total := len(suggestions)
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return &NextResponse{Items: suggestions, Total: total, Weights: s.weights, GeneratedAt: now}, nil
+}
+
+// today is the user's date at now, as a UTC midnight like the due dates read from the
+// database. A timezone the runtime does not know falls back to UTC.
+func today(now time.Time, timezone string) time.Time {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	year, month, date := now.In(loc).Date()
+	return time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+}
+
+type NextRequest struct {
+	UserID  int    `json:"-" auth:"user_id"`
+	Context string `json:"-" form:"context" binding:"omitempty,max=50"`
+	Minutes int    `json:"-" form:"minutes" binding:"omitempty,min=1,max=1440"`
+	Energy  string `json:"-" form:"energy" binding:"omitempty,oneof=low med high"`
+	Limit   int    `json:"-" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// NextResponse lists the fitting next actions, best first. Total counts every fitting one
+// before the limit, and Weights are the ones the scores were computed with.
+type NextResponse struct {
+	Items       []Suggestion `json:"items"`
+	Total       int          `json:"total"`
+	Weights     Weights      `json:"weights"`
+	GeneratedAt time.Time    `json:"generated_at"`
+}
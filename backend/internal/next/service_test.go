@@ -0,0 +1,38 @@
+package next_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/next"
+	nextmocks "yangdongju/gtd_todo/internal/next/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNext_ReadsDueDatesOnTheUsersClockAndAppliesTheLimit(t *testing.T) {
+	// given
+	// 20:00 UTC on the 19th is already the 20th in Seoul, where the todo is due.
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	mockRepo := nextmocks.NewNextRepository(t)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("Asia/Seoul", nil)
+	mockRepo.EXPECT().FindCandidates(mock.Anything, 7).Return([]next.Candidate{
+		{ID: 1, Title: "Sort photos", CreatedAt: now},
+		{ID: 2, Title: "Pay rent", DueDate: &due, CreatedAt: now},
+	}, nil)
+	weights := next.Weights{Due: 1}
+	service := next.NewNextService(mockRepo, weights, func() time.Time { return now })
+
+	// when
+	res, err := service.Next(context.Background(), next.NextRequest{UserID: 7, Limit: 1})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+	assert.Len(t, res.Items, 1)
+	assert.Equal(t, 2, res.Items[0].TodoID)
+	assert.Equal(t, 1.0, res.Items[0].Breakdown.Due)
+	assert.Equal(t, weights, res.Weights)
+}
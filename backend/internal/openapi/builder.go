@@ -0,0 +1,146 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ginParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+const bearerAuth = "bearerAuth"
+
+// Route describes one registered endpoint. Request and Responses hold zero values of
+// the Go types that are bound and returned, so the schema follows the code.
+type Route struct {
+	Method      string
+	Path        string
+	Summary     string
+	Tag         string
+	Auth        bool
+	Query       any
+	Headers     []Parameter
+	Request     any
+	Responses   map[int]any
+	ContentType string
+}
+
+type Builder struct {
+	document *Document
+	registry *schemaRegistry
+}
+
+func NewBuilder(title string, version string) *Builder {
+	return &Builder{
+		document: &Document{
+			OpenAPI: Version,
+			Info:    Info{Title: title, Version: version},
+			Paths:   map[string]PathItem{},
+		},
+		registry: newSchemaRegistry(),
+	}
+}
+
+func (b *Builder) Add(route Route) {
+	path := ToOpenAPIPath(route.Path)
+	operation := &Operation{
+		OperationID: operationID(route.Method, path),
+		Summary:     route.Summary,
+		Responses:   map[string]Response{},
+	}
+	if route.Tag != "" {
+		operation.Tags = []string{route.Tag}
+	}
+
+	for _, match := range ginParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+	operation.Parameters = append(operation.Parameters, b.taggedParameters(route.Query, "form", "query")...)
+	operation.Parameters = append(operation.Parameters, b.taggedParameters(route.Query, "header", "header")...)
+	operation.Parameters = append(operation.Parameters, b.taggedParameters(route.Request, "header", "header")...)
+	operation.Parameters = append(operation.Parameters, route.Headers...)
+
+	if route.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: b.registry.schemaOf(route.Request)}},
+		}
+	}
+
+	for status, body := range route.Responses {
+		// ContentType describes successful responses; errors are always JSON.
+		contentType := route.ContentType
+		if contentType == "" || status >= http.StatusMultipleChoices {
+			contentType = "application/json"
+		}
+		response := Response{Description: http.StatusText(status)}
+		if body != nil {
+			response.Content = map[string]MediaType{contentType: {Schema: b.registry.schemaOf(body)}}
+		}
+		operation.Responses[strconv.Itoa(status)] = response
+	}
+
+	if route.Auth {
+		operation.Security = []map[string][]any{{bearerAuth: {}}}
+		b.document.Components.SecuritySchemes = map[string]SecurityScheme{
+			bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		}
+	}
+
+	item, ok := b.document.Paths[path]
+	if !ok {
+		item = PathItem{}
+		b.document.Paths[path] = item
+	}
+	item[strings.ToLower(route.Method)] = operation
+}
+
+func (b *Builder) Document() *Document {
+	b.document.Components.Schemas = b.registry.schemas
+	return b.document
+}
+
+// taggedParameters turns the fields of a bound struct carrying tag, such as `form` for the
+// query string or `header`, into parameters located in "in".
+func (b *Builder) taggedParameters(value any, tag string, in string) []Parameter {
+	if value == nil {
+		return nil
+	}
+	t := reflect.TypeOf(value)
+
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema := b.registry.schemaOfType(field.Type)
+		required := applyBindingRules(schema, field.Tag.Get("binding"))
+		parameters = append(parameters, Parameter{Name: name, In: in, Required: required, Schema: schema})
+	}
+	return parameters
+}
+
+func ToOpenAPIPath(ginPath string) string {
+	return ginParamPattern.ReplaceAllString(ginPath, "{$1}")
+}
+
+func operationID(method string, path string) string {
+	var builder strings.Builder
+	builder.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, "{}")
+		if segment == "" || segment == "api" {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+			builder.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,128 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/openapi"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type createItemRequest struct {
+	Title       string   `json:"title" binding:"required,max=500"`
+	Description *string  `json:"description"`
+	Status      string   `json:"status" binding:"omitempty,oneof=inbox done"`
+	Priority    int      `json:"priority" binding:"min=1"`
+	Labels      []string `json:"labels" binding:"max=3,dive,max=20"`
+	Internal    string   `json:"-"`
+}
+
+type itemResponse struct {
+	ID        int       `json:"id"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type listItemsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=inbox done"`
+	Limit  int    `form:"limit"`
+}
+
+func TestAdd_RequestSchemaFollowsBindingTags(t *testing.T) {
+	// given
+	builder := openapi.NewBuilder("test", "1.0.0")
+
+	// when
+	builder.Add(openapi.Route{
+		Method:    http.MethodPost,
+		Path:      "/api/items",
+		Request:   createItemRequest{},
+		Responses: map[int]any{http.StatusCreated: itemResponse{}},
+	})
+	document := builder.Document()
+
+	// then
+	operation := document.Paths["/api/items"]["post"]
+	assert.Equal(t, "postItems", operation.OperationID)
+	assert.Equal(t, "#/components/schemas/createItemRequest", operation.RequestBody.Content["application/json"].Schema.Ref)
+
+	schema := document.Components.Schemas["createItemRequest"]
+	assert.Equal(t, []string{"title"}, schema.Required)
+	assert.Equal(t, false, schema.AdditionalProperties)
+	assert.Equal(t, 500, *schema.Properties["title"].MaxLength)
+	assert.Equal(t, []string{"string", "null"}, schema.Properties["description"].Type)
+	assert.Equal(t, []any{"inbox", "done"}, schema.Properties["status"].Enum)
+	assert.Equal(t, 1.0, *schema.Properties["priority"].Minimum)
+	assert.Equal(t, 3, *schema.Properties["labels"].MaxItems)
+	assert.Nil(t, schema.Properties["labels"].Maximum)
+	assert.Equal(t, 20, *schema.Properties["labels"].Items.MaxLength)
+	assert.NotContains(t, schema.Properties, "Internal")
+}
+
+func TestAdd_ResponseSchema(t *testing.T) {
+	// given
+	builder := openapi.NewBuilder("test", "1.0.0")
+
+	// when
+	builder.Add(openapi.Route{
+		Method:    http.MethodGet,
+		Path:      "/api/items/:id",
+		Responses: map[int]any{http.StatusOK: itemResponse{}, http.StatusNoContent: nil},
+	})
+	document := builder.Document()
+
+	// then
+	operation := document.Paths["/api/items/{id}"]["get"]
+	assert.Equal(t, "getItemsId", operation.OperationID)
+	assert.Equal(t, []openapi.Parameter{{Name: "id", In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}}}, operation.Parameters)
+	assert.Equal(t, "OK", operation.Responses["200"].Description)
+	assert.Nil(t, operation.Responses["204"].Content)
+
+	schema := document.Components.Schemas["itemResponse"]
+	assert.Equal(t, "integer", schema.Properties["id"].Type)
+	assert.Equal(t, "array", schema.Properties["tags"].Type)
+	assert.Equal(t, "string", schema.Properties["tags"].Items.Type)
+	assert.Equal(t, "date-time", schema.Properties["created_at"].Format)
+}
+
+func TestAdd_QueryParameters(t *testing.T) {
+	// given
+	builder := openapi.NewBuilder("test", "1.0.0")
+
+	// when
+	builder.Add(openapi.Route{
+		Method:    http.MethodGet,
+		Path:      "/api/items",
+		Query:     listItemsQuery{},
+		Responses: map[int]any{http.StatusOK: []itemResponse{}},
+	})
+	document := builder.Document()
+
+	// then
+	parameters := document.Paths["/api/items"]["get"].Parameters
+	assert.Len(t, parameters, 2)
+	assert.Equal(t, "status", parameters[0].Name)
+	assert.Equal(t, "query", parameters[0].In)
+	assert.Equal(t, []any{"inbox", "done"}, parameters[0].Schema.Enum)
+	assert.Equal(t, "limit", parameters[1].Name)
+	assert.NotContains(t, document.Components.Schemas, "listItemsQuery")
+}
+
+func TestAdd_AuthRouteRequiresBearerToken(t *testing.T) {
+	// given
+	builder := openapi.NewBuilder("test", "1.0.0")
+
+	// when
+	builder.Add(openapi.Route{Method: http.MethodGet, Path: "/api/me", Auth: true, Responses: map[int]any{http.StatusOK: nil}})
+	document := builder.Document()
+
+	// then
+	assert.Equal(t, []map[string][]any{{"bearerAuth": {}}}, document.Paths["/api/me"]["get"].Security)
+	assert.Equal(t, "bearer", document.Components.SecuritySchemes["bearerAuth"].Scheme)
+}
+
+func TestToOpenAPIPath(t *testing.T) {
+	assert.Equal(t, "/api/todos/{id}/status", openapi.ToOpenAPIPath("/api/todos/:id/status"))
+	assert.Equal(t, "/api/health", openapi.ToOpenAPIPath("/api/health"))
+}
@@ -0,0 +1,8 @@
+package openapi
+
+import _ "embed"
+
+// DocsPage is a dependency free page that renders the document served at /api/openapi.json.
+//
+//go:embed docs.html
+var DocsPage []byte
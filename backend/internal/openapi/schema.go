@@ -0,0 +1,229 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType           = reflect.TypeOf(time.Time{})
+	rawMessageType     = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*interface{ MarshalText() ([]byte, error) })(nil)).Elem()
+	schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+)
+
+// SchemaProvider lets a type with custom JSON encoding describe its own schema.
+type SchemaProvider interface {
+	OpenAPISchema() *Schema
+}
+
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: map[string]*Schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// schemaOf returns a schema for the type of value. Named structs are stored in components
+// and referenced with $ref.
+func (r *schemaRegistry) schemaOf(value any) *Schema {
+	if value == nil {
+		return nil
+	}
+	return r.schemaOfType(reflect.TypeOf(value))
+}
+
+func (r *schemaRegistry) schemaOfType(t reflect.Type) *Schema {
+	if t.Implements(schemaProviderType) {
+		return reflect.Zero(t).Interface().(SchemaProvider).OpenAPISchema()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Kind() != reflect.Struct && t.Kind() != reflect.Pointer &&
+		(t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(r.schemaOfType(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return r.structRef(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func (r *schemaRegistry) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return r.structSchema(t)
+	}
+
+	name, ok := r.names[t]
+	if !ok {
+		name = r.uniqueName(t)
+		r.names[t] = name
+		r.schemas[name] = &Schema{}
+		*r.schemas[name] = *r.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (r *schemaRegistry) uniqueName(t reflect.Type) string {
+	name := t.Name()
+	if _, taken := r.schemas[name]; !taken {
+		return name
+	}
+	pkg := []rune(path.Base(t.PkgPath()))
+	pkg[0] = unicode.ToUpper(pkg[0])
+	return string(pkg) + name
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	r.collectFields(t, schema)
+	return schema
+}
+
+func (r *schemaRegistry) collectFields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+			r.collectFields(field.Type, schema)
+			continue
+		}
+
+		property := r.schemaOfType(field.Type)
+		required := applyBindingRules(property, field.Tag.Get("binding"))
+		if required && !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+func jsonName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" || option == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// applyBindingRules maps the gin validator tags we use onto schema keywords
+// and reports whether the field is required. Rules after `dive` apply to the items.
+func applyBindingRules(schema *Schema, binding string) bool {
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "dive":
+			if schema.Items != nil {
+				_, itemRules, _ := strings.Cut(binding, "dive,")
+				applyBindingRules(schema.Items, itemRules)
+			}
+			return required
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "min", "gte":
+			setBound(schema, value, &schema.MinLength, &schema.MinItems, &schema.Minimum)
+		case "max", "lte":
+			setBound(schema, value, &schema.MaxLength, &schema.MaxItems, &schema.Maximum)
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				schema.Enum = append(schema.Enum, option)
+			}
+		}
+	}
+	return required
+}
+
+func setBound(schema *Schema, value string, length **int, items **int, number **float64) {
+	switch baseType(schema) {
+	case "string":
+		if n, err := strconv.Atoi(value); err == nil {
+			*length = &n
+		}
+		return
+	case "array":
+		if n, err := strconv.Atoi(value); err == nil {
+			*items = &n
+		}
+		return
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		*number = &f
+	}
+}
+
+// baseType returns the non-null type of a schema, also for nullable ["type", "null"] schemas.
+func baseType(schema *Schema) string {
+	switch t := schema.Type.(type) {
+	case string:
+		return t
+	case []string:
+		return t[0]
+	}
+	return ""
+}
+
+func nullable(schema *Schema) *Schema {
+	if typeName, ok := schema.Type.(string); ok && schema.Ref == "" {
+		copied := *schema
+		copied.Type = []string{typeName, "null"}
+		return &copied
+	}
+	return schema
+}
@@ -0,0 +1,329 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"yangdongju/gtd_todo/internal/apperror"
+)
+
+type Violation = apperror.Violation
+
+// Validator checks requests and responses against a Document. It understands the subset of
+// JSON Schema that the Builder emits.
+type Validator struct {
+	document *Document
+}
+
+func NewValidator(document *Document) *Validator {
+	return &Validator{document: document}
+}
+
+// FindOperation resolves a concrete request path such as /api/todos/3 to its documented
+// path template and operation.
+func (v *Validator) FindOperation(method string, requestPath string) (string, *Operation, bool) {
+	method = strings.ToLower(method)
+	if item, ok := v.document.Paths[requestPath]; ok {
+		operation, ok := item[method]
+		return requestPath, operation, ok
+	}
+
+	// Literal segments win over parameters, e.g. /api/todos/search over /api/todos/{id}.
+	requestSegments := strings.Split(strings.Trim(requestPath, "/"), "/")
+	bestTemplate, bestLiterals := "", -1
+	var bestOperation *Operation
+	for template, item := range v.document.Paths {
+		operation, ok := item[method]
+		if !ok {
+			continue
+		}
+		literals, matched := matchTemplate(strings.Split(strings.Trim(template, "/"), "/"), requestSegments)
+		if matched && literals > bestLiterals {
+			bestTemplate, bestLiterals, bestOperation = template, literals, operation
+		}
+	}
+	return bestTemplate, bestOperation, bestOperation != nil
+}
+
+func matchTemplate(templateSegments []string, requestSegments []string) (int, bool) {
+	if len(templateSegments) != len(requestSegments) {
+		return 0, false
+	}
+	literals := 0
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != requestSegments[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+func (v *Validator) ValidateRequest(method string, requestPath string, query url.Values, body []byte) []Violation {
+	_, operation, ok := v.FindOperation(method, requestPath)
+	if !ok {
+		return []Violation{{Location: "path", Message: fmt.Sprintf("%s %s is not documented", method, requestPath)}}
+	}
+
+	var violations []Violation
+	documentedQuery := map[string]bool{}
+	for _, parameter := range operation.Parameters {
+		if parameter.In != "query" {
+			continue
+		}
+		documentedQuery[parameter.Name] = true
+		values, present := query[parameter.Name]
+		if !present {
+			if parameter.Required {
+				violations = append(violations, Violation{Location: "query." + parameter.Name, Message: "is required"})
+			}
+			continue
+		}
+		for _, raw := range values {
+			violations = append(violations, v.validateQueryValue(parameter, raw)...)
+		}
+	}
+	for _, name := range sortedKeys(query) {
+		if !documentedQuery[name] {
+			violations = append(violations, Violation{Location: "query." + name, Message: "is not documented"})
+		}
+	}
+
+	if operation.RequestBody == nil {
+		if len(bytes.TrimSpace(body)) > 0 {
+			violations = append(violations, Violation{Location: "body", Message: "request body is not documented"})
+		}
+		return violations
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if operation.RequestBody.Required {
+			violations = append(violations, Violation{Location: "body", Message: "is required"})
+		}
+		return violations
+	}
+	schema := operation.RequestBody.Content["application/json"].Schema
+	return append(violations, v.validateJSON(schema, body, "body")...)
+}
+
+func (v *Validator) ValidateResponse(method string, requestPath string, status int, contentType string, body []byte) []Violation {
+	_, operation, ok := v.FindOperation(method, requestPath)
+	if !ok {
+		return []Violation{{Location: "path", Message: fmt.Sprintf("%s %s is not documented", method, requestPath)}}
+	}
+
+	response, ok := operation.Responses[strconv.Itoa(status)]
+	if !ok {
+		return []Violation{{Location: "status", Message: fmt.Sprintf("status %d is not documented", status)}}
+	}
+	if response.Content == nil {
+		return nil
+	}
+
+	mediaType, ok := response.Content["application/json"]
+	if !ok || !strings.HasPrefix(contentType, "application/json") {
+		return nil
+	}
+	return v.validateJSON(mediaType.Schema, body, "response")
+}
+
+func (v *Validator) validateJSON(schema *Schema, body []byte, location string) []Violation {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []Violation{{Location: location, Message: "is not valid JSON: " + err.Error()}}
+	}
+	return v.ValidateValue(schema, value, location)
+}
+
+func (v *Validator) validateQueryValue(parameter Parameter, raw string) []Violation {
+	location := "query." + parameter.Name
+	var value any = raw
+	switch baseType(parameter.Schema) {
+	case "integer", "number":
+		value = json.Number(raw)
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return []Violation{{Location: location, Message: "must be a number"}}
+		}
+	case "boolean":
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []Violation{{Location: location, Message: "must be a boolean"}}
+		}
+		value = parsed
+	case "array":
+		return v.ValidateValue(parameter.Schema.Items, raw, location)
+	}
+	return v.ValidateValue(parameter.Schema, value, location)
+}
+
+// ValidateValue validates a value decoded with json.Decoder.UseNumber against schema.
+func (v *Validator) ValidateValue(schema *Schema, value any, location string) []Violation {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := v.document.Components.Schemas[name]
+		if !ok {
+			return []Violation{{Location: location, Message: "unknown schema " + schema.Ref}}
+		}
+		return v.ValidateValue(resolved, value, location)
+	}
+
+	if value == nil {
+		if allowsNull(schema) {
+			return nil
+		}
+		return []Violation{{Location: location, Message: "must not be null"}}
+	}
+
+	var violations []Violation
+	switch baseType(schema) {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []Violation{{Location: location, Message: "must be an object"}}
+		}
+		violations = v.validateObject(schema, object, location)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return []Violation{{Location: location, Message: "must be an array"}}
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			violations = append(violations, Violation{Location: location, Message: fmt.Sprintf("must have at least %d items", *schema.MinItems)})
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			violations = append(violations, Violation{Location: location, Message: fmt.Sprintf("must have at most %d items", *schema.MaxItems)})
+		}
+		for i, item := range items {
+			violations = append(violations, v.ValidateValue(schema.Items, item, fmt.Sprintf("%s[%d]", location, i))...)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return []Violation{{Location: location, Message: "must be a string"}}
+		}
+		violations = validateString(schema, text, location)
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			return []Violation{{Location: location, Message: "must be a number"}}
+		}
+		violations = validateNumber(schema, number, location)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []Violation{{Location: location, Message: "must be a boolean"}}
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		violations = append(violations, Violation{Location: location, Message: fmt.Sprintf("must be one of %v", schema.Enum)})
+	}
+	return violations
+}
+
+func (v *Validator) validateObject(schema *Schema, object map[string]any, location string) []Violation {
+	var violations []Violation
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			violations = append(violations, Violation{Location: location + "." + name, Message: "is required"})
+		}
+	}
+
+	for _, name := range sortedKeys(object) {
+		property, documented := schema.Properties[name]
+		switch {
+		case documented:
+			violations = append(violations, v.ValidateValue(property, object[name], location+"."+name)...)
+		case schema.AdditionalProperties == false:
+			violations = append(violations, Violation{Location: location + "." + name, Message: "is not documented"})
+		default:
+			if additional, ok := schema.AdditionalProperties.(*Schema); ok {
+				violations = append(violations, v.ValidateValue(additional, object[name], location+"."+name)...)
+			}
+		}
+	}
+	return violations
+}
+
+func validateString(schema *Schema, text string, location string) []Violation {
+	var violations []Violation
+	length := utf8.RuneCountInString(text)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		violations = append(violations, Violation{Location: location, Message: fmt.Sprintf("must be at least %d characters", *schema.MinLength)})
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		violations = append(violations, Violation{Location: location, Message: fmt.Sprintf("must be at most %d characters", *schema.MaxLength)})
+	}
+
+	switch schema.Format {
+	case "email":
+		if _, err := mail.ParseAddress(text); err != nil {
+			violations = append(violations, Violation{Location: location, Message: "must be an email address"})
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+			violations = append(violations, Violation{Location: location, Message: "must be an RFC 3339 date-time"})
+		}
+	}
+	return violations
+}
+
+func validateNumber(schema *Schema, number json.Number, location string) []Violation {
+	if baseType(schema) == "integer" {
+		if _, err := number.Int64(); err != nil {
+			return []Violation{{Location: location, Message: "must be an integer"}}
+		}
+	}
+
+	f, err := number.Float64()
+	if err != nil {
+		return []Violation{{Location: location, Message: "must be a number"}}
+	}
+	var violations []Violation
+	if schema.Minimum != nil && f < *schema.Minimum {
+		violations = append(violations, Violation{Location: location, Message: fmt.Sprintf("must be >= %v", *schema.Minimum)})
+	}
+	if schema.Maximum != nil && f > *schema.Maximum {
+		violations = append(violations, Violation{Location: location, Message: fmt.Sprintf("must be <= %v", *schema.Maximum)})
+	}
+	return violations
+}
+
+func allowsNull(schema *Schema) bool {
+	if schema.Type == nil {
+		return true
+	}
+	types, ok := schema.Type.([]string)
+	return ok && len(types) == 2 && types[1] == "null"
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, option := range enum {
+		if fmt.Sprint(option) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
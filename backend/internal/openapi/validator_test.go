@@ -0,0 +1,173 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"yangdongju/gtd_todo/internal/openapi"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type signUpRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+type signUpResponse struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+func newTestValidator() *openapi.Validator {
+	builder := openapi.NewBuilder("test", "1.0.0")
+	builder.Add(openapi.Route{
+		Method:    http.MethodPost,
+		Path:      "/api/auth/signup",
+		Request:   signUpRequest{},
+		Responses: map[int]any{http.StatusCreated: signUpResponse{}},
+	})
+	builder.Add(openapi.Route{
+		Method:    http.MethodGet,
+		Path:      "/api/items/:id",
+		Responses: map[int]any{http.StatusOK: itemResponse{}},
+	})
+	builder.Add(openapi.Route{
+		Method:    http.MethodGet,
+		Path:      "/api/items",
+		Query:     listItemsQuery{},
+		Responses: map[int]any{http.StatusOK: []itemResponse{}},
+	})
+	return openapi.NewValidator(builder.Document())
+}
+
+func TestValidateRequest_Valid(t *testing.T) {
+	// given
+	validator := newTestValidator()
+	body := []byte(`{"email":"hello@example.com","password":"password1234"}`)
+
+	// when
+	violations := validator.ValidateRequest(http.MethodPost, "/api/auth/signup", url.Values{}, body)
+
+	// then
+	assert.Empty(t, violations)
+}
+
+func TestValidateRequest_SchemaViolations(t *testing.T) {
+	// given
+	validator := newTestValidator()
+	body := []byte(`{"email":"not-an-email","password":"short","nickname":"gtd"}`)
+
+	// when
+	violations := validator.ValidateRequest(http.MethodPost, "/api/auth/signup", url.Values{}, body)
+
+	// then
+	assert.ElementsMatch(t, []openapi.Violation{
+		{Location: "body.email", Message: "must be an email address"},
+		{Location: "body.password", Message: "must be at least 8 characters"},
+		{Location: "body.nickname", Message: "is not documented"},
+	}, violations)
+}
+
+func TestValidateRequest_MissingRequiredField(t *testing.T) {
+	// given
+	validator := newTestValidator()
+
+	// when
+	violations := validator.ValidateRequest(http.MethodPost, "/api/auth/signup", url.Values{}, []byte(`{"email":"hello@example.com"}`))
+
+	// then
+	assert.Equal(t, []openapi.Violation{{Location: "body.password", Message: "is required"}}, violations)
+}
+
+func TestValidateRequest_QueryParameters(t *testing.T) {
+	// given
+	validator := newTestValidator()
+	query := url.Values{"status": {"archived"}, "limit": {"ten"}, "page": {"2"}}
+
+	// when
+	violations := validator.ValidateRequest(http.MethodGet, "/api/items", query, nil)
+
+	// then
+	assert.ElementsMatch(t, []openapi.Violation{
+		{Location: "query.status", Message: "must be one of [inbox done]"},
+		{Location: "query.limit", Message: "must be a number"},
+		{Location: "query.page", Message: "is not documented"},
+	}, violations)
+}
+
+func TestValidateRequest_UndocumentedRoute(t *testing.T) {
+	// given
+	validator := newTestValidator()
+
+	// when
+	violations := validator.ValidateRequest(http.MethodDelete, "/api/items/1", url.Values{}, nil)
+
+	// then
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "path", violations[0].Location)
+}
+
+func TestValidateResponse_PathTemplate(t *testing.T) {
+	// given
+	validator := newTestValidator()
+	body := []byte(`{"id":1,"tags":["home"],"created_at":"2025-01-01T00:00:00Z"}`)
+
+	// when
+	violations := validator.ValidateResponse(http.MethodGet, "/api/items/1", http.StatusOK, "application/json; charset=utf-8", body)
+
+	// then
+	assert.Empty(t, violations)
+}
+
+func TestValidateResponse_UndocumentedStatus(t *testing.T) {
+	// given
+	validator := newTestValidator()
+
+	// when
+	violations := validator.ValidateResponse(http.MethodGet, "/api/items/1", http.StatusTeapot, "application/json", []byte(`{}`))
+
+	// then
+	assert.Equal(t, []openapi.Violation{{Location: "status", Message: "status 418 is not documented"}}, violations)
+}
+
+func TestValidateResponse_WrongTypes(t *testing.T) {
+	// given
+	validator := newTestValidator()
+	body := []byte(`{"id":1.5,"tags":"home","created_at":"yesterday","extra":true}`)
+
+	// when
+	violations := validator.ValidateResponse(http.MethodGet, "/api/items/1", http.StatusOK, "application/json", body)
+
+	// then
+	assert.ElementsMatch(t, []openapi.Violation{
+		{Location: "response.id", Message: "must be an integer"},
+		{Location: "response.tags", Message: "must be an array"},
+		{Location: "response.created_at", Message: "must be an RFC 3339 date-time"},
+		{Location: "response.extra", Message: "is not documented"},
+	}, violations)
+}
+
+func TestValidator_DocumentRoundTrip(t *testing.T) {
+	// given
+	builder := openapi.NewBuilder("test", "1.0.0")
+	builder.Add(openapi.Route{
+		Method:    http.MethodPost,
+		Path:      "/api/items",
+		Request:   createItemRequest{},
+		Responses: map[int]any{http.StatusCreated: itemResponse{}},
+	})
+	encoded, _ := json.Marshal(builder.Document())
+	var decoded openapi.Document
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	validator := openapi.NewValidator(&decoded)
+
+	// when
+	valid := validator.ValidateRequest(http.MethodPost, "/api/items", url.Values{}, []byte(`{"title":"a","description":null}`))
+	invalid := validator.ValidateRequest(http.MethodPost, "/api/items", url.Values{}, []byte(`{"title":"a","unknown":1}`))
+
+	// then
+	assert.Empty(t, valid)
+	assert.Equal(t, []openapi.Violation{{Location: "body.unknown", Message: "is not documented"}}, invalid)
+}
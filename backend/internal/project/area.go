@@ -0,0 +1,224 @@
+package project
+
+import (
+	"context"
+	"time"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+const defaultAreaColor = "#6B7280"
+
+func (s *projectService) CreateArea(ctx context.Context, req CreateAreaRequest) (*AreaResponse, error) {
+	color := defaultAreaColor
+	if req.Color != nil {
+		color = *req.Color
+	}
+
+	var saved *Area
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.projectRepository.SaveArea(ctx, &Area{UserID: req.UserID, Name: req.Name, Color: color})
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.AreaCreated, saved.ID, toAreaResponse(saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAreaResponse(saved), nil
+}
+
+func (s *projectService) ListAreas(ctx context.Context, req ListAreasRequest) (*AreaListResponse, error) {
+	areas, err := s.projectRepository.FindAreas(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	res := &AreaListResponse{Areas: make([]AreaResponse, 0, len(areas))}
+	for i := range areas {
+		res.Areas = append(res.Areas, *toAreaResponse(&areas[i]))
+	}
+	return res, nil
+}
+
+// GetArea returns the area with its project tree.
+func (s *projectService) GetArea(ctx context.Context, req GetAreaRequest) (*AreaTreeResponse, error) {
+	area, err := s.projectRepository.FindAreaByID(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if area == nil {
+		return nil, NewAreaNotFoundError(req.ID)
+	}
+	nodes, err := s.projectRepository.FindTree(ctx, req.UserID, &area.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toAreaTreeResponse(area, buildTree(nodes)), nil
+}
+
+// UpdateArea applies a partial change on top of the version named in If-Match.
+func (s *projectService) UpdateArea(ctx context.Context, req UpdateAreaRequest) (*AreaResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Area
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		area, err := s.findAreaForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			area.Name = *req.Name
+		}
+		if req.Color != nil {
+			area.Color = *req.Color
+		}
+
+		updated, err = s.projectRepository.UpdateArea(ctx, area)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return NewAreaVersionConflictError(area, expectedVersion)
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.AreaUpdated, updated.ID, toAreaResponse(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAreaResponse(updated), nil
+}
+
+// DeleteArea keeps the area's projects, now outside any area, and announces them.
+func (s *projectService) DeleteArea(ctx context.Context, req DeleteAreaRequest) (*DeleteAreaResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		area, err := s.findAreaForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		projectIDs, err := s.projectRepository.FindAreaProjectIDs(ctx, area.ID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.projectRepository.DeleteArea(ctx, area)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return NewAreaVersionConflictError(area, expectedVersion)
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, event.AreaDeleted, area.ID, event.Deleted{ID: area.ID}); err != nil {
+			return err
+		}
+		return s.publishDetachedProjects(ctx, req.UserID, projectIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteAreaResponse{Message: "Area deleted"}, nil
+}
+
+func (s *projectService) findAreaForWrite(ctx context.Context, userID int, id int, expectedVersion int) (*Area, error) {
+	area, err := s.projectRepository.FindAreaByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if area == nil {
+		return nil, NewAreaNotFoundError(id)
+	}
+	if area.Version != expectedVersion {
+		return nil, NewAreaVersionConflictError(area, expectedVersion)
+	}
+	return area, nil
+}
+
+type CreateAreaRequest struct {
+	UserID int     `json:"-" auth:"user_id"`
+	Name   string  `json:"name" binding:"required,max=100"`
+	Color  *string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+type ListAreasRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+}
+
+type GetAreaRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+}
+
+type UpdateAreaRequest struct {
+	UserID  int     `json:"-" auth:"user_id"`
+	ID      int     `json:"-" uri:"id"`
+	IfMatch string  `json:"-" header:"If-Match"`
+	Name    *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Color   *string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+type DeleteAreaRequest struct {
+	UserID  int    `json:"-" auth:"user_id"`
+	ID      int    `json:"-" uri:"id"`
+	IfMatch string `json:"-" header:"If-Match"`
+}
+
+type AreaResponse struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Color        string    `json:"color"`
+	ProjectCount int       `json:"project_count"`
+	Version      int       `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (r AreaResponse) ETag() string {
+	return etag.FromVersion(r.Version)
+}
+
+type AreaListResponse struct {
+	Areas []AreaResponse `json:"areas"`
+}
+
+// AreaTreeResponse is an area with its projects as trees. Progress covers every project
+// in the area.
+type AreaTreeResponse struct {
+	Area     AreaResponse              `json:"area"`
+	Progress ProgressResponse          `json:"progress"`
+	Projects []ProjectTreeNodeResponse `json:"projects"`
+}
+
+type DeleteAreaResponse struct {
+	Message string `json:"message"`
+}
+
+func toAreaResponse(area *Area) *AreaResponse {
+	return &AreaResponse{
+		ID:           area.ID,
+		Name:         area.Name,
+		Color:        area.Color,
+		ProjectCount: area.ProjectCount,
+		Version:      area.Version,
+		CreatedAt:    area.CreatedAt,
+		UpdatedAt:    area.UpdatedAt,
+	}
+}
+
+func toAreaTreeResponse(area *Area, projects []ProjectTreeNodeResponse) *AreaTreeResponse {
+	res := &AreaTreeResponse{Area: *toAreaResponse(area), Projects: projects}
+	for _, project := range projects {
+		res.Progress.Done += project.Progress.Done
+		res.Progress.Total += project.Progress.Total
+	}
+	return res
+}
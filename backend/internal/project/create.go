@@ -0,0 +1,73 @@
+package project
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+const defaultColor = "#3B82F6"
+
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+)
+
+// Create adds a project, inside an area or under a parent project when the request names
+// one. A client_id that was already used returns the project created with it instead of a
+// duplicate.
+func (s *projectService) Create(ctx context.Context, req CreateProjectRequest) (*ProjectResponse, error) {
+	color := defaultColor
+	if req.Color != nil {
+		color = *req.Color
+	}
+	priority := PriorityNormal
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+
+	var saved *Project
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if req.ClientID != nil {
+			existing, err := s.projectRepository.FindByClientID(ctx, req.UserID, *req.ClientID)
+			if err != nil || existing != nil {
+				saved = existing
+				return err
+			}
+		}
+
+		areaID, err := s.place(ctx, req.UserID, 0, 1, req.AreaID, req.ParentProjectID)
+		if err != nil {
+			return err
+		}
+		saved, err = s.projectRepository.Save(ctx, &Project{
+			UserID:          req.UserID,
+			ClientID:        req.ClientID,
+			AreaID:          areaID,
+			ParentProjectID: req.ParentProjectID,
+			Name:            req.Name,
+			Description:     req.Description,
+			Color:           color,
+			Priority:        priority,
+		})
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.ProjectCreated, saved.ID, toProjectResponse(saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProjectResponse(saved), nil
+}
+
+type CreateProjectRequest struct {
+	UserID          int     `json:"-" auth:"user_id"`
+	ClientID        *string `json:"client_id" binding:"omitempty,uuid"`
+	Name            string  `json:"name" binding:"required,max=255"`
+	Description     *string `json:"description"`
+	Color           *string `json:"color" binding:"omitempty,hexcolor"`
+	Priority        *string `json:"priority" binding:"omitempty,oneof=high normal low"`
+	AreaID          *int    `json:"area_id"`
+	ParentProjectID *int    `json:"parent_project_id"`
+}
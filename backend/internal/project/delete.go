@@ -0,0 +1,84 @@
+package project
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+func (s *projectService) Delete(ctx context.Context, req DeleteProjectRequest) (*DeleteProjectResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		project, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		detached, err := s.projectRepository.FindDetachedIDs(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := s.projectRepository.Delete(ctx, project)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return NewVersionConflictError(project, expectedVersion)
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, event.ProjectDeleted, project.ID, event.Deleted{ID: project.ID}); err != nil {
+			return err
+		}
+		if err := s.publishDetachedProjects(ctx, req.UserID, detached.ProjectIDs); err != nil {
+			return err
+		}
+		return s.publishDetachedTodos(ctx, req.UserID, detached.TodoIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteProjectResponse{Message: "Project deleted"}, nil
+}
+
+// publishDetachedProjects announces projects the database changed on its own when what
+// held them was deleted. They are read again for their new version.
+func (s *projectService) publishDetachedProjects(ctx context.Context, userID int, ids []int) error {
+	for _, id := range ids {
+		detached, err := s.projectRepository.FindByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, userID, event.ProjectUpdated, id, toProjectResponse(detached)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishDetachedTodos does the same for todos, read through the todo use case.
+func (s *projectService) publishDetachedTodos(ctx context.Context, userID int, ids []int) error {
+	for _, id := range ids {
+		detached, err := s.todoUsecase.Get(ctx, todo.GetTodoRequest{UserID: userID, ID: id})
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, userID, event.TodoUpdated, id, detached); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type DeleteProjectRequest struct {
+	UserID  int    `json:"-" auth:"user_id"`
+	ID      int    `json:"-" uri:"id"`
+	IfMatch string `json:"-" header:"If-Match"`
+}
+
+type DeleteProjectResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,181 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type ProjectNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e ProjectNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewProjectNotFoundError(id int) *ProjectNotFoundError {
+	return &ProjectNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("Project not found. id=%v", id),
+		NestedErr: nil,
+	}
+}
+
+// VersionConflictError carries the current project so the client can merge and retry.
+type VersionConflictError struct {
+	Code      int
+	Message   string
+	Current   *Project
+	NestedErr error
+}
+
+func (e VersionConflictError) Error() string {
+	return e.Message
+}
+
+func NewVersionConflictError(current *Project, expectedVersion int) *VersionConflictError {
+	return &VersionConflictError{
+		Code:      http.StatusPreconditionFailed,
+		Message:   fmt.Sprintf("Project was modified. id=%v & version=%v & expected=%v", current.ID, current.Version, expectedVersion),
+		Current:   current,
+		NestedErr: nil,
+	}
+}
+
+type AreaNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e AreaNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewAreaNotFoundError(id int) *AreaNotFoundError {
+	return &AreaNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("Area not found. id=%v", id),
+		NestedErr: nil,
+	}
+}
+
+type AreaNameTakenError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e AreaNameTakenError) Error() string {
+	return e.Message
+}
+
+func NewAreaNameTakenError(name string) *AreaNameTakenError {
+	return &AreaNameTakenError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Area name already in use. name=%v", name),
+		NestedErr: nil,
+	}
+}
+
+// AreaVersionConflictError carries the current area so the client can merge and retry.
+type AreaVersionConflictError struct {
+	Code      int
+	Message   string
+	Current   *Area
+	NestedErr error
+}
+
+func (e AreaVersionConflictError) Error() string {
+	return e.Message
+}
+
+func NewAreaVersionConflictError(current *Area, expectedVersion int) *AreaVersionConflictError {
+	return &AreaVersionConflictError{
+		Code:      http.StatusPreconditionFailed,
+		Message:   fmt.Sprintf("Area was modified. id=%v & version=%v & expected=%v", current.ID, current.Version, expectedVersion),
+		Current:   current,
+		NestedErr: nil,
+	}
+}
+
+// InvalidPlacementError rejects an area or parent project that does not exist, or an
+// area that is not the one of the parent.
+type InvalidPlacementError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidPlacementError) Error() string {
+	return e.Message
+}
+
+func NewInvalidPlacementError(reason string) *InvalidPlacementError {
+	return &InvalidPlacementError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid project placement. %v", reason),
+		NestedErr: nil,
+	}
+}
+
+// HierarchyError rejects a parent that would put a project inside itself or nest projects
+// deeper than maxProjectDepth.
+type HierarchyError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e HierarchyError) Error() string {
+	return e.Message
+}
+
+func NewHierarchyError(reason string) *HierarchyError {
+	return &HierarchyError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Project hierarchy violated. %v", reason),
+		NestedErr: nil,
+	}
+}
+
+// StatusTransitionError rejects a status a project cannot move to from its current one.
+type StatusTransitionError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e StatusTransitionError) Error() string {
+	return e.Message
+}
+
+func NewStatusTransitionError(from string, to string) *StatusTransitionError {
+	return &StatusTransitionError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Project cannot move from %v to %v", from, to),
+		NestedErr: nil,
+	}
+}
+
+// OpenTodosError rejects completing a project that still has todos to do, unless the
+// request forces them done.
+type OpenTodosError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e OpenTodosError) Error() string {
+	return e.Message
+}
+
+func NewOpenTodosError(count int) *OpenTodosError {
+	return &OpenTodosError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Project has %v open todos. Complete them first or set force", count),
+		NestedErr: nil,
+	}
+}
@@ -0,0 +1,104 @@
+package project
+
+import (
+	"context"
+	"time"
+	"yangdongju/gtd_todo/internal/query"
+)
+
+var projectSorts = query.Sorts{
+	{Name: "created_at", Columns: []string{"created_at"}},
+	{Name: "updated_at", Columns: []string{"updated_at"}},
+	{Name: "name", Columns: []string{"name"}},
+}
+
+func (s *projectService) Get(ctx context.Context, req GetProjectRequest) (*ProjectResponse, error) {
+	project, err := s.projectRepository.FindByID(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, NewProjectNotFoundError(req.ID)
+	}
+	return toProjectResponse(project), nil
+}
+
+// List returns one page of the projects matching the request's filters. Total counts every
+// match, and NextCursor reads the page after this one. Archived projects are left out
+// unless the status filter asks for them.
+func (s *projectService) List(ctx context.Context, req ListProjectsRequest) (*ProjectListResponse, error) {
+	spec, err := query.NewSpec(projectSorts, req.Sort, req.Order, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	spec.Filters = []query.Filter{
+		query.Between("created_at", req.CreatedAfter, req.CreatedBefore),
+		query.Between("updated_at", req.UpdatedAfter, req.UpdatedBefore),
+		query.In("status", req.Status),
+	}
+	if len(req.Status) == 0 {
+		spec.Filters = append(spec.Filters, query.Raw("status <> ?", StatusArchived))
+	}
+
+	projects, total, err := s.projectRepository.FindPage(ctx, req.UserID, spec)
+	if err != nil {
+		return nil, err
+	}
+	projects, next := query.Page(spec, projects, projectSortValue)
+
+	res := &ProjectListResponse{Projects: make([]ProjectResponse, 0, len(projects)), Total: total, NextCursor: next}
+	for i := range projects {
+		res.Projects = append(res.Projects, *toProjectResponse(&projects[i]))
+	}
+	return res, nil
+}
+
+func projectSortValue(project Project, column string) any {
+	switch column {
+	case "created_at":
+		return project.CreatedAt
+	case "updated_at":
+		return project.UpdatedAt
+	case "name":
+		return project.Name
+	}
+	return project.ID
+}
+
+// ListChanged returns the projects written after the sync cursor in Since, oldest change first.
+func (s *projectService) ListChanged(ctx context.Context, req ListChangedProjectsRequest) ([]ProjectResponse, error) {
+	projects, err := s.projectRepository.FindChangedSince(ctx, req.UserID, req.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ProjectResponse, 0, len(projects))
+	for i := range projects {
+		res = append(res, *toProjectResponse(&projects[i]))
+	}
+	return res, nil
+}
+
+type GetProjectRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+}
+
+// ListProjectsRequest filters with half-open ranges: created_after <= created_at < created_before.
+type ListProjectsRequest struct {
+	UserID        int        `json:"-" auth:"user_id"`
+	CreatedAfter  *time.Time `json:"-" form:"created_after"`
+	CreatedBefore *time.Time `json:"-" form:"created_before"`
+	UpdatedAfter  *time.Time `json:"-" form:"updated_after"`
+	UpdatedBefore *time.Time `json:"-" form:"updated_before"`
+	Status        []string   `json:"-" form:"status" binding:"omitempty,dive,oneof=todo in_progress done archived"`
+	Sort          string     `json:"-" form:"sort" binding:"omitempty,oneof=created_at updated_at name"`
+	Order         string     `json:"-" form:"order" binding:"omitempty,oneof=asc desc"`
+	Cursor        string     `json:"-" form:"cursor"`
+	Limit         int        `json:"-" form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+type ListChangedProjectsRequest struct {
+	UserID int
+	Since  int64
+}
@@ -0,0 +1,166 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/query"
+)
+
+type ProjectHandler struct {
+	projectUsecase ProjectUsecase
+}
+
+func NewProjectHandler(projectUsecase ProjectUsecase) *ProjectHandler {
+	return &ProjectHandler{projectUsecase: projectUsecase}
+}
+
+func (h *ProjectHandler) HandleCreate(ctx context.Context, req CreateProjectRequest) (int, any) {
+	res, err := h.projectUsecase.Create(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusCreated, res
+}
+
+func (h *ProjectHandler) HandleGet(ctx context.Context, req GetProjectRequest) (int, any) {
+	res, err := h.projectUsecase.Get(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleList(ctx context.Context, req ListProjectsRequest) (int, any) {
+	res, err := h.projectUsecase.List(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleUpdate(ctx context.Context, req UpdateProjectRequest) (int, any) {
+	res, err := h.projectUsecase.Update(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleDelete(ctx context.Context, req DeleteProjectRequest) (int, any) {
+	res, err := h.projectUsecase.Delete(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleChangeStatus(ctx context.Context, req ChangeProjectStatusRequest) (int, any) {
+	res, err := h.projectUsecase.ChangeStatus(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleListArchived(ctx context.Context, req ListArchivedProjectsRequest) (int, any) {
+	res, err := h.projectUsecase.ListArchived(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleMove(ctx context.Context, req MoveProjectRequest) (int, any) {
+	res, err := h.projectUsecase.Move(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleTree(ctx context.Context, req ProjectTreeRequest) (int, any) {
+	res, err := h.projectUsecase.Tree(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleCreateArea(ctx context.Context, req CreateAreaRequest) (int, any) {
+	res, err := h.projectUsecase.CreateArea(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusCreated, res
+}
+
+func (h *ProjectHandler) HandleListAreas(ctx context.Context, req ListAreasRequest) (int, any) {
+	res, err := h.projectUsecase.ListAreas(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleGetArea(ctx context.Context, req GetAreaRequest) (int, any) {
+	res, err := h.projectUsecase.GetArea(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleUpdateArea(ctx context.Context, req UpdateAreaRequest) (int, any) {
+	res, err := h.projectUsecase.UpdateArea(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ProjectHandler) HandleDeleteArea(ctx context.Context, req DeleteAreaRequest) (int, any) {
+	res, err := h.projectUsecase.DeleteArea(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError answers a version conflict with the current project or area, so the body of
+// a 412 is a ProjectResponse or an AreaResponse rather than an ErrorResponse.
+func handleError(err error) (int, any) {
+	var notFoundError *ProjectNotFoundError
+	var areaNotFoundError *AreaNotFoundError
+	var versionConflictError *VersionConflictError
+	var areaVersionConflictError *AreaVersionConflictError
+	var areaNameTakenError *AreaNameTakenError
+	var invalidPlacementError *InvalidPlacementError
+	var hierarchyError *HierarchyError
+	var statusTransitionError *StatusTransitionError
+	var openTodosError *OpenTodosError
+
+	switch {
+	case errors.As(err, &notFoundError), errors.As(err, &areaNotFoundError):
+		return http.StatusNotFound, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &versionConflictError):
+		return http.StatusPreconditionFailed, toProjectResponse(versionConflictError.Current)
+	case errors.As(err, &areaVersionConflictError):
+		return http.StatusPreconditionFailed, toAreaResponse(areaVersionConflictError.Current)
+	case errors.As(err, &areaNameTakenError), errors.As(err, &hierarchyError),
+		errors.As(err, &statusTransitionError), errors.As(err, &openTodosError):
+		return http.StatusConflict, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &invalidPlacementError):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	case errors.Is(err, etag.ErrMissingIfMatch):
+		return http.StatusPreconditionRequired, ErrorResponse{Error: err.Error()}
+	case errors.Is(err, etag.ErrInvalidVersion), errors.Is(err, query.ErrInvalidCursor), errors.Is(err, query.ErrUnknownSort):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	default:
+		return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+	}
+}
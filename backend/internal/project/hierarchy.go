@@ -0,0 +1,204 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// maxProjectDepth is how many levels projects nest: a project, its sub-projects and
+// theirs.
+const maxProjectDepth = 3
+
+// Move puts the project, with its sub-projects, under another parent or at the top of an
+// area. The request names the whole new place: a parent, whose area the project joins, or
+// an area, or neither for a top-level project outside any area.
+func (s *projectService) Move(ctx context.Context, req MoveProjectRequest) (*ProjectResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Project
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		project, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		height, err := s.projectRepository.SubtreeHeight(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		if project.AreaID, err = s.place(ctx, req.UserID, project.ID, height, req.AreaID, req.ParentProjectID); err != nil {
+			return err
+		}
+		project.ParentProjectID = req.ParentProjectID
+
+		updated, err = s.projectRepository.Update(ctx, project)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return NewVersionConflictError(project, expectedVersion)
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, event.ProjectUpdated, updated.ID, toProjectResponse(updated)); err != nil {
+			return err
+		}
+		descendants, err := s.projectRepository.SetDescendantsArea(ctx, updated.ID, updated.AreaID)
+		if err != nil {
+			return err
+		}
+		for i := range descendants {
+			if err := s.publisher.Publish(ctx, req.UserID, event.ProjectUpdated, descendants[i].ID, toProjectResponse(&descendants[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProjectResponse(updated), nil
+}
+
+// place checks a new place for a project and returns the area it ends up in. id is 0 for
+// a project not created yet, and height is how many levels it brings along, itself
+// included.
+func (s *projectService) place(ctx context.Context, userID int, id int, height int, areaID *int, parentID *int) (*int, error) {
+	if parentID == nil {
+		if areaID == nil {
+			return nil, nil
+		}
+		area, err := s.projectRepository.FindAreaByID(ctx, userID, *areaID)
+		if err != nil {
+			return nil, err
+		}
+		if area == nil {
+			return nil, NewInvalidPlacementError(fmt.Sprintf("area does not exist. area_id=%v", *areaID))
+		}
+		return areaID, nil
+	}
+
+	parent, err := s.projectRepository.FindByID(ctx, userID, *parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, NewInvalidPlacementError(fmt.Sprintf("parent project does not exist. parent_project_id=%v", *parentID))
+	}
+	if areaID != nil && (parent.AreaID == nil || *parent.AreaID != *areaID) {
+		return nil, NewInvalidPlacementError("a sub-project belongs to the area of its parent")
+	}
+	lineage, err := s.projectRepository.FindLineage(ctx, parent.ID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(lineage, id) {
+		return nil, NewHierarchyError("a project cannot be moved under itself or its sub-projects")
+	}
+	if depth := len(lineage) + height; depth > maxProjectDepth {
+		return nil, NewHierarchyError(fmt.Sprintf("projects would nest %v levels deep, at most %v are allowed", depth, maxProjectDepth))
+	}
+	return parent.AreaID, nil
+}
+
+// Tree returns every project of the user as a tree under its area, with the projects
+// outside any area apart.
+func (s *projectService) Tree(ctx context.Context, req ProjectTreeRequest) (*ProjectTreeResponse, error) {
+	areas, err := s.projectRepository.FindAreas(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.projectRepository.FindTree(ctx, req.UserID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byArea := map[int][]ProjectNode{}
+	unassigned := []ProjectNode{}
+	for _, node := range nodes {
+		if node.AreaID == nil {
+			unassigned = append(unassigned, node)
+			continue
+		}
+		byArea[*node.AreaID] = append(byArea[*node.AreaID], node)
+	}
+	res := &ProjectTreeResponse{Areas: make([]AreaTreeResponse, 0, len(areas)), Unassigned: buildTree(unassigned)}
+	for i := range areas {
+		res.Areas = append(res.Areas, *toAreaTreeResponse(&areas[i], buildTree(byArea[areas[i].ID])))
+	}
+	return res, nil
+}
+
+// buildTree nests the nodes under their parents, keeping their order among siblings, and
+// rolls the todo counts up so each node's progress covers its sub-projects too. A node
+// whose parent is not among them becomes a root.
+func buildTree(nodes []ProjectNode) []ProjectTreeNodeResponse {
+	present := map[int]bool{}
+	for _, node := range nodes {
+		present[node.ID] = true
+	}
+	children := map[int][]ProjectNode{}
+	var roots []ProjectNode
+	for _, node := range nodes {
+		if node.ParentProjectID != nil && present[*node.ParentProjectID] {
+			children[*node.ParentProjectID] = append(children[*node.ParentProjectID], node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+
+	var build func(node ProjectNode) ProjectTreeNodeResponse
+	build = func(node ProjectNode) ProjectTreeNodeResponse {
+		res := ProjectTreeNodeResponse{
+			Project:  *toProjectResponse(&node.Project),
+			Progress: ProgressResponse{Done: node.DoneCount, Total: node.TodoCount},
+			Children: []ProjectTreeNodeResponse{},
+		}
+		for _, child := range children[node.ID] {
+			built := build(child)
+			res.Progress.Done += built.Progress.Done
+			res.Progress.Total += built.Progress.Total
+			res.Children = append(res.Children, built)
+		}
+		return res
+	}
+	tree := make([]ProjectTreeNodeResponse, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
+
+type MoveProjectRequest struct {
+	UserID          int    `json:"-" auth:"user_id"`
+	ID              int    `json:"-" uri:"id"`
+	IfMatch         string `json:"-" header:"If-Match"`
+	AreaID          *int   `json:"area_id"`
+	ParentProjectID *int   `json:"parent_project_id"`
+}
+
+type ProjectTreeRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+}
+
+// ProjectTreeResponse lists each area with its project tree, by name, then the projects
+// outside any area.
+type ProjectTreeResponse struct {
+	Areas      []AreaTreeResponse        `json:"areas"`
+	Unassigned []ProjectTreeNodeResponse `json:"unassigned"`
+}
+
+type ProjectTreeNodeResponse struct {
+	Project  ProjectResponse           `json:"project"`
+	Progress ProgressResponse          `json:"progress"`
+	Children []ProjectTreeNodeResponse `json:"children"`
+}
+
+// ProgressResponse counts the done todos of a project and everything below it.
+type ProgressResponse struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
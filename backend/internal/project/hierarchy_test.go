@@ -0,0 +1,176 @@
+package project_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/project"
+	projectmocks "yangdongju/gtd_todo/internal/project/mocks"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// ============ Test Cases ============
+
+func TestMove_UnderOwnSubProjectIsRejected(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Version: 1}, nil)
+	mockRepo.EXPECT().SubtreeHeight(mock.Anything, 1).Return(2, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 2).Return(&project.Project{ID: 2, UserID: 7, ParentProjectID: ptr(1), Version: 1}, nil)
+	mockRepo.EXPECT().FindLineage(mock.Anything, 2).Return([]int{2, 1}, nil)
+
+	handler := project.NewProjectHandler(project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil))
+
+	// when
+	code, _ := handler.HandleMove(context.Background(), project.MoveProjectRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, ParentProjectID: ptr(2),
+	})
+
+	// then
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func TestCreate_DeeperThanThreeLevelsIsRejected(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 3).Return(&project.Project{ID: 3, UserID: 7, ParentProjectID: ptr(2)}, nil)
+	mockRepo.EXPECT().FindLineage(mock.Anything, 3).Return([]int{3, 2, 1}, nil)
+
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil)
+
+	// when
+	_, err := service.Create(context.Background(), project.CreateProjectRequest{
+		UserID: 7, Name: "Too deep", ParentProjectID: ptr(3),
+	})
+
+	// then
+	var hierarchyErr *project.HierarchyError
+	assert.ErrorAs(t, err, &hierarchyErr)
+}
+
+func TestCreate_AreaMustMatchParent(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, AreaID: ptr(4)}, nil)
+
+	handler := project.NewProjectHandler(project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil))
+
+	// when
+	code, _ := handler.HandleCreate(context.Background(), project.CreateProjectRequest{
+		UserID: 7, Name: "Child", AreaID: ptr(5), ParentProjectID: ptr(1),
+	})
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestMove_SubProjectsFollowIntoTheNewArea(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Version: 1}, nil)
+	mockRepo.EXPECT().SubtreeHeight(mock.Anything, 1).Return(2, nil)
+	mockRepo.EXPECT().FindAreaByID(mock.Anything, 7, 4).Return(&project.Area{ID: 4, UserID: 7}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, p *project.Project) (*project.Project, error) {
+		updated := *p
+		updated.Version++
+		return &updated, nil
+	})
+	mockRepo.EXPECT().SetDescendantsArea(mock.Anything, 1, ptr(4)).Return([]project.Project{
+		{ID: 2, UserID: 7, AreaID: ptr(4), ParentProjectID: ptr(1), Version: 3},
+	}, nil)
+
+	publisher := &recordingPublisher{}
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, publisher, nil)
+
+	// when
+	res, err := service.Move(context.Background(), project.MoveProjectRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, AreaID: ptr(4),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, ptr(4), res.AreaID)
+	assert.Nil(t, res.ParentProjectID)
+	assert.Equal(t, []string{event.ProjectUpdated, event.ProjectUpdated}, publisher.types)
+}
+
+func TestTree_RollsProgressUp(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindAreas(mock.Anything, 7).Return([]project.Area{{ID: 4, UserID: 7, Name: "Work"}}, nil)
+	mockRepo.EXPECT().FindTree(mock.Anything, 7, (*int)(nil)).Return([]project.ProjectNode{
+		{Project: project.Project{ID: 1, AreaID: ptr(4), Name: "Launch"}, TodoCount: 2, DoneCount: 1},
+		{Project: project.Project{ID: 2, AreaID: ptr(4), ParentProjectID: ptr(1), Name: "Docs"}, TodoCount: 3, DoneCount: 3},
+		{Project: project.Project{ID: 3, AreaID: ptr(4), ParentProjectID: ptr(2), Name: "Guide"}, TodoCount: 1, DoneCount: 0},
+		{Project: project.Project{ID: 5, Name: "Errands"}, TodoCount: 4, DoneCount: 2},
+	}, nil)
+
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil)
+
+	// when
+	res, err := service.Tree(context.Background(), project.ProjectTreeRequest{UserID: 7})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Areas, 1)
+	assert.Equal(t, project.ProgressResponse{Done: 4, Total: 6}, res.Areas[0].Progress)
+	launch := res.Areas[0].Projects[0]
+	assert.Equal(t, project.ProgressResponse{Done: 4, Total: 6}, launch.Progress)
+	assert.Equal(t, project.ProgressResponse{Done: 3, Total: 4}, launch.Children[0].Progress)
+	assert.Equal(t, "Guide", launch.Children[0].Children[0].Project.Name)
+	assert.Len(t, res.Unassigned, 1)
+	assert.Equal(t, project.ProgressResponse{Done: 2, Total: 4}, res.Unassigned[0].Progress)
+}
+
+func TestDelete_PublishesSubProjectsAndTodosLeftWithoutIt(t *testing.T) {
+	// given
+	deleting := &project.Project{ID: 1, UserID: 7, Version: 1}
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(deleting, nil)
+	mockRepo.EXPECT().FindDetachedIDs(mock.Anything, 1).Return(&project.DetachedIDs{ProjectIDs: []int{2}, TodoIDs: []int{8, 9}}, nil)
+	mockRepo.EXPECT().Delete(mock.Anything, deleting).Return(true, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 2).Return(&project.Project{ID: 2, UserID: 7, Version: 4}, nil)
+	mockTodo := todomocks.NewTodoUsecase(t)
+	mockTodo.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 8}).Return(&todo.TodoResponse{ID: 8, Version: 3}, nil)
+	mockTodo.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 9}).Return(&todo.TodoResponse{ID: 9, Version: 2}, nil)
+
+	publisher := &recordingPublisher{}
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, publisher, mockTodo)
+
+	// when
+	_, err := service.Delete(context.Background(), project.DeleteProjectRequest{UserID: 7, ID: 1, IfMatch: `"1"`})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{event.ProjectDeleted, event.ProjectUpdated, event.TodoUpdated, event.TodoUpdated}, publisher.types)
+}
+
+func TestDeleteArea_PublishesItsProjects(t *testing.T) {
+	// given
+	area := &project.Area{ID: 4, UserID: 7, Version: 1}
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindAreaByID(mock.Anything, 7, 4).Return(area, nil)
+	mockRepo.EXPECT().FindAreaProjectIDs(mock.Anything, 4).Return([]int{1, 2}, nil)
+	mockRepo.EXPECT().DeleteArea(mock.Anything, area).Return(true, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Version: 2}, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 2).Return(&project.Project{ID: 2, UserID: 7, ParentProjectID: ptr(1), Version: 5}, nil)
+
+	publisher := &recordingPublisher{}
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, publisher, nil)
+
+	// when
+	_, err := service.DeleteArea(context.Background(), project.DeleteAreaRequest{UserID: 7, ID: 4, IfMatch: `"1"`})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{event.AreaDeleted, event.ProjectUpdated, event.ProjectUpdated}, publisher.types)
+}
@@ -0,0 +1,21 @@
+//go:generate mockery
+package project
+
+import (
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func InitializeHandler(pool *sqlx.DB, publisher event.Publisher) *ProjectHandler {
+	txManager := database.NewTxManager(pool)
+	return NewProjectHandler(NewProjectService(
+		NewProjectRepository(pool),
+		txManager,
+		publisher,
+		todo.NewTodoService(todo.NewTodoRepository(pool), txManager, publisher, time.Now),
+	))
+}
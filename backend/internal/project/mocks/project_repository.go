@@ -0,0 +1,1227 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package projectmocks
+
+import (
+	context "context"
+	project "yangdongju/gtd_todo/internal/project"
+
+	mock "github.com/stretchr/testify/mock"
+
+	query "yangdongju/gtd_todo/internal/query"
+)
+
+// ProjectRepository is an autogenerated mock type for the ProjectRepository type
+type ProjectRepository struct {
+	mock.Mock
+}
+
+type ProjectRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ProjectRepository) EXPECT() *ProjectRepository_Expecter {
+	return &ProjectRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, _a1
+func (_m *ProjectRepository) Delete(ctx context.Context, _a1 *project.Project) (bool, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Project) (bool, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Project) bool); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *project.Project) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ProjectRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *project.Project
+func (_e *ProjectRepository_Expecter) Delete(ctx interface{}, _a1 interface{}) *ProjectRepository_Delete_Call {
+	return &ProjectRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, _a1)}
+}
+
+func (_c *ProjectRepository_Delete_Call) Run(run func(ctx context.Context, _a1 *project.Project)) *ProjectRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*project.Project))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_Delete_Call) Return(_a0 bool, _a1 error) *ProjectRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_Delete_Call) RunAndReturn(run func(context.Context, *project.Project) (bool, error)) *ProjectRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteArea provides a mock function with given fields: ctx, area
+func (_m *ProjectRepository) DeleteArea(ctx context.Context, area *project.Area) (bool, error) {
+	ret := _m.Called(ctx, area)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArea")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Area) (bool, error)); ok {
+		return rf(ctx, area)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Area) bool); ok {
+		r0 = rf(ctx, area)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *project.Area) error); ok {
+		r1 = rf(ctx, area)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_DeleteArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteArea'
+type ProjectRepository_DeleteArea_Call struct {
+	*mock.Call
+}
+
+// DeleteArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - area *project.Area
+func (_e *ProjectRepository_Expecter) DeleteArea(ctx interface{}, area interface{}) *ProjectRepository_DeleteArea_Call {
+	return &ProjectRepository_DeleteArea_Call{Call: _e.mock.On("DeleteArea", ctx, area)}
+}
+
+func (_c *ProjectRepository_DeleteArea_Call) Run(run func(ctx context.Context, area *project.Area)) *ProjectRepository_DeleteArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*project.Area))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_DeleteArea_Call) Return(_a0 bool, _a1 error) *ProjectRepository_DeleteArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_DeleteArea_Call) RunAndReturn(run func(context.Context, *project.Area) (bool, error)) *ProjectRepository_DeleteArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindArchived provides a mock function with given fields: ctx, userID
+func (_m *ProjectRepository) FindArchived(ctx context.Context, userID int) ([]project.ProjectNode, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindArchived")
+	}
+
+	var r0 []project.ProjectNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]project.ProjectNode, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []project.ProjectNode); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]project.ProjectNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindArchived_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindArchived'
+type ProjectRepository_FindArchived_Call struct {
+	*mock.Call
+}
+
+// FindArchived is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *ProjectRepository_Expecter) FindArchived(ctx interface{}, userID interface{}) *ProjectRepository_FindArchived_Call {
+	return &ProjectRepository_FindArchived_Call{Call: _e.mock.On("FindArchived", ctx, userID)}
+}
+
+func (_c *ProjectRepository_FindArchived_Call) Run(run func(ctx context.Context, userID int)) *ProjectRepository_FindArchived_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindArchived_Call) Return(_a0 []project.ProjectNode, _a1 error) *ProjectRepository_FindArchived_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindArchived_Call) RunAndReturn(run func(context.Context, int) ([]project.ProjectNode, error)) *ProjectRepository_FindArchived_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAreaByID provides a mock function with given fields: ctx, userID, id
+func (_m *ProjectRepository) FindAreaByID(ctx context.Context, userID int, id int) (*project.Area, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAreaByID")
+	}
+
+	var r0 *project.Area
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*project.Area, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *project.Area); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.Area)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindAreaByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAreaByID'
+type ProjectRepository_FindAreaByID_Call struct {
+	*mock.Call
+}
+
+// FindAreaByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int
+func (_e *ProjectRepository_Expecter) FindAreaByID(ctx interface{}, userID interface{}, id interface{}) *ProjectRepository_FindAreaByID_Call {
+	return &ProjectRepository_FindAreaByID_Call{Call: _e.mock.On("FindAreaByID", ctx, userID, id)}
+}
+
+func (_c *ProjectRepository_FindAreaByID_Call) Run(run func(ctx context.Context, userID int, id int)) *ProjectRepository_FindAreaByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindAreaByID_Call) Return(_a0 *project.Area, _a1 error) *ProjectRepository_FindAreaByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindAreaByID_Call) RunAndReturn(run func(context.Context, int, int) (*project.Area, error)) *ProjectRepository_FindAreaByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAreaProjectIDs provides a mock function with given fields: ctx, areaID
+func (_m *ProjectRepository) FindAreaProjectIDs(ctx context.Context, areaID int) ([]int, error) {
+	ret := _m.Called(ctx, areaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAreaProjectIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int, error)); ok {
+		return rf(ctx, areaID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, areaID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, areaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindAreaProjectIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAreaProjectIDs'
+type ProjectRepository_FindAreaProjectIDs_Call struct {
+	*mock.Call
+}
+
+// FindAreaProjectIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - areaID int
+func (_e *ProjectRepository_Expecter) FindAreaProjectIDs(ctx interface{}, areaID interface{}) *ProjectRepository_FindAreaProjectIDs_Call {
+	return &ProjectRepository_FindAreaProjectIDs_Call{Call: _e.mock.On("FindAreaProjectIDs", ctx, areaID)}
+}
+
+func (_c *ProjectRepository_FindAreaProjectIDs_Call) Run(run func(ctx context.Context, areaID int)) *ProjectRepository_FindAreaProjectIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindAreaProjectIDs_Call) Return(_a0 []int, _a1 error) *ProjectRepository_FindAreaProjectIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindAreaProjectIDs_Call) RunAndReturn(run func(context.Context, int) ([]int, error)) *ProjectRepository_FindAreaProjectIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAreas provides a mock function with given fields: ctx, userID
+func (_m *ProjectRepository) FindAreas(ctx context.Context, userID int) ([]project.Area, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAreas")
+	}
+
+	var r0 []project.Area
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]project.Area, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []project.Area); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]project.Area)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindAreas_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAreas'
+type ProjectRepository_FindAreas_Call struct {
+	*mock.Call
+}
+
+// FindAreas is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *ProjectRepository_Expecter) FindAreas(ctx interface{}, userID interface{}) *ProjectRepository_FindAreas_Call {
+	return &ProjectRepository_FindAreas_Call{Call: _e.mock.On("FindAreas", ctx, userID)}
+}
+
+func (_c *ProjectRepository_FindAreas_Call) Run(run func(ctx context.Context, userID int)) *ProjectRepository_FindAreas_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindAreas_Call) Return(_a0 []project.Area, _a1 error) *ProjectRepository_FindAreas_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindAreas_Call) RunAndReturn(run func(context.Context, int) ([]project.Area, error)) *ProjectRepository_FindAreas_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByClientID provides a mock function with given fields: ctx, userID, clientID
+func (_m *ProjectRepository) FindByClientID(ctx context.Context, userID int, clientID string) (*project.Project, error) {
+	ret := _m.Called(ctx, userID, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByClientID")
+	}
+
+	var r0 *project.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*project.Project, error)); ok {
+		return rf(ctx, userID, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *project.Project); ok {
+		r0 = rf(ctx, userID, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindByClientID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByClientID'
+type ProjectRepository_FindByClientID_Call struct {
+	*mock.Call
+}
+
+// FindByClientID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - clientID string
+func (_e *ProjectRepository_Expecter) FindByClientID(ctx interface{}, userID interface{}, clientID interface{}) *ProjectRepository_FindByClientID_Call {
+	return &ProjectRepository_FindByClientID_Call{Call: _e.mock.On("FindByClientID", ctx, userID, clientID)}
+}
+
+func (_c *ProjectRepository_FindByClientID_Call) Run(run func(ctx context.Context, userID int, clientID string)) *ProjectRepository_FindByClientID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindByClientID_Call) Return(_a0 *project.Project, _a1 error) *ProjectRepository_FindByClientID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindByClientID_Call) RunAndReturn(run func(context.Context, int, string) (*project.Project, error)) *ProjectRepository_FindByClientID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *ProjectRepository) FindByID(ctx context.Context, userID int, id int) (*project.Project, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *project.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*project.Project, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *project.Project); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type ProjectRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int
+func (_e *ProjectRepository_Expecter) FindByID(ctx interface{}, userID interface{}, id interface{}) *ProjectRepository_FindByID_Call {
+	return &ProjectRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, userID, id)}
+}
+
+func (_c *ProjectRepository_FindByID_Call) Run(run func(ctx context.Context, userID int, id int)) *ProjectRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindByID_Call) Return(_a0 *project.Project, _a1 error) *ProjectRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindByID_Call) RunAndReturn(run func(context.Context, int, int) (*project.Project, error)) *ProjectRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindChangedSince provides a mock function with given fields: ctx, userID, cursor
+func (_m *ProjectRepository) FindChangedSince(ctx context.Context, userID int, cursor int64) ([]project.Project, error) {
+	ret := _m.Called(ctx, userID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindChangedSince")
+	}
+
+	var r0 []project.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) ([]project.Project, error)); ok {
+		return rf(ctx, userID, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) []project.Project); ok {
+		r0 = rf(ctx, userID, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]project.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindChangedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindChangedSince'
+type ProjectRepository_FindChangedSince_Call struct {
+	*mock.Call
+}
+
+// FindChangedSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - cursor int64
+func (_e *ProjectRepository_Expecter) FindChangedSince(ctx interface{}, userID interface{}, cursor interface{}) *ProjectRepository_FindChangedSince_Call {
+	return &ProjectRepository_FindChangedSince_Call{Call: _e.mock.On("FindChangedSince", ctx, userID, cursor)}
+}
+
+func (_c *ProjectRepository_FindChangedSince_Call) Run(run func(ctx context.Context, userID int, cursor int64)) *ProjectRepository_FindChangedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindChangedSince_Call) Return(_a0 []project.Project, _a1 error) *ProjectRepository_FindChangedSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindChangedSince_Call) RunAndReturn(run func(context.Context, int, int64) ([]project.Project, error)) *ProjectRepository_FindChangedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDetachedIDs provides a mock function with given fields: ctx, id
+func (_m *ProjectRepository) FindDetachedIDs(ctx context.Context, id int) (*project.DetachedIDs, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDetachedIDs")
+	}
+
+	var r0 *project.DetachedIDs
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*project.DetachedIDs, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *project.DetachedIDs); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.DetachedIDs)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindDetachedIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDetachedIDs'
+type ProjectRepository_FindDetachedIDs_Call struct {
+	*mock.Call
+}
+
+// FindDetachedIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *ProjectRepository_Expecter) FindDetachedIDs(ctx interface{}, id interface{}) *ProjectRepository_FindDetachedIDs_Call {
+	return &ProjectRepository_FindDetachedIDs_Call{Call: _e.mock.On("FindDetachedIDs", ctx, id)}
+}
+
+func (_c *ProjectRepository_FindDetachedIDs_Call) Run(run func(ctx context.Context, id int)) *ProjectRepository_FindDetachedIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindDetachedIDs_Call) Return(_a0 *project.DetachedIDs, _a1 error) *ProjectRepository_FindDetachedIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindDetachedIDs_Call) RunAndReturn(run func(context.Context, int) (*project.DetachedIDs, error)) *ProjectRepository_FindDetachedIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindLineage provides a mock function with given fields: ctx, id
+func (_m *ProjectRepository) FindLineage(ctx context.Context, id int) ([]int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLineage")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindLineage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindLineage'
+type ProjectRepository_FindLineage_Call struct {
+	*mock.Call
+}
+
+// FindLineage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *ProjectRepository_Expecter) FindLineage(ctx interface{}, id interface{}) *ProjectRepository_FindLineage_Call {
+	return &ProjectRepository_FindLineage_Call{Call: _e.mock.On("FindLineage", ctx, id)}
+}
+
+func (_c *ProjectRepository_FindLineage_Call) Run(run func(ctx context.Context, id int)) *ProjectRepository_FindLineage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindLineage_Call) Return(_a0 []int, _a1 error) *ProjectRepository_FindLineage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindLineage_Call) RunAndReturn(run func(context.Context, int) ([]int, error)) *ProjectRepository_FindLineage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOpenTodoIDs provides a mock function with given fields: ctx, id
+func (_m *ProjectRepository) FindOpenTodoIDs(ctx context.Context, id int) ([]int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOpenTodoIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindOpenTodoIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOpenTodoIDs'
+type ProjectRepository_FindOpenTodoIDs_Call struct {
+	*mock.Call
+}
+
+// FindOpenTodoIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *ProjectRepository_Expecter) FindOpenTodoIDs(ctx interface{}, id interface{}) *ProjectRepository_FindOpenTodoIDs_Call {
+	return &ProjectRepository_FindOpenTodoIDs_Call{Call: _e.mock.On("FindOpenTodoIDs", ctx, id)}
+}
+
+func (_c *ProjectRepository_FindOpenTodoIDs_Call) Run(run func(ctx context.Context, id int)) *ProjectRepository_FindOpenTodoIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindOpenTodoIDs_Call) Return(_a0 []int, _a1 error) *ProjectRepository_FindOpenTodoIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindOpenTodoIDs_Call) RunAndReturn(run func(context.Context, int) ([]int, error)) *ProjectRepository_FindOpenTodoIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPage provides a mock function with given fields: ctx, userID, spec
+func (_m *ProjectRepository) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]project.Project, int, error) {
+	ret := _m.Called(ctx, userID, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []project.Project
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *query.Spec) ([]project.Project, int, error)); ok {
+		return rf(ctx, userID, spec)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *query.Spec) []project.Project); ok {
+		r0 = rf(ctx, userID, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]project.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *query.Spec) int); ok {
+		r1 = rf(ctx, userID, spec)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, *query.Spec) error); ok {
+		r2 = rf(ctx, userID, spec)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ProjectRepository_FindPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPage'
+type ProjectRepository_FindPage_Call struct {
+	*mock.Call
+}
+
+// FindPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - spec *query.Spec
+func (_e *ProjectRepository_Expecter) FindPage(ctx interface{}, userID interface{}, spec interface{}) *ProjectRepository_FindPage_Call {
+	return &ProjectRepository_FindPage_Call{Call: _e.mock.On("FindPage", ctx, userID, spec)}
+}
+
+func (_c *ProjectRepository_FindPage_Call) Run(run func(ctx context.Context, userID int, spec *query.Spec)) *ProjectRepository_FindPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*query.Spec))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindPage_Call) Return(_a0 []project.Project, _a1 int, _a2 error) *ProjectRepository_FindPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ProjectRepository_FindPage_Call) RunAndReturn(run func(context.Context, int, *query.Spec) ([]project.Project, int, error)) *ProjectRepository_FindPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTree provides a mock function with given fields: ctx, userID, areaID
+func (_m *ProjectRepository) FindTree(ctx context.Context, userID int, areaID *int) ([]project.ProjectNode, error) {
+	ret := _m.Called(ctx, userID, areaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTree")
+	}
+
+	var r0 []project.ProjectNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int) ([]project.ProjectNode, error)); ok {
+		return rf(ctx, userID, areaID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int) []project.ProjectNode); ok {
+		r0 = rf(ctx, userID, areaID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]project.ProjectNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *int) error); ok {
+		r1 = rf(ctx, userID, areaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_FindTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTree'
+type ProjectRepository_FindTree_Call struct {
+	*mock.Call
+}
+
+// FindTree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - areaID *int
+func (_e *ProjectRepository_Expecter) FindTree(ctx interface{}, userID interface{}, areaID interface{}) *ProjectRepository_FindTree_Call {
+	return &ProjectRepository_FindTree_Call{Call: _e.mock.On("FindTree", ctx, userID, areaID)}
+}
+
+func (_c *ProjectRepository_FindTree_Call) Run(run func(ctx context.Context, userID int, areaID *int)) *ProjectRepository_FindTree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_FindTree_Call) Return(_a0 []project.ProjectNode, _a1 error) *ProjectRepository_FindTree_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_FindTree_Call) RunAndReturn(run func(context.Context, int, *int) ([]project.ProjectNode, error)) *ProjectRepository_FindTree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *ProjectRepository) Save(ctx context.Context, _a1 *project.Project) (*project.Project, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *project.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Project) (*project.Project, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Project) *project.Project); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *project.Project) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type ProjectRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *project.Project
+func (_e *ProjectRepository_Expecter) Save(ctx interface{}, _a1 interface{}) *ProjectRepository_Save_Call {
+	return &ProjectRepository_Save_Call{Call: _e.mock.On("Save", ctx, _a1)}
+}
+
+func (_c *ProjectRepository_Save_Call) Run(run func(ctx context.Context, _a1 *project.Project)) *ProjectRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*project.Project))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_Save_Call) Return(_a0 *project.Project, _a1 error) *ProjectRepository_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_Save_Call) RunAndReturn(run func(context.Context, *project.Project) (*project.Project, error)) *ProjectRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveArea provides a mock function with given fields: ctx, area
+func (_m *ProjectRepository) SaveArea(ctx context.Context, area *project.Area) (*project.Area, error) {
+	ret := _m.Called(ctx, area)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveArea")
+	}
+
+	var r0 *project.Area
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Area) (*project.Area, error)); ok {
+		return rf(ctx, area)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Area) *project.Area); ok {
+		r0 = rf(ctx, area)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.Area)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *project.Area) error); ok {
+		r1 = rf(ctx, area)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_SaveArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveArea'
+type ProjectRepository_SaveArea_Call struct {
+	*mock.Call
+}
+
+// SaveArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - area *project.Area
+func (_e *ProjectRepository_Expecter) SaveArea(ctx interface{}, area interface{}) *ProjectRepository_SaveArea_Call {
+	return &ProjectRepository_SaveArea_Call{Call: _e.mock.On("SaveArea", ctx, area)}
+}
+
+func (_c *ProjectRepository_SaveArea_Call) Run(run func(ctx context.Context, area *project.Area)) *ProjectRepository_SaveArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*project.Area))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_SaveArea_Call) Return(_a0 *project.Area, _a1 error) *ProjectRepository_SaveArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_SaveArea_Call) RunAndReturn(run func(context.Context, *project.Area) (*project.Area, error)) *ProjectRepository_SaveArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDescendantsArea provides a mock function with given fields: ctx, id, areaID
+func (_m *ProjectRepository) SetDescendantsArea(ctx context.Context, id int, areaID *int) ([]project.Project, error) {
+	ret := _m.Called(ctx, id, areaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDescendantsArea")
+	}
+
+	var r0 []project.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int) ([]project.Project, error)); ok {
+		return rf(ctx, id, areaID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int) []project.Project); ok {
+		r0 = rf(ctx, id, areaID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]project.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *int) error); ok {
+		r1 = rf(ctx, id, areaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_SetDescendantsArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDescendantsArea'
+type ProjectRepository_SetDescendantsArea_Call struct {
+	*mock.Call
+}
+
+// SetDescendantsArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - areaID *int
+func (_e *ProjectRepository_Expecter) SetDescendantsArea(ctx interface{}, id interface{}, areaID interface{}) *ProjectRepository_SetDescendantsArea_Call {
+	return &ProjectRepository_SetDescendantsArea_Call{Call: _e.mock.On("SetDescendantsArea", ctx, id, areaID)}
+}
+
+func (_c *ProjectRepository_SetDescendantsArea_Call) Run(run func(ctx context.Context, id int, areaID *int)) *ProjectRepository_SetDescendantsArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_SetDescendantsArea_Call) Return(_a0 []project.Project, _a1 error) *ProjectRepository_SetDescendantsArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_SetDescendantsArea_Call) RunAndReturn(run func(context.Context, int, *int) ([]project.Project, error)) *ProjectRepository_SetDescendantsArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubtreeHeight provides a mock function with given fields: ctx, id
+func (_m *ProjectRepository) SubtreeHeight(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubtreeHeight")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_SubtreeHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubtreeHeight'
+type ProjectRepository_SubtreeHeight_Call struct {
+	*mock.Call
+}
+
+// SubtreeHeight is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *ProjectRepository_Expecter) SubtreeHeight(ctx interface{}, id interface{}) *ProjectRepository_SubtreeHeight_Call {
+	return &ProjectRepository_SubtreeHeight_Call{Call: _e.mock.On("SubtreeHeight", ctx, id)}
+}
+
+func (_c *ProjectRepository_SubtreeHeight_Call) Run(run func(ctx context.Context, id int)) *ProjectRepository_SubtreeHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_SubtreeHeight_Call) Return(_a0 int, _a1 error) *ProjectRepository_SubtreeHeight_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_SubtreeHeight_Call) RunAndReturn(run func(context.Context, int) (int, error)) *ProjectRepository_SubtreeHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *ProjectRepository) Update(ctx context.Context, _a1 *project.Project) (*project.Project, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *project.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Project) (*project.Project, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Project) *project.Project); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *project.Project) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ProjectRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *project.Project
+func (_e *ProjectRepository_Expecter) Update(ctx interface{}, _a1 interface{}) *ProjectRepository_Update_Call {
+	return &ProjectRepository_Update_Call{Call: _e.mock.On("Update", ctx, _a1)}
+}
+
+func (_c *ProjectRepository_Update_Call) Run(run func(ctx context.Context, _a1 *project.Project)) *ProjectRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*project.Project))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_Update_Call) Return(_a0 *project.Project, _a1 error) *ProjectRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_Update_Call) RunAndReturn(run func(context.Context, *project.Project) (*project.Project, error)) *ProjectRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateArea provides a mock function with given fields: ctx, area
+func (_m *ProjectRepository) UpdateArea(ctx context.Context, area *project.Area) (*project.Area, error) {
+	ret := _m.Called(ctx, area)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArea")
+	}
+
+	var r0 *project.Area
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Area) (*project.Area, error)); ok {
+		return rf(ctx, area)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *project.Area) *project.Area); ok {
+		r0 = rf(ctx, area)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.Area)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *project.Area) error); ok {
+		r1 = rf(ctx, area)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectRepository_UpdateArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateArea'
+type ProjectRepository_UpdateArea_Call struct {
+	*mock.Call
+}
+
+// UpdateArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - area *project.Area
+func (_e *ProjectRepository_Expecter) UpdateArea(ctx interface{}, area interface{}) *ProjectRepository_UpdateArea_Call {
+	return &ProjectRepository_UpdateArea_Call{Call: _e.mock.On("UpdateArea", ctx, area)}
+}
+
+func (_c *ProjectRepository_UpdateArea_Call) Run(run func(ctx context.Context, area *project.Area)) *ProjectRepository_UpdateArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*project.Area))
+	})
+	return _c
+}
+
+func (_c *ProjectRepository_UpdateArea_Call) Return(_a0 *project.Area, _a1 error) *ProjectRepository_UpdateArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectRepository_UpdateArea_Call) RunAndReturn(run func(context.Context, *project.Area) (*project.Area, error)) *ProjectRepository_UpdateArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewProjectRepository creates a new instance of ProjectRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProjectRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProjectRepository {
+	mock := &ProjectRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
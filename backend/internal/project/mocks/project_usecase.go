@@ -0,0 +1,922 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package projectmocks
+
+import (
+	context "context"
+	project "yangdongju/gtd_todo/internal/project"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProjectUsecase is an autogenerated mock type for the ProjectUsecase type
+type ProjectUsecase struct {
+	mock.Mock
+}
+
+type ProjectUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ProjectUsecase) EXPECT() *ProjectUsecase_Expecter {
+	return &ProjectUsecase_Expecter{mock: &_m.Mock}
+}
+
+// ChangeStatus provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) ChangeStatus(ctx context.Context, request project.ChangeProjectStatusRequest) (*project.ProjectStatusChangeResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeStatus")
+	}
+
+	var r0 *project.ProjectStatusChangeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.ChangeProjectStatusRequest) (*project.ProjectStatusChangeResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.ChangeProjectStatusRequest) *project.ProjectStatusChangeResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ProjectStatusChangeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.ChangeProjectStatusRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_ChangeStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeStatus'
+type ProjectUsecase_ChangeStatus_Call struct {
+	*mock.Call
+}
+
+// ChangeStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.ChangeProjectStatusRequest
+func (_e *ProjectUsecase_Expecter) ChangeStatus(ctx interface{}, request interface{}) *ProjectUsecase_ChangeStatus_Call {
+	return &ProjectUsecase_ChangeStatus_Call{Call: _e.mock.On("ChangeStatus", ctx, request)}
+}
+
+func (_c *ProjectUsecase_ChangeStatus_Call) Run(run func(ctx context.Context, request project.ChangeProjectStatusRequest)) *ProjectUsecase_ChangeStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.ChangeProjectStatusRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_ChangeStatus_Call) Return(_a0 *project.ProjectStatusChangeResponse, _a1 error) *ProjectUsecase_ChangeStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_ChangeStatus_Call) RunAndReturn(run func(context.Context, project.ChangeProjectStatusRequest) (*project.ProjectStatusChangeResponse, error)) *ProjectUsecase_ChangeStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) Create(ctx context.Context, request project.CreateProjectRequest) (*project.ProjectResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *project.ProjectResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.CreateProjectRequest) (*project.ProjectResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.CreateProjectRequest) *project.ProjectResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ProjectResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.CreateProjectRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ProjectUsecase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.CreateProjectRequest
+func (_e *ProjectUsecase_Expecter) Create(ctx interface{}, request interface{}) *ProjectUsecase_Create_Call {
+	return &ProjectUsecase_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *ProjectUsecase_Create_Call) Run(run func(ctx context.Context, request project.CreateProjectRequest)) *ProjectUsecase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.CreateProjectRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Create_Call) Return(_a0 *project.ProjectResponse, _a1 error) *ProjectUsecase_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Create_Call) RunAndReturn(run func(context.Context, project.CreateProjectRequest) (*project.ProjectResponse, error)) *ProjectUsecase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateArea provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) CreateArea(ctx context.Context, request project.CreateAreaRequest) (*project.AreaResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateArea")
+	}
+
+	var r0 *project.AreaResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.CreateAreaRequest) (*project.AreaResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.CreateAreaRequest) *project.AreaResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.AreaResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.CreateAreaRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_CreateArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateArea'
+type ProjectUsecase_CreateArea_Call struct {
+	*mock.Call
+}
+
+// CreateArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.CreateAreaRequest
+func (_e *ProjectUsecase_Expecter) CreateArea(ctx interface{}, request interface{}) *ProjectUsecase_CreateArea_Call {
+	return &ProjectUsecase_CreateArea_Call{Call: _e.mock.On("CreateArea", ctx, request)}
+}
+
+func (_c *ProjectUsecase_CreateArea_Call) Run(run func(ctx context.Context, request project.CreateAreaRequest)) *ProjectUsecase_CreateArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.CreateAreaRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_CreateArea_Call) Return(_a0 *project.AreaResponse, _a1 error) *ProjectUsecase_CreateArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_CreateArea_Call) RunAndReturn(run func(context.Context, project.CreateAreaRequest) (*project.AreaResponse, error)) *ProjectUsecase_CreateArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) Delete(ctx context.Context, request project.DeleteProjectRequest) (*project.DeleteProjectResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *project.DeleteProjectResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.DeleteProjectRequest) (*project.DeleteProjectResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.DeleteProjectRequest) *project.DeleteProjectResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.DeleteProjectResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.DeleteProjectRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ProjectUsecase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.DeleteProjectRequest
+func (_e *ProjectUsecase_Expecter) Delete(ctx interface{}, request interface{}) *ProjectUsecase_Delete_Call {
+	return &ProjectUsecase_Delete_Call{Call: _e.mock.On("Delete", ctx, request)}
+}
+
+func (_c *ProjectUsecase_Delete_Call) Run(run func(ctx context.Context, request project.DeleteProjectRequest)) *ProjectUsecase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.DeleteProjectRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Delete_Call) Return(_a0 *project.DeleteProjectResponse, _a1 error) *ProjectUsecase_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Delete_Call) RunAndReturn(run func(context.Context, project.DeleteProjectRequest) (*project.DeleteProjectResponse, error)) *ProjectUsecase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteArea provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) DeleteArea(ctx context.Context, request project.DeleteAreaRequest) (*project.DeleteAreaResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArea")
+	}
+
+	var r0 *project.DeleteAreaResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.DeleteAreaRequest) (*project.DeleteAreaResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.DeleteAreaRequest) *project.DeleteAreaResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.DeleteAreaResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.DeleteAreaRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_DeleteArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteArea'
+type ProjectUsecase_DeleteArea_Call struct {
+	*mock.Call
+}
+
+// DeleteArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.DeleteAreaRequest
+func (_e *ProjectUsecase_Expecter) DeleteArea(ctx interface{}, request interface{}) *ProjectUsecase_DeleteArea_Call {
+	return &ProjectUsecase_DeleteArea_Call{Call: _e.mock.On("DeleteArea", ctx, request)}
+}
+
+func (_c *ProjectUsecase_DeleteArea_Call) Run(run func(ctx context.Context, request project.DeleteAreaRequest)) *ProjectUsecase_DeleteArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.DeleteAreaRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_DeleteArea_Call) Return(_a0 *project.DeleteAreaResponse, _a1 error) *ProjectUsecase_DeleteArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_DeleteArea_Call) RunAndReturn(run func(context.Context, project.DeleteAreaRequest) (*project.DeleteAreaResponse, error)) *ProjectUsecase_DeleteArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) Get(ctx context.Context, request project.GetProjectRequest) (*project.ProjectResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *project.ProjectResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.GetProjectRequest) (*project.ProjectResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.GetProjectRequest) *project.ProjectResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ProjectResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.GetProjectRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ProjectUsecase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.GetProjectRequest
+func (_e *ProjectUsecase_Expecter) Get(ctx interface{}, request interface{}) *ProjectUsecase_Get_Call {
+	return &ProjectUsecase_Get_Call{Call: _e.mock.On("Get", ctx, request)}
+}
+
+func (_c *ProjectUsecase_Get_Call) Run(run func(ctx context.Context, request project.GetProjectRequest)) *ProjectUsecase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.GetProjectRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Get_Call) Return(_a0 *project.ProjectResponse, _a1 error) *ProjectUsecase_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Get_Call) RunAndReturn(run func(context.Context, project.GetProjectRequest) (*project.ProjectResponse, error)) *ProjectUsecase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArea provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) GetArea(ctx context.Context, request project.GetAreaRequest) (*project.AreaTreeResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArea")
+	}
+
+	var r0 *project.AreaTreeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.GetAreaRequest) (*project.AreaTreeResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.GetAreaRequest) *project.AreaTreeResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.AreaTreeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.GetAreaRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_GetArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArea'
+type ProjectUsecase_GetArea_Call struct {
+	*mock.Call
+}
+
+// GetArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.GetAreaRequest
+func (_e *ProjectUsecase_Expecter) GetArea(ctx interface{}, request interface{}) *ProjectUsecase_GetArea_Call {
+	return &ProjectUsecase_GetArea_Call{Call: _e.mock.On("GetArea", ctx, request)}
+}
+
+func (_c *ProjectUsecase_GetArea_Call) Run(run func(ctx context.Context, request project.GetAreaRequest)) *ProjectUsecase_GetArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.GetAreaRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_GetArea_Call) Return(_a0 *project.AreaTreeResponse, _a1 error) *ProjectUsecase_GetArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_GetArea_Call) RunAndReturn(run func(context.Context, project.GetAreaRequest) (*project.AreaTreeResponse, error)) *ProjectUsecase_GetArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) List(ctx context.Context, request project.ListProjectsRequest) (*project.ProjectListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *project.ProjectListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListProjectsRequest) (*project.ProjectListResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListProjectsRequest) *project.ProjectListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ProjectListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.ListProjectsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ProjectUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.ListProjectsRequest
+func (_e *ProjectUsecase_Expecter) List(ctx interface{}, request interface{}) *ProjectUsecase_List_Call {
+	return &ProjectUsecase_List_Call{Call: _e.mock.On("List", ctx, request)}
+}
+
+func (_c *ProjectUsecase_List_Call) Run(run func(ctx context.Context, request project.ListProjectsRequest)) *ProjectUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.ListProjectsRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_List_Call) Return(_a0 *project.ProjectListResponse, _a1 error) *ProjectUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_List_Call) RunAndReturn(run func(context.Context, project.ListProjectsRequest) (*project.ProjectListResponse, error)) *ProjectUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListArchived provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) ListArchived(ctx context.Context, request project.ListArchivedProjectsRequest) (*project.ArchivedProjectListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListArchived")
+	}
+
+	var r0 *project.ArchivedProjectListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListArchivedProjectsRequest) (*project.ArchivedProjectListResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListArchivedProjectsRequest) *project.ArchivedProjectListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ArchivedProjectListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.ListArchivedProjectsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_ListArchived_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListArchived'
+type ProjectUsecase_ListArchived_Call struct {
+	*mock.Call
+}
+
+// ListArchived is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.ListArchivedProjectsRequest
+func (_e *ProjectUsecase_Expecter) ListArchived(ctx interface{}, request interface{}) *ProjectUsecase_ListArchived_Call {
+	return &ProjectUsecase_ListArchived_Call{Call: _e.mock.On("ListArchived", ctx, request)}
+}
+
+func (_c *ProjectUsecase_ListArchived_Call) Run(run func(ctx context.Context, request project.ListArchivedProjectsRequest)) *ProjectUsecase_ListArchived_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.ListArchivedProjectsRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_ListArchived_Call) Return(_a0 *project.ArchivedProjectListResponse, _a1 error) *ProjectUsecase_ListArchived_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_ListArchived_Call) RunAndReturn(run func(context.Context, project.ListArchivedProjectsRequest) (*project.ArchivedProjectListResponse, error)) *ProjectUsecase_ListArchived_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAreas provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) ListAreas(ctx context.Context, request project.ListAreasRequest) (*project.AreaListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAreas")
+	}
+
+	var r0 *project.AreaListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListAreasRequest) (*project.AreaListResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListAreasRequest) *project.AreaListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.AreaListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.ListAreasRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_ListAreas_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAreas'
+type ProjectUsecase_ListAreas_Call struct {
+	*mock.Call
+}
+
+// ListAreas is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.ListAreasRequest
+func (_e *ProjectUsecase_Expecter) ListAreas(ctx interface{}, request interface{}) *ProjectUsecase_ListAreas_Call {
+	return &ProjectUsecase_ListAreas_Call{Call: _e.mock.On("ListAreas", ctx, request)}
+}
+
+func (_c *ProjectUsecase_ListAreas_Call) Run(run func(ctx context.Context, request project.ListAreasRequest)) *ProjectUsecase_ListAreas_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.ListAreasRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_ListAreas_Call) Return(_a0 *project.AreaListResponse, _a1 error) *ProjectUsecase_ListAreas_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_ListAreas_Call) RunAndReturn(run func(context.Context, project.ListAreasRequest) (*project.AreaListResponse, error)) *ProjectUsecase_ListAreas_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListChanged provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) ListChanged(ctx context.Context, request project.ListChangedProjectsRequest) ([]project.ProjectResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChanged")
+	}
+
+	var r0 []project.ProjectResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListChangedProjectsRequest) ([]project.ProjectResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.ListChangedProjectsRequest) []project.ProjectResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]project.ProjectResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.ListChangedProjectsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_ListChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChanged'
+type ProjectUsecase_ListChanged_Call struct {
+	*mock.Call
+}
+
+// ListChanged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.ListChangedProjectsRequest
+func (_e *ProjectUsecase_Expecter) ListChanged(ctx interface{}, request interface{}) *ProjectUsecase_ListChanged_Call {
+	return &ProjectUsecase_ListChanged_Call{Call: _e.mock.On("ListChanged", ctx, request)}
+}
+
+func (_c *ProjectUsecase_ListChanged_Call) Run(run func(ctx context.Context, request project.ListChangedProjectsRequest)) *ProjectUsecase_ListChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.ListChangedProjectsRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_ListChanged_Call) Return(_a0 []project.ProjectResponse, _a1 error) *ProjectUsecase_ListChanged_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_ListChanged_Call) RunAndReturn(run func(context.Context, project.ListChangedProjectsRequest) ([]project.ProjectResponse, error)) *ProjectUsecase_ListChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Move provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) Move(ctx context.Context, request project.MoveProjectRequest) (*project.ProjectResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Move")
+	}
+
+	var r0 *project.ProjectResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.MoveProjectRequest) (*project.ProjectResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.MoveProjectRequest) *project.ProjectResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ProjectResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.MoveProjectRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Move_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Move'
+type ProjectUsecase_Move_Call struct {
+	*mock.Call
+}
+
+// Move is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.MoveProjectRequest
+func (_e *ProjectUsecase_Expecter) Move(ctx interface{}, request interface{}) *ProjectUsecase_Move_Call {
+	return &ProjectUsecase_Move_Call{Call: _e.mock.On("Move", ctx, request)}
+}
+
+func (_c *ProjectUsecase_Move_Call) Run(run func(ctx context.Context, request project.MoveProjectRequest)) *ProjectUsecase_Move_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.MoveProjectRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Move_Call) Return(_a0 *project.ProjectResponse, _a1 error) *ProjectUsecase_Move_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Move_Call) RunAndReturn(run func(context.Context, project.MoveProjectRequest) (*project.ProjectResponse, error)) *ProjectUsecase_Move_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tree provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) Tree(ctx context.Context, request project.ProjectTreeRequest) (*project.ProjectTreeResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tree")
+	}
+
+	var r0 *project.ProjectTreeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.ProjectTreeRequest) (*project.ProjectTreeResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.ProjectTreeRequest) *project.ProjectTreeResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ProjectTreeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.ProjectTreeRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Tree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tree'
+type ProjectUsecase_Tree_Call struct {
+	*mock.Call
+}
+
+// Tree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.ProjectTreeRequest
+func (_e *ProjectUsecase_Expecter) Tree(ctx interface{}, request interface{}) *ProjectUsecase_Tree_Call {
+	return &ProjectUsecase_Tree_Call{Call: _e.mock.On("Tree", ctx, request)}
+}
+
+func (_c *ProjectUsecase_Tree_Call) Run(run func(ctx context.Context, request project.ProjectTreeRequest)) *ProjectUsecase_Tree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.ProjectTreeRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Tree_Call) Return(_a0 *project.ProjectTreeResponse, _a1 error) *ProjectUsecase_Tree_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Tree_Call) RunAndReturn(run func(context.Context, project.ProjectTreeRequest) (*project.ProjectTreeResponse, error)) *ProjectUsecase_Tree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) Update(ctx context.Context, request project.UpdateProjectRequest) (*project.ProjectResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *project.ProjectResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.UpdateProjectRequest) (*project.ProjectResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.UpdateProjectRequest) *project.ProjectResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.ProjectResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.UpdateProjectRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ProjectUsecase_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.UpdateProjectRequest
+func (_e *ProjectUsecase_Expecter) Update(ctx interface{}, request interface{}) *ProjectUsecase_Update_Call {
+	return &ProjectUsecase_Update_Call{Call: _e.mock.On("Update", ctx, request)}
+}
+
+func (_c *ProjectUsecase_Update_Call) Run(run func(ctx context.Context, request project.UpdateProjectRequest)) *ProjectUsecase_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.UpdateProjectRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_Update_Call) Return(_a0 *project.ProjectResponse, _a1 error) *ProjectUsecase_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_Update_Call) RunAndReturn(run func(context.Context, project.UpdateProjectRequest) (*project.ProjectResponse, error)) *ProjectUsecase_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateArea provides a mock function with given fields: ctx, request
+func (_m *ProjectUsecase) UpdateArea(ctx context.Context, request project.UpdateAreaRequest) (*project.AreaResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArea")
+	}
+
+	var r0 *project.AreaResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, project.UpdateAreaRequest) (*project.AreaResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, project.UpdateAreaRequest) *project.AreaResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*project.AreaResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, project.UpdateAreaRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProjectUsecase_UpdateArea_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateArea'
+type ProjectUsecase_UpdateArea_Call struct {
+	*mock.Call
+}
+
+// UpdateArea is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request project.UpdateAreaRequest
+func (_e *ProjectUsecase_Expecter) UpdateArea(ctx interface{}, request interface{}) *ProjectUsecase_UpdateArea_Call {
+	return &ProjectUsecase_UpdateArea_Call{Call: _e.mock.On("UpdateArea", ctx, request)}
+}
+
+func (_c *ProjectUsecase_UpdateArea_Call) Run(run func(ctx context.Context, request project.UpdateAreaRequest)) *ProjectUsecase_UpdateArea_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(project.UpdateAreaRequest))
+	})
+	return _c
+}
+
+func (_c *ProjectUsecase_UpdateArea_Call) Return(_a0 *project.AreaResponse, _a1 error) *ProjectUsecase_UpdateArea_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProjectUsecase_UpdateArea_Call) RunAndReturn(run func(context.Context, project.UpdateAreaRequest) (*project.AreaResponse, error)) *ProjectUsecase_UpdateArea_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewProjectUsecase creates a new instance of ProjectUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProjectUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProjectUsecase {
+	mock := &ProjectUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,418 @@
+package project
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/query"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ProjectRepository interface {
+	Save(ctx context.Context, project *Project) (*Project, error)
+	FindByID(ctx context.Context, userID int, id int) (*Project, error)
+	FindByClientID(ctx context.Context, userID int, clientID string) (*Project, error)
+	FindChangedSince(ctx context.Context, userID int, cursor int64) ([]Project, error)
+	FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Project, int, error)
+	Update(ctx context.Context, project *Project) (*Project, error)
+	Delete(ctx context.Context, project *Project) (bool, error)
+	FindLineage(ctx context.Context, id int) ([]int, error)
+	SubtreeHeight(ctx context.Context, id int) (int, error)
+	SetDescendantsArea(ctx context.Context, id int, areaID *int) ([]Project, error)
+	FindTree(ctx context.Context, userID int, areaID *int) ([]ProjectNode, error)
+	FindArchived(ctx context.Context, userID int) ([]ProjectNode, error)
+	FindOpenTodoIDs(ctx context.Context, id int) ([]int, error)
+	FindDetachedIDs(ctx context.Context, id int) (*DetachedIDs, error)
+	FindAreaProjectIDs(ctx context.Context, areaID int) ([]int, error)
+	SaveArea(ctx context.Context, area *Area) (*Area, error)
+	FindAreaByID(ctx context.Context, userID int, id int) (*Area, error)
+	FindAreas(ctx context.Context, userID int) ([]Area, error)
+	UpdateArea(ctx context.Context, area *Area) (*Area, error)
+	DeleteArea(ctx context.Context, area *Area) (bool, error)
+}
+
+type projectRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+type Project struct {
+	ID              int        `db:"id"`
+	UserID          int        `db:"user_id"`
+	ClientID        *string    `db:"client_id"`
+	AreaID          *int       `db:"area_id"`
+	ParentProjectID *int       `db:"parent_project_id"`
+	Name            string     `db:"name"`
+	Description     *string    `db:"description"`
+	Color           string     `db:"color"`
+	Priority        string     `db:"priority"`
+	Status          string     `db:"status"`
+	CompletedAt     *time.Time `db:"completed_at"`
+	ArchivedAt      *time.Time `db:"archived_at"`
+	Version         int        `db:"version"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+// DetachedIDs are what deleting a project leaves behind: its direct sub-projects, which
+// become top-level, and its own todos, which leave any project.
+type DetachedIDs struct {
+	ProjectIDs []int
+	TodoIDs    []int
+}
+
+// ProjectNode is a project with how many todos it holds itself and how many of those are
+// done. Sub-projects are counted on their own nodes.
+type ProjectNode struct {
+	Project
+	TodoCount int `db:"todo_count"`
+	DoneCount int `db:"done_count"`
+}
+
+// Area is an area of responsibility grouping projects, such as Work or Health.
+type Area struct {
+	ID           int       `db:"id"`
+	UserID       int       `db:"user_id"`
+	Name         string    `db:"name"`
+	Color        string    `db:"color"`
+	Version      int       `db:"version"`
+	ProjectCount int       `db:"project_count"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+const projectColumns = "id, user_id, client_id, area_id, parent_project_id, name, description, color, priority, " +
+	"status, completed_at, archived_at, version, created_at, updated_at"
+
+const areaColumns = "id, user_id, name, color, version, created_at, updated_at, " +
+	"(SELECT COUNT(*) FROM projects WHERE area_id = areas.id) AS project_count"
+
+const uniqueAreaNameIndex = "idx_areas_user_name"
+
+func NewProjectRepository(db *sqlx.DB) *projectRepositoryImpl {
+	return &projectRepositoryImpl{db: db}
+}
+
+// Save inserts the project. One that leaves the priority unset gets the normal priority.
+func (r *projectRepositoryImpl) Save(ctx context.Context, project *Project) (*Project, error) {
+	priority := project.Priority
+	if priority == "" {
+		priority = PriorityNormal
+	}
+	var saved Project
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO projects (user_id, client_id, area_id, parent_project_id, name, description, color, priority)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+projectColumns,
+		project.UserID, project.ClientID, project.AreaID, project.ParentProjectID,
+		project.Name, project.Description, project.Color, priority)
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *projectRepositoryImpl) FindByID(ctx context.Context, userID int, id int) (*Project, error) {
+	var project Project
+	err := database.Conn(ctx, r.db).GetContext(ctx, &project,
+		"SELECT "+projectColumns+" FROM projects WHERE id = $1 AND user_id = $2", id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepositoryImpl) FindByClientID(ctx context.Context, userID int, clientID string) (*Project, error) {
+	var project Project
+	err := database.Conn(ctx, r.db).GetContext(ctx, &project,
+		"SELECT "+projectColumns+" FROM projects WHERE client_id = $1 AND user_id = $2", clientID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// FindChangedSince returns the projects created or modified after the sync cursor, oldest change first.
+func (r *projectRepositoryImpl) FindChangedSince(ctx context.Context, userID int, cursor int64) ([]Project, error) {
+	projects := []Project{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &projects,
+		"SELECT "+projectColumns+" FROM projects WHERE user_id = $1 AND change_seq > $2 ORDER BY change_seq", userID, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// FindPage returns the user's projects matching spec, one row past its limit when there
+// are more, and how many match in total.
+func (r *projectRepositoryImpl) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Project, int, error) {
+	page, count := spec.Build(projectColumns, "projects", query.Equal("user_id", userID))
+	conn := database.Conn(ctx, r.db)
+
+	projects := []Project{}
+	if err := conn.SelectContext(ctx, &projects, page.SQL, page.Args...); err != nil {
+		return nil, 0, err
+	}
+	var total int
+	if err := conn.GetContext(ctx, &total, count.SQL, count.Args...); err != nil {
+		return nil, 0, err
+	}
+	return projects, total, nil
+}
+
+// Update writes the project only if its version is still the one that was read, and bumps
+// the version. It returns nil when another writer got there first. completed_at and
+// archived_at follow the status: each is stamped when the project enters done or archived,
+// completed_at survives archiving a done project, and both clear once it is active again.
+func (r *projectRepositoryImpl) Update(ctx context.Context, project *Project) (*Project, error) {
+	var updated Project
+	err := database.Conn(ctx, r.db).GetContext(ctx, &updated, `
+		UPDATE projects
+		SET area_id = $4, parent_project_id = $5, name = $6, description = $7, color = $8, priority = $9,
+			status = $10,
+			completed_at = CASE $10
+				WHEN 'done' THEN COALESCE(completed_at, CURRENT_TIMESTAMP)
+				WHEN 'archived' THEN completed_at
+			END,
+			archived_at = CASE $10 WHEN 'archived' THEN COALESCE(archived_at, CURRENT_TIMESTAMP) END,
+			version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND version = $3
+		RETURNING `+projectColumns,
+		project.ID, project.UserID, project.Version, project.AreaID, project.ParentProjectID,
+		project.Name, project.Description, project.Color, project.Priority, project.Status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *projectRepositoryImpl) Delete(ctx context.Context, project *Project) (bool, error) {
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM projects WHERE id = $1 AND user_id = $2 AND version = $3",
+		project.ID, project.UserID, project.Version)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	return deleted == 1, err
+}
+
+// FindLineage returns the project's id followed by its ancestors', nearest first, so its
+// length is the project's depth.
+func (r *projectRepositoryImpl) FindLineage(ctx context.Context, id int) ([]int, error) {
+	lineage := []int{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &lineage, `
+		WITH RECURSIVE lineage (id, parent_project_id, depth) AS (
+			SELECT id, parent_project_id, 1 FROM projects WHERE id = $1
+			UNION ALL
+			SELECT p.id, p.parent_project_id, l.depth + 1
+			FROM projects p JOIN lineage l ON p.id = l.parent_project_id
+		)
+		SELECT id FROM lineage ORDER BY depth`,
+		id)
+	if err != nil {
+		return nil, err
+	}
+	return lineage, nil
+}
+
+// SubtreeHeight counts the levels from the project down to its deepest sub-project, 1 for
+// a project without any.
+func (r *projectRepositoryImpl) SubtreeHeight(ctx context.Context, id int) (int, error) {
+	var height int
+	err := database.Conn(ctx, r.db).GetContext(ctx, &height, `
+		WITH RECURSIVE subtree (id, depth) AS (
+			SELECT id, 1 FROM projects WHERE id = $1
+			UNION ALL
+			SELECT p.id, s.depth + 1 FROM projects p JOIN subtree s ON p.parent_project_id = s.id
+		)
+		SELECT COALESCE(MAX(depth), 0) FROM subtree`,
+		id)
+	return height, err
+}
+
+// SetDescendantsArea moves every sub-project below the project, at any depth, to the
+// area, and returns the ones that changed with their new versions.
+func (r *projectRepositoryImpl) SetDescendantsArea(ctx context.Context, id int, areaID *int) ([]Project, error) {
+	projects := []Project{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &projects, `
+		WITH RECURSIVE descendants (id) AS (
+			SELECT id FROM projects WHERE parent_project_id = $1
+			UNION ALL
+			SELECT p.id FROM projects p JOIN descendants d ON p.parent_project_id = d.id
+		)
+		UPDATE projects SET area_id = $2, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT id FROM descendants) AND area_id IS DISTINCT FROM $2
+		RETURNING `+projectColumns,
+		id, areaID)
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// FindTree returns the user's projects that are not archived with their todo counts, by
+// name. With areaID set only the projects of that area are returned, which are whole
+// subtrees because sub-projects share the area of their parent.
+func (r *projectRepositoryImpl) FindTree(ctx context.Context, userID int, areaID *int) ([]ProjectNode, error) {
+	nodes := []ProjectNode{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &nodes, `
+		SELECT `+projectColumns+`,
+			(SELECT COUNT(*) FROM todos t WHERE t.project_id = projects.id) AS todo_count,
+			(SELECT COUNT(*) FROM todos t WHERE t.project_id = projects.id AND t.status = 'done') AS done_count
+		FROM projects
+		WHERE user_id = $1 AND ($2::int IS NULL OR area_id = $2) AND status <> 'archived'
+		ORDER BY LOWER(name), id`,
+		userID, areaID)
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// FindArchived returns the user's archived projects with their todo counts, most recently
+// archived first.
+func (r *projectRepositoryImpl) FindArchived(ctx context.Context, userID int) ([]ProjectNode, error) {
+	nodes := []ProjectNode{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &nodes, `
+		SELECT `+projectColumns+`,
+			(SELECT COUNT(*) FROM todos t WHERE t.project_id = projects.id) AS todo_count,
+			(SELECT COUNT(*) FROM todos t WHERE t.project_id = projects.id AND t.status = 'done') AS done_count
+		FROM projects
+		WHERE user_id = $1 AND status = 'archived'
+		ORDER BY archived_at DESC, id DESC`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// FindOpenTodoIDs returns the todos of the project that are not done, sub-projects'
+// excluded, in board order.
+func (r *projectRepositoryImpl) FindOpenTodoIDs(ctx context.Context, id int) ([]int, error) {
+	ids := []int{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &ids, `
+		SELECT id FROM todos
+		WHERE project_id = $1 AND status <> 'done'
+		ORDER BY status, position, id`,
+		id)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindDetachedIDs returns the projects and todos that ON DELETE SET NULL will take out of
+// the project when it is deleted, by id.
+func (r *projectRepositoryImpl) FindDetachedIDs(ctx context.Context, id int) (*DetachedIDs, error) {
+	conn := database.Conn(ctx, r.db)
+	detached := &DetachedIDs{ProjectIDs: []int{}, TodoIDs: []int{}}
+	if err := conn.SelectContext(ctx, &detached.ProjectIDs,
+		"SELECT id FROM projects WHERE parent_project_id = $1 ORDER BY id", id); err != nil {
+		return nil, err
+	}
+	if err := conn.SelectContext(ctx, &detached.TodoIDs,
+		"SELECT id FROM todos WHERE project_id = $1 ORDER BY id", id); err != nil {
+		return nil, err
+	}
+	return detached, nil
+}
+
+// FindAreaProjectIDs returns the projects of the area, sub-projects included, by id.
+func (r *projectRepositoryImpl) FindAreaProjectIDs(ctx context.Context, areaID int) ([]int, error) {
+	ids := []int{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &ids,
+		"SELECT id FROM projects WHERE area_id = $1 ORDER BY id", areaID)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SaveArea returns an AreaNameTakenError when the user already has an area with that
+// name, compared case-insensitively.
+func (r *projectRepositoryImpl) SaveArea(ctx context.Context, area *Area) (*Area, error) {
+	var saved Area
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO areas (user_id, name, color)
+		VALUES ($1, $2, $3)
+		RETURNING `+areaColumns,
+		area.UserID, area.Name, area.Color)
+	if database.IsUniqueViolation(err, uniqueAreaNameIndex) {
+		return nil, NewAreaNameTakenError(area.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *projectRepositoryImpl) FindAreaByID(ctx context.Context, userID int, id int) (*Area, error) {
+	var area Area
+	err := database.Conn(ctx, r.db).GetContext(ctx, &area,
+		"SELECT "+areaColumns+" FROM areas WHERE id = $1 AND user_id = $2", id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+// FindAreas returns the user's areas by name.
+func (r *projectRepositoryImpl) FindAreas(ctx context.Context, userID int) ([]Area, error) {
+	areas := []Area{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &areas,
+		"SELECT "+areaColumns+" FROM areas WHERE user_id = $1 ORDER BY LOWER(name), id", userID)
+	if err != nil {
+		return nil, err
+	}
+	return areas, nil
+}
+
+// UpdateArea writes the area only if its version is still the one that was read, and
+// bumps the version. It returns nil when another writer got there first.
+func (r *projectRepositoryImpl) UpdateArea(ctx context.Context, area *Area) (*Area, error) {
+	var updated Area
+	err := database.Conn(ctx, r.db).GetContext(ctx, &updated, `
+		UPDATE areas
+		SET name = $4, color = $5, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND version = $3
+		RETURNING `+areaColumns,
+		area.ID, area.UserID, area.Version, area.Name, area.Color)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if database.IsUniqueViolation(err, uniqueAreaNameIndex) {
+		return nil, NewAreaNameTakenError(area.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteArea leaves the area's projects without an area.
+func (r *projectRepositoryImpl) DeleteArea(ctx context.Context, area *Area) (bool, error) {
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM areas WHERE id = $1 AND user_id = $2 AND version = $3",
+		area.ID, area.UserID, area.Version)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	return deleted == 1, err
+}
@@ -0,0 +1,150 @@
+package project_test
+
+import (
+	"context"
+	"testing"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectRepository_ConditionalWrites(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	repository := project.NewProjectRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	saved, err := repository.Save(ctx, &project.Project{UserID: userID, Name: "Home", Color: "#3B82F6"})
+	assert.NoError(t, err)
+	stale := *saved
+
+	// when
+	saved.Name = "House"
+	updated, _ := repository.Update(ctx, saved)
+	staleUpdate, _ := repository.Update(ctx, &stale)
+	staleDelete, _ := repository.Delete(ctx, &stale)
+	deleted, _ := repository.Delete(ctx, updated)
+
+	// then
+	assert.Equal(t, 2, updated.Version)
+	assert.Nil(t, staleUpdate)
+	assert.False(t, staleDelete)
+	assert.True(t, deleted)
+}
+
+func TestProjectRepository_FindIsScopedToUser(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	repository := project.NewProjectRepository(testhelper.GetTestDB())
+	saved, _ := repository.Save(context.Background(), &project.Project{UserID: userID, Name: "Home", Color: "#3B82F6"})
+
+	// when
+	own, _ := repository.FindByID(context.Background(), userID, saved.ID)
+	other, _ := repository.FindByID(context.Background(), userID+1, saved.ID)
+
+	// then
+	assert.NotNil(t, own)
+	assert.Nil(t, other)
+}
+
+func TestProjectRepository_Hierarchy(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	repository := project.NewProjectRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	area, err := repository.SaveArea(ctx, &project.Area{UserID: userID, Name: "Work", Color: "#6B7280"})
+	assert.NoError(t, err)
+	root, _ := repository.Save(ctx, &project.Project{UserID: userID, Name: "Launch", Color: "#3B82F6"})
+	child, _ := repository.Save(ctx, &project.Project{UserID: userID, ParentProjectID: &root.ID, Name: "Docs", Color: "#3B82F6"})
+	grandchild, _ := repository.Save(ctx, &project.Project{UserID: userID, ParentProjectID: &child.ID, Name: "Guide", Color: "#3B82F6"})
+	_, _ = testhelper.GetTestDB().Exec(
+		"INSERT INTO todos (user_id, project_id, title, status) VALUES ($1, $2, 'a', 'done'), ($1, $2, 'b', 'inbox')",
+		userID, grandchild.ID)
+
+	// when
+	lineage, _ := repository.FindLineage(ctx, grandchild.ID)
+	height, _ := repository.SubtreeHeight(ctx, root.ID)
+	moved, _ := repository.SetDescendantsArea(ctx, root.ID, &area.ID)
+	tree, _ := repository.FindTree(ctx, userID, &area.ID)
+	duplicate, duplicateErr := repository.SaveArea(ctx, &project.Area{UserID: userID, Name: "work", Color: "#6B7280"})
+
+	// then
+	assert.Equal(t, []int{grandchild.ID, child.ID, root.ID}, lineage)
+	assert.Equal(t, 3, height)
+	assert.Len(t, moved, 2)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "Docs", tree[0].Name)
+	assert.Equal(t, "Guide", tree[1].Name)
+	assert.Equal(t, 2, tree[1].TodoCount)
+	assert.Equal(t, 1, tree[1].DoneCount)
+	assert.Nil(t, duplicate)
+	var takenErr *project.AreaNameTakenError
+	assert.ErrorAs(t, duplicateErr, &takenErr)
+}
+
+func TestProjectRepository_StatusDates(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	repository := project.NewProjectRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	saved, _ := repository.Save(ctx, &project.Project{UserID: userID, Name: "Home", Color: "#3B82F6"})
+	_, _ = repository.Save(ctx, &project.Project{UserID: userID, Name: "Garden", Color: "#3B82F6"})
+
+	// when
+	saved.Status = project.StatusDone
+	done, _ := repository.Update(ctx, saved)
+	done.Status = project.StatusArchived
+	archived, _ := repository.Update(ctx, done)
+	archive, _ := repository.FindArchived(ctx, userID)
+	tree, _ := repository.FindTree(ctx, userID, nil)
+	archived.Status = project.StatusInProgress
+	reopened, _ := repository.Update(ctx, archived)
+
+	// then
+	assert.Equal(t, project.StatusTodo, saved.Status)
+	assert.NotNil(t, done.CompletedAt)
+	assert.Nil(t, done.ArchivedAt)
+	assert.Equal(t, done.CompletedAt, archived.CompletedAt)
+	assert.NotNil(t, archived.ArchivedAt)
+	assert.Len(t, archive, 1)
+	assert.Equal(t, saved.ID, archive[0].ID)
+	assert.Len(t, tree, 1)
+	assert.Equal(t, "Garden", tree[0].Name)
+	assert.Nil(t, reopened.CompletedAt)
+	assert.Nil(t, reopened.ArchivedAt)
+}
+
+func TestProjectRepository_FindDetachedIDs(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	repository := project.NewProjectRepository(db)
+	ctx := context.Background()
+	parent, _ := repository.Save(ctx, &project.Project{UserID: userID, Name: "Launch", Color: "#3B82F6"})
+	child, _ := repository.Save(ctx, &project.Project{UserID: userID, Name: "Docs", Color: "#3B82F6", ParentProjectID: &parent.ID})
+	_, _ = repository.Save(ctx, &project.Project{UserID: userID, Name: "Guide", Color: "#3B82F6", ParentProjectID: &child.ID})
+	var todoID int
+	_ = db.Get(&todoID, "INSERT INTO todos (user_id, title, project_id) VALUES ($1, 'Write brief', $2) RETURNING id", userID, parent.ID)
+
+	// when
+	detached, err := repository.FindDetachedIDs(ctx, parent.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{child.ID}, detached.ProjectIDs, "only direct sub-projects lose their parent")
+	assert.Equal(t, []int{todoID}, detached.TodoIDs)
+}
@@ -0,0 +1,52 @@
+package project
+
+import (
+	"time"
+	"yangdongju/gtd_todo/internal/etag"
+)
+
+type ProjectResponse struct {
+	ID              int        `json:"id"`
+	ClientID        *string    `json:"client_id"`
+	AreaID          *int       `json:"area_id"`
+	ParentProjectID *int       `json:"parent_project_id"`
+	Name            string     `json:"name"`
+	Description     *string    `json:"description"`
+	Color           string     `json:"color"`
+	Priority        string     `json:"priority"`
+	Status          string     `json:"status"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	ArchivedAt      *time.Time `json:"archived_at"`
+	Version         int        `json:"version"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (r ProjectResponse) ETag() string {
+	return etag.FromVersion(r.Version)
+}
+
+type ProjectListResponse struct {
+	Projects   []ProjectResponse `json:"projects"`
+	Total      int               `json:"total"`
+	NextCursor *string           `json:"next_cursor"`
+}
+
+func toProjectResponse(project *Project) *ProjectResponse {
+	return &ProjectResponse{
+		ID:              project.ID,
+		ClientID:        project.ClientID,
+		AreaID:          project.AreaID,
+		ParentProjectID: project.ParentProjectID,
+		Name:            project.Name,
+		Description:     project.Description,
+		Color:           project.Color,
+		Priority:        project.Priority,
+		Status:          project.Status,
+		CompletedAt:     project.CompletedAt,
+		ArchivedAt:      project.ArchivedAt,
+		Version:         project.Version,
+		CreatedAt:       project.CreatedAt,
+		UpdatedAt:       project.UpdatedAt,
+	}
+}
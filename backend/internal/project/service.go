@@ -0,0 +1,58 @@
+package project
+
+import (
+	"context"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+type ProjectUsecase interface {
+	Create(ctx context.Context, request CreateProjectRequest) (*ProjectResponse, error)
+	Get(ctx context.Context, request GetProjectRequest) (*ProjectResponse, error)
+	List(ctx context.Context, request ListProjectsRequest) (*ProjectListResponse, error)
+	ListChanged(ctx context.Context, request ListChangedProjectsRequest) ([]ProjectResponse, error)
+	Update(ctx context.Context, request UpdateProjectRequest) (*ProjectResponse, error)
+	Delete(ctx context.Context, request DeleteProjectRequest) (*DeleteProjectResponse, error)
+	ChangeStatus(ctx context.Context, request ChangeProjectStatusRequest) (*ProjectStatusChangeResponse, error)
+	ListArchived(ctx context.Context, request ListArchivedProjectsRequest) (*ArchivedProjectListResponse, error)
+	Move(ctx context.Context, request MoveProjectRequest) (*ProjectResponse, error)
+	Tree(ctx context.Context, request ProjectTreeRequest) (*ProjectTreeResponse, error)
+	CreateArea(ctx context.Context, request CreateAreaRequest) (*AreaResponse, error)
+	ListAreas(ctx context.Context, request ListAreasRequest) (*AreaListResponse, error)
+	GetArea(ctx context.Context, request GetAreaRequest) (*AreaTreeResponse, error)
+	UpdateArea(ctx context.Context, request UpdateAreaRequest) (*AreaResponse, error)
+	DeleteArea(ctx context.Context, request DeleteAreaRequest) (*DeleteAreaResponse, error)
+}
+
+type projectService struct {
+	projectRepository ProjectRepository
+	txManager         database.TxManager
+	publisher         event.Publisher
+	todoUsecase       todo.TodoUsecase
+}
+
+func NewProjectService(repository ProjectRepository, txManager database.TxManager, publisher event.Publisher, todoUsecase todo.TodoUsecase) *projectService {
+	return &projectService{
+		projectRepository: repository,
+		txManager:         txManager,
+		publisher:         publisher,
+		todoUsecase:       todoUsecase,
+	}
+}
+
+// findForWrite loads the project a conditional write targets and checks the version
+// the client based its change on.
+func (s *projectService) findForWrite(ctx context.Context, userID int, id int, expectedVersion int) (*Project, error) {
+	project, err := s.projectRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, NewProjectNotFoundError(id)
+	}
+	if project.Version != expectedVersion {
+		return nil, NewVersionConflictError(project, expectedVersion)
+	}
+	return project, nil
+}
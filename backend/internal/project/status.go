@@ -0,0 +1,153 @@
+package project
+
+import (
+	"context"
+	"slices"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+	StatusArchived   = "archived"
+)
+
+// projectTransitions lists the statuses a project can move to from each status. A done
+// project reopens as in progress, and an archived one comes back as todo or in progress.
+var projectTransitions = map[string][]string{
+	StatusTodo:       {StatusInProgress, StatusDone, StatusArchived},
+	StatusInProgress: {StatusTodo, StatusDone, StatusArchived},
+	StatusDone:       {StatusInProgress, StatusArchived},
+	StatusArchived:   {StatusTodo, StatusInProgress},
+}
+
+// ChangeStatus moves the project to another status. Asking for the status it already has
+// changes nothing. Completing a project needs every todo of its own done, sub-projects'
+// aside; with Force the open ones are completed along with it, and recurring ones stop
+// repeating.
+func (s *projectService) ChangeStatus(ctx context.Context, req ChangeProjectStatusRequest) (*ProjectStatusChangeResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ProjectStatusChangeResponse{Completed: []todo.TodoResponse{}}
+	var updated *Project
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		project, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		if req.Status == project.Status {
+			updated = project
+			return nil
+		}
+		if !slices.Contains(projectTransitions[project.Status], req.Status) {
+			return NewStatusTransitionError(project.Status, req.Status)
+		}
+
+		if req.Status == StatusDone {
+			if res.Completed, err = s.completeOpenTodos(ctx, req.UserID, project.ID, req.Force); err != nil {
+				return err
+			}
+		}
+
+		project.Status = req.Status
+		updated, err = s.projectRepository.Update(ctx, project)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return NewVersionConflictError(project, expectedVersion)
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.ProjectUpdated, updated.ID, toProjectResponse(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Project = toProjectResponse(updated)
+	return res, nil
+}
+
+// completeOpenTodos marks the open todos of the project done through the todo service, so
+// they move, unblock and publish as if completed one by one. Each is read again first
+// because completing one can touch the next.
+func (s *projectService) completeOpenTodos(ctx context.Context, userID int, id int, force bool) ([]todo.TodoResponse, error) {
+	ids, err := s.projectRepository.FindOpenTodoIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 && !force {
+		return nil, NewOpenTodosError(len(ids))
+	}
+
+	completed := make([]todo.TodoResponse, 0, len(ids))
+	for _, todoID := range ids {
+		current, err := s.todoUsecase.Get(ctx, todo.GetTodoRequest{UserID: userID, ID: todoID})
+		if err != nil {
+			return nil, err
+		}
+		moved, err := s.todoUsecase.ChangeStatus(ctx, todo.ChangeStatusRequest{
+			UserID: userID, ID: todoID, IfMatch: current.ETag(), Status: todo.StatusDone, EndRecurrence: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		completed = append(completed, *moved.Todo)
+	}
+	return completed, nil
+}
+
+// ListArchived is the archive: every archived project with its progress, most recently
+// archived first.
+func (s *projectService) ListArchived(ctx context.Context, req ListArchivedProjectsRequest) (*ArchivedProjectListResponse, error) {
+	nodes, err := s.projectRepository.FindArchived(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	res := &ArchivedProjectListResponse{Projects: make([]ArchivedProjectResponse, 0, len(nodes))}
+	for i := range nodes {
+		res.Projects = append(res.Projects, ArchivedProjectResponse{
+			Project:  *toProjectResponse(&nodes[i].Project),
+			Progress: ProgressResponse{Done: nodes[i].DoneCount, Total: nodes[i].TodoCount},
+		})
+	}
+	return res, nil
+}
+
+type ChangeProjectStatusRequest struct {
+	UserID  int    `json:"-" auth:"user_id"`
+	ID      int    `json:"-" uri:"id"`
+	IfMatch string `json:"-" header:"If-Match"`
+	Status  string `json:"status" binding:"required,oneof=todo in_progress done archived"`
+	Force   bool   `json:"force"`
+}
+
+type ListArchivedProjectsRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+}
+
+// ProjectStatusChangeResponse is the moved project and the todos completed with it. Its
+// ETag is the project's.
+type ProjectStatusChangeResponse struct {
+	Project   *ProjectResponse    `json:"project"`
+	Completed []todo.TodoResponse `json:"completed"`
+}
+
+func (r ProjectStatusChangeResponse) ETag() string {
+	return r.Project.ETag()
+}
+
+type ArchivedProjectListResponse struct {
+	Projects []ArchivedProjectResponse `json:"projects"`
+}
+
+// ArchivedProjectResponse is a project in the archive. Its completed_at is null when it
+// was archived without being completed.
+type ArchivedProjectResponse struct {
+	Project  ProjectResponse  `json:"project"`
+	Progress ProgressResponse `json:"progress"`
+}
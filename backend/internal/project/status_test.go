@@ -0,0 +1,106 @@
+package project_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/project"
+	projectmocks "yangdongju/gtd_todo/internal/project/mocks"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// ============ Test Cases ============
+
+func TestChangeStatus_CompletingWithOpenTodosIsRejected(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Status: project.StatusInProgress, Version: 1}, nil)
+	mockRepo.EXPECT().FindOpenTodoIDs(mock.Anything, 1).Return([]int{10, 11}, nil)
+
+	handler := project.NewProjectHandler(project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, todomocks.NewTodoUsecase(t)))
+
+	// when
+	code, res := handler.HandleChangeStatus(context.Background(), project.ChangeProjectStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: project.StatusDone,
+	})
+	errRes, ok := res.(project.ErrorResponse)
+
+	// then
+	assert.Equal(t, http.StatusConflict, code)
+	assert.True(t, ok, "Expected project.ErrorResponse type")
+	assert.Contains(t, errRes.Error, "2 open todos")
+}
+
+func TestChangeStatus_ForceCompletesOpenTodos(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Status: project.StatusInProgress, Version: 1}, nil)
+	mockRepo.EXPECT().FindOpenTodoIDs(mock.Anything, 1).Return([]int{10}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(p *project.Project) bool {
+		return p.Status == project.StatusDone
+	})).RunAndReturn(func(ctx context.Context, p *project.Project) (*project.Project, error) {
+		updated := *p
+		updated.Version++
+		return &updated, nil
+	})
+	mockTodo := todomocks.NewTodoUsecase(t)
+	mockTodo.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 10}).Return(&todo.TodoResponse{ID: 10, Version: 3}, nil)
+	mockTodo.EXPECT().ChangeStatus(mock.Anything, todo.ChangeStatusRequest{
+		UserID: 7, ID: 10, IfMatch: `"3"`, Status: todo.StatusDone, EndRecurrence: true,
+	}).Return(&todo.StatusChangeResponse{Todo: &todo.TodoResponse{ID: 10, Status: todo.StatusDone, Version: 4}}, nil)
+
+	publisher := &recordingPublisher{}
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, publisher, mockTodo)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), project.ChangeProjectStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: project.StatusDone, Force: true,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, project.StatusDone, res.Project.Status)
+	assert.Equal(t, `"2"`, res.ETag())
+	assert.Len(t, res.Completed, 1)
+	assert.Equal(t, []string{event.ProjectUpdated}, publisher.types)
+}
+
+func TestChangeStatus_DoneProjectReopensOnlyAsInProgress(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Status: project.StatusDone, Version: 1}, nil)
+
+	handler := project.NewProjectHandler(project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil))
+
+	// when
+	code, _ := handler.HandleChangeStatus(context.Background(), project.ChangeProjectStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: project.StatusTodo,
+	})
+
+	// then
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func TestChangeStatus_SameStatusChangesNothing(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Status: project.StatusArchived, Version: 5}, nil)
+
+	publisher := &recordingPublisher{}
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, publisher, nil)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), project.ChangeProjectStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"5"`, Status: project.StatusArchived,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 5, res.Project.Version)
+	assert.Empty(t, publisher.types)
+}
@@ -0,0 +1,59 @@
+package project
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// Update applies a partial change on top of the version named in If-Match.
+func (s *projectService) Update(ctx context.Context, req UpdateProjectRequest) (*ProjectResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Project
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		project, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			project.Name = *req.Name
+		}
+		if req.Description != nil {
+			project.Description = req.Description
+		}
+		if req.Color != nil {
+			project.Color = *req.Color
+		}
+		if req.Priority != nil {
+			project.Priority = *req.Priority
+		}
+
+		updated, err = s.projectRepository.Update(ctx, project)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return NewVersionConflictError(project, expectedVersion)
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.ProjectUpdated, updated.ID, toProjectResponse(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProjectResponse(updated), nil
+}
+
+type UpdateProjectRequest struct {
+	UserID      int     `json:"-" auth:"user_id"`
+	ID          int     `json:"-" uri:"id"`
+	IfMatch     string  `json:"-" header:"If-Match"`
+	Name        *string `json:"name" binding:"omitempty,min=1,max=255"`
+	Description *string `json:"description"`
+	Color       *string `json:"color" binding:"omitempty,hexcolor"`
+	Priority    *string `json:"priority" binding:"omitempty,oneof=high normal low"`
+}
@@ -0,0 +1,85 @@
+package project_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/project"
+	projectmocks "yangdongju/gtd_todo/internal/project/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type recordingPublisher struct {
+	types []string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, userID int, eventType string, entityID int, payload any) error {
+	p.types = append(p.types, eventType)
+	return nil
+}
+
+// ============ Test Cases ============
+
+func TestUpdate_Success(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Name: "Home", Color: "#3B82F6", Version: 1}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, p *project.Project) (*project.Project, error) {
+		updated := *p
+		updated.Version++
+		return &updated, nil
+	})
+
+	service := project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil)
+	color := "#FF0000"
+
+	// when
+	res, err := service.Update(context.Background(), project.UpdateProjectRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Color: &color,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "Home", res.Name)
+	assert.Equal(t, "#FF0000", res.Color)
+	assert.Equal(t, 2, res.Version)
+}
+
+func TestDelete_StaleVersionReturnsCurrentProject(t *testing.T) {
+	// given
+	mockRepo := projectmocks.NewProjectRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&project.Project{ID: 1, UserID: 7, Name: "Renamed", Version: 4}, nil)
+
+	handler := project.NewProjectHandler(project.NewProjectService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil))
+
+	// when
+	code, res := handler.HandleDelete(context.Background(), project.DeleteProjectRequest{UserID: 7, ID: 1, IfMatch: `"3"`})
+	current, ok := res.(*project.ProjectResponse)
+
+	// then
+	assert.Equal(t, http.StatusPreconditionFailed, code)
+	assert.True(t, ok, "Expected *project.ProjectResponse type")
+	assert.Equal(t, "Renamed", current.Name)
+	assert.Equal(t, `"4"`, current.ETag())
+}
+
+func TestDelete_RequiresIfMatch(t *testing.T) {
+	// given
+	handler := project.NewProjectHandler(project.NewProjectService(projectmocks.NewProjectRepository(t), passThroughTxManager{}, &recordingPublisher{}, nil))
+
+	// when
+	code, res := handler.HandleDelete(context.Background(), project.DeleteProjectRequest{UserID: 7, ID: 1})
+
+	// then
+	assert.Equal(t, http.StatusPreconditionRequired, code)
+	assert.Equal(t, project.ErrorResponse{Error: etag.ErrMissingIfMatch.Error()}, res)
+}
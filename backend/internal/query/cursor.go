@@ -0,0 +1,34 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("cursor is invalid or was issued for a different sort")
+
+// cursor is the position after the last row of a page: the values of that row's sort
+// columns, id last. Clients only ever see it base64-encoded.
+type cursor struct {
+	Sort   string `json:"s"`
+	Order  string `json:"o"`
+	Values []any  `json:"v"`
+}
+
+func (c cursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(encoded string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
@@ -0,0 +1,149 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter is one condition of a list query. Constructors return nil for a condition the
+// client did not ask for, and nil filters are skipped.
+type Filter interface {
+	appendTo(w *where)
+}
+
+type where struct {
+	conditions []string
+	args       []any
+}
+
+func (w *where) arg(value any) string {
+	w.args = append(w.args, value)
+	return "$" + strconv.Itoa(len(w.args))
+}
+
+func (w *where) add(filters ...Filter) {
+	for _, filter := range filters {
+		if filter != nil {
+			filter.appendTo(w)
+		}
+	}
+}
+
+func (w *where) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+type equal struct {
+	column string
+	value  any
+}
+
+func (f equal) appendTo(w *where) {
+	w.conditions = append(w.conditions, f.column+" = "+w.arg(f.value))
+}
+
+// Equal matches column = value.
+func Equal(column string, value any) Filter {
+	return equal{column: column, value: value}
+}
+
+// EqualIfSet is Equal for optional query parameters.
+func EqualIfSet[T any](column string, value *T) Filter {
+	if value == nil {
+		return nil
+	}
+	return equal{column: column, value: *value}
+}
+
+type in[T any] struct {
+	column string
+	values []T
+}
+
+func (f in[T]) appendTo(w *where) {
+	placeholders := make([]string, 0, len(f.values))
+	for _, value := range f.values {
+		placeholders = append(placeholders, w.arg(value))
+	}
+	w.conditions = append(w.conditions, f.column+" IN ("+strings.Join(placeholders, ", ")+")")
+}
+
+// In matches any of values. No values means no condition.
+func In[T any](column string, values []T) Filter {
+	if len(values) == 0 {
+		return nil
+	}
+	return in[T]{column: column, values: values}
+}
+
+type between struct {
+	column   string
+	from, to *time.Time
+}
+
+func (f between) appendTo(w *where) {
+	if f.from != nil {
+		w.conditions = append(w.conditions, f.column+" >= "+w.arg(*f.from))
+	}
+	if f.to != nil {
+		w.conditions = append(w.conditions, f.column+" < "+w.arg(*f.to))
+	}
+}
+
+// Between matches from <= column < to. Either bound may be nil.
+func Between(column string, from *time.Time, to *time.Time) Filter {
+	if from == nil && to == nil {
+		return nil
+	}
+	return between{column: column, from: from, to: to}
+}
+
+type present struct {
+	column string
+	want   bool
+}
+
+func (f present) appendTo(w *where) {
+	if f.want {
+		w.conditions = append(w.conditions, f.column+" IS NOT NULL")
+	} else {
+		w.conditions = append(w.conditions, f.column+" IS NULL")
+	}
+}
+
+// Present matches rows where column is set (true) or empty (false).
+func Present(column string, want *bool) Filter {
+	if want == nil {
+		return nil
+	}
+	return present{column: column, want: *want}
+}
+
+type raw struct {
+	condition string
+	args      []any
+}
+
+func (f raw) appendTo(w *where) {
+	var condition strings.Builder
+	next := 0
+	for _, r := range f.condition {
+		if r == '?' {
+			condition.WriteString(w.arg(f.args[next]))
+			next++
+			continue
+		}
+		condition.WriteRune(r)
+	}
+	w.conditions = append(w.conditions, condition.String())
+}
+
+// Raw is a condition written in SQL for what the other filters cannot express, such as
+// subqueries. Each ? is replaced by the next argument.
+func Raw(condition string, args ...any) Filter {
+	return raw{condition: condition, args: args}
+}
@@ -0,0 +1,156 @@
+package query
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownSort = errors.New("unknown sort or order")
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 500
+)
+
+const (
+	Asc  = "asc"
+	Desc = "desc"
+)
+
+// SortKey is a sort a client may ask for by Name. Rows are ordered by Columns and then by
+// id, which makes the order total so a keyset cursor can resume after any row. Order is
+// used when the client names no order, and defaults to Asc.
+type SortKey struct {
+	Name    string
+	Columns []string
+	Order   string
+}
+
+// Sorts whitelists the sort keys of one resource. The first key is the default.
+type Sorts []SortKey
+
+func (s Sorts) find(name string) (SortKey, bool) {
+	if name == "" {
+		return s[0], true
+	}
+	for _, key := range s {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return SortKey{}, false
+}
+
+// Names lists the sort keys, e.g. for a `oneof` binding rule or documentation.
+func (s Sorts) Names() []string {
+	names := make([]string, 0, len(s))
+	for _, key := range s {
+		names = append(names, key.Name)
+	}
+	return names
+}
+
+// Spec is a parsed list request: which rows, in which order, and where the page starts.
+type Spec struct {
+	Filters []Filter
+	sort    SortKey
+	order   string
+	limit   int
+	after   *cursor
+}
+
+// NewSpec checks the paging parameters of a request against the resource's sorts. An
+// empty sort, order or limit takes the default, and a cursor must come from a page with
+// the same sort and order.
+func NewSpec(sorts Sorts, sort string, order string, encodedCursor string, limit int) (*Spec, error) {
+	key, ok := sorts.find(sort)
+	if order == "" {
+		order = key.Order
+	}
+	if order == "" {
+		order = Asc
+	}
+	if !ok || (order != Asc && order != Desc) {
+		return nil, ErrUnknownSort
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	spec := &Spec{sort: key, order: order, limit: limit}
+	if encodedCursor != "" {
+		after, err := decodeCursor(encodedCursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.Sort != key.Name || after.Order != order || len(after.Values) != len(key.Columns)+1 {
+			return nil, ErrInvalidCursor
+		}
+		spec.after = after
+	}
+	return spec, nil
+}
+
+func (s *Spec) Limit() int {
+	return s.limit
+}
+
+// Statement is SQL with its positional arguments.
+type Statement struct {
+	SQL  string
+	Args []any
+}
+
+// Build returns the page query, which reads one row more than the limit to learn whether
+// another page exists, and the count query over the same filters without the cursor.
+func (s *Spec) Build(columns string, from string, scope ...Filter) (page Statement, count Statement) {
+	filtered := where{}
+	filtered.add(scope...)
+	filtered.add(s.Filters...)
+	count = Statement{SQL: "SELECT COUNT(*) FROM " + from + filtered.String(), Args: filtered.args}
+
+	paged := where{conditions: append([]string{}, filtered.conditions...), args: append([]any{}, filtered.args...)}
+	orderColumns := append(append([]string{}, s.sort.Columns...), "id")
+	if s.after != nil {
+		values := make([]string, 0, len(orderColumns))
+		for _, value := range s.after.Values {
+			values = append(values, paged.arg(value))
+		}
+		operator := ">"
+		if s.order == Desc {
+			operator = "<"
+		}
+		paged.conditions = append(paged.conditions,
+			"("+strings.Join(orderColumns, ", ")+") "+operator+" ("+strings.Join(values, ", ")+")")
+	}
+
+	direction := strings.ToUpper(s.order)
+	orderBy := make([]string, 0, len(orderColumns))
+	for _, column := range orderColumns {
+		orderBy = append(orderBy, column+" "+direction)
+	}
+	page = Statement{
+		SQL: "SELECT " + columns + " FROM " + from + paged.String() +
+			" ORDER BY " + strings.Join(orderBy, ", ") + " LIMIT " + strconv.Itoa(s.limit+1),
+		Args: paged.args,
+	}
+	return page, count
+}
+
+// Page trims rows read with Build to the limit and returns the cursor of the next page,
+// or nil on the last page. value returns a row's value of a sort column or of "id".
+func Page[T any](spec *Spec, rows []T, value func(row T, column string) any) ([]T, *string) {
+	if len(rows) <= spec.limit {
+		return rows, nil
+	}
+	rows = rows[:spec.limit]
+	last := rows[len(rows)-1]
+	values := make([]any, 0, len(spec.sort.Columns)+1)
+	for _, column := range append(append([]string{}, spec.sort.Columns...), "id") {
+		values = append(values, value(last, column))
+	}
+	next := cursor{Sort: spec.sort.Name, Order: spec.order, Values: values}.encode()
+	return rows, &next
+}
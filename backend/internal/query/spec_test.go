@@ -0,0 +1,129 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/query"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var itemSorts = query.Sorts{
+	{Name: "position", Columns: []string{"status", "position"}},
+	{Name: "created_at", Columns: []string{"created_at"}},
+}
+
+type item struct {
+	ID       int64
+	Status   string
+	Position int
+}
+
+func itemKey(row item, column string) any {
+	switch column {
+	case "status":
+		return row.Status
+	case "position":
+		return row.Position
+	}
+	return row.ID
+}
+
+func TestBuild_CombinesScopeAndFilters(t *testing.T) {
+	// given
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	projectID := 3
+	spec, err := query.NewSpec(itemSorts, "", "", "", 0)
+	assert.NoError(t, err)
+	spec.Filters = []query.Filter{
+		query.In("status", []string{"inbox", "done"}),
+		query.EqualIfSet("project_id", &projectID),
+		query.EqualIfSet[int]("position", nil),
+		query.Between("created_at", &from, nil),
+		query.In[string]("title", nil),
+	}
+
+	// when
+	page, count := spec.Build("id, status", "todos", query.Equal("user_id", 7))
+
+	// then
+	assert.Equal(t, "SELECT COUNT(*) FROM todos WHERE user_id = $1 AND status IN ($2, $3) AND project_id = $4 AND created_at >= $5", count.SQL)
+	assert.Equal(t, []any{7, "inbox", "done", 3, from}, count.Args)
+	assert.Equal(t, "SELECT id, status FROM todos WHERE user_id = $1 AND status IN ($2, $3) AND project_id = $4 AND created_at >= $5 "+
+		"ORDER BY status ASC, position ASC, id ASC LIMIT 101", page.SQL)
+	assert.Equal(t, count.Args, page.Args)
+}
+
+func TestPage_ReturnsCursorThatResumesAfterLastRow(t *testing.T) {
+	// given
+	first, err := query.NewSpec(itemSorts, "position", "desc", "", 2)
+	assert.NoError(t, err)
+	rows := []item{{ID: 9, Status: "next_actions", Position: 2}, {ID: 4, Status: "next_actions", Position: 1}, {ID: 5, Status: "inbox", Position: 0}}
+
+	// when
+	rows, next := query.Page(first, rows, itemKey)
+	second, err := query.NewSpec(itemSorts, "position", "desc", *next, 2)
+	page, _ := second.Build("id", "todos", query.Equal("user_id", 7))
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "SELECT id FROM todos WHERE user_id = $1 AND (status, position, id) < ($2, $3, $4) "+
+		"ORDER BY status DESC, position DESC, id DESC LIMIT 3", page.SQL)
+	assert.Equal(t, []any{7, "next_actions", 1.0, 4.0}, page.Args)
+}
+
+func TestPage_LastPageHasNoCursor(t *testing.T) {
+	// given
+	spec, _ := query.NewSpec(itemSorts, "", "", "", 2)
+
+	// when
+	rows, next := query.Page(spec, []item{{ID: 1}, {ID: 2}}, itemKey)
+
+	// then
+	assert.Len(t, rows, 2)
+	assert.Nil(t, next)
+}
+
+func TestNewSpec_RejectsCursorFromAnotherSort(t *testing.T) {
+	// given
+	spec, _ := query.NewSpec(itemSorts, "position", "", "", 1)
+	_, next := query.Page(spec, []item{{ID: 1}, {ID: 2}}, itemKey)
+
+	// when
+	_, sortErr := query.NewSpec(itemSorts, "created_at", "", *next, 1)
+	_, orderErr := query.NewSpec(itemSorts, "position", "desc", *next, 1)
+	_, garbageErr := query.NewSpec(itemSorts, "position", "", "not-a-cursor", 1)
+
+	// then
+	assert.ErrorIs(t, sortErr, query.ErrInvalidCursor)
+	assert.ErrorIs(t, orderErr, query.ErrInvalidCursor)
+	assert.ErrorIs(t, garbageErr, query.ErrInvalidCursor)
+}
+
+func TestNewSpec_RejectsUnknownSortAndCapsLimit(t *testing.T) {
+	// when
+	_, sortErr := query.NewSpec(itemSorts, "title", "", "", 0)
+	_, orderErr := query.NewSpec(itemSorts, "", "sideways", "", 0)
+	capped, _ := query.NewSpec(itemSorts, "", "", "", 10000)
+
+	// then
+	assert.ErrorIs(t, sortErr, query.ErrUnknownSort)
+	assert.ErrorIs(t, orderErr, query.ErrUnknownSort)
+	assert.Equal(t, query.MaxLimit, capped.Limit())
+}
+
+func TestBuild_RawConditionNumbersItsArguments(t *testing.T) {
+	// given
+	spec, _ := query.NewSpec(itemSorts, "", "", "", 0)
+	spec.Filters = []query.Filter{
+		query.Raw("id IN (SELECT todo_id FROM todo_tags WHERE tag_id IN (?, ?))", 4, 5),
+	}
+
+	// when
+	_, count := spec.Build("id", "todos", query.Equal("user_id", 7))
+
+	// then
+	assert.Equal(t, "SELECT COUNT(*) FROM todos WHERE user_id = $1 AND id IN (SELECT todo_id FROM todo_tags WHERE tag_id IN ($2, $3))", count.SQL)
+	assert.Equal(t, []any{7, 4, 5}, count.Args)
+}
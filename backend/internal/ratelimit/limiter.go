@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Policy is a token bucket that holds up to Limit tokens and refills Limit tokens per Window.
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// Validate rejects a policy that could never refill. Limits and windows come from the
+// environment, so a zero there would otherwise surface as NaN or infinite wait times.
+func (p Policy) Validate() error {
+	if p.Limit <= 0 {
+		return fmt.Errorf("rate limit %v must allow at least one request. got=%v", p.Name, p.Limit)
+	}
+	if p.Window <= 0 {
+		return fmt.Errorf("rate limit %v must have a positive window. got=%v", p.Name, p.Window)
+	}
+	return nil
+}
+
+func (p Policy) refillPerSecond() float64 {
+	return float64(p.Limit) / p.Window.Seconds()
+}
+
+// Header renders the policy in the RateLimit-Policy header format, e.g. "10;w=60".
+func (p Policy) Header() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, int(p.Window.Seconds()))
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+type Store interface {
+	// Take refills the bucket for key up to now and consumes one token when available.
+	// It returns the tokens left after the call and whether a token was consumed.
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (tokens float64, allowed bool, err error)
+}
+
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+func NewLimiter(store Store, now func() time.Time) *Limiter {
+	return &Limiter{store: store, now: now}
+}
+
+func (l *Limiter) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	tokens, allowed, err := l.store.Take(ctx, policy.Name+":"+key, policy, l.now())
+	if err != nil {
+		return Result{}, err
+	}
+	return newResult(policy, tokens, allowed), nil
+}
+
+func newResult(policy Policy, tokens float64, allowed bool) Result {
+	rate := policy.refillPerSecond()
+	result := Result{
+		Allowed:    allowed,
+		Limit:      policy.Limit,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: secondsToDuration((float64(policy.Limit) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	return result
+}
+
+// secondsToDuration rounds up to whole seconds, the resolution of the rate limit headers.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(math.Max(seconds, 0))) * time.Second
+}
+
+func refill(tokens float64, updatedAt time.Time, policy Policy, now time.Time) float64 {
+	elapsed := now.Sub(updatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(policy.Limit), tokens+elapsed*policy.refillPerSecond())
+}
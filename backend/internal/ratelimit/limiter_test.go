@@ -0,0 +1,116 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+var authPolicy = ratelimit.Policy{Name: "auth", Limit: 3, Window: time.Minute}
+
+func TestAllow_ConsumesUntilEmpty(t *testing.T) {
+	// given
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), clock.Now)
+	ctx := context.Background()
+
+	// when
+	first, _ := limiter.Allow(ctx, "ip:1.2.3.4", authPolicy)
+	_, _ = limiter.Allow(ctx, "ip:1.2.3.4", authPolicy)
+	third, _ := limiter.Allow(ctx, "ip:1.2.3.4", authPolicy)
+	denied, _ := limiter.Allow(ctx, "ip:1.2.3.4", authPolicy)
+
+	// then
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 2, first.Remaining)
+	assert.Equal(t, 3, first.Limit)
+	assert.Equal(t, 20*time.Second, first.ResetAfter)
+	assert.True(t, third.Allowed)
+	assert.Equal(t, 0, third.Remaining)
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 20*time.Second, denied.RetryAfter)
+	assert.Equal(t, time.Minute, denied.ResetAfter)
+}
+
+func TestAllow_RefillsOverTime(t *testing.T) {
+	// given
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), clock.Now)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, _ = limiter.Allow(ctx, "user:1", authPolicy)
+	}
+
+	// when
+	clock.Advance(20 * time.Second)
+	refilled, _ := limiter.Allow(ctx, "user:1", authPolicy)
+	emptyAgain, _ := limiter.Allow(ctx, "user:1", authPolicy)
+
+	// then
+	assert.True(t, refilled.Allowed)
+	assert.False(t, emptyAgain.Allowed)
+}
+
+func TestAllow_RefillNeverExceedsLimit(t *testing.T) {
+	// given
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), clock.Now)
+	ctx := context.Background()
+	_, _ = limiter.Allow(ctx, "user:1", authPolicy)
+
+	// when
+	clock.Advance(time.Hour)
+	result, _ := limiter.Allow(ctx, "user:1", authPolicy)
+
+	// then
+	assert.Equal(t, 2, result.Remaining)
+}
+
+func TestAllow_KeysAndPoliciesAreIsolated(t *testing.T) {
+	// given
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), clock.Now)
+	readPolicy := ratelimit.Policy{Name: "read", Limit: 3, Window: time.Minute}
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, _ = limiter.Allow(ctx, "user:1", authPolicy)
+	}
+
+	// when
+	otherUser, _ := limiter.Allow(ctx, "user:2", authPolicy)
+	otherPolicy, _ := limiter.Allow(ctx, "user:1", readPolicy)
+
+	// then
+	assert.True(t, otherUser.Allowed)
+	assert.True(t, otherPolicy.Allowed)
+}
+
+func TestPolicyHeader(t *testing.T) {
+	assert.Equal(t, "3;w=60", authPolicy.Header())
+}
+
+func TestValidate_RejectsPoliciesThatNeverRefill(t *testing.T) {
+	// when
+	zeroLimit := ratelimit.Policy{Name: "read", Limit: 0, Window: time.Minute}.Validate()
+	zeroWindow := ratelimit.Policy{Name: "read", Limit: 10}.Validate()
+
+	// then
+	assert.Error(t, zeroLimit)
+	assert.Error(t, zeroWindow)
+	assert.NoError(t, authPolicy.Validate())
+}
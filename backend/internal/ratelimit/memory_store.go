@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepEvery = 1000
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	window    time.Duration
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+}
+
+func NewMemoryStore() *memoryStore {
+	return &memoryStore{buckets: map[string]*bucket{}}
+}
+
+func (s *memoryStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (float64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.takes++
+	if s.takes%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Limit), updatedAt: now, window: policy.Window}
+		s.buckets[key] = b
+	}
+
+	b.tokens = refill(b.tokens, b.updatedAt, policy, now)
+	b.updatedAt = now
+	if b.tokens < 1 {
+		return b.tokens, false, nil
+	}
+	b.tokens--
+	return b.tokens, true, nil
+}
+
+// sweep drops buckets that have been idle long enough to be full again.
+func (s *memoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.updatedAt) > b.window {
+			delete(s.buckets, key)
+		}
+	}
+}
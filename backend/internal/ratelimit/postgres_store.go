@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresStore struct {
+	db *sqlx.DB
+}
+
+// NewPostgresStore shares buckets between every instance connected to the same database.
+func NewPostgresStore(db *sqlx.DB) *postgresStore {
+	return &postgresStore{db: db}
+}
+
+// Take refills and consumes in one upsert. The update reads the locked row, so concurrent
+// requests for the same key are serialized across instances.
+func (s *postgresStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (float64, bool, error) {
+	var result struct {
+		Tokens  float64 `db:"tokens"`
+		Allowed bool    `db:"allowed"`
+	}
+	const refilled = `LEAST($2::DOUBLE PRECISION, rate_limit_buckets.tokens +
+		GREATEST(EXTRACT(EPOCH FROM ($3::TIMESTAMPTZ - rate_limit_buckets.updated_at)), 0) * $4::DOUBLE PRECISION)`
+	err := s.db.GetContext(ctx, &result, `
+		INSERT INTO rate_limit_buckets (key, tokens, allowed, updated_at)
+		VALUES ($1, $2::DOUBLE PRECISION - 1, true, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE WHEN `+refilled+` >= 1 THEN `+refilled+` - 1 ELSE `+refilled+` END,
+			allowed = `+refilled+` >= 1,
+			updated_at = $3
+		RETURNING tokens, allowed`,
+		key, policy.Limit, now, policy.refillPerSecond())
+	if err != nil {
+		return 0, false, err
+	}
+	return result.Tokens, result.Allowed, nil
+}
+
+// Prune drops buckets untouched since before. Pass a time at least one window back: by
+// then a bucket has refilled and recreating it on the next request changes nothing.
+func (s *postgresStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM rate_limit_buckets WHERE updated_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
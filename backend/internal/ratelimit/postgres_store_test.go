@@ -0,0 +1,86 @@
+package ratelimit_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/ratelimit"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresStore_ConsumesAndRefills(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(ratelimit.NewPostgresStore(testhelper.GetTestDB()), clock.Now)
+	ctx := context.Background()
+
+	// when
+	var results []ratelimit.Result
+	for i := 0; i < 4; i++ {
+		result, err := limiter.Allow(ctx, "ip:1.2.3.4", authPolicy)
+		assert.NoError(t, err)
+		results = append(results, result)
+	}
+	clock.Advance(20 * time.Second)
+	refilled, _ := limiter.Allow(ctx, "ip:1.2.3.4", authPolicy)
+
+	// then
+	assert.Equal(t, []bool{true, true, true, false}, []bool{
+		results[0].Allowed, results[1].Allowed, results[2].Allowed, results[3].Allowed,
+	})
+	assert.Equal(t, 2, results[0].Remaining)
+	assert.True(t, refilled.Allowed)
+}
+
+func TestPostgresStore_ConcurrentTakesNeverOverspend(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := ratelimit.NewLimiter(ratelimit.NewPostgresStore(testhelper.GetTestDB()), func() time.Time { return now })
+	policy := ratelimit.Policy{Name: "write", Limit: 5, Window: time.Hour}
+	_, _ = limiter.Allow(context.Background(), "user:1", policy)
+
+	// when
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	allowed := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := limiter.Allow(context.Background(), "user:1", policy)
+			if err == nil && result.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// then
+	assert.Equal(t, 4, allowed)
+}
+
+func TestPostgresStore_PruneDropsIdleBuckets(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := ratelimit.NewPostgresStore(testhelper.GetTestDB())
+	limiter := ratelimit.NewLimiter(store, clock.Now)
+	ctx := context.Background()
+	_, _ = limiter.Allow(ctx, "ip:1.2.3.4", authPolicy)
+	clock.Advance(2 * time.Minute)
+	_, _ = limiter.Allow(ctx, "ip:5.6.7.8", authPolicy)
+
+	// when
+	deleted, err := store.Prune(ctx, clock.Now().Add(-authPolicy.Window))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
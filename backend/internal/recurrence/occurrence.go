@@ -0,0 +1,203 @@
+package recurrence
+
+import "time"
+
+// maxPeriods bounds the search for the next occurrence of rules that rarely or never match,
+// such as BYMONTHDAY=1;BYDAY=5MO.
+const maxPeriods = 1000
+
+// Days are handled as midnight UTC so that stepping through the calendar never meets a
+// DST change. Only UNTIL needs the real instant, and At places a day on the user's wall
+// clock for that.
+
+// Next returns the day of the occurrence after the nth one, which falls on day. clock is
+// the time of day of the occurrences and loc the user's timezone. It reports false when
+// the series ends with the nth occurrence.
+func (r *Rule) Next(day time.Time, n int, clock time.Duration, loc *time.Location) (time.Time, bool) {
+	if r.Count > 0 && n >= r.Count {
+		return time.Time{}, false
+	}
+	start := Day(day)
+	next, ok := r.search(start, start, r.Interval, false)
+	if !ok || !r.before(next, clock, loc) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// NextAfterCompletion returns the day of the occurrence that follows completing the nth one
+// at completedAt. FREQ and INTERVAL count from the day it was completed in loc, and BYDAY
+// or BYMONTHDAY then pick the first matching day from there. A month later than January
+// 31 is the last day of February.
+func (r *Rule) NextAfterCompletion(completedAt time.Time, n int, clock time.Duration, loc *time.Location) (time.Time, bool) {
+	if r.Count > 0 && n >= r.Count {
+		return time.Time{}, false
+	}
+	from := Day(completedAt.In(loc))
+	switch r.Freq {
+	case Daily:
+		from = from.AddDate(0, 0, r.Interval)
+	case Weekly:
+		from = from.AddDate(0, 0, 7*r.Interval)
+	case Monthly:
+		from = addMonths(from, r.Interval)
+	case Yearly:
+		from = addMonths(from, 12*r.Interval)
+	}
+
+	next := from
+	if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+		var ok bool
+		if next, ok = r.search(from, from, 1, true); !ok {
+			return time.Time{}, false
+		}
+	}
+	if !r.before(next, clock, loc) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// At places a day on the wall clock of loc the way Postgres reads a local time: a time
+// skipped by a DST change moves forward by the gap, and a time that happens twice takes
+// the offset in effect after the change.
+func At(day time.Time, clock time.Duration, loc *time.Location) time.Time {
+	y, m, d := day.Date()
+	wall := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Add(clock)
+	_, before := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := wall.Add(24 * time.Hour).In(loc).Zone()
+	if at := wall.Add(-time.Duration(after) * time.Second).In(loc); Day(at).Equal(Day(wall)) && sinceMidnight(at) == clock {
+		return at
+	}
+	return wall.Add(-time.Duration(before) * time.Second).In(loc)
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+// Day returns the calendar day of t as midnight UTC.
+func Day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// before reports whether an occurrence on day is still within UNTIL.
+func (r *Rule) before(day time.Time, clock time.Duration, loc *time.Location) bool {
+	switch {
+	case r.Until == nil:
+		return true
+	case r.UntilDate:
+		return !day.After(*r.Until)
+	default:
+		return !At(day, clock, loc).After(*r.Until)
+	}
+}
+
+// search walks the periods of the series anchored at anchor, every interval-th one, and
+// returns the first matching day after from, or on it when inclusive.
+func (r *Rule) search(anchor time.Time, from time.Time, interval int, inclusive bool) (time.Time, bool) {
+	first := r.periodStart(anchor)
+	for i := 0; i < maxPeriods; i++ {
+		start := r.step(first, i*interval)
+		end := r.step(start, 1)
+		// A day and the instants on it are at most a day apart in any timezone.
+		if r.Until != nil && start.Sub(*r.Until) > 48*time.Hour {
+			return time.Time{}, false
+		}
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			if (day.After(from) || inclusive && day.Equal(from)) && r.matches(day, anchor) {
+				return day, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *Rule) periodStart(day time.Time) time.Time {
+	switch r.Freq {
+	case Weekly:
+		return day.AddDate(0, 0, -int((day.Weekday()-r.WeekStart+7)%7))
+	case Monthly:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case Yearly:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func (r *Rule) step(start time.Time, periods int) time.Time {
+	switch r.Freq {
+	case Weekly:
+		return start.AddDate(0, 0, 7*periods)
+	case Monthly:
+		return start.AddDate(0, periods, 0)
+	case Yearly:
+		return start.AddDate(periods, 0, 0)
+	default:
+		return start.AddDate(0, 0, periods)
+	}
+}
+
+// matches reports whether day is an occurrence. Without BYDAY or BYMONTHDAY the series
+// repeats the weekday, day of month or date of anchor.
+func (r *Rule) matches(day time.Time, anchor time.Time) bool {
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		switch r.Freq {
+		case Weekly:
+			return day.Weekday() == anchor.Weekday()
+		case Monthly:
+			return day.Day() == anchor.Day()
+		case Yearly:
+			return day.Month() == anchor.Month() && day.Day() == anchor.Day()
+		default:
+			return true
+		}
+	}
+	if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(day) {
+		return false
+	}
+	return len(r.ByDay) == 0 || r.matchesWeekday(day)
+}
+
+func (r *Rule) matchesMonthDay(day time.Time) bool {
+	last := daysIn(day.Year(), day.Month())
+	for _, want := range r.ByMonthDay {
+		if want > 0 && day.Day() == want || want < 0 && day.Day() == last+want+1 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday checks BYDAY. Positions count within the month for FREQ=MONTHLY and
+// within the year for FREQ=YEARLY.
+func (r *Rule) matchesWeekday(day time.Time) bool {
+	start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	if r.Freq == Yearly {
+		start = time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, 0)
+	}
+	daysBefore := int(day.Sub(start).Hours() / 24)
+	daysAfter := int(end.Sub(day).Hours()/24) - 1
+	fromStart, fromEnd := daysBefore/7+1, -(daysAfter/7 + 1)
+
+	for _, want := range r.ByDay {
+		if want.Weekday == day.Weekday() && (want.N == 0 || want.N == fromStart || want.N == fromEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+// addMonths moves a day by whole months, keeping it in the target month.
+func addMonths(day time.Time, months int) time.Time {
+	target := time.Date(day.Year(), day.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	return target.AddDate(0, 0, min(day.Day(), daysIn(target.Year(), target.Month()))-1)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,234 @@
+// Package recurrence reads RFC 5545 recurrence rules and steps through their occurrences
+// on a user's wall clock.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+const (
+	untilDateLayout    = "20060102"
+	untilInstantLayout = "20060102T150405Z"
+)
+
+var weekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Rule is the part of an RRULE todos use: FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT, UNTIL
+// and WKST.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	// Until is a calendar day when UntilDate is set, and a UTC instant otherwise.
+	Until     *time.Time
+	UntilDate bool
+	WeekStart time.Weekday
+}
+
+// WeekdayNum is a BYDAY entry: MO is every Monday, 2TU the second Tuesday and -1FR the last
+// Friday of the month, or of the year for FREQ=YEARLY.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Parse reads a rule such as FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH. An RRULE: prefix is allowed.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, errors.New("rule is empty")
+	}
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, val, _ := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if val == "" {
+			return nil, fmt.Errorf("%s needs a value", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%s appears more than once", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq, err = parseFrequency(val)
+		case "INTERVAL":
+			rule.Interval, err = parseNumber(name, val, 1, 1000)
+		case "COUNT":
+			rule.Count, err = parseNumber(name, val, 1, 1000)
+		case "UNTIL":
+			rule.Until, rule.UntilDate, err = parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(val)
+		case "WKST":
+			rule.WeekStart, err = parseWeekday(val)
+		default:
+			err = fmt.Errorf("%s is not supported", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == "" {
+		return errors.New("FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("COUNT and UNTIL cannot be used together")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	for _, day := range r.ByDay {
+		switch {
+		case day.N == 0:
+		case r.Freq == Monthly && (day.N < -5 || day.N > 5):
+			return fmt.Errorf("BYDAY %s is out of range for FREQ=MONTHLY", day)
+		case r.Freq != Monthly && r.Freq != Yearly:
+			return fmt.Errorf("BYDAY %s needs FREQ=MONTHLY or FREQ=YEARLY", day)
+		}
+	}
+	return nil
+}
+
+// String writes the rule back in a canonical order, which is how it is stored.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(untilDateLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilInstantLayout))
+		}
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdays[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func (d WeekdayNum) String() string {
+	if d.N == 0 {
+		return weekdays[d.Weekday]
+	}
+	return strconv.Itoa(d.N) + weekdays[d.Weekday]
+}
+
+func parseFrequency(value string) (Frequency, error) {
+	switch freq := Frequency(value); freq {
+	case Daily, Weekly, Monthly, Yearly:
+		return freq, nil
+	default:
+		return "", fmt.Errorf("FREQ=%s is not supported", value)
+	}
+}
+
+func parseNumber(name string, value string, low int, high int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < low || n > high {
+		return 0, fmt.Errorf("%s must be between %d and %d", name, low, high)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (*time.Time, bool, error) {
+	if until, err := time.Parse(untilDateLayout, value); err == nil {
+		return &until, true, nil
+	}
+	if until, err := time.Parse(untilInstantLayout, value); err == nil {
+		return &until, false, nil
+	}
+	return nil, false, errors.New("UNTIL must be a date like 20261231 or a UTC time like 20261231T235959Z")
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	i := slices.Index(weekdays, value)
+	if i < 0 {
+		return 0, fmt.Errorf("unknown weekday %q", value)
+	}
+	return time.Weekday(i), nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("unknown weekday %q", item)
+		}
+		split := len(item) - 2
+		weekday, err := parseWeekday(item[split:])
+		if err != nil {
+			return nil, err
+		}
+		day := WeekdayNum{Weekday: weekday}
+		if split > 0 {
+			day.N, err = strconv.Atoi(item[:split])
+			if err != nil || day.N == 0 || day.N < -53 || day.N > 53 {
+				return nil, fmt.Errorf("BYDAY %s has an invalid position", item)
+			}
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(item)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("BYMONTHDAY %s must be between 1 and 31, or -31 and -1", item)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
@@ -0,0 +1,257 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/recurrence"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_WritesRulesBackCanonically(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=weekly;byday=th,mo;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TH,MO"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=12", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=12"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;INTERVAL=1", "FREQ=MONTHLY;BYMONTHDAY=1,-1"},
+		{"FREQ=YEARLY;UNTIL=20301231", "FREQ=YEARLY;UNTIL=20301231"},
+		{"FREQ=WEEKLY;UNTIL=20270314T130000Z;WKST=SU", "FREQ=WEEKLY;UNTIL=20270314T130000Z;WKST=SU"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// when
+			rule, err := recurrence.Parse(tt.input)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"", "rule is empty"},
+		{"INTERVAL=2", "FREQ is required"},
+		{"FREQ=HOURLY", "FREQ=HOURLY is not supported"},
+		{"FREQ=DAILY;BYHOUR=9", "BYHOUR is not supported"},
+		{"FREQ=DAILY;FREQ=WEEKLY", "FREQ appears more than once"},
+		{"FREQ=DAILY;COUNT=", "COUNT needs a value"},
+		{"FREQ=DAILY;INTERVAL=0", "INTERVAL must be between 1 and 1000"},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20270101", "COUNT and UNTIL cannot be used together"},
+		{"FREQ=DAILY;UNTIL=2027-01-01", "UNTIL must be a date like 20261231 or a UTC time like 20261231T235959Z"},
+		{"FREQ=WEEKLY;BYDAY=XX", `unknown weekday "XX"`},
+		{"FREQ=WEEKLY;BYDAY=2MO", "BYDAY 2MO needs FREQ=MONTHLY or FREQ=YEARLY"},
+		{"FREQ=MONTHLY;BYDAY=6MO", "BYDAY 6MO is out of range for FREQ=MONTHLY"},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", "BYMONTHDAY cannot be used with FREQ=WEEKLY"},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", "BYMONTHDAY 32 must be between 1 and 31, or -31 and -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// when
+			_, err := recurrence.Parse(tt.input)
+
+			// then
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestNext_Calendar(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		day  string
+		want string
+	}{
+		{"every day", "FREQ=DAILY", "2027-01-10", "2027-01-11"},
+		{"every third day", "FREQ=DAILY;INTERVAL=3", "2027-01-10", "2027-01-13"},
+		{"weekdays only", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", "2027-01-08", "2027-01-11"},
+		{"same weekday", "FREQ=WEEKLY", "2027-01-06", "2027-01-13"},
+		{"later in the same week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2027-01-04", "2027-01-07"},
+		{"end of week skips the interval", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2027-01-07", "2027-01-18"},
+		{"week starting monday", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU", "2027-01-03", "2027-01-11"},
+		{"week starting sunday", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU;WKST=SU", "2027-01-03", "2027-01-04"},
+		{"same day of month", "FREQ=MONTHLY", "2027-01-15", "2027-02-15"},
+		{"31st skips short months", "FREQ=MONTHLY", "2027-01-31", "2027-03-31"},
+		{"bymonthday 31 skips short months", "FREQ=MONTHLY;BYMONTHDAY=31", "2027-01-31", "2027-03-31"},
+		{"last day of month", "FREQ=MONTHLY;BYMONTHDAY=-1", "2027-01-31", "2027-02-28"},
+		{"first and fifteenth", "FREQ=MONTHLY;BYMONTHDAY=1,15", "2027-01-01", "2027-01-15"},
+		{"second tuesday", "FREQ=MONTHLY;BYDAY=2TU", "2027-01-12", "2027-02-09"},
+		{"last friday", "FREQ=MONTHLY;BYDAY=-1FR", "2027-01-29", "2027-02-26"},
+		{"quarterly", "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1", "2027-01-01", "2027-04-01"},
+		{"friday the 13th", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", "2027-08-13", "2028-10-13"},
+		{"same date", "FREQ=YEARLY", "2027-04-05", "2028-04-05"},
+		{"leap day", "FREQ=YEARLY", "2028-02-29", "2032-02-29"},
+		{"first monday of the year", "FREQ=YEARLY;BYDAY=1MO", "2027-01-04", "2028-01-03"},
+		{"yearly bymonthday covers every month", "FREQ=YEARLY;BYMONTHDAY=1", "2027-01-01", "2027-02-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			rule, err := recurrence.Parse(tt.rule)
+			assert.NoError(t, err)
+
+			// when
+			next, ok := rule.Next(day(t, tt.day), 1, 0, time.UTC)
+
+			// then
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, next.Format(time.DateOnly))
+		})
+	}
+}
+
+func TestNext_SeriesEnds(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	tests := []struct {
+		name  string
+		rule  string
+		day   string
+		n     int
+		clock time.Duration
+		want  string
+	}{
+		{"before the last count", "FREQ=DAILY;COUNT=3", "2027-01-10", 2, 0, "2027-01-11"},
+		{"at the last count", "FREQ=DAILY;COUNT=3", "2027-01-10", 3, 0, ""},
+		{"until the date", "FREQ=DAILY;UNTIL=20270112", "2027-01-11", 5, 0, "2027-01-12"},
+		{"past the date", "FREQ=DAILY;UNTIL=20270112", "2027-01-12", 5, 0, ""},
+		// 09:00 on 2027-03-14 is 13:00 UTC, the first day of daylight saving time.
+		{"until right after spring forward", "FREQ=DAILY;UNTIL=20270314T130000Z", "2027-03-13", 1, 9 * time.Hour, "2027-03-14"},
+		// 09:00 on 2027-11-07 is 14:00 UTC, standard time again.
+		{"until right after fall back", "FREQ=DAILY;UNTIL=20271107T133000Z", "2027-11-06", 1, 9 * time.Hour, ""},
+		{"rule that never matches", "FREQ=MONTHLY;BYMONTHDAY=1;BYDAY=5MO", "2027-01-01", 1, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			rule, err := recurrence.Parse(tt.rule)
+			assert.NoError(t, err)
+
+			// when
+			next, ok := rule.Next(day(t, tt.day), tt.n, tt.clock, newYork)
+
+			// then
+			if tt.want == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, next.Format(time.DateOnly))
+		})
+	}
+}
+
+func TestNext_KeepsTheWallClockAcrossDST(t *testing.T) {
+	// given
+	newYork := mustLoad(t, "America/New_York")
+	rule, err := recurrence.Parse("FREQ=DAILY")
+	assert.NoError(t, err)
+
+	// when
+	next, ok := rule.Next(day(t, "2027-03-13"), 1, 9*time.Hour, newYork)
+
+	// then
+	assert.True(t, ok)
+	before := recurrence.At(day(t, "2027-03-13"), 9*time.Hour, newYork)
+	after := recurrence.At(next, 9*time.Hour, newYork)
+	assert.Equal(t, "2027-03-13T14:00:00Z", before.UTC().Format(time.RFC3339))
+	assert.Equal(t, "2027-03-14T13:00:00Z", after.UTC().Format(time.RFC3339))
+	assert.Equal(t, 23*time.Hour, after.Sub(before))
+}
+
+func TestAt_ReadsWallTimesLikePostgres(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	tests := []struct {
+		name  string
+		day   string
+		clock time.Duration
+		want  string
+	}{
+		{"before spring forward", "2027-03-14", 30 * time.Minute, "2027-03-14T00:30:00-05:00"},
+		{"skipped by spring forward", "2027-03-14", 2*time.Hour + 30*time.Minute, "2027-03-14T03:30:00-04:00"},
+		{"after spring forward", "2027-03-14", 9 * time.Hour, "2027-03-14T09:00:00-04:00"},
+		{"before fall back", "2027-11-07", 30 * time.Minute, "2027-11-07T00:30:00-04:00"},
+		{"repeated by fall back", "2027-11-07", time.Hour + 30*time.Minute, "2027-11-07T01:30:00-05:00"},
+		{"after fall back", "2027-11-07", 9 * time.Hour, "2027-11-07T09:00:00-05:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			at := recurrence.At(day(t, tt.day), tt.clock, newYork)
+
+			// then
+			assert.Equal(t, tt.want, at.Format(time.RFC3339))
+		})
+	}
+}
+
+func TestNextAfterCompletion(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	tests := []struct {
+		name        string
+		rule        string
+		completedAt string
+		want        string
+	}{
+		{"a day after", "FREQ=DAILY", "2027-01-10T15:00:00Z", "2027-01-11"},
+		{"two weeks after", "FREQ=WEEKLY;INTERVAL=2", "2027-01-06T15:00:00Z", "2027-01-20"},
+		{"a month after the 31st", "FREQ=MONTHLY", "2027-01-31T15:00:00Z", "2027-02-28"},
+		{"a year after a leap day", "FREQ=YEARLY", "2028-02-29T15:00:00Z", "2029-02-28"},
+		{"next friday a week later", "FREQ=WEEKLY;BYDAY=FR", "2027-01-06T15:00:00Z", "2027-01-15"},
+		{"first of the month after a month", "FREQ=MONTHLY;BYMONTHDAY=1", "2027-01-15T15:00:00Z", "2027-03-01"},
+		// 22:30 on 2027-03-13 in New York, the evening before spring forward.
+		{"evening before spring forward", "FREQ=DAILY", "2027-03-14T03:30:00Z", "2027-03-14"},
+		// 23:30 on 2027-11-07 in New York, the evening after fall back.
+		{"evening after fall back", "FREQ=DAILY", "2027-11-08T04:30:00Z", "2027-11-08"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			rule, err := recurrence.Parse(tt.rule)
+			assert.NoError(t, err)
+			completedAt, err := time.Parse(time.RFC3339, tt.completedAt)
+			assert.NoError(t, err)
+
+			// when
+			next, ok := rule.NextAfterCompletion(completedAt, 1, 0, newYork)
+
+			// then
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, next.Format(time.DateOnly))
+		})
+	}
+}
+
+func TestNextAfterCompletion_SeriesEnds(t *testing.T) {
+	// given
+	rule, err := recurrence.Parse("FREQ=WEEKLY;UNTIL=20270115")
+	assert.NoError(t, err)
+	completedAt := time.Date(2027, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	// when
+	_, ok := rule.NextAfterCompletion(completedAt, 1, 0, time.UTC)
+
+	// then
+	assert.False(t, ok)
+}
+
+func day(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.DateOnly, value)
+	assert.NoError(t, err)
+	return parsed
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	assert.NoError(t, err)
+	return loc
+}
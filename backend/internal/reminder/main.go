@@ -0,0 +1,14 @@
+//go:generate mockery
+package reminder
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitializeScheduler needs no transaction manager: a reminder is claimed by one statement
+// and delivered outside any transaction, so nothing is ever retried around a delivery.
+func InitializeScheduler(pool *sqlx.DB, notifier Notifier, interval time.Duration) *Scheduler {
+	return NewScheduler(NewReminderRepository(pool), notifier, time.Now, interval)
+}
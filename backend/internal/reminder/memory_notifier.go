@@ -0,0 +1,41 @@
+package reminder
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryNotifier records reminders instead of delivering them. Tests use it to see what
+// would have been sent.
+type MemoryNotifier struct {
+	mu   sync.Mutex
+	sent []Reminder
+	err  error
+}
+
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) Notify(ctx context.Context, reminder Reminder) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, reminder)
+	return nil
+}
+
+// FailWith makes every following Notify return err, or succeed again when err is nil.
+func (n *MemoryNotifier) FailWith(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.err = err
+}
+
+func (n *MemoryNotifier) Sent() []Reminder {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Reminder(nil), n.sent...)
+}
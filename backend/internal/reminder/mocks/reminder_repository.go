@@ -0,0 +1,196 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package remindermocks
+
+import (
+	context "context"
+	reminder "yangdongju/gtd_todo/internal/reminder"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReminderRepository is an autogenerated mock type for the ReminderRepository type
+type ReminderRepository struct {
+	mock.Mock
+}
+
+type ReminderRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReminderRepository) EXPECT() *ReminderRepository_Expecter {
+	return &ReminderRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDue provides a mock function with given fields: ctx, now, claimedUntil, limit
+func (_m *ReminderRepository) ClaimDue(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]reminder.Reminder, error) {
+	ret := _m.Called(ctx, now, claimedUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []reminder.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]reminder.Reminder, error)); ok {
+		return rf(ctx, now, claimedUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []reminder.Reminder); ok {
+		r0 = rf(ctx, now, claimedUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reminder.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, claimedUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReminderRepository_ClaimDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDue'
+type ReminderRepository_ClaimDue_Call struct {
+	*mock.Call
+}
+
+// ClaimDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - claimedUntil time.Time
+//   - limit int
+func (_e *ReminderRepository_Expecter) ClaimDue(ctx interface{}, now interface{}, claimedUntil interface{}, limit interface{}) *ReminderRepository_ClaimDue_Call {
+	return &ReminderRepository_ClaimDue_Call{Call: _e.mock.On("ClaimDue", ctx, now, claimedUntil, limit)}
+}
+
+func (_c *ReminderRepository_ClaimDue_Call) Run(run func(ctx context.Context, now time.Time, claimedUntil time.Time, limit int)) *ReminderRepository_ClaimDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ReminderRepository_ClaimDue_Call) Return(_a0 []reminder.Reminder, _a1 error) *ReminderRepository_ClaimDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReminderRepository_ClaimDue_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]reminder.Reminder, error)) *ReminderRepository_ClaimDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, _a1, reason
+func (_m *ReminderRepository) MarkFailed(ctx context.Context, _a1 reminder.Reminder, reason string) error {
+	ret := _m.Called(ctx, _a1, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, reminder.Reminder, string) error); ok {
+		r0 = rf(ctx, _a1, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReminderRepository_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type ReminderRepository_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 reminder.Reminder
+//   - reason string
+func (_e *ReminderRepository_Expecter) MarkFailed(ctx interface{}, _a1 interface{}, reason interface{}) *ReminderRepository_MarkFailed_Call {
+	return &ReminderRepository_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, _a1, reason)}
+}
+
+func (_c *ReminderRepository_MarkFailed_Call) Run(run func(ctx context.Context, _a1 reminder.Reminder, reason string)) *ReminderRepository_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(reminder.Reminder), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ReminderRepository_MarkFailed_Call) Return(_a0 error) *ReminderRepository_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReminderRepository_MarkFailed_Call) RunAndReturn(run func(context.Context, reminder.Reminder, string) error) *ReminderRepository_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSent provides a mock function with given fields: ctx, _a1, sentAt
+func (_m *ReminderRepository) MarkSent(ctx context.Context, _a1 reminder.Reminder, sentAt time.Time) error {
+	ret := _m.Called(ctx, _a1, sentAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, reminder.Reminder, time.Time) error); ok {
+		r0 = rf(ctx, _a1, sentAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReminderRepository_MarkSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSent'
+type ReminderRepository_MarkSent_Call struct {
+	*mock.Call
+}
+
+// MarkSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 reminder.Reminder
+//   - sentAt time.Time
+func (_e *ReminderRepository_Expecter) MarkSent(ctx interface{}, _a1 interface{}, sentAt interface{}) *ReminderRepository_MarkSent_Call {
+	return &ReminderRepository_MarkSent_Call{Call: _e.mock.On("MarkSent", ctx, _a1, sentAt)}
+}
+
+func (_c *ReminderRepository_MarkSent_Call) Run(run func(ctx context.Context, _a1 reminder.Reminder, sentAt time.Time)) *ReminderRepository_MarkSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(reminder.Reminder), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ReminderRepository_MarkSent_Call) Return(_a0 error) *ReminderRepository_MarkSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReminderRepository_MarkSent_Call) RunAndReturn(run func(context.Context, reminder.Reminder, time.Time) error) *ReminderRepository_MarkSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReminderRepository creates a new instance of ReminderRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReminderRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReminderRepository {
+	mock := &ReminderRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Notifier delivers one reminder. Key is the same every time the same reminder is handed
+// over, so receivers can drop a resend after a crash between delivery and bookkeeping.
+type Notifier interface {
+	Notify(ctx context.Context, reminder Reminder) error
+}
+
+const (
+	KindDue      = "due"
+	KindFollowUp = "follow_up"
+)
+
+// Reminder is a due reminder together with what a notification needs to say. A follow_up
+// reminder is about the follow-up date of a delegated todo, which its due fields carry.
+type Reminder struct {
+	ID            int64     `db:"id"`
+	TodoID        int       `db:"todo_id"`
+	Kind          string    `db:"kind"`
+	UserID        int       `db:"user_id"`
+	Email         string    `db:"email"`
+	Timezone      string    `db:"timezone"`
+	Title         string    `db:"title"`
+	DelegatedTo   *string   `db:"delegated_to"`
+	DueDate       time.Time `db:"due_date"`
+	DueTime       *string   `db:"due_time"`
+	DueAt         time.Time `db:"due_at"`
+	MinutesBefore int       `db:"minutes_before"`
+	RemindAt      time.Time `db:"remind_at"`
+}
+
+func (r Reminder) Key() string {
+	return fmt.Sprintf("reminder-%d-%d", r.ID, r.RemindAt.Unix())
+}
+
+// DueText says when the todo is due in the user's own timezone, with the time of day only
+// when the todo has one.
+func (r Reminder) DueText() string {
+	if r.DueTime == nil {
+		return r.DueDate.Format("Mon, 2 Jan 2006")
+	}
+	location, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	return r.DueAt.In(location).Format("Mon, 2 Jan 2006 15:04 MST")
+}
@@ -0,0 +1,90 @@
+package reminder
+
+import (
+	"context"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// MaxAttempts is how many failed deliveries a reminder gets before it is given up on.
+const MaxAttempts = 5
+
+type ReminderRepository interface {
+	ClaimDue(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]Reminder, error)
+	MarkSent(ctx context.Context, reminder Reminder, sentAt time.Time) error
+	MarkFailed(ctx context.Context, reminder Reminder, reason string) error
+}
+
+type reminderRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewReminderRepository(db *sqlx.DB) *reminderRepositoryImpl {
+	return &reminderRepositoryImpl{db: db}
+}
+
+// ClaimDue leases up to limit unsent reminders whose time has come, earliest first, until
+// claimedUntil. It is one statement, so the claim commits at once and no lock is held while
+// the reminders are delivered. Rows another instance is claiming are skipped, and a leased
+// reminder is not claimed again until its lease runs out.
+//
+// Reminders of done todos stay unsent, as do ones whose time had already passed when
+// they were scheduled. A follow_up reminder reads its due fields from the follow-up date.
+func (r *reminderRepositoryImpl) ClaimDue(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]Reminder, error) {
+	reminders := []Reminder{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &reminders, `
+		WITH due AS (
+			SELECT r.id
+			FROM reminders r
+			JOIN todos t ON t.id = r.todo_id
+			WHERE r.sent_at IS NULL AND r.remind_at <= $1 AND r.remind_at >= r.scheduled_at
+				AND r.attempts < $2 AND t.status <> 'done'
+				AND (r.claimed_until IS NULL OR r.claimed_until <= $1)
+			ORDER BY r.remind_at, r.id
+			LIMIT $3
+			FOR UPDATE OF r SKIP LOCKED
+		), claimed AS (
+			UPDATE reminders r SET claimed_until = $4
+			FROM due
+			WHERE r.id = due.id
+			RETURNING r.id, r.todo_id, r.kind, r.minutes_before, r.remind_at
+		)
+		SELECT r.id, r.todo_id, r.kind, t.user_id, u.email, u.timezone, t.title, t.delegated_to, d.due_date,
+			to_char(d.due_time, 'HH24:MI') AS due_time,
+			todo_due_at(d.due_date, d.due_time, u.timezone) AS due_at,
+			r.minutes_before, r.remind_at
+		FROM claimed r
+		JOIN todos t ON t.id = r.todo_id
+		JOIN users u ON u.id = t.user_id
+		CROSS JOIN LATERAL (
+			SELECT CASE WHEN r.kind = 'follow_up' THEN t.follow_up_date ELSE t.due_date END AS due_date,
+				CASE WHEN r.kind = 'follow_up' THEN NULL ELSE t.due_time END AS due_time
+		) d
+		ORDER BY r.remind_at, r.id`,
+		now, MaxAttempts, limit, claimedUntil)
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// MarkSent records the delivery and releases the lease. A reminder rescheduled while it
+// was being delivered is left armed for its new time.
+func (r *reminderRepositoryImpl) MarkSent(ctx context.Context, reminder Reminder, sentAt time.Time) error {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE reminders SET sent_at = $3, attempts = attempts + 1, last_error = NULL, claimed_until = NULL
+		WHERE id = $1 AND remind_at = $2`,
+		reminder.ID, reminder.RemindAt, sentAt)
+	return err
+}
+
+// MarkFailed records the failure and releases the lease, so the next run tries again.
+func (r *reminderRepositoryImpl) MarkFailed(ctx context.Context, reminder Reminder, reason string) error {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE reminders SET attempts = attempts + 1, last_error = $3, claimed_until = NULL
+		WHERE id = $1 AND remind_at = $2`,
+		reminder.ID, reminder.RemindAt, reason)
+	return err
+}
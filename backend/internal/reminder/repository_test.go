@@ -0,0 +1,156 @@
+package reminder_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/reminder"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func insertReminder(t *testing.T, userID int, status string, remindAt time.Time, scheduledAt time.Time) int64 {
+	db := testhelper.GetTestDB()
+	var todoID int
+	err := db.Get(&todoID, "INSERT INTO todos (user_id, title, status, due_date) VALUES ($1, 'File taxes', $2, CURRENT_DATE) RETURNING id", userID, status)
+	assert.NoError(t, err)
+	var id int64
+	err = db.Get(&id, "INSERT INTO reminders (todo_id, minutes_before, remind_at, scheduled_at) VALUES ($1, 0, $2, $3) RETURNING id",
+		todoID, remindAt, scheduledAt)
+	assert.NoError(t, err)
+	return id
+}
+
+func TestReminderRepository_ConcurrentClaimsNeverShareAReminder(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	now := time.Now()
+	first := insertReminder(t, userID, "inbox", now.Add(-2*time.Minute), now.Add(-time.Hour))
+	second := insertReminder(t, userID, "inbox", now.Add(-time.Minute), now.Add(-time.Hour))
+	_ = insertReminder(t, userID, "done", now.Add(-time.Minute), now.Add(-time.Hour))
+	_ = insertReminder(t, userID, "inbox", now.Add(-time.Minute), now)
+	repository := reminder.NewReminderRepository(db)
+	txManager := database.NewTxManager(db, database.WithIsolation(sql.LevelReadCommitted), database.WithMaxAttempts(1))
+
+	// when
+	var claimedFirst, claimedSecond []reminder.Reminder
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		var err error
+		if claimedFirst, err = repository.ClaimDue(ctx, now, now.Add(time.Minute), 1); err != nil {
+			return err
+		}
+		return txManager.WithinTx(context.Background(), func(other context.Context) error {
+			claimedSecond, err = repository.ClaimDue(other, now, now.Add(time.Minute), 10)
+			return err
+		})
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, claimedFirst, 1)
+	assert.Equal(t, first, claimedFirst[0].ID)
+	assert.Equal(t, "hello@example.com", claimedFirst[0].Email)
+	assert.Len(t, claimedSecond, 1)
+	assert.Equal(t, second, claimedSecond[0].ID)
+}
+
+func TestReminderRepository_LeasedRemindersWaitForTheLeaseToRunOut(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	now := time.Now()
+	id := insertReminder(t, userID, "inbox", now.Add(-time.Minute), now.Add(-time.Hour))
+	repository := reminder.NewReminderRepository(db)
+	ctx := context.Background()
+
+	// when
+	first, _ := repository.ClaimDue(ctx, now, now.Add(time.Minute), 10)
+	whileLeased, _ := repository.ClaimDue(ctx, now.Add(30*time.Second), now.Add(90*time.Second), 10)
+	afterLease, err := repository.ClaimDue(ctx, now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, first, 1)
+	assert.Empty(t, whileLeased)
+	assert.Len(t, afterLease, 1)
+	assert.Equal(t, id, afterLease[0].ID)
+}
+
+func TestReminderRepository_RescheduledWhileDeliveringStaysArmed(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	now := time.Now()
+	id := insertReminder(t, userID, "inbox", now.Add(-time.Minute), now.Add(-time.Hour))
+	repository := reminder.NewReminderRepository(db)
+	ctx := context.Background()
+	claimed, _ := repository.ClaimDue(ctx, now, now.Add(time.Minute), 10)
+	db.MustExec("UPDATE reminders SET remind_at = $2, claimed_until = NULL WHERE id = $1", id, now.Add(time.Hour))
+
+	// when
+	err := repository.MarkSent(ctx, claimed[0], now)
+	var sentAt sql.NullTime
+	_ = db.Get(&sentAt, "SELECT sent_at FROM reminders WHERE id = $1", id)
+
+	// then
+	assert.NoError(t, err)
+	assert.False(t, sentAt.Valid)
+}
+
+func TestReminderRepository_SentRemindersAreNotClaimedAgain(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	now := time.Now()
+	_ = insertReminder(t, userID, "inbox", now.Add(-time.Minute), now.Add(-time.Hour))
+	repository := reminder.NewReminderRepository(db)
+	ctx := context.Background()
+
+	// when
+	due, _ := repository.ClaimDue(ctx, now, now, 10)
+	markErr := repository.MarkSent(ctx, due[0], now)
+	claimed, claimErr := repository.ClaimDue(ctx, now, now, 10)
+
+	// then
+	assert.NoError(t, markErr)
+	assert.NoError(t, claimErr)
+	assert.Empty(t, claimed)
+}
+
+func TestReminderRepository_FollowUpRemindersCarryTheFollowUpDate(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID, todoID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	_ = db.Get(&todoID, `
+		INSERT INTO todos (user_id, title, status, delegated_to, follow_up_date)
+		VALUES ($1, 'Hear back from landlord', 'waiting_for', 'Landlord', '2026-10-19') RETURNING id`, userID)
+	now := time.Now()
+	db.MustExec("INSERT INTO reminders (todo_id, kind, minutes_before, remind_at, scheduled_at) VALUES ($1, 'follow_up', 0, $2, $3)",
+		todoID, now.Add(-time.Minute), now.Add(-time.Hour))
+	repository := reminder.NewReminderRepository(db)
+
+	// when
+	claimed, err := repository.ClaimDue(context.Background(), now, now.Add(time.Minute), 10)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, reminder.KindFollowUp, claimed[0].Kind)
+	assert.Equal(t, "Landlord", *claimed[0].DelegatedTo)
+	assert.Equal(t, "2026-10-19", claimed[0].DueDate.Format("2006-01-02"))
+	assert.Nil(t, claimed[0].DueTime)
+}
@@ -0,0 +1,90 @@
+package reminder
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	DefaultInterval = 30 * time.Second
+	batchSize       = 20
+	notifyTimeout   = 10 * time.Second
+	// claimLease outlasts a whole batch delivered one after another, so a reminder is
+	// claimed again only when the instance that claimed it has stopped.
+	claimLease = 2 * batchSize * notifyTimeout
+)
+
+// Scheduler hands due reminders to a Notifier. Every instance of the server runs one;
+// a lease on each reminder decides which instance delivers it.
+type Scheduler struct {
+	repository ReminderRepository
+	notifier   Notifier
+	now        func() time.Time
+	interval   time.Duration
+}
+
+func NewScheduler(repository ReminderRepository, notifier Notifier, now func() time.Time, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		repository: repository,
+		notifier:   notifier,
+		now:        now,
+		interval:   interval,
+	}
+}
+
+// Run delivers due reminders every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Reminder delivery failed. %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce delivers what is due now and reports how many reminders were sent. Each batch is
+// leased first, then delivered with no transaction or lock held, and each reminder is
+// marked sent only once its delivery succeeded. Failed deliveries wait for the next run.
+// An instance that stops between delivering and marking leaves the reminder to be sent
+// again when the lease runs out, under the same Key.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		now := s.now()
+		reminders, err := s.repository.ClaimDue(ctx, now, now.Add(claimLease), batchSize)
+		if err != nil {
+			return sent, err
+		}
+		failed := 0
+		for _, reminder := range reminders {
+			if err := s.deliver(ctx, reminder); err != nil {
+				log.Printf("Reminder not delivered. id=%v err=%v", reminder.ID, err)
+				failed++
+				if err := s.repository.MarkFailed(ctx, reminder, err.Error()); err != nil {
+					return sent, err
+				}
+				continue
+			}
+			if err := s.repository.MarkSent(ctx, reminder, s.now()); err != nil {
+				return sent, err
+			}
+			sent++
+		}
+		if len(reminders) < batchSize || failed > 0 {
+			return sent, nil
+		}
+	}
+}
+
+func (s *Scheduler) deliver(ctx context.Context, reminder Reminder) error {
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	return s.notifier.Notify(ctx, reminder)
+}
@@ -0,0 +1,76 @@
+package reminder_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/reminder"
+	remindermocks "yangdongju/gtd_todo/internal/reminder/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+func fixedNow() time.Time {
+	return now
+}
+
+// ============ Test Cases ============
+
+func TestRunOnce_MarksDeliveredRemindersSent(t *testing.T) {
+	// given
+	due := reminder.Reminder{ID: 1, TodoID: 4, UserID: 7, Email: "hello@example.com", Title: "File taxes", RemindAt: now.Add(-time.Minute)}
+	mockRepo := remindermocks.NewReminderRepository(t)
+	mockRepo.EXPECT().ClaimDue(mock.Anything, now, mock.Anything, mock.Anything).Return([]reminder.Reminder{due}, nil)
+	mockRepo.EXPECT().MarkSent(mock.Anything, due, now).Return(nil)
+
+	notifier := reminder.NewMemoryNotifier()
+	scheduler := reminder.NewScheduler(mockRepo, notifier, fixedNow, time.Minute)
+
+	// when
+	sent, err := scheduler.RunOnce(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []reminder.Reminder{due}, notifier.Sent())
+}
+
+func TestRunOnce_FailedDeliveryIsRecordedAndLeftForTheNextRun(t *testing.T) {
+	// given
+	mockRepo := remindermocks.NewReminderRepository(t)
+	mockRepo.EXPECT().ClaimDue(mock.Anything, now, mock.Anything, mock.Anything).Return([]reminder.Reminder{{ID: 1}, {ID: 2}}, nil).Once()
+	mockRepo.EXPECT().MarkFailed(mock.Anything, reminder.Reminder{ID: 1}, "smtp: connection refused").Return(nil)
+	mockRepo.EXPECT().MarkFailed(mock.Anything, reminder.Reminder{ID: 2}, "smtp: connection refused").Return(nil)
+
+	notifier := reminder.NewMemoryNotifier()
+	notifier.FailWith(errors.New("smtp: connection refused"))
+	scheduler := reminder.NewScheduler(mockRepo, notifier, fixedNow, time.Minute)
+
+	// when
+	sent, err := scheduler.RunOnce(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, notifier.Sent())
+}
+
+func TestReminder_DueTextUsesTheUsersTimezone(t *testing.T) {
+	// given
+	dueTime := "14:30"
+	withTime := reminder.Reminder{
+		Timezone: "Asia/Seoul",
+		DueDate:  time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+		DueTime:  &dueTime,
+		DueAt:    time.Date(2026, 10, 23, 5, 30, 0, 0, time.UTC),
+	}
+	dateOnly := reminder.Reminder{Timezone: "Asia/Seoul", DueDate: time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)}
+
+	// then
+	assert.Equal(t, "Fri, 23 Oct 2026 14:30 KST", withTime.DueText())
+	assert.Equal(t, "Fri, 23 Oct 2026", dateOnly.DueText())
+}
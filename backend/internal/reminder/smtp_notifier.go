@@ -0,0 +1,135 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpNotifier struct {
+	config SMTPConfig
+	auth   smtp.Auth
+	now    func() time.Time
+}
+
+// NewSMTPNotifier mails reminders to the address the user signed up with. Without a
+// username the server is used unauthenticated.
+func NewSMTPNotifier(config SMTPConfig, now func() time.Time) *smtpNotifier {
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	return &smtpNotifier{config: config, auth: auth, now: now}
+}
+
+// Notify sends one message. Its Message-ID derives from the reminder key, so mail clients
+// show a resent reminder only once. The whole conversation with the server ends by ctx's
+// deadline, and cancelling ctx cuts it off.
+func (n *smtpNotifier) Notify(ctx context.Context, reminder Reminder) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(n.config.Host, n.config.Port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := n.send(conn, reminder); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
+
+// send follows smtp.SendMail: STARTTLS when the server offers it, then AUTH, then the
+// message itself.
+func (n *smtpNotifier) send(conn net.Conn, reminder Reminder) error {
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host}); err != nil {
+			return err
+		}
+	}
+	if n.auth != nil {
+		if err := client.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(n.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(reminder.Email); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(n.message(reminder)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (n *smtpNotifier) message(reminder Reminder) []byte {
+	domain := "localhost"
+	if at := strings.LastIndex(n.config.From, "@"); at >= 0 {
+		domain = n.config.From[at+1:]
+	}
+	prefix, when := "Reminder: ", "Due "
+	if reminder.Kind == KindFollowUp {
+		prefix, when = "Follow up: ", "Follow up on "
+	}
+	subject := mime.QEncoding.Encode("utf-8", prefix+singleLine(reminder.Title))
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&message, "To: %s\r\n", reminder.Email)
+	fmt.Fprintf(&message, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&message, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "Message-ID: <%s@%s>\r\n", reminder.Key(), domain)
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("\r\n")
+	fmt.Fprintf(&message, "%s\r\n\r\n%s%s\r\n", singleLine(reminder.Title), when, reminder.DueText())
+	if reminder.DelegatedTo != nil {
+		fmt.Fprintf(&message, "Waiting for %s\r\n", singleLine(*reminder.DelegatedTo))
+	}
+	return message.Bytes()
+}
+
+// singleLine keeps titles from adding headers of their own.
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
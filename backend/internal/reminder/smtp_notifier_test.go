@@ -0,0 +1,37 @@
+package reminder_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/reminder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMTPNotifier_GivesUpOnASilentServerAtTheDeadline(t *testing.T) {
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	notifier := reminder.NewSMTPNotifier(reminder.SMTPConfig{Host: host, Port: port, From: "gtd@example.com"}, time.Now)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// when
+	started := time.Now()
+	err = notifier.Notify(ctx, reminder.Reminder{ID: 1, Email: "hello@example.com", Title: "File taxes"})
+
+	// then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+}
@@ -0,0 +1,91 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	signatureHeader   = "X-Signature-256"
+)
+
+type webhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookNotifier posts reminders as JSON to url. With a secret, the body is signed
+// with HMAC-SHA256 in the X-Signature-256 header as "sha256=<hex>".
+func NewWebhookNotifier(url string, secret string, client *http.Client) *webhookNotifier {
+	return &webhookNotifier{url: url, secret: secret, client: client}
+}
+
+type webhookPayload struct {
+	Key           string    `json:"key"`
+	Kind          string    `json:"kind"`
+	TodoID        int       `json:"todo_id"`
+	UserID        int       `json:"user_id"`
+	Title         string    `json:"title"`
+	DelegatedTo   *string   `json:"delegated_to"`
+	DueDate       string    `json:"due_date"`
+	DueTime       *string   `json:"due_time"`
+	DueAt         time.Time `json:"due_at"`
+	Timezone      string    `json:"timezone"`
+	MinutesBefore int       `json:"minutes_before"`
+	RemindAt      time.Time `json:"remind_at"`
+}
+
+// Notify treats any status outside 2xx as a failed delivery. The reminder key goes in
+// the Idempotency-Key header.
+func (n *webhookNotifier) Notify(ctx context.Context, reminder Reminder) error {
+	body, err := json.Marshal(webhookPayload{
+		Key:           reminder.Key(),
+		Kind:          reminder.Kind,
+		TodoID:        reminder.TodoID,
+		UserID:        reminder.UserID,
+		Title:         reminder.Title,
+		DelegatedTo:   reminder.DelegatedTo,
+		DueDate:       reminder.DueDate.Format("2006-01-02"),
+		DueTime:       reminder.DueTime,
+		DueAt:         reminder.DueAt,
+		Timezone:      reminder.Timezone,
+		MinutesBefore: reminder.MinutesBefore,
+		RemindAt:      reminder.RemindAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotencyHeader, reminder.Key())
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package reminder_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/reminder"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier_PostsSignedReminderWithIdempotencyKey(t *testing.T) {
+	// given
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	notifier := reminder.NewWebhookNotifier(server.URL, "s3cret", server.Client())
+	due := reminder.Reminder{
+		ID: 12, TodoID: 4, UserID: 7, Title: "File taxes", Timezone: "UTC",
+		DueDate:  time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+		RemindAt: time.Date(2026, 10, 23, 8, 0, 0, 0, time.UTC),
+	}
+
+	// when
+	err := notifier.Notify(context.Background(), due)
+
+	// then
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	var payload map[string]any
+	_ = json.Unmarshal(body, &payload)
+	assert.NoError(t, err)
+	assert.Equal(t, due.Key(), header.Get("Idempotency-Key"))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get("X-Signature-256"))
+	assert.Equal(t, "File taxes", payload["title"])
+	assert.Equal(t, "2026-10-23", payload["due_date"])
+}
+
+func TestWebhookNotifier_NonSuccessStatusFails(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	notifier := reminder.NewWebhookNotifier(server.URL, "", server.Client())
+
+	// when
+	err := notifier.Notify(context.Background(), reminder.Reminder{ID: 1})
+
+	// then
+	assert.EqualError(t, err, "webhook answered 502")
+}
@@ -0,0 +1,40 @@
+package review
+
+import (
+	"context"
+	"time"
+)
+
+// Advance moves the review to its next step, or completes it after the last one. Items
+// left without a decision do not hold a review back.
+func (s *reviewService) Advance(ctx context.Context, req AdvanceReviewRequest) (*ReviewResponse, error) {
+	var res *ReviewResponse
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		review, err := s.findOpen(ctx, req.UserID, req.ID)
+		if err != nil {
+			return err
+		}
+		if next, ok := nextStep(review.Step); ok {
+			review.Step = next
+		} else {
+			completedAt := time.Now()
+			review.CompletedAt = &completedAt
+		}
+
+		updated, err := s.reviewRepository.Update(ctx, review)
+		if err != nil {
+			return err
+		}
+		res = toReviewResponse(updated)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type AdvanceReviewRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+}
@@ -0,0 +1,117 @@
+package review
+
+import (
+	"context"
+	"slices"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+// Decide records a decision about an item of the review's current step and applies it in
+// the same transaction: done, someday and activate move the todo, trash deletes the todo or
+// project, and keep and followed_up change nothing. Deciding again about the same item
+// replaces the earlier decision.
+//
+// The item is changed at the version it has now, since the user decides on what the step
+// shows rather than on a version they hold.
+func (s *reviewService) Decide(ctx context.Context, req DecideRequest) (*DecideResponse, error) {
+	res := &DecideResponse{}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		review, err := s.findOpen(ctx, req.UserID, req.ID)
+		if err != nil {
+			return err
+		}
+		if req.Step != review.Step {
+			return NewStepNotCurrentError(review.ID, req.Step, review.Step)
+		}
+		if !allows(req.Step, req.ItemType, req.Decision) {
+			return NewInvalidDecisionError(req.Step, req.ItemType, req.Decision)
+		}
+
+		if req.ItemType == ItemProject {
+			res.Project, err = s.decideProject(ctx, req)
+		} else {
+			res.Todo, err = s.decideTodo(ctx, req)
+		}
+		if err != nil {
+			return err
+		}
+
+		saved, err := s.reviewRepository.SaveDecision(ctx, &Decision{
+			ReviewID: review.ID, Step: req.Step, ItemType: req.ItemType, ItemID: req.ItemID,
+			Decision: req.Decision, Note: req.Note,
+		})
+		if err != nil {
+			return err
+		}
+		res.Decision = *toDecisionResponse(saved)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// decideTodo applies a decision to a todo of the step and returns it as it is now, or nil
+// when it was deleted.
+func (s *reviewService) decideTodo(ctx context.Context, req DecideRequest) (*todo.TodoResponse, error) {
+	current, err := s.todoUsecase.Get(ctx, todo.GetTodoRequest{UserID: req.UserID, ID: req.ItemID})
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(stepStatuses[req.Step], current.Status) {
+		return nil, NewItemNotInStepError(req.Step, req.ItemType, req.ItemID)
+	}
+	ifMatch := etag.FromVersion(current.Version)
+
+	if req.Decision == DecisionTrash {
+		_, err := s.todoUsecase.Delete(ctx, todo.DeleteTodoRequest{UserID: req.UserID, ID: req.ItemID, IfMatch: ifMatch})
+		return nil, err
+	}
+	status, ok := decisionStatuses[req.Decision]
+	if !ok {
+		return current, nil
+	}
+	moved, err := s.todoUsecase.ChangeStatus(ctx, todo.ChangeStatusRequest{
+		UserID: req.UserID, ID: req.ItemID, IfMatch: ifMatch, Status: status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved.Todo, nil
+}
+
+// decideProject is decideTodo for the projects step.
+func (s *reviewService) decideProject(ctx context.Context, req DecideRequest) (*project.ProjectResponse, error) {
+	ids, err := s.reviewRepository.FindProjectIDsWithoutNextAction(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(ids, req.ItemID) {
+		return nil, NewItemNotInStepError(req.Step, req.ItemType, req.ItemID)
+	}
+	current, err := s.projectUsecase.Get(ctx, project.GetProjectRequest{UserID: req.UserID, ID: req.ItemID})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Decision == DecisionTrash {
+		_, err := s.projectUsecase.Delete(ctx, project.DeleteProjectRequest{
+			UserID: req.UserID, ID: req.ItemID, IfMatch: etag.FromVersion(current.Version),
+		})
+		return nil, err
+	}
+	return current, nil
+}
+
+type DecideRequest struct {
+	UserID   int     `json:"-" auth:"user_id"`
+	ID       int     `json:"-" uri:"id"`
+	Step     string  `json:"-" uri:"step"`
+	ItemType string  `json:"item_type" binding:"required,oneof=todo project"`
+	ItemID   int     `json:"item_id" binding:"required"`
+	Decision string  `json:"decision" binding:"required,oneof=keep done someday activate followed_up trash"`
+	Note     *string `json:"note" binding:"omitempty,max=1000"`
+}
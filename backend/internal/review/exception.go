@@ -0,0 +1,108 @@
+package review
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type ReviewNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e ReviewNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewReviewNotFoundError(id int) *ReviewNotFoundError {
+	return &ReviewNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("Review not found. id=%v", id),
+		NestedErr: nil,
+	}
+}
+
+type ReviewInProgressError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e ReviewInProgressError) Error() string {
+	return e.Message
+}
+
+func NewReviewInProgressError() *ReviewInProgressError {
+	return &ReviewInProgressError{
+		Code:      http.StatusConflict,
+		Message:   "A review is already in progress. Finish it before starting another",
+		NestedErr: nil,
+	}
+}
+
+// ReviewStateError rejects a change the review is not at the point to accept: a decision
+// outside the current step, or anything on a completed review.
+type ReviewStateError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e ReviewStateError) Error() string {
+	return e.Message
+}
+
+func NewReviewCompletedError(id int) *ReviewStateError {
+	return &ReviewStateError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Review is already completed. id=%v", id),
+		NestedErr: nil,
+	}
+}
+
+func NewStepNotCurrentError(id int, step string, current string) *ReviewStateError {
+	return &ReviewStateError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Review is at another step. id=%v & step=%v & current=%v", id, step, current),
+		NestedErr: nil,
+	}
+}
+
+type InvalidDecisionError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidDecisionError) Error() string {
+	return e.Message
+}
+
+func NewInvalidDecisionError(step string, itemType string, decision string) *InvalidDecisionError {
+	return &InvalidDecisionError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid decision for this step. step=%v & item_type=%v & decision=%v", step, itemType, decision),
+		NestedErr: nil,
+	}
+}
+
+// ItemNotInStepError rejects a decision about an item the step does not list, usually
+// because it has moved since the step was read.
+type ItemNotInStepError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e ItemNotInStepError) Error() string {
+	return e.Message
+}
+
+func NewItemNotInStepError(step string, itemType string, itemID int) *ItemNotInStepError {
+	return &ItemNotInStepError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Item is not up for review in this step. step=%v & item_type=%v & item_id=%v", step, itemType, itemID),
+		NestedErr: nil,
+	}
+}
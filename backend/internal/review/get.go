@@ -0,0 +1,186 @@
+package review
+
+import (
+	"context"
+	"slices"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/query"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+var reviewSorts = query.Sorts{
+	{Name: "started_at", Columns: []string{"started_at"}, Order: query.Desc},
+}
+
+func (s *reviewService) Get(ctx context.Context, req GetReviewRequest) (*ReviewDetailResponse, error) {
+	review, err := s.find(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	decisions, err := s.reviewRepository.FindDecisions(ctx, review.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ReviewDetailResponse{
+		ID:          review.ID,
+		Kind:        review.Kind,
+		Step:        review.Step,
+		Steps:       Steps,
+		StartedAt:   review.StartedAt,
+		CompletedAt: review.CompletedAt,
+		Decisions:   make([]DecisionResponse, 0, len(decisions)),
+	}
+	for i := range decisions {
+		res.Decisions = append(res.Decisions, *toDecisionResponse(&decisions[i]))
+	}
+	return res, nil
+}
+
+// List returns one page of the user's reviews, newest first, so completed_at over the
+// pages shows how regularly the user reviews.
+func (s *reviewService) List(ctx context.Context, req ListReviewsRequest) (*ReviewListResponse, error) {
+	spec, err := query.NewSpec(reviewSorts, "", req.Order, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	spec.Filters = []query.Filter{
+		query.Present("completed_at", req.Completed),
+		query.EqualIfSet("kind", req.Kind),
+	}
+
+	reviews, total, err := s.reviewRepository.FindPage(ctx, req.UserID, spec)
+	if err != nil {
+		return nil, err
+	}
+	reviews, next := query.Page(spec, reviews, reviewSortValue)
+
+	res := &ReviewListResponse{Reviews: make([]ReviewResponse, 0, len(reviews)), Total: total, NextCursor: next}
+	for i := range reviews {
+		res.Reviews = append(res.Reviews, *toReviewResponse(&reviews[i]))
+	}
+	return res, nil
+}
+
+func reviewSortValue(review Review, column string) any {
+	switch column {
+	case "started_at":
+		return review.StartedAt
+	default:
+		return review.ID
+	}
+}
+
+// GetStep lists what a step needs the user to look at. Any step of a review can be read,
+// including those of a completed review, which show the items as they are now.
+func (s *reviewService) GetStep(ctx context.Context, req GetStepRequest) (*StepResponse, error) {
+	review, err := s.find(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	decisions, err := s.reviewRepository.FindDecisions(ctx, review.ID)
+	if err != nil {
+		return nil, err
+	}
+	decided := map[int]*DecisionResponse{}
+	for i := range decisions {
+		if decisions[i].Step == req.Step {
+			decided[decisions[i].ItemID] = toDecisionResponse(&decisions[i])
+		}
+	}
+	cutoff := staleBefore(review)
+
+	res := &StepResponse{Step: req.Step, Items: []ItemResponse{}}
+	if req.Step == StepProjects {
+		projects, err := s.projectsWithoutNextAction(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range projects {
+			res.Items = append(res.Items, ItemResponse{
+				Type: ItemProject, Project: &projects[i],
+				Stale: projects[i].UpdatedAt.Before(cutoff), Decision: decided[projects[i].ID],
+			})
+		}
+		return res, nil
+	}
+
+	todos, err := s.todosIn(ctx, req.UserID, stepStatuses[req.Step])
+	if err != nil {
+		return nil, err
+	}
+	for i := range todos {
+		res.Items = append(res.Items, ItemResponse{
+			Type: ItemTodo, Todo: &todos[i],
+			Stale: todos[i].UpdatedAt.Before(cutoff), Decision: decided[todos[i].ID],
+		})
+	}
+	return res, nil
+}
+
+// todosIn reads every page of the user's todos in statuses, least recently changed first.
+func (s *reviewService) todosIn(ctx context.Context, userID int, statuses []string) ([]todo.TodoResponse, error) {
+	req := todo.ListTodosRequest{UserID: userID, Status: statuses, Sort: "updated_at", Order: query.Asc, Limit: query.MaxLimit}
+	todos := []todo.TodoResponse{}
+	for {
+		page, err := s.todoUsecase.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, page.Todos...)
+		if page.NextCursor == nil {
+			return todos, nil
+		}
+		req.Cursor = *page.NextCursor
+	}
+}
+
+// projectsWithoutNextAction reads every page of the user's projects, least recently
+// changed first, and keeps those with nothing to do next.
+func (s *reviewService) projectsWithoutNextAction(ctx context.Context, userID int) ([]project.ProjectResponse, error) {
+	ids, err := s.reviewRepository.FindProjectIDsWithoutNextAction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	projects := []project.ProjectResponse{}
+	if len(ids) == 0 {
+		return projects, nil
+	}
+
+	req := project.ListProjectsRequest{UserID: userID, Sort: "updated_at", Order: query.Asc, Limit: query.MaxLimit}
+	for {
+		page, err := s.projectUsecase.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Projects {
+			if slices.Contains(ids, p.ID) {
+				projects = append(projects, p)
+			}
+		}
+		if page.NextCursor == nil {
+			return projects, nil
+		}
+		req.Cursor = *page.NextCursor
+	}
+}
+
+type GetReviewRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+}
+
+type ListReviewsRequest struct {
+	UserID    int     `json:"-" auth:"user_id"`
+	Kind      *string `json:"-" form:"kind" binding:"omitempty,oneof=daily weekly monthly"`
+	Completed *bool   `json:"-" form:"completed"`
+	Order     string  `json:"-" form:"order" binding:"omitempty,oneof=asc desc"`
+	Cursor    string  `json:"-" form:"cursor"`
+	Limit     int     `json:"-" form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+type GetStepRequest struct {
+	UserID int    `json:"-" auth:"user_id"`
+	ID     int    `json:"-" uri:"id"`
+	Step   string `json:"-" uri:"step" binding:"oneof=inbox next_actions waiting_for projects someday"`
+}
@@ -0,0 +1,97 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/query"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+type ReviewHandler struct {
+	reviewUsecase ReviewUsecase
+}
+
+func NewReviewHandler(reviewUsecase ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{reviewUsecase: reviewUsecase}
+}
+
+func (h *ReviewHandler) HandleStart(ctx context.Context, req StartReviewRequest) (int, any) {
+	res, err := h.reviewUsecase.Start(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusCreated, res
+}
+
+func (h *ReviewHandler) HandleGet(ctx context.Context, req GetReviewRequest) (int, any) {
+	res, err := h.reviewUsecase.Get(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ReviewHandler) HandleList(ctx context.Context, req ListReviewsRequest) (int, any) {
+	res, err := h.reviewUsecase.List(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ReviewHandler) HandleGetStep(ctx context.Context, req GetStepRequest) (int, any) {
+	res, err := h.reviewUsecase.GetStep(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ReviewHandler) HandleDecide(ctx context.Context, req DecideRequest) (int, any) {
+	res, err := h.reviewUsecase.Decide(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *ReviewHandler) HandleAdvance(ctx context.Context, req AdvanceReviewRequest) (int, any) {
+	res, err := h.reviewUsecase.Advance(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError answers an item that changed while being decided on with 409, like one that
+// has left the step.
+func handleError(err error) (int, any) {
+	var notFoundError *ReviewNotFoundError
+	var todoNotFoundError *todo.TodoNotFoundError
+	var projectNotFoundError *project.ProjectNotFoundError
+	var inProgressError *ReviewInProgressError
+	var stateError *ReviewStateError
+	var itemNotInStepError *ItemNotInStepError
+	var todoConflictError *todo.VersionConflictError
+	var projectConflictError *project.VersionConflictError
+	var invalidDecisionError *InvalidDecisionError
+
+	switch {
+	case errors.As(err, &notFoundError), errors.As(err, &todoNotFoundError), errors.As(err, &projectNotFoundError):
+		return http.StatusNotFound, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &inProgressError), errors.As(err, &stateError), errors.As(err, &itemNotInStepError),
+		errors.As(err, &todoConflictError), errors.As(err, &projectConflictError):
+		return http.StatusConflict, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &invalidDecisionError):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	case errors.Is(err, query.ErrInvalidCursor), errors.Is(err, query.ErrUnknownSort):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	default:
+		return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+	}
+}
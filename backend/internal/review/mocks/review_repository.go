@@ -0,0 +1,461 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package reviewmocks
+
+import (
+	context "context"
+	query "yangdongju/gtd_todo/internal/query"
+
+	mock "github.com/stretchr/testify/mock"
+
+	review "yangdongju/gtd_todo/internal/review"
+)
+
+// ReviewRepository is an autogenerated mock type for the ReviewRepository type
+type ReviewRepository struct {
+	mock.Mock
+}
+
+type ReviewRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReviewRepository) EXPECT() *ReviewRepository_Expecter {
+	return &ReviewRepository_Expecter{mock: &_m.Mock}
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *ReviewRepository) FindByID(ctx context.Context, userID int, id int) (*review.Review, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*review.Review, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *review.Review); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type ReviewRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int
+func (_e *ReviewRepository_Expecter) FindByID(ctx interface{}, userID interface{}, id interface{}) *ReviewRepository_FindByID_Call {
+	return &ReviewRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, userID, id)}
+}
+
+func (_c *ReviewRepository_FindByID_Call) Run(run func(ctx context.Context, userID int, id int)) *ReviewRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ReviewRepository_FindByID_Call) Return(_a0 *review.Review, _a1 error) *ReviewRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewRepository_FindByID_Call) RunAndReturn(run func(context.Context, int, int) (*review.Review, error)) *ReviewRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDecisions provides a mock function with given fields: ctx, reviewID
+func (_m *ReviewRepository) FindDecisions(ctx context.Context, reviewID int) ([]review.Decision, error) {
+	ret := _m.Called(ctx, reviewID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDecisions")
+	}
+
+	var r0 []review.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]review.Decision, error)); ok {
+		return rf(ctx, reviewID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []review.Decision); ok {
+		r0 = rf(ctx, reviewID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]review.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, reviewID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewRepository_FindDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDecisions'
+type ReviewRepository_FindDecisions_Call struct {
+	*mock.Call
+}
+
+// FindDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reviewID int
+func (_e *ReviewRepository_Expecter) FindDecisions(ctx interface{}, reviewID interface{}) *ReviewRepository_FindDecisions_Call {
+	return &ReviewRepository_FindDecisions_Call{Call: _e.mock.On("FindDecisions", ctx, reviewID)}
+}
+
+func (_c *ReviewRepository_FindDecisions_Call) Run(run func(ctx context.Context, reviewID int)) *ReviewRepository_FindDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ReviewRepository_FindDecisions_Call) Return(_a0 []review.Decision, _a1 error) *ReviewRepository_FindDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewRepository_FindDecisions_Call) RunAndReturn(run func(context.Context, int) ([]review.Decision, error)) *ReviewRepository_FindDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPage provides a mock function with given fields: ctx, userID, spec
+func (_m *ReviewRepository) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]review.Review, int, error) {
+	ret := _m.Called(ctx, userID, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []review.Review
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *query.Spec) ([]review.Review, int, error)); ok {
+		return rf(ctx, userID, spec)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *query.Spec) []review.Review); ok {
+		r0 = rf(ctx, userID, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *query.Spec) int); ok {
+		r1 = rf(ctx, userID, spec)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, *query.Spec) error); ok {
+		r2 = rf(ctx, userID, spec)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReviewRepository_FindPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPage'
+type ReviewRepository_FindPage_Call struct {
+	*mock.Call
+}
+
+// FindPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - spec *query.Spec
+func (_e *ReviewRepository_Expecter) FindPage(ctx interface{}, userID interface{}, spec interface{}) *ReviewRepository_FindPage_Call {
+	return &ReviewRepository_FindPage_Call{Call: _e.mock.On("FindPage", ctx, userID, spec)}
+}
+
+func (_c *ReviewRepository_FindPage_Call) Run(run func(ctx context.Context, userID int, spec *query.Spec)) *ReviewRepository_FindPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*query.Spec))
+	})
+	return _c
+}
+
+func (_c *ReviewRepository_FindPage_Call) Return(_a0 []review.Review, _a1 int, _a2 error) *ReviewRepository_FindPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ReviewRepository_FindPage_Call) RunAndReturn(run func(context.Context, int, *query.Spec) ([]review.Review, int, error)) *ReviewRepository_FindPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindProjectIDsWithoutNextAction provides a mock function with given fields: ctx, userID
+func (_m *ReviewRepository) FindProjectIDsWithoutNextAction(ctx context.Context, userID int) ([]int, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProjectIDsWithoutNextAction")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewRepository_FindProjectIDsWithoutNextAction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProjectIDsWithoutNextAction'
+type ReviewRepository_FindProjectIDsWithoutNextAction_Call struct {
+	*mock.Call
+}
+
+// FindProjectIDsWithoutNextAction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *ReviewRepository_Expecter) FindProjectIDsWithoutNextAction(ctx interface{}, userID interface{}) *ReviewRepository_FindProjectIDsWithoutNextAction_Call {
+	return &ReviewRepository_FindProjectIDsWithoutNextAction_Call{Call: _e.mock.On("FindProjectIDsWithoutNextAction", ctx, userID)}
+}
+
+func (_c *ReviewRepository_FindProjectIDsWithoutNextAction_Call) Run(run func(ctx context.Context, userID int)) *ReviewRepository_FindProjectIDsWithoutNextAction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ReviewRepository_FindProjectIDsWithoutNextAction_Call) Return(_a0 []int, _a1 error) *ReviewRepository_FindProjectIDsWithoutNextAction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewRepository_FindProjectIDsWithoutNextAction_Call) RunAndReturn(run func(context.Context, int) ([]int, error)) *ReviewRepository_FindProjectIDsWithoutNextAction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *ReviewRepository) Save(ctx context.Context, _a1 *review.Review) (*review.Review, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *review.Review) (*review.Review, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *review.Review) *review.Review); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *review.Review) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type ReviewRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *review.Review
+func (_e *ReviewRepository_Expecter) Save(ctx interface{}, _a1 interface{}) *ReviewRepository_Save_Call {
+	return &ReviewRepository_Save_Call{Call: _e.mock.On("Save", ctx, _a1)}
+}
+
+func (_c *ReviewRepository_Save_Call) Run(run func(ctx context.Context, _a1 *review.Review)) *ReviewRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*review.Review))
+	})
+	return _c
+}
+
+func (_c *ReviewRepository_Save_Call) Return(_a0 *review.Review, _a1 error) *ReviewRepository_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewRepository_Save_Call) RunAndReturn(run func(context.Context, *review.Review) (*review.Review, error)) *ReviewRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDecision provides a mock function with given fields: ctx, decision
+func (_m *ReviewRepository) SaveDecision(ctx context.Context, decision *review.Decision) (*review.Decision, error) {
+	ret := _m.Called(ctx, decision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDecision")
+	}
+
+	var r0 *review.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *review.Decision) (*review.Decision, error)); ok {
+		return rf(ctx, decision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *review.Decision) *review.Decision); ok {
+		r0 = rf(ctx, decision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *review.Decision) error); ok {
+		r1 = rf(ctx, decision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewRepository_SaveDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDecision'
+type ReviewRepository_SaveDecision_Call struct {
+	*mock.Call
+}
+
+// SaveDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - decision *review.Decision
+func (_e *ReviewRepository_Expecter) SaveDecision(ctx interface{}, decision interface{}) *ReviewRepository_SaveDecision_Call {
+	return &ReviewRepository_SaveDecision_Call{Call: _e.mock.On("SaveDecision", ctx, decision)}
+}
+
+func (_c *ReviewRepository_SaveDecision_Call) Run(run func(ctx context.Context, decision *review.Decision)) *ReviewRepository_SaveDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*review.Decision))
+	})
+	return _c
+}
+
+func (_c *ReviewRepository_SaveDecision_Call) Return(_a0 *review.Decision, _a1 error) *ReviewRepository_SaveDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewRepository_SaveDecision_Call) RunAndReturn(run func(context.Context, *review.Decision) (*review.Decision, error)) *ReviewRepository_SaveDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *ReviewRepository) Update(ctx context.Context, _a1 *review.Review) (*review.Review, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *review.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *review.Review) (*review.Review, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *review.Review) *review.Review); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *review.Review) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ReviewRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *review.Review
+func (_e *ReviewRepository_Expecter) Update(ctx interface{}, _a1 interface{}) *ReviewRepository_Update_Call {
+	return &ReviewRepository_Update_Call{Call: _e.mock.On("Update", ctx, _a1)}
+}
+
+func (_c *ReviewRepository_Update_Call) Run(run func(ctx context.Context, _a1 *review.Review)) *ReviewRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*review.Review))
+	})
+	return _c
+}
+
+func (_c *ReviewRepository_Update_Call) Return(_a0 *review.Review, _a1 error) *ReviewRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewRepository_Update_Call) RunAndReturn(run func(context.Context, *review.Review) (*review.Review, error)) *ReviewRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReviewRepository creates a new instance of ReviewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewRepository {
+	mock := &ReviewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,391 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package reviewmocks
+
+import (
+	context "context"
+	review "yangdongju/gtd_todo/internal/review"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewUsecase is an autogenerated mock type for the ReviewUsecase type
+type ReviewUsecase struct {
+	mock.Mock
+}
+
+type ReviewUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReviewUsecase) EXPECT() *ReviewUsecase_Expecter {
+	return &ReviewUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Advance provides a mock function with given fields: ctx, request
+func (_m *ReviewUsecase) Advance(ctx context.Context, request review.AdvanceReviewRequest) (*review.ReviewResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Advance")
+	}
+
+	var r0 *review.ReviewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.AdvanceReviewRequest) (*review.ReviewResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.AdvanceReviewRequest) *review.ReviewResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.ReviewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.AdvanceReviewRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewUsecase_Advance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Advance'
+type ReviewUsecase_Advance_Call struct {
+	*mock.Call
+}
+
+// Advance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request review.AdvanceReviewRequest
+func (_e *ReviewUsecase_Expecter) Advance(ctx interface{}, request interface{}) *ReviewUsecase_Advance_Call {
+	return &ReviewUsecase_Advance_Call{Call: _e.mock.On("Advance", ctx, request)}
+}
+
+func (_c *ReviewUsecase_Advance_Call) Run(run func(ctx context.Context, request review.AdvanceReviewRequest)) *ReviewUsecase_Advance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(review.AdvanceReviewRequest))
+	})
+	return _c
+}
+
+func (_c *ReviewUsecase_Advance_Call) Return(_a0 *review.ReviewResponse, _a1 error) *ReviewUsecase_Advance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewUsecase_Advance_Call) RunAndReturn(run func(context.Context, review.AdvanceReviewRequest) (*review.ReviewResponse, error)) *ReviewUsecase_Advance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Decide provides a mock function with given fields: ctx, request
+func (_m *ReviewUsecase) Decide(ctx context.Context, request review.DecideRequest) (*review.DecideResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decide")
+	}
+
+	var r0 *review.DecideResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.DecideRequest) (*review.DecideResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.DecideRequest) *review.DecideResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.DecideResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.DecideRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewUsecase_Decide_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decide'
+type ReviewUsecase_Decide_Call struct {
+	*mock.Call
+}
+
+// Decide is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request review.DecideRequest
+func (_e *ReviewUsecase_Expecter) Decide(ctx interface{}, request interface{}) *ReviewUsecase_Decide_Call {
+	return &ReviewUsecase_Decide_Call{Call: _e.mock.On("Decide", ctx, request)}
+}
+
+func (_c *ReviewUsecase_Decide_Call) Run(run func(ctx context.Context, request review.DecideRequest)) *ReviewUsecase_Decide_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(review.DecideRequest))
+	})
+	return _c
+}
+
+func (_c *ReviewUsecase_Decide_Call) Return(_a0 *review.DecideResponse, _a1 error) *ReviewUsecase_Decide_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewUsecase_Decide_Call) RunAndReturn(run func(context.Context, review.DecideRequest) (*review.DecideResponse, error)) *ReviewUsecase_Decide_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, request
+func (_m *ReviewUsecase) Get(ctx context.Context, request review.GetReviewRequest) (*review.ReviewDetailResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *review.ReviewDetailResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.GetReviewRequest) (*review.ReviewDetailResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.GetReviewRequest) *review.ReviewDetailResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.ReviewDetailResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.GetReviewRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewUsecase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ReviewUsecase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request review.GetReviewRequest
+func (_e *ReviewUsecase_Expecter) Get(ctx interface{}, request interface{}) *ReviewUsecase_Get_Call {
+	return &ReviewUsecase_Get_Call{Call: _e.mock.On("Get", ctx, request)}
+}
+
+func (_c *ReviewUsecase_Get_Call) Run(run func(ctx context.Context, request review.GetReviewRequest)) *ReviewUsecase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(review.GetReviewRequest))
+	})
+	return _c
+}
+
+func (_c *ReviewUsecase_Get_Call) Return(_a0 *review.ReviewDetailResponse, _a1 error) *ReviewUsecase_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewUsecase_Get_Call) RunAndReturn(run func(context.Context, review.GetReviewRequest) (*review.ReviewDetailResponse, error)) *ReviewUsecase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStep provides a mock function with given fields: ctx, request
+func (_m *ReviewUsecase) GetStep(ctx context.Context, request review.GetStepRequest) (*review.StepResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStep")
+	}
+
+	var r0 *review.StepResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.GetStepRequest) (*review.StepResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.GetStepRequest) *review.StepResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.StepResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.GetStepRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewUsecase_GetStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStep'
+type ReviewUsecase_GetStep_Call struct {
+	*mock.Call
+}
+
+// GetStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request review.GetStepRequest
+func (_e *ReviewUsecase_Expecter) GetStep(ctx interface{}, request interface{}) *ReviewUsecase_GetStep_Call {
+	return &ReviewUsecase_GetStep_Call{Call: _e.mock.On("GetStep", ctx, request)}
+}
+
+func (_c *ReviewUsecase_GetStep_Call) Run(run func(ctx context.Context, request review.GetStepRequest)) *ReviewUsecase_GetStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(review.GetStepRequest))
+	})
+	return _c
+}
+
+func (_c *ReviewUsecase_GetStep_Call) Return(_a0 *review.StepResponse, _a1 error) *ReviewUsecase_GetStep_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewUsecase_GetStep_Call) RunAndReturn(run func(context.Context, review.GetStepRequest) (*review.StepResponse, error)) *ReviewUsecase_GetStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *ReviewUsecase) List(ctx context.Context, request review.ListReviewsRequest) (*review.ReviewListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *review.ReviewListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.ListReviewsRequest) (*review.ReviewListResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.ListReviewsRequest) *review.ReviewListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.ReviewListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.ListReviewsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ReviewUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request review.ListReviewsRequest
+func (_e *ReviewUsecase_Expecter) List(ctx interface{}, request interface{}) *ReviewUsecase_List_Call {
+	return &ReviewUsecase_List_Call{Call: _e.mock.On("List", ctx, request)}
+}
+
+func (_c *ReviewUsecase_List_Call) Run(run func(ctx context.Context, request review.ListReviewsRequest)) *ReviewUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(review.ListReviewsRequest))
+	})
+	return _c
+}
+
+func (_c *ReviewUsecase_List_Call) Return(_a0 *review.ReviewListResponse, _a1 error) *ReviewUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewUsecase_List_Call) RunAndReturn(run func(context.Context, review.ListReviewsRequest) (*review.ReviewListResponse, error)) *ReviewUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, request
+func (_m *ReviewUsecase) Start(ctx context.Context, request review.StartReviewRequest) (*review.ReviewResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 *review.ReviewResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, review.StartReviewRequest) (*review.ReviewResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, review.StartReviewRequest) *review.ReviewResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*review.ReviewResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, review.StartReviewRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewUsecase_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type ReviewUsecase_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request review.StartReviewRequest
+func (_e *ReviewUsecase_Expecter) Start(ctx interface{}, request interface{}) *ReviewUsecase_Start_Call {
+	return &ReviewUsecase_Start_Call{Call: _e.mock.On("Start", ctx, request)}
+}
+
+func (_c *ReviewUsecase_Start_Call) Run(run func(ctx context.Context, request review.StartReviewRequest)) *ReviewUsecase_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(review.StartReviewRequest))
+	})
+	return _c
+}
+
+func (_c *ReviewUsecase_Start_Call) Return(_a0 *review.ReviewResponse, _a1 error) *ReviewUsecase_Start_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewUsecase_Start_Call) RunAndReturn(run func(context.Context, review.StartReviewRequest) (*review.ReviewResponse, error)) *ReviewUsecase_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReviewUsecase creates a new instance of ReviewUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewUsecase {
+	mock := &ReviewUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
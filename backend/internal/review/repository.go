@@ -0,0 +1,165 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/query"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const uniqueOpenIndex = "idx_reviews_user_open"
+
+type ReviewRepository interface {
+	Save(ctx context.Context, review *Review) (*Review, error)
+	FindByID(ctx context.Context, userID int, id int) (*Review, error)
+	FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Review, int, error)
+	Update(ctx context.Context, review *Review) (*Review, error)
+	SaveDecision(ctx context.Context, decision *Decision) (*Decision, error)
+	FindDecisions(ctx context.Context, reviewID int) ([]Decision, error)
+	FindProjectIDsWithoutNextAction(ctx context.Context, userID int) ([]int, error)
+}
+
+type reviewRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+type Review struct {
+	ID          int        `db:"id"`
+	UserID      int        `db:"user_id"`
+	Kind        string     `db:"kind"`
+	Step        string     `db:"step"`
+	StartedAt   time.Time  `db:"started_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+// Decision is what the user decided about one item in one step of a review.
+type Decision struct {
+	ID        int       `db:"id"`
+	ReviewID  int       `db:"review_id"`
+	Step      string    `db:"step"`
+	ItemType  string    `db:"item_type"`
+	ItemID    int       `db:"item_id"`
+	Decision  string    `db:"decision"`
+	Note      *string   `db:"note"`
+	DecidedAt time.Time `db:"decided_at"`
+}
+
+const reviewColumns = "id, user_id, kind, step, started_at, completed_at"
+
+const decisionColumns = "id, review_id, step, item_type, item_id, decision, note, decided_at"
+
+func NewReviewRepository(db *sqlx.DB) *reviewRepositoryImpl {
+	return &reviewRepositoryImpl{db: db}
+}
+
+// Save translates a unique violation on the open review index into ReviewInProgressError.
+func (r *reviewRepositoryImpl) Save(ctx context.Context, review *Review) (*Review, error) {
+	var saved Review
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO reviews (user_id, kind, step)
+		VALUES ($1, $2, $3)
+		RETURNING `+reviewColumns,
+		review.UserID, review.Kind, review.Step)
+	if database.IsUniqueViolation(err, uniqueOpenIndex) {
+		return nil, NewReviewInProgressError()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// FindByID locks the review, so decisions and advancing the same review run one at a time.
+func (r *reviewRepositoryImpl) FindByID(ctx context.Context, userID int, id int) (*Review, error) {
+	var review Review
+	err := database.Conn(ctx, r.db).GetContext(ctx, &review,
+		"SELECT "+reviewColumns+" FROM reviews WHERE id = $1 AND user_id = $2 FOR UPDATE", id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindPage returns the user's reviews matching spec, one row past its limit when there
+// are more, and how many match in total.
+func (r *reviewRepositoryImpl) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Review, int, error) {
+	page, count := spec.Build(reviewColumns, "reviews", query.Equal("user_id", userID))
+	conn := database.Conn(ctx, r.db)
+
+	reviews := []Review{}
+	if err := conn.SelectContext(ctx, &reviews, page.SQL, page.Args...); err != nil {
+		return nil, 0, err
+	}
+	var total int
+	if err := conn.GetContext(ctx, &total, count.SQL, count.Args...); err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// Update moves the review to its step, and stamps completed_at with the database clock
+// when the review is marked completed.
+func (r *reviewRepositoryImpl) Update(ctx context.Context, review *Review) (*Review, error) {
+	var updated Review
+	err := database.Conn(ctx, r.db).GetContext(ctx, &updated, `
+		UPDATE reviews
+		SET step = $3, completed_at = CASE WHEN $4 THEN COALESCE(completed_at, CURRENT_TIMESTAMP) END
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+reviewColumns,
+		review.ID, review.UserID, review.Step, review.CompletedAt != nil)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SaveDecision replaces an earlier decision about the same item in the same step.
+func (r *reviewRepositoryImpl) SaveDecision(ctx context.Context, d *Decision) (*Decision, error) {
+	var saved Decision
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO review_decisions (review_id, step, item_type, item_id, decision, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (review_id, step, item_type, item_id)
+		DO UPDATE SET decision = EXCLUDED.decision, note = EXCLUDED.note, decided_at = CURRENT_TIMESTAMP
+		RETURNING `+decisionColumns,
+		d.ReviewID, d.Step, d.ItemType, d.ItemID, d.Decision, d.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *reviewRepositoryImpl) FindDecisions(ctx context.Context, reviewID int) ([]Decision, error) {
+	decisions := []Decision{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &decisions,
+		"SELECT "+decisionColumns+" FROM review_decisions WHERE review_id = $1 ORDER BY decided_at, id", reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+// FindProjectIDsWithoutNextAction returns the user's active projects, todo or in_progress,
+// with no todo in next_actions or in_progress.
+func (r *reviewRepositoryImpl) FindProjectIDsWithoutNextAction(ctx context.Context, userID int) ([]int, error) {
+	ids := []int{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &ids, `
+		SELECT p.id FROM projects p
+		WHERE p.user_id = $1 AND p.status IN ('todo', 'in_progress')
+			AND NOT EXISTS (
+				SELECT 1 FROM todos t
+				WHERE t.project_id = p.id AND t.status IN ('next_actions', 'in_progress')
+			)
+		ORDER BY p.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,80 @@
+package review_test
+
+import (
+	"context"
+	"testing"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/review"
+	"yangdongju/gtd_todo/internal/todo"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewRepository_OneOpenReviewPerUser(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	repository := review.NewReviewRepository(testhelper.GetTestDB())
+	first, _ := repository.Save(ctx, &review.Review{UserID: userID, Kind: review.KindWeekly, Step: review.StepInbox})
+
+	// when
+	_, errWhileOpen := repository.Save(ctx, &review.Review{UserID: userID, Kind: review.KindDaily, Step: review.StepInbox})
+	first.CompletedAt = &first.StartedAt
+	completed, _ := repository.Update(ctx, first)
+	_, errAfterCompleting := repository.Save(ctx, &review.Review{UserID: userID, Kind: review.KindDaily, Step: review.StepInbox})
+
+	// then
+	var inProgressError *review.ReviewInProgressError
+	assert.ErrorAs(t, errWhileOpen, &inProgressError)
+	assert.NotNil(t, completed.CompletedAt)
+	assert.NoError(t, errAfterCompleting)
+}
+
+func TestReviewRepository_DecidingAgainReplacesTheDecision(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	repository := review.NewReviewRepository(testhelper.GetTestDB())
+	saved, _ := repository.Save(ctx, &review.Review{UserID: userID, Kind: review.KindWeekly, Step: review.StepInbox})
+	decision := &review.Decision{ReviewID: saved.ID, Step: review.StepInbox, ItemType: review.ItemTodo, ItemID: 4, Decision: review.DecisionKeep}
+	_, _ = repository.SaveDecision(ctx, decision)
+
+	// when
+	decision.Decision = review.DecisionTrash
+	_, err := repository.SaveDecision(ctx, decision)
+	decisions, _ := repository.FindDecisions(ctx, saved.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, decisions, 1)
+	assert.Equal(t, review.DecisionTrash, decisions[0].Decision)
+}
+
+func TestReviewRepository_FindsProjectsWithoutNextAction(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	ctx := context.Background()
+	projects := project.NewProjectRepository(testhelper.GetTestDB())
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	moving, _ := projects.Save(ctx, &project.Project{UserID: userID, Name: "Moving", Color: "#3B82F6"})
+	taxes, _ := projects.Save(ctx, &project.Project{UserID: userID, Name: "Taxes", Color: "#3B82F6"})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, ProjectID: &moving.ID, Title: "Book a van", Status: todo.StatusNextActions})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, ProjectID: &taxes.ID, Title: "Receipts", Status: todo.StatusSomeday})
+
+	// when
+	ids, err := review.NewReviewRepository(testhelper.GetTestDB()).FindProjectIDsWithoutNextAction(ctx, userID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []int{taxes.ID}, ids)
+}
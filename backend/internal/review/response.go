@@ -0,0 +1,89 @@
+package review
+
+import (
+	"time"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+type ReviewResponse struct {
+	ID          int        `json:"id"`
+	Kind        string     `json:"kind"`
+	Step        string     `json:"step"`
+	Steps       []string   `json:"steps"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// ReviewDetailResponse is a review with every decision made in it so far.
+type ReviewDetailResponse struct {
+	ID          int                `json:"id"`
+	Kind        string             `json:"kind"`
+	Step        string             `json:"step"`
+	Steps       []string           `json:"steps"`
+	StartedAt   time.Time          `json:"started_at"`
+	CompletedAt *time.Time         `json:"completed_at"`
+	Decisions   []DecisionResponse `json:"decisions"`
+}
+
+type ReviewListResponse struct {
+	Reviews    []ReviewResponse `json:"reviews"`
+	Total      int              `json:"total"`
+	NextCursor *string          `json:"next_cursor"`
+}
+
+type DecisionResponse struct {
+	Step      string    `json:"step"`
+	ItemType  string    `json:"item_type"`
+	ItemID    int       `json:"item_id"`
+	Decision  string    `json:"decision"`
+	Note      *string   `json:"note"`
+	DecidedAt time.Time `json:"decided_at"`
+}
+
+// StepResponse lists the items a step needs the user to look at, oldest change first.
+// Stale items have not changed within the review's period, and an item decided on in
+// this review carries the decision.
+type StepResponse struct {
+	Step  string         `json:"step"`
+	Items []ItemResponse `json:"items"`
+}
+
+// ItemResponse is one todo or project up for review; the other field is null.
+type ItemResponse struct {
+	Type     string                   `json:"type"`
+	Todo     *todo.TodoResponse       `json:"todo"`
+	Project  *project.ProjectResponse `json:"project"`
+	Stale    bool                     `json:"stale"`
+	Decision *DecisionResponse        `json:"decision"`
+}
+
+// DecideResponse is the recorded decision with the item as it is now. Both are null for
+// an item the decision deleted.
+type DecideResponse struct {
+	Decision DecisionResponse         `json:"decision"`
+	Todo     *todo.TodoResponse       `json:"todo"`
+	Project  *project.ProjectResponse `json:"project"`
+}
+
+func toReviewResponse(review *Review) *ReviewResponse {
+	return &ReviewResponse{
+		ID:          review.ID,
+		Kind:        review.Kind,
+		Step:        review.Step,
+		Steps:       Steps,
+		StartedAt:   review.StartedAt,
+		CompletedAt: review.CompletedAt,
+	}
+}
+
+func toDecisionResponse(decision *Decision) *DecisionResponse {
+	return &DecisionResponse{
+		Step:      decision.Step,
+		ItemType:  decision.ItemType,
+		ItemID:    decision.ItemID,
+		Decision:  decision.Decision,
+		Note:      decision.Note,
+		DecidedAt: decision.DecidedAt,
+	}
+}
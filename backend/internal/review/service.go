@@ -0,0 +1,59 @@
+package review
+
+import (
+	"context"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+type ReviewUsecase interface {
+	Start(ctx context.Context, request StartReviewRequest) (*ReviewResponse, error)
+	Get(ctx context.Context, request GetReviewRequest) (*ReviewDetailResponse, error)
+	List(ctx context.Context, request ListReviewsRequest) (*ReviewListResponse, error)
+	GetStep(ctx context.Context, request GetStepRequest) (*StepResponse, error)
+	Decide(ctx context.Context, request DecideRequest) (*DecideResponse, error)
+	Advance(ctx context.Context, request AdvanceReviewRequest) (*ReviewResponse, error)
+}
+
+type reviewService struct {
+	reviewRepository ReviewRepository
+	txManager        database.TxManager
+	todoUsecase      todo.TodoUsecase
+	projectUsecase   project.ProjectUsecase
+}
+
+// NewReviewService reads and changes items through the todo and project use cases, so a
+// decision gets the same checks and events as the REST endpoints.
+func NewReviewService(repository ReviewRepository, txManager database.TxManager, todoUsecase todo.TodoUsecase, projectUsecase project.ProjectUsecase) *reviewService {
+	return &reviewService{
+		reviewRepository: repository,
+		txManager:        txManager,
+		todoUsecase:      todoUsecase,
+		projectUsecase:   projectUsecase,
+	}
+}
+
+// find returns the user's review or ReviewNotFoundError.
+func (s *reviewService) find(ctx context.Context, userID int, id int) (*Review, error) {
+	review, err := s.reviewRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, NewReviewNotFoundError(id)
+	}
+	return review, nil
+}
+
+// findOpen is find for changes, which a completed review no longer takes.
+func (s *reviewService) findOpen(ctx context.Context, userID int, id int) (*Review, error) {
+	review, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.CompletedAt != nil {
+		return nil, NewReviewCompletedError(id)
+	}
+	return review, nil
+}
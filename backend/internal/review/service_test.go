@@ -0,0 +1,219 @@
+package review_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/project"
+	projectmocks "yangdongju/gtd_todo/internal/project/mocks"
+	"yangdongju/gtd_todo/internal/review"
+	reviewmocks "yangdongju/gtd_todo/internal/review/mocks"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+var started = time.Date(2027, 1, 15, 17, 0, 0, 0, time.UTC)
+
+func openReview(step string) *review.Review {
+	return &review.Review{ID: 3, UserID: 7, Kind: review.KindWeekly, Step: step, StartedAt: started}
+}
+
+func TestGetStep_FlagsStalledItemsAndCarriesDecisions(t *testing.T) {
+	// given
+	mockRepo := reviewmocks.NewReviewRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 3).Return(openReview(review.StepNextActions), nil)
+	mockRepo.EXPECT().FindDecisions(mock.Anything, 3).Return([]review.Decision{
+		{Step: review.StepInbox, ItemType: review.ItemTodo, ItemID: 1, Decision: review.DecisionKeep},
+		{Step: review.StepNextActions, ItemType: review.ItemTodo, ItemID: 2, Decision: review.DecisionKeep},
+	}, nil)
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().List(mock.Anything, mock.MatchedBy(func(req todo.ListTodosRequest) bool {
+		return assert.ObjectsAreEqual([]string{todo.StatusNextActions, todo.StatusInProgress}, req.Status) &&
+			req.Sort == "updated_at" && req.Cursor == ""
+	})).Return(&todo.TodoListResponse{Todos: []todo.TodoResponse{
+		{ID: 2, UpdatedAt: started.AddDate(0, 0, -8)},
+	}, NextCursor: ptr("next")}, nil)
+	mockTodos.EXPECT().List(mock.Anything, mock.MatchedBy(func(req todo.ListTodosRequest) bool {
+		return req.Cursor == "next"
+	})).Return(&todo.TodoListResponse{Todos: []todo.TodoResponse{
+		{ID: 1, UpdatedAt: started.AddDate(0, 0, -6)},
+	}}, nil)
+
+	service := review.NewReviewService(mockRepo, passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t))
+
+	// when
+	res, err := service.GetStep(context.Background(), review.GetStepRequest{UserID: 7, ID: 3, Step: review.StepNextActions})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 2)
+	assert.True(t, res.Items[0].Stale)
+	assert.Equal(t, review.DecisionKeep, res.Items[0].Decision.Decision)
+	assert.False(t, res.Items[1].Stale)
+	assert.Nil(t, res.Items[1].Decision)
+}
+
+func TestGetStep_ListsProjectsWithoutANextAction(t *testing.T) {
+	// given
+	mockRepo := reviewmocks.NewReviewRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 3).Return(openReview(review.StepProjects), nil)
+	mockRepo.EXPECT().FindDecisions(mock.Anything, 3).Return([]review.Decision{}, nil)
+	mockRepo.EXPECT().FindProjectIDsWithoutNextAction(mock.Anything, 7).Return([]int{5}, nil)
+	mockProjects := projectmocks.NewProjectUsecase(t)
+	mockProjects.EXPECT().List(mock.Anything, mock.Anything).Return(&project.ProjectListResponse{
+		Projects: []project.ProjectResponse{{ID: 4, Name: "Moving"}, {ID: 5, Name: "Taxes"}},
+	}, nil)
+
+	service := review.NewReviewService(mockRepo, passThroughTxManager{}, todomocks.NewTodoUsecase(t), mockProjects)
+
+	// when
+	res, err := service.GetStep(context.Background(), review.GetStepRequest{UserID: 7, ID: 3, Step: review.StepProjects})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 1)
+	assert.Equal(t, review.ItemProject, res.Items[0].Type)
+	assert.Equal(t, "Taxes", res.Items[0].Project.Name)
+}
+
+func TestDecide_MovesTheTodoAndRecordsTheDecision(t *testing.T) {
+	// given
+	mockRepo := reviewmocks.NewReviewRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 3).Return(openReview(review.StepWaitingFor), nil)
+	mockRepo.EXPECT().SaveDecision(mock.Anything, &review.Decision{
+		ReviewID: 3, Step: review.StepWaitingFor, ItemType: review.ItemTodo, ItemID: 9, Decision: review.DecisionActivate,
+	}).Return(&review.Decision{ID: 1, Step: review.StepWaitingFor, ItemID: 9, Decision: review.DecisionActivate}, nil)
+	mockTodos := todomocks.NewTodoUsecase(t)
+	mockTodos.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 9}).
+		Return(&todo.TodoResponse{ID: 9, Status: todo.StatusWaitingFor, Version: 4}, nil)
+	mockTodos.EXPECT().ChangeStatus(mock.Anything, todo.ChangeStatusRequest{UserID: 7, ID: 9, IfMatch: `"4"`, Status: todo.StatusNextActions}).
+		Return(&todo.StatusChangeResponse{Todo: &todo.TodoResponse{ID: 9, Status: todo.StatusNextActions, Version: 5}}, nil)
+
+	service := review.NewReviewService(mockRepo, passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t))
+
+	// when
+	res, err := service.Decide(context.Background(), review.DecideRequest{
+		UserID: 7, ID: 3, Step: review.StepWaitingFor, ItemType: review.ItemTodo, ItemID: 9, Decision: review.DecisionActivate,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, review.DecisionActivate, res.Decision.Decision)
+	assert.Equal(t, todo.StatusNextActions, res.Todo.Status)
+}
+
+func TestDecide_Rejects(t *testing.T) {
+	completedAt := started.Add(time.Hour)
+	tests := []struct {
+		name    string
+		review  *review.Review
+		req     review.DecideRequest
+		current *todo.TodoResponse
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name:   "a step the review is not at",
+			review: openReview(review.StepInbox),
+			req:    review.DecideRequest{Step: review.StepSomeday, ItemType: review.ItemTodo, Decision: review.DecisionKeep},
+			check: func(t *testing.T, err error) {
+				var target *review.ReviewStateError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name:   "a completed review",
+			review: &review.Review{ID: 3, Step: review.StepSomeday, CompletedAt: &completedAt},
+			req:    review.DecideRequest{Step: review.StepSomeday, ItemType: review.ItemTodo, Decision: review.DecisionKeep},
+			check: func(t *testing.T, err error) {
+				var target *review.ReviewStateError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name:   "a decision the step does not offer",
+			review: openReview(review.StepInbox),
+			req:    review.DecideRequest{Step: review.StepInbox, ItemType: review.ItemTodo, Decision: review.DecisionDone},
+			check: func(t *testing.T, err error) {
+				var target *review.InvalidDecisionError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name:    "an item that has left the step",
+			review:  openReview(review.StepSomeday),
+			req:     review.DecideRequest{Step: review.StepSomeday, ItemType: review.ItemTodo, Decision: review.DecisionTrash},
+			current: &todo.TodoResponse{ID: 9, Status: todo.StatusNextActions},
+			check: func(t *testing.T, err error) {
+				var target *review.ItemNotInStepError
+				assert.ErrorAs(t, err, &target)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockRepo := reviewmocks.NewReviewRepository(t)
+			mockRepo.EXPECT().FindByID(mock.Anything, 7, 3).Return(tt.review, nil)
+			mockTodos := todomocks.NewTodoUsecase(t)
+			if tt.current != nil {
+				mockTodos.EXPECT().Get(mock.Anything, mock.Anything).Return(tt.current, nil)
+			}
+			service := review.NewReviewService(mockRepo, passThroughTxManager{}, mockTodos, projectmocks.NewProjectUsecase(t))
+			req := tt.req
+			req.UserID, req.ID, req.ItemID = 7, 3, 9
+
+			// when
+			res, err := service.Decide(context.Background(), req)
+
+			// then
+			assert.Nil(t, res)
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestAdvance_CompletesAfterTheLastStep(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		complete bool
+	}{
+		{review.StepInbox, review.StepNextActions, false},
+		{review.StepProjects, review.StepSomeday, false},
+		{review.StepSomeday, review.StepSomeday, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from, func(t *testing.T) {
+			// given
+			mockRepo := reviewmocks.NewReviewRepository(t)
+			mockRepo.EXPECT().FindByID(mock.Anything, 7, 3).Return(openReview(tt.from), nil)
+			mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(r *review.Review) bool {
+				return r.Step == tt.to && (r.CompletedAt != nil) == tt.complete
+			})).RunAndReturn(func(ctx context.Context, r *review.Review) (*review.Review, error) {
+				return r, nil
+			})
+			service := review.NewReviewService(mockRepo, passThroughTxManager{}, todomocks.NewTodoUsecase(t), projectmocks.NewProjectUsecase(t))
+
+			// when
+			res, err := service.Advance(context.Background(), review.AdvanceReviewRequest{UserID: 7, ID: 3})
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, res.Step)
+			assert.Equal(t, tt.complete, res.CompletedAt != nil)
+		})
+	}
+}
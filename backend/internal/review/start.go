@@ -0,0 +1,21 @@
+package review
+
+import "context"
+
+// Start opens a review at its first step. A user has one open review at a time.
+func (s *reviewService) Start(ctx context.Context, req StartReviewRequest) (*ReviewResponse, error) {
+	kind := KindWeekly
+	if req.Kind != nil {
+		kind = *req.Kind
+	}
+	saved, err := s.reviewRepository.Save(ctx, &Review{UserID: req.UserID, Kind: kind, Step: Steps[0]})
+	if err != nil {
+		return nil, err
+	}
+	return toReviewResponse(saved), nil
+}
+
+type StartReviewRequest struct {
+	UserID int     `json:"-" auth:"user_id"`
+	Kind   *string `json:"kind" binding:"omitempty,oneof=daily weekly monthly"`
+}
@@ -0,0 +1,96 @@
+package review
+
+import (
+	"slices"
+	"time"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+const (
+	KindDaily   = "daily"
+	KindWeekly  = "weekly"
+	KindMonthly = "monthly"
+)
+
+const (
+	StepInbox       = "inbox"
+	StepNextActions = "next_actions"
+	StepWaitingFor  = "waiting_for"
+	StepProjects    = "projects"
+	StepSomeday     = "someday"
+)
+
+// Steps is the order a review walks through.
+var Steps = []string{StepInbox, StepNextActions, StepWaitingFor, StepProjects, StepSomeday}
+
+const (
+	ItemTodo    = "todo"
+	ItemProject = "project"
+)
+
+const (
+	DecisionKeep       = "keep"
+	DecisionDone       = "done"
+	DecisionSomeday    = "someday"
+	DecisionActivate   = "activate"
+	DecisionFollowedUp = "followed_up"
+	DecisionTrash      = "trash"
+)
+
+// stepStatuses are the todo statuses each todo step lists.
+var stepStatuses = map[string][]string{
+	StepInbox:       {todo.StatusInbox},
+	StepNextActions: {todo.StatusNextActions, todo.StatusInProgress},
+	StepWaitingFor:  {todo.StatusWaitingFor},
+	StepSomeday:     {todo.StatusSomeday},
+}
+
+// stepDecisions are the decisions each step accepts. Inbox items are clarified through the
+// clarify endpoint; the review only keeps, defers or trashes them.
+var stepDecisions = map[string][]string{
+	StepInbox:       {DecisionKeep, DecisionSomeday, DecisionTrash},
+	StepNextActions: {DecisionKeep, DecisionDone, DecisionSomeday, DecisionTrash},
+	StepWaitingFor:  {DecisionKeep, DecisionFollowedUp, DecisionActivate, DecisionDone, DecisionTrash},
+	StepProjects:    {DecisionKeep, DecisionTrash},
+	StepSomeday:     {DecisionKeep, DecisionActivate, DecisionTrash},
+}
+
+// decisionStatuses are the statuses decisions move a todo to. Keeping and following up
+// only record the decision.
+var decisionStatuses = map[string]string{
+	DecisionDone:     todo.StatusDone,
+	DecisionSomeday:  todo.StatusSomeday,
+	DecisionActivate: todo.StatusNextActions,
+}
+
+// stalePeriods is how long an item may go untouched before a review of each kind flags it.
+var stalePeriods = map[string]time.Duration{
+	KindDaily:   24 * time.Hour,
+	KindWeekly:  7 * 24 * time.Hour,
+	KindMonthly: 30 * 24 * time.Hour,
+}
+
+func stepItemType(step string) string {
+	if step == StepProjects {
+		return ItemProject
+	}
+	return ItemTodo
+}
+
+func allows(step string, itemType string, decision string) bool {
+	return stepItemType(step) == itemType && slices.Contains(stepDecisions[step], decision)
+}
+
+// nextStep returns the step after step, or false when step is the last.
+func nextStep(step string) (string, bool) {
+	i := slices.Index(Steps, step)
+	if i < 0 || i == len(Steps)-1 {
+		return "", false
+	}
+	return Steps[i+1], true
+}
+
+// staleBefore is the instant before which an item counts as stalled in review.
+func staleBefore(review *Review) time.Time {
+	return review.StartedAt.Add(-stalePeriods[review.Kind])
+}
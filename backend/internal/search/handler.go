@@ -0,0 +1,39 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+)
+
+type SearchHandler struct {
+	searchUsecase SearchUsecase
+}
+
+func NewSearchHandler(searchUsecase SearchUsecase) *SearchHandler {
+	return &SearchHandler{searchUsecase: searchUsecase}
+}
+
+func (h *SearchHandler) HandleSearch(ctx context.Context, req SearchRequest) (int, any) {
+	res, err := h.searchUsecase.Search(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError reports a malformed query as a violation of the q parameter, with the
+// column where parsing stopped in the message.
+func handleError(err error) (int, any) {
+	var parseError *ParseError
+	if errors.As(err, &parseError) {
+		return http.StatusBadRequest, ErrorResponse{
+			Error:      "Invalid search query.",
+			Violations: []apperror.Violation{{Location: "query.q", Message: parseError.Error()}},
+		}
+	}
+	return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+}
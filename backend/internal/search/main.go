@@ -0,0 +1,8 @@
+//go:generate mockery
+package search
+
+import "github.com/jmoiron/sqlx"
+
+func InitializeHandler(pool *sqlx.DB) *SearchHandler {
+	return NewSearchHandler(NewSearchService(NewSearchRepository(pool)))
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package searchmocks
+
+import (
+	context "context"
+	search "yangdongju/gtd_todo/internal/search"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchRepository is an autogenerated mock type for the SearchRepository type
+type SearchRepository struct {
+	mock.Mock
+}
+
+type SearchRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SearchRepository) EXPECT() *SearchRepository_Expecter {
+	return &SearchRepository_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx, userID, query, limit
+func (_m *SearchRepository) Search(ctx context.Context, userID int, query *search.Query, limit int) ([]search.Hit, error) {
+	ret := _m.Called(ctx, userID, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []search.Hit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *search.Query, int) ([]search.Hit, error)); ok {
+		return rf(ctx, userID, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *search.Query, int) []search.Hit); ok {
+		r0 = rf(ctx, userID, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]search.Hit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *search.Query, int) error); ok {
+		r1 = rf(ctx, userID, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type SearchRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - query *search.Query
+//   - limit int
+func (_e *SearchRepository_Expecter) Search(ctx interface{}, userID interface{}, query interface{}, limit interface{}) *SearchRepository_Search_Call {
+	return &SearchRepository_Search_Call{Call: _e.mock.On("Search", ctx, userID, query, limit)}
+}
+
+func (_c *SearchRepository_Search_Call) Run(run func(ctx context.Context, userID int, query *search.Query, limit int)) *SearchRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*search.Query), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *SearchRepository_Search_Call) Return(_a0 []search.Hit, _a1 error) *SearchRepository_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchRepository_Search_Call) RunAndReturn(run func(context.Context, int, *search.Query, int) ([]search.Hit, error)) *SearchRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSearchRepository creates a new instance of SearchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SearchRepository {
+	mock := &SearchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
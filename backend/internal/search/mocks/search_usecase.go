@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package searchmocks
+
+import (
+	context "context"
+	search "yangdongju/gtd_todo/internal/search"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchUsecase is an autogenerated mock type for the SearchUsecase type
+type SearchUsecase struct {
+	mock.Mock
+}
+
+type SearchUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SearchUsecase) EXPECT() *SearchUsecase_Expecter {
+	return &SearchUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx, request
+func (_m *SearchUsecase) Search(ctx context.Context, request search.SearchRequest) (*search.SearchResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *search.SearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, search.SearchRequest) (*search.SearchResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, search.SearchRequest) *search.SearchResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*search.SearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, search.SearchRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUsecase_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type SearchUsecase_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request search.SearchRequest
+func (_e *SearchUsecase_Expecter) Search(ctx interface{}, request interface{}) *SearchUsecase_Search_Call {
+	return &SearchUsecase_Search_Call{Call: _e.mock.On("Search", ctx, request)}
+}
+
+func (_c *SearchUsecase_Search_Call) Run(run func(ctx context.Context, request search.SearchRequest)) *SearchUsecase_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(search.SearchRequest))
+	})
+	return _c
+}
+
+func (_c *SearchUsecase_Search_Call) Return(_a0 *search.SearchResponse, _a1 error) *SearchUsecase_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchUsecase_Search_Call) RunAndReturn(run func(context.Context, search.SearchRequest) (*search.SearchResponse, error)) *SearchUsecase_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSearchUsecase creates a new instance of SearchUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearchUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SearchUsecase {
+	mock := &SearchUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
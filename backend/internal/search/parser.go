@@ -0,0 +1,241 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+const (
+	TypeTodo    = "todo"
+	TypeProject = "project"
+)
+
+var operators = []string{"status", "project", "is"}
+
+var statuses = []string{
+	todo.StatusInbox, todo.StatusNextActions, todo.StatusInProgress,
+	todo.StatusDone, todo.StatusSomeday, todo.StatusWaitingFor,
+}
+
+// Query is a parsed search string. Free text becomes Terms; operators narrow the results.
+// Repeating an operator, or listing values with commas, matches any of the values.
+type Query struct {
+	Terms    []Term
+	Statuses []string
+	Projects []string
+	Types    []string
+	Overdue  bool
+}
+
+// Term is a bare word, matched as a prefix, or a quoted phrase, matched word for word.
+type Term struct {
+	Words  []string
+	Phrase bool
+}
+
+// ParseError points at the character of the search string where parsing failed.
+type ParseError struct {
+	Column  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Message, e.Column)
+}
+
+// Parse reads a search string such as `milk status:waiting_for project:"Home reno"`.
+// Columns in errors count characters from 1.
+func Parse(input string) (*Query, error) {
+	p := parser{input: []rune(input)}
+	query := &Query{}
+	for {
+		p.skipSpace()
+		if p.done() {
+			return query, nil
+		}
+		if err := p.item(query); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Empty reports whether the query has neither text nor operators.
+func (q *Query) Empty() bool {
+	return len(q.Terms) == 0 && len(q.Statuses) == 0 && len(q.Projects) == 0 && len(q.Types) == 0 && !q.Overdue
+}
+
+// TodosOnly reports whether an operator only todos can satisfy is present.
+func (q *Query) TodosOnly() bool {
+	return len(q.Statuses) > 0 || len(q.Projects) > 0 || q.Overdue
+}
+
+// TSQuery renders the terms for to_tsquery, or "" when there is no free text. Words carry
+// only letters and digits, so no user input reaches the tsquery syntax.
+func (q *Query) TSQuery() string {
+	parts := make([]string, 0, len(q.Terms))
+	for _, term := range q.Terms {
+		lexemes := make([]string, 0, len(term.Words))
+		for _, word := range term.Words {
+			if term.Phrase {
+				lexemes = append(lexemes, "'"+word+"'")
+			} else {
+				lexemes = append(lexemes, "'"+word+"':*")
+			}
+		}
+		if term.Phrase {
+			parts = append(parts, "("+strings.Join(lexemes, " <-> ")+")")
+		} else {
+			parts = append(parts, strings.Join(lexemes, " & "))
+		}
+	}
+	return strings.Join(parts, " & ")
+}
+
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) fail(pos int, format string, args ...any) *ParseError {
+	return &ParseError{Column: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+// item reads one word, phrase or operator.
+func (p *parser) item(query *Query) error {
+	if p.input[p.pos] == '"' {
+		phrase, err := p.quoted()
+		if err != nil {
+			return err
+		}
+		query.addTerm(phrase, true)
+		return nil
+	}
+
+	key := p.operatorKey()
+	if key == "" {
+		query.addTerm(p.bare(), false)
+		return nil
+	}
+
+	if !slices.Contains(operators, key) {
+		return p.fail(p.pos, "unknown operator %q", key)
+	}
+	p.pos += len([]rune(key)) + 1
+	valueStart := p.pos
+	var value string
+	if !p.done() && p.input[p.pos] == '"' {
+		quoted, err := p.quoted()
+		if err != nil {
+			return err
+		}
+		value = quoted
+	} else {
+		value = p.bare()
+	}
+	if strings.TrimSpace(value) == "" {
+		return p.fail(valueStart, "%s: needs a value", key)
+	}
+
+	switch key {
+	case "status":
+		return p.eachValue(value, valueStart, func(status string, at int) error {
+			if !slices.Contains(statuses, status) {
+				return p.fail(at, "unknown status %q", status)
+			}
+			query.Statuses = append(query.Statuses, status)
+			return nil
+		})
+	case "project":
+		query.Projects = append(query.Projects, value)
+	case "is":
+		return p.eachValue(value, valueStart, func(is string, at int) error {
+			switch is {
+			case TypeTodo, TypeProject:
+				query.Types = append(query.Types, is)
+				return nil
+			case "overdue":
+				query.Overdue = true
+				return nil
+			}
+			return p.fail(at, "unknown is: value %q", is)
+		})
+	}
+	return nil
+}
+
+// eachValue calls fn with every comma-separated value and the position it starts at.
+func (p *parser) eachValue(value string, start int, fn func(value string, at int) error) error {
+	at := start
+	for _, part := range strings.Split(value, ",") {
+		if err := fn(part, at); err != nil {
+			return err
+		}
+		at += len([]rune(part)) + 1
+	}
+	return nil
+}
+
+// operatorKey returns the letters before a colon at the current position, or "" when the
+// item is not an operator.
+func (p *parser) operatorKey() string {
+	end := p.pos
+	for end < len(p.input) && unicode.IsLetter(p.input[end]) {
+		end++
+	}
+	if end == p.pos || end >= len(p.input) || p.input[end] != ':' {
+		return ""
+	}
+	return string(p.input[p.pos:end])
+}
+
+func (p *parser) bare() string {
+	start := p.pos
+	for !p.done() && !unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+func (p *parser) quoted() (string, error) {
+	open := p.pos
+	p.pos++
+	start := p.pos
+	for !p.done() && p.input[p.pos] != '"' {
+		p.pos++
+	}
+	if p.done() {
+		return "", p.fail(open, "unterminated quote")
+	}
+	value := string(p.input[start:p.pos])
+	p.pos++
+	return value, nil
+}
+
+func (q *Query) addTerm(text string, phrase bool) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	if len(words) == 0 {
+		return
+	}
+	if !phrase {
+		for _, word := range words {
+			q.Terms = append(q.Terms, Term{Words: []string{word}})
+		}
+		return
+	}
+	q.Terms = append(q.Terms, Term{Words: words, Phrase: true})
+}
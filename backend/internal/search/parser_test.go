@@ -0,0 +1,73 @@
+package search_test
+
+import (
+	"testing"
+	"yangdongju/gtd_todo/internal/search"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_TermsAndOperators(t *testing.T) {
+	// when
+	query, err := search.Parse(`buy Milk status:waiting_for,inbox project:"Home reno" "oat  milk" is:todo`)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []search.Term{
+		{Words: []string{"buy"}},
+		{Words: []string{"milk"}},
+		{Words: []string{"oat", "milk"}, Phrase: true},
+	}, query.Terms)
+	assert.Equal(t, []string{"waiting_for", "inbox"}, query.Statuses)
+	assert.Equal(t, []string{"Home reno"}, query.Projects)
+	assert.Equal(t, []string{search.TypeTodo}, query.Types)
+	assert.Equal(t, `'buy':* & 'milk':* & ('oat' <-> 'milk')`, query.TSQuery())
+}
+
+func TestParse_OverdueLimitsResultsToTodos(t *testing.T) {
+	// when
+	query, err := search.Parse(`is:overdue`)
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, query.Overdue)
+	assert.True(t, query.TodosOnly())
+	assert.False(t, query.Empty())
+}
+
+func TestParse_PunctuationNeverReachesTSQuery(t *testing.T) {
+	// when
+	query, err := search.Parse(`우유를 e-mail 10:30 '&!|`)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, `'우유를':* & 'e':* & 'mail':* & '10':* & '30':*`, query.TSQuery())
+}
+
+func TestParse_ErrorPositions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		message string
+		column  int
+	}{
+		{"unknown operator", `milk colour:red`, `unknown operator "colour"`, 6},
+		{"unknown status", `status:inbox,later`, `unknown status "later"`, 14},
+		{"missing value", `status: milk`, `status: needs a value`, 8},
+		{"unterminated quote", `project:"Home reno`, `unterminated quote`, 9},
+		{"unterminated phrase", `우유 "oat milk`, `unterminated quote`, 4},
+		{"unknown is value", `is:starred`, `unknown is: value "starred"`, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			_, err := search.Parse(tt.input)
+
+			// then
+			var parseError *search.ParseError
+			assert.ErrorAs(t, err, &parseError)
+			assert.Equal(t, tt.message, parseError.Message)
+			assert.Equal(t, tt.column, parseError.Column)
+		})
+	}
+}
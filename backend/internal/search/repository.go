@@ -0,0 +1,108 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Headlines mark matches with private-use characters, which the service turns into
+// <mark> tags after escaping the text around them.
+const (
+	markStart = "\uE000"
+	markStop  = "\uE001"
+)
+
+var (
+	titleHeadline       = "HighlightAll=true, StartSel=" + markStart + ", StopSel=" + markStop
+	descriptionHeadline = `MaxFragments=2, MaxWords=20, MinWords=8, FragmentDelimiter=" ... ", StartSel=` + markStart + ", StopSel=" + markStop
+)
+
+type SearchRepository interface {
+	Search(ctx context.Context, userID int, query *Query, limit int) ([]Hit, error)
+}
+
+type searchRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// Hit is a matching todo or project. Headlines are nil when the query has no free text,
+// and the checklist headline also when no checklist item matches.
+type Hit struct {
+	Type                string    `db:"type"`
+	ID                  int       `db:"id"`
+	Title               string    `db:"title"`
+	Description         *string   `db:"description"`
+	Status              *string   `db:"status"`
+	ProjectID           *int      `db:"project_id"`
+	Rank                float64   `db:"rank"`
+	TitleHeadline       *string   `db:"title_headline"`
+	DescriptionHeadline *string   `db:"description_headline"`
+	ChecklistHeadline   *string   `db:"checklist_headline"`
+	UpdatedAt           time.Time `db:"updated_at"`
+}
+
+func NewSearchRepository(db *sqlx.DB) *searchRepositoryImpl {
+	return &searchRepositoryImpl{db: db}
+}
+
+// Search ranks todos and projects together, best match first. Without free text every
+// item passing the operators matches, most recently updated first.
+func (r *searchRepositoryImpl) Search(ctx context.Context, userID int, query *Query, limit int) ([]Hit, error) {
+	var statuses, projects pq.StringArray
+	if len(query.Statuses) > 0 {
+		statuses = query.Statuses
+	}
+	for _, name := range query.Projects {
+		projects = append(projects, strings.ToLower(name))
+	}
+	includeTodos := len(query.Types) == 0 || slices.Contains(query.Types, TypeTodo)
+	includeProjects := (len(query.Types) == 0 || slices.Contains(query.Types, TypeProject)) && !query.TodosOnly()
+
+	hits := []Hit{}
+	err := r.db.SelectContext(ctx, &hits, `
+		WITH q AS (
+			SELECT CASE WHEN $2 = '' THEN NULL ELSE to_tsquery('simple', $2) END AS query
+		)
+		SELECT * FROM (
+			SELECT 'todo' AS type, t.id, t.title, t.description, t.status, t.project_id,
+				COALESCE(ts_rank_cd(t.search_vector, q.query), 0) AS rank,
+				ts_headline('simple', t.title, q.query, $5) AS title_headline,
+				ts_headline('simple', t.description, q.query, $6) AS description_headline,
+				CASE WHEN to_tsvector('simple', COALESCE(t.checklist_text, '')) @@ q.query
+					THEN ts_headline('simple', t.checklist_text, q.query, $6) END AS checklist_headline,
+				t.updated_at
+			FROM todos t
+			CROSS JOIN q
+			JOIN users u ON u.id = t.user_id
+			LEFT JOIN projects p ON p.id = t.project_id
+			WHERE $7 AND t.user_id = $1
+				AND (q.query IS NULL OR t.search_vector @@ q.query)
+				AND ($3::text[] IS NULL OR t.status = ANY($3))
+				AND ($4::text[] IS NULL OR LOWER(p.name) = ANY($4))
+				AND (NOT $10 OR (t.status <> 'done' AND todo_due_at(t.due_date, t.due_time, u.timezone) < CURRENT_TIMESTAMP))
+			UNION ALL
+			SELECT 'project' AS type, p.id, p.name, p.description, NULL::varchar, NULL::integer,
+				COALESCE(ts_rank_cd(p.search_vector, q.query), 0) AS rank,
+				ts_headline('simple', p.name, q.query, $5) AS title_headline,
+				ts_headline('simple', p.description, q.query, $6) AS description_headline,
+				NULL AS checklist_headline,
+				p.updated_at
+			FROM projects p
+			CROSS JOIN q
+			WHERE $8 AND p.user_id = $1
+				AND (q.query IS NULL OR p.search_vector @@ q.query)
+		) hits
+		ORDER BY rank DESC, updated_at DESC, type, id
+		LIMIT $9`,
+		userID, query.TSQuery(), statuses, projects, titleHeadline, descriptionHeadline,
+		includeTodos, includeProjects, limit, query.Overdue)
+	if err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
@@ -0,0 +1,139 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/search"
+	"yangdongju/gtd_todo/internal/todo"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func insertUser(t *testing.T, email string) int {
+	var id int
+	err := testhelper.GetTestDB().Get(&id,
+		"INSERT INTO users (email, password_hash) VALUES ($1, 'hash') RETURNING id", email)
+	assert.NoError(t, err)
+	return id
+}
+
+func TestSearchRepository_RanksPrefixMatchesAcrossTodosAndProjects(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t, "hello@example.com")
+	otherID := insertUser(t, "other@example.com")
+	ctx := context.Background()
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	projects := project.NewProjectRepository(testhelper.GetTestDB())
+	home, _ := projects.Save(ctx, &project.Project{UserID: userID, Name: "Home reno", Color: "#3B82F6"})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Paint the kitchen", Status: todo.StatusInbox, ProjectID: &home.ID})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Call plumber", Description: ptr("Ask about the kitchen sink"), Status: todo.StatusWaitingFor})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: otherID, Title: "Kitchen scale", Status: todo.StatusInbox})
+	repository := search.NewSearchRepository(testhelper.GetTestDB())
+
+	// when
+	all, allErr := repository.Search(ctx, userID, mustParse(t, "kitch"), 20)
+	waiting, waitingErr := repository.Search(ctx, userID, mustParse(t, "kitchen status:waiting_for"), 20)
+	inProject, projectErr := repository.Search(ctx, userID, mustParse(t, `project:"home RENO"`), 20)
+
+	// then
+	assert.NoError(t, allErr)
+	assert.NoError(t, waitingErr)
+	assert.NoError(t, projectErr)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "Paint the kitchen", all[0].Title, "a title match outranks a description match")
+	assert.Len(t, waiting, 1)
+	assert.Equal(t, "Call plumber", waiting[0].Title)
+	assert.Contains(t, *waiting[0].DescriptionHeadline, "kitchen")
+	assert.Len(t, inProject, 1)
+	assert.Equal(t, "Paint the kitchen", inProject[0].Title)
+	assert.Nil(t, inProject[0].TitleHeadline)
+}
+
+func TestSearchRepository_OverdueReadsDueDatesInTheUsersTimezone(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t, "hello@example.com")
+	testhelper.GetTestDB().MustExec("UPDATE users SET timezone = 'Pacific/Kiritimati' WHERE id = $1", userID)
+	ctx := context.Background()
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	yesterday := time.Now().In(mustLoad(t, "Pacific/Kiritimati")).AddDate(0, 0, -1)
+	due := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, time.UTC)
+	tomorrow := due.AddDate(0, 0, 2)
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, Title: "File taxes", Status: todo.StatusInbox, DueDate: &due})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Pay rent", Status: todo.StatusDone, DueDate: &due})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Book dentist", Status: todo.StatusInbox, DueDate: &tomorrow})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Read book", Status: todo.StatusInbox})
+	repository := search.NewSearchRepository(testhelper.GetTestDB())
+
+	// when
+	overdue, err := repository.Search(ctx, userID, mustParse(t, "is:overdue"), 20)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, overdue, 1)
+	assert.Equal(t, "File taxes", overdue[0].Title)
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	assert.NoError(t, err)
+	return location
+}
+
+func TestSearchRepository_MatchesChecklistItems(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t, "hello@example.com")
+	ctx := context.Background()
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	release, _ := todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Prepare release", Status: todo.StatusNextActions})
+	_, _ = todos.Save(ctx, &todo.Todo{UserID: userID, Title: "Changelog cleanup", Status: todo.StatusInbox})
+	_ = todos.SaveChecklistItem(ctx, &todo.ChecklistItem{TodoID: release.ID, Title: "Update the changelog"})
+	_, _ = todos.TouchChecklist(ctx, release.ID)
+	repository := search.NewSearchRepository(testhelper.GetTestDB())
+
+	// when
+	hits, err := repository.Search(ctx, userID, mustParse(t, "changelog"), 20)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, hits, 2)
+	assert.Equal(t, "Changelog cleanup", hits[0].Title, "a title match outranks a checklist match")
+	assert.Nil(t, hits[0].ChecklistHeadline)
+	assert.Equal(t, "Prepare release", hits[1].Title)
+	assert.Contains(t, *hits[1].ChecklistHeadline, "changelog")
+}
+
+func TestSearchRepository_MatchesTodosByProjectName(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t, "hello@example.com")
+	ctx := context.Background()
+	garden, _ := project.NewProjectRepository(testhelper.GetTestDB()).Save(ctx, &project.Project{UserID: userID, Name: "Garden", Color: "#3B82F6"})
+	todos := todo.NewTodoRepository(testhelper.GetTestDB())
+	seeds, _ := todos.Save(ctx, &todo.Todo{UserID: userID, ProjectID: &garden.ID, Title: "Buy seeds", Status: todo.StatusInbox})
+	_, err := testhelper.GetTestDB().Exec("UPDATE projects SET name = 'Orchard', version = version + 1 WHERE id = $1", garden.ID)
+	assert.NoError(t, err)
+	repository := search.NewSearchRepository(testhelper.GetTestDB())
+
+	// when
+	hits, err := repository.Search(ctx, userID, mustParse(t, "orchard"), 20)
+	renamed, _ := todos.FindByID(ctx, userID, seeds.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, hits, 2)
+	assert.Equal(t, search.TypeProject, hits[0].Type, "a project name match outranks a todo's project")
+	assert.Equal(t, seeds.ID, hits[1].ID)
+	assert.Equal(t, seeds.Version, renamed.Version, "refreshing the copied name leaves the todo's version")
+}
+
+func mustParse(t *testing.T, input string) *search.Query {
+	query, err := search.Parse(input)
+	assert.NoError(t, err)
+	return query
+}
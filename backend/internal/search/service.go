@@ -0,0 +1,98 @@
+package search
+
+import (
+	"context"
+	"html"
+	"strings"
+)
+
+const defaultLimit = 20
+
+type SearchUsecase interface {
+	Search(ctx context.Context, request SearchRequest) (*SearchResponse, error)
+}
+
+type searchService struct {
+	searchRepository SearchRepository
+}
+
+func NewSearchService(repository SearchRepository) *searchService {
+	return &searchService{searchRepository: repository}
+}
+
+// Search parses the query string and returns the best matches. Snippets are HTML-escaped
+// with matches wrapped in <mark>, so clients can render them as markup.
+func (s *searchService) Search(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	query, err := Parse(req.Q)
+	if err != nil {
+		return nil, err
+	}
+	res := &SearchResponse{Results: []SearchResult{}}
+	if query.Empty() {
+		return res, nil
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	hits, err := s.searchRepository.Search(ctx, req.UserID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hit := range hits {
+		result := SearchResult{
+			Type:         hit.Type,
+			ID:           hit.ID,
+			Title:        hit.Title,
+			Status:       hit.Status,
+			ProjectID:    hit.ProjectID,
+			Rank:         hit.Rank,
+			TitleSnippet: snippet(hit.TitleHeadline, hit.Title),
+		}
+		if hit.Description != nil {
+			description := snippet(hit.DescriptionHeadline, *hit.Description)
+			result.DescriptionSnippet = &description
+		}
+		if hit.ChecklistHeadline != nil {
+			checklist := snippet(hit.ChecklistHeadline, "")
+			result.ChecklistSnippet = &checklist
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// snippet escapes a headline, or the raw text when there is none, and turns the match
+// markers into <mark> tags.
+func snippet(headline *string, raw string) string {
+	text := raw
+	if headline != nil {
+		text = *headline
+	}
+	text = html.EscapeString(text)
+	return strings.NewReplacer(markStart, "<mark>", markStop, "</mark>").Replace(text)
+}
+
+type SearchRequest struct {
+	UserID int    `json:"-" auth:"user_id"`
+	Q      string `json:"-" form:"q" binding:"required,max=500"`
+	Limit  int    `json:"-" form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type SearchResponse struct {
+	Results []SearchResult `json:"results"`
+}
+
+type SearchResult struct {
+	Type               string  `json:"type"`
+	ID                 int     `json:"id"`
+	Title              string  `json:"title"`
+	Status             *string `json:"status"`
+	ProjectID          *int    `json:"project_id"`
+	Rank               float64 `json:"rank"`
+	TitleSnippet       string  `json:"title_snippet"`
+	DescriptionSnippet *string `json:"description_snippet"`
+	ChecklistSnippet   *string `json:"checklist_snippet"`
+}
@@ -0,0 +1,60 @@
+package search_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/search"
+	searchmocks "yangdongju/gtd_todo/internal/search/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestSearch_EscapesSnippetsAroundMatches(t *testing.T) {
+	// given
+	mockRepo := searchmocks.NewSearchRepository(t)
+	mockRepo.EXPECT().Search(mock.Anything, 7, mock.MatchedBy(func(q *search.Query) bool {
+		return q.TSQuery() == "'milk':*"
+	}), 20).Return([]search.Hit{{
+		Type:                search.TypeTodo,
+		ID:                  1,
+		Title:               "Buy milk <now>",
+		Description:         ptr("Oat & soy"),
+		Status:              ptr("inbox"),
+		Rank:                0.1,
+		TitleHeadline:       ptr("Buy \uE000milk\uE001 <now>"),
+		DescriptionHeadline: nil,
+		ChecklistHeadline:   ptr("Check the \uE000milk\uE001 & bread"),
+	}}, nil)
+
+	service := search.NewSearchService(mockRepo)
+
+	// when
+	res, err := service.Search(context.Background(), search.SearchRequest{UserID: 7, Q: "milk"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 1)
+	assert.Equal(t, "Buy <mark>milk</mark> &lt;now&gt;", res.Results[0].TitleSnippet)
+	assert.Equal(t, "Oat &amp; soy", *res.Results[0].DescriptionSnippet)
+	assert.Equal(t, "Check the <mark>milk</mark> &amp; bread", *res.Results[0].ChecklistSnippet)
+}
+
+func TestSearch_ParseErrorIsBadRequest(t *testing.T) {
+	// given
+	handler := search.NewSearchHandler(search.NewSearchService(searchmocks.NewSearchRepository(t)))
+
+	// when
+	status, body := handler.HandleSearch(context.Background(), search.SearchRequest{UserID: 7, Q: "milk colour:red"})
+
+	// then
+	res := body.(search.ErrorResponse)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "query.q", res.Violations[0].Location)
+	assert.Equal(t, `unknown operator "colour" at column 6`, res.Violations[0].Message)
+}
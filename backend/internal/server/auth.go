@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/user"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	userIDKey       = "userID"
+	eventStreamPath = "/api/events"
+)
+
+// authenticate resolves the bearer token when one is sent. Routes that need a user
+// additionally use requireAuth. The event stream also takes a ticket in the query, as a
+// browser EventSource cannot set headers.
+func authenticate(parser user.Parser) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, ok := bearerToken(c); ok {
+			if claims, err := parser.Parse(token); err == nil {
+				c.Set(userIDKey, claims.UserID)
+			}
+		} else if ticket := c.Query("ticket"); ticket != "" && c.FullPath() == eventStreamPath {
+			if claims, err := parser.ParseTicket(ticket, user.StreamAudience); err == nil {
+				c.Set(userIDKey, claims.UserID)
+			}
+		}
+		c.Next()
+	}
+}
+
+// bearerToken reads the Authorization header. Browsers cannot set headers on a WebSocket
+// handshake, so there the token may also be offered as a "bearer.<token>" subprotocol.
+func bearerToken(c *gin.Context) (string, bool) {
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		return token, token != ""
+	}
+	for _, protocol := range websocket.Subprotocols(c.Request) {
+		if token, ok := strings.CutPrefix(protocol, "bearer."); ok && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+func requireAuth(c *gin.Context) {
+	if _, ok := currentUserID(c); !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, apperror.ErrorResponse{Error: "Authentication required"})
+		return
+	}
+	c.Next()
+}
+
+func currentUserID(c *gin.Context) (int, bool) {
+	userID, ok := c.Get(userIDKey)
+	if !ok {
+		return 0, false
+	}
+	return userID.(int), true
+}
+
+// clientKey identifies the caller by user when authenticated, otherwise by client IP.
+func clientKey(c *gin.Context) string {
+	if userID, ok := currentUserID(c); ok {
+		return "user:" + strconv.Itoa(userID)
+	}
+	return "ip:" + c.ClientIP()
+}
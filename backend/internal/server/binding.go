@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// bindRequest fills payload from the JSON body or, for bodiless requests, the query string,
+// then from the path, headers and authenticated user through the uri, header and auth tags.
+// Only keys a tag declares are mapped, so a client cannot reach fields like UserID.
+func bindRequest(c *gin.Context, payload any, withBody bool) error {
+	if withBody {
+		if err := c.ShouldBindJSON(payload); err != nil {
+			return err
+		}
+	} else if err := mapTagged(payload, "form", func(name string) []string { return c.QueryArray(name) }); err != nil {
+		return err
+	}
+
+	sources := map[string]func(name string) []string{
+		"uri": func(name string) []string {
+			if value, ok := c.Params.Get(name); ok {
+				return []string{value}
+			}
+			return nil
+		},
+		"header": func(name string) []string { return c.Request.Header.Values(http.CanonicalHeaderKey(name)) },
+		"auth": func(name string) []string {
+			if userID, ok := currentUserID(c); ok && name == "user_id" {
+				return []string{strconv.Itoa(userID)}
+			}
+			return nil
+		},
+	}
+	for tag, lookup := range sources {
+		if err := mapTagged(payload, tag, lookup); err != nil {
+			return err
+		}
+	}
+
+	if withBody {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(payload)
+}
+
+func mapTagged(payload any, tag string, lookup func(name string) []string) error {
+	values := map[string][]string{}
+	t := reflect.TypeOf(payload).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get(tag), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if found := lookup(name); len(found) > 0 {
+			values[name] = found
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return binding.MapFormWithTag(payload, values, tag)
+}
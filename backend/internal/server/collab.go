@@ -0,0 +1,29 @@
+package server
+
+import (
+	"log"
+	"yangdongju/gtd_todo/internal/collab"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// The default origin check stays on: browsers only connect from the page's own origin.
+var collabUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	Subprotocols:    collab.Subprotocols(),
+}
+
+func (a *ginAdapter) collaborate(c *gin.Context) {
+	userID, _ := currentUserID(c)
+	conn, err := collabUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has already answered the handshake with an error status.
+		return
+	}
+
+	if err := a.collabHub.Serve(c.Request.Context(), conn, userID); err != nil {
+		log.Printf("Collaboration session closed. user=%v err=%v", userID, err)
+	}
+}
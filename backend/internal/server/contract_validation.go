@@ -0,0 +1,44 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contractValidation rejects requests that do not match the OpenAPI document
+// before they reach a handler.
+func contractValidation(validator *openapi.Validator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			read, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, apperror.ErrorResponse{Error: err.Error()})
+				return
+			}
+			body = read
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		path := openapi.ToOpenAPIPath(c.FullPath())
+		violations := validator.ValidateRequest(c.Request.Method, path, c.Request.URL.Query(), body)
+		if len(violations) > 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, apperror.ErrorResponse{
+				Error:      "request does not match the API contract",
+				Violations: violations,
+			})
+			return
+		}
+		c.Next()
+	}
+}
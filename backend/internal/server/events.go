@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+const sseRetryMillis = 3000
+
+func (a *ginAdapter) streamTicket(c *gin.Context) {
+	handleRequest(c, &user.IssueTicketRequest{}, a.ticketHandler.HandleIssueStreamTicket)
+}
+
+func (a *ginAdapter) events(c *gin.Context) {
+	req := &event.StreamRequest{}
+	if err := bindRequest(c, req, false); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorResponse{Error: err.Error()})
+		return
+	}
+	lastEventID, err := req.ResumeFrom()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetryMillis)
+	c.Writer.Flush()
+
+	if err := a.eventStreamer.Stream(c.Request.Context(), req.UserID, lastEventID, sseEmitter{c: c}); err != nil {
+		log.Printf("Event stream closed. user=%v err=%v", req.UserID, err)
+	}
+}
+
+type sseEmitter struct {
+	c *gin.Context
+}
+
+func (e sseEmitter) Emit(ev event.Event) error {
+	if _, err := fmt.Fprintf(e.c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, ev.Payload); err != nil {
+		return err
+	}
+	e.c.Writer.Flush()
+	return nil
+}
+
+func (e sseEmitter) Heartbeat() error {
+	if _, err := fmt.Fprint(e.c.Writer, ": keep-alive\n\n"); err != nil {
+		return err
+	}
+	e.c.Writer.Flush()
+	return nil
+}
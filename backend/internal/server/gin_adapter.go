@@ -1,16 +1,50 @@
 package server
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"time"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/attention"
+	"yangdongju/gtd_todo/internal/clarify"
+	"yangdongju/gtd_todo/internal/collab"
+	"yangdongju/gtd_todo/internal/delta"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/health"
+	"yangdongju/gtd_todo/internal/idempotency"
+	"yangdongju/gtd_todo/internal/migrate"
+	"yangdongju/gtd_todo/internal/next"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/ratelimit"
+	"yangdongju/gtd_todo/internal/review"
+	"yangdongju/gtd_todo/internal/search"
+	"yangdongju/gtd_todo/internal/tag"
+	"yangdongju/gtd_todo/internal/todo"
 	"yangdongju/gtd_todo/internal/user"
+	"yangdongju/gtd_todo/migrations"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 )
 
 type ginAdapter struct {
-	userHandler *user.UserHandler
+	userHandler      *user.UserHandler
+	settingsHandler  *user.SettingsHandler
+	ticketHandler    *user.TicketHandler
+	todoHandler      *todo.TodoHandler
+	projectHandler   *project.ProjectHandler
+	tagHandler       *tag.TagHandler
+	clarifyHandler   *clarify.ClarifyHandler
+	reviewHandler    *review.ReviewHandler
+	attentionHandler *attention.AttentionHandler
+	nextHandler      *next.NextHandler
+	syncHandler      *delta.SyncHandler
+	searchHandler    *search.SearchHandler
+	healthHandler    *health.HealthHandler
+	eventStreamer    *event.Streamer
+	activity         *event.ActivityHandler
+	collabHub        *collab.Hub
 }
 
 func (a *ginAdapter) signUp(c *gin.Context) {
@@ -21,29 +55,383 @@ func (a *ginAdapter) login(c *gin.Context) {
 	handleJSONRequest(c, &user.LoginRequest{}, a.userHandler.HandleLogin)
 }
 
-func handleJSONRequest[T any, R any](c *gin.Context, payload *T, handle func(T) (int, R)) {
-	if err := c.ShouldBindJSON(payload); err != nil {
-		c.JSON(http.StatusBadRequest, err.Error())
-		return
+func (a *ginAdapter) getSettings(c *gin.Context) {
+	handleRequest(c, &user.GetSettingsRequest{}, a.settingsHandler.HandleGet)
+}
+
+func (a *ginAdapter) updateSettings(c *gin.Context) {
+	handleJSONRequest(c, &user.UpdateSettingsRequest{}, a.settingsHandler.HandleUpdate)
+}
+
+func (a *ginAdapter) createTodo(c *gin.Context) {
+	handleJSONRequest(c, &todo.CreateTodoRequest{}, a.todoHandler.HandleCreate)
+}
+
+func (a *ginAdapter) listTodos(c *gin.Context) {
+	handleRequest(c, &todo.ListTodosRequest{}, a.todoHandler.HandleList)
+}
+
+func (a *ginAdapter) listTodosByContext(c *gin.Context) {
+	handleRequest(c, &todo.ListByContextRequest{}, a.todoHandler.HandleListByContext)
+}
+
+func (a *ginAdapter) listWaiting(c *gin.Context) {
+	handleRequest(c, &todo.ListWaitingRequest{}, a.todoHandler.HandleListWaiting)
+}
+
+func (a *ginAdapter) getTodo(c *gin.Context) {
+	handleRequest(c, &todo.GetTodoRequest{}, a.todoHandler.HandleGet)
+}
+
+func (a *ginAdapter) updateTodo(c *gin.Context) {
+	handleJSONRequest(c, &todo.UpdateTodoRequest{}, a.todoHandler.HandleUpdate)
+}
+
+func (a *ginAdapter) changeTodoStatus(c *gin.Context) {
+	handleJSONRequest(c, &todo.ChangeStatusRequest{}, a.todoHandler.HandleChangeStatus)
+}
+
+func (a *ginAdapter) clarifyTodo(c *gin.Context) {
+	handleJSONRequest(c, &clarify.ClarifyRequest{}, a.clarifyHandler.HandleClarify)
+}
+
+func (a *ginAdapter) deleteTodo(c *gin.Context) {
+	handleRequest(c, &todo.DeleteTodoRequest{}, a.todoHandler.HandleDelete)
+}
+
+func (a *ginAdapter) addChecklistItem(c *gin.Context) {
+	handleJSONRequest(c, &todo.AddChecklistItemRequest{}, a.todoHandler.HandleAddChecklistItem)
+}
+
+func (a *ginAdapter) updateChecklistItem(c *gin.Context) {
+	handleJSONRequest(c, &todo.UpdateChecklistItemRequest{}, a.todoHandler.HandleUpdateChecklistItem)
+}
+
+func (a *ginAdapter) deleteChecklistItem(c *gin.Context) {
+	handleRequest(c, &todo.DeleteChecklistItemRequest{}, a.todoHandler.HandleDeleteChecklistItem)
+}
+
+func (a *ginAdapter) reorderChecklist(c *gin.Context) {
+	handleJSONRequest(c, &todo.ReorderChecklistRequest{}, a.todoHandler.HandleReorderChecklist)
+}
+
+func (a *ginAdapter) getTodoDependencies(c *gin.Context) {
+	handleRequest(c, &todo.GetDependenciesRequest{}, a.todoHandler.HandleGetDependencies)
+}
+
+func (a *ginAdapter) addTodoDependency(c *gin.Context) {
+	handleJSONRequest(c, &todo.AddDependencyRequest{}, a.todoHandler.HandleAddDependency)
+}
+
+func (a *ginAdapter) removeTodoDependency(c *gin.Context) {
+	handleRequest(c, &todo.RemoveDependencyRequest{}, a.todoHandler.HandleRemoveDependency)
+}
+
+func (a *ginAdapter) createProject(c *gin.Context) {
+	handleJSONRequest(c, &project.CreateProjectRequest{}, a.projectHandler.HandleCreate)
+}
+
+func (a *ginAdapter) listProjects(c *gin.Context) {
+	handleRequest(c, &project.ListProjectsRequest{}, a.projectHandler.HandleList)
+}
+
+func (a *ginAdapter) getProject(c *gin.Context) {
+	handleRequest(c, &project.GetProjectRequest{}, a.projectHandler.HandleGet)
+}
+
+func (a *ginAdapter) updateProject(c *gin.Context) {
+	handleJSONRequest(c, &project.UpdateProjectRequest{}, a.projectHandler.HandleUpdate)
+}
+
+func (a *ginAdapter) deleteProject(c *gin.Context) {
+	handleRequest(c, &project.DeleteProjectRequest{}, a.projectHandler.HandleDelete)
+}
+
+func (a *ginAdapter) moveProject(c *gin.Context) {
+	handleJSONRequest(c, &project.MoveProjectRequest{}, a.projectHandler.HandleMove)
+}
+
+func (a *ginAdapter) changeProjectStatus(c *gin.Context) {
+	handleJSONRequest(c, &project.ChangeProjectStatusRequest{}, a.projectHandler.HandleChangeStatus)
+}
+
+func (a *ginAdapter) listArchivedProjects(c *gin.Context) {
+	handleRequest(c, &project.ListArchivedProjectsRequest{}, a.projectHandler.HandleListArchived)
+}
+
+func (a *ginAdapter) getProjectTree(c *gin.Context) {
+	handleRequest(c, &project.ProjectTreeRequest{}, a.projectHandler.HandleTree)
+}
+
+func (a *ginAdapter) createArea(c *gin.Context) {
+	handleJSONRequest(c, &project.CreateAreaRequest{}, a.projectHandler.HandleCreateArea)
+}
+
+func (a *ginAdapter) listAreas(c *gin.Context) {
+	handleRequest(c, &project.ListAreasRequest{}, a.projectHandler.HandleListAreas)
+}
+
+func (a *ginAdapter) getArea(c *gin.Context) {
+	handleRequest(c, &project.GetAreaRequest{}, a.projectHandler.HandleGetArea)
+}
+
+func (a *ginAdapter) updateArea(c *gin.Context) {
+	handleJSONRequest(c, &project.UpdateAreaRequest{}, a.projectHandler.HandleUpdateArea)
+}
+
+func (a *ginAdapter) deleteArea(c *gin.Context) {
+	handleRequest(c, &project.DeleteAreaRequest{}, a.projectHandler.HandleDeleteArea)
+}
+
+func (a *ginAdapter) createTag(c *gin.Context) {
+	handleJSONRequest(c, &tag.CreateTagRequest{}, a.tagHandler.HandleCreate)
+}
+
+func (a *ginAdapter) listTags(c *gin.Context) {
+	handleRequest(c, &tag.ListTagsRequest{}, a.tagHandler.HandleList)
+}
+
+func (a *ginAdapter) getTag(c *gin.Context) {
+	handleRequest(c, &tag.GetTagRequest{}, a.tagHandler.HandleGet)
+}
+
+func (a *ginAdapter) updateTag(c *gin.Context) {
+	handleJSONRequest(c, &tag.UpdateTagRequest{}, a.tagHandler.HandleUpdate)
+}
+
+func (a *ginAdapter) mergeTag(c *gin.Context) {
+	handleJSONRequest(c, &tag.MergeTagRequest{}, a.tagHandler.HandleMerge)
+}
+
+func (a *ginAdapter) deleteTag(c *gin.Context) {
+	handleRequest(c, &tag.DeleteTagRequest{}, a.tagHandler.HandleDelete)
+}
+
+func (a *ginAdapter) startReview(c *gin.Context) {
+	handleJSONRequest(c, &review.StartReviewRequest{}, a.reviewHandler.HandleStart)
+}
+
+func (a *ginAdapter) listReviews(c *gin.Context) {
+	handleRequest(c, &review.ListReviewsRequest{}, a.reviewHandler.HandleList)
+}
+
+func (a *ginAdapter) getReview(c *gin.Context) {
+	handleRequest(c, &review.GetReviewRequest{}, a.reviewHandler.HandleGet)
+}
+
+func (a *ginAdapter) getReviewStep(c *gin.Context) {
+	handleRequest(c, &review.GetStepRequest{}, a.reviewHandler.HandleGetStep)
+}
+
+func (a *ginAdapter) decideReviewItem(c *gin.Context) {
+	handleJSONRequest(c, &review.DecideRequest{}, a.reviewHandler.HandleDecide)
+}
+
+func (a *ginAdapter) advanceReview(c *gin.Context) {
+	handleRequest(c, &review.AdvanceReviewRequest{}, a.reviewHandler.HandleAdvance)
+}
+
+func (a *ginAdapter) listAttention(c *gin.Context) {
+	handleRequest(c, &attention.ListAttentionRequest{}, a.attentionHandler.HandleList)
+}
+
+func (a *ginAdapter) getAttentionSettings(c *gin.Context) {
+	handleRequest(c, &attention.GetSettingsRequest{}, a.attentionHandler.HandleGetSettings)
+}
+
+func (a *ginAdapter) updateAttentionSettings(c *gin.Context) {
+	handleJSONRequest(c, &attention.UpdateSettingsRequest{}, a.attentionHandler.HandleUpdateSettings)
+}
+
+func (a *ginAdapter) listNext(c *gin.Context) {
+	handleRequest(c, &next.NextRequest{}, a.nextHandler.HandleNext)
+}
+
+func (a *ginAdapter) listActivity(c *gin.Context) {
+	handleRequest(c, &event.ListActivityRequest{}, a.activity.HandleList)
+}
+
+func (a *ginAdapter) search(c *gin.Context) {
+	handleRequest(c, &search.SearchRequest{}, a.searchHandler.HandleSearch)
+}
+
+func (a *ginAdapter) pullChanges(c *gin.Context) {
+	handleRequest(c, &delta.PullRequest{}, a.syncHandler.HandlePull)
+}
+
+func (a *ginAdapter) pushMutations(c *gin.Context) {
+	handleJSONRequest(c, &delta.PushRequest{}, a.syncHandler.HandlePush)
+}
+
+func (a *ginAdapter) liveness(c *gin.Context) {
+	c.JSON(a.healthHandler.HandleLiveness())
+}
+
+func (a *ginAdapter) readiness(c *gin.Context) {
+	c.JSON(a.healthHandler.HandleReadiness(c.Request.Context()))
+}
+
+// handleJSONRequest binds the body into payload and writes the handler's result. Mutating
+// requests that send an Idempotency-Key are replayed instead of handled twice.
+func handleJSONRequest[T any, R any](c *gin.Context, payload *T, handle func(context.Context, T) (int, R)) {
+	handleBound(c, payload, true, handle)
+}
+
+// handleRequest is handleJSONRequest for requests without a body, bound from the query.
+func handleRequest[T any, R any](c *gin.Context, payload *T, handle func(context.Context, T) (int, R)) {
+	handleBound(c, payload, false, handle)
+}
+
+func handleBound[T any, R any](c *gin.Context, payload *T, withBody bool, handle func(context.Context, T) (int, R)) {
+	respondIdempotently(c, func() (int, any) {
+		if err := bindRequest(c, payload, withBody); err != nil {
+			return http.StatusBadRequest, apperror.ErrorResponse{Error: err.Error()}
+		}
+		return handle(c.Request.Context(), *payload)
+	})
+}
+
+type routerOptions struct {
+	probe             *health.Probe
+	readinessTimeout  time.Duration
+	requestValidation bool
+	trustedProxies    []string
+	tokenParser       user.Parser
+	limiter           *ratelimit.Limiter
+	rateLimitPolicies map[string]ratelimit.Policy
+	idempotencyStore  idempotency.Store
+	eventBroker       *event.Broker
+	eventHeartbeat    time.Duration
+	collabHeartbeat   time.Duration
+	nextWeights       next.Weights
+}
+
+type Option func(*routerOptions)
+
+// WithProbe shares the readiness probe with the caller so it can flip readiness during shutdown.
+func WithProbe(probe *health.Probe) Option {
+	return func(o *routerOptions) {
+		o.probe = probe
+	}
+}
+
+func WithReadinessTimeout(timeout time.Duration) Option {
+	return func(o *routerOptions) {
+		o.readinessTimeout = timeout
 	}
+}
 
-	code, res := handle(*payload)
-	c.JSON(code, res)
-	log.Printf("API response : status=%v / path=%v / res=%v", code, c.Request.RequestURI, res)
+// WithRequestValidation rejects requests that violate the OpenAPI document with 400.
+func WithRequestValidation(enabled bool) Option {
+	return func(o *routerOptions) {
+		o.requestValidation = enabled
+	}
 }
 
-func SetupRouter(pool *sqlx.DB) *gin.Engine {
+// WithTrustedProxies lists the proxies, as IPs or CIDRs, whose X-Forwarded-For is believed
+// when resolving the client IP. By default none are, so the client IP is the peer address.
+func WithTrustedProxies(proxies []string) Option {
+	return func(o *routerOptions) {
+		o.trustedProxies = proxies
+	}
+}
+
+// WithRateLimiter replaces the default in-memory limiter. A nil limiter disables rate limiting.
+func WithRateLimiter(limiter *ratelimit.Limiter, policies map[string]ratelimit.Policy) Option {
+	return func(o *routerOptions) {
+		o.limiter = limiter
+		o.rateLimitPolicies = policies
+	}
+}
+
+// WithIdempotencyStore replaces the default Postgres store for Idempotency-Key records.
+func WithIdempotencyStore(store idempotency.Store) Option {
+	return func(o *routerOptions) {
+		o.idempotencyStore = store
+	}
+}
+
+// WithEventBroker shares the broker with the caller so it can feed it from LISTEN/NOTIFY.
+func WithEventBroker(broker *event.Broker) Option {
+	return func(o *routerOptions) {
+		o.eventBroker = broker
+	}
+}
+
+func WithEventHeartbeat(interval time.Duration) Option {
+	return func(o *routerOptions) {
+		o.eventHeartbeat = interval
+	}
+}
+
+func WithCollabHeartbeat(interval time.Duration) Option {
+	return func(o *routerOptions) {
+		o.collabHeartbeat = interval
+	}
+}
+
+// WithNextWeights replaces the default weights GET /api/next ranks with.
+func WithNextWeights(weights next.Weights) Option {
+	return func(o *routerOptions) {
+		o.nextWeights = weights
+	}
+}
+
+func SetupRouter(pool *sqlx.DB, opts ...Option) *gin.Engine {
+	options := routerOptions{
+		readinessTimeout:  time.Second,
+		tokenParser:       user.InitializeTokenParser(),
+		limiter:           ratelimit.NewLimiter(ratelimit.NewMemoryStore(), time.Now),
+		rateLimitPolicies: defaultRateLimitPolicies(),
+		idempotencyStore:  idempotency.NewPostgresStore(pool, idempotency.DefaultTTL, time.Now),
+		eventBroker:       event.NewBroker(),
+		eventHeartbeat:    15 * time.Second,
+		collabHeartbeat:   30 * time.Second,
+		nextWeights:       next.DefaultWeights(),
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.probe == nil {
+		options.probe = newProbe(pool, options)
+	}
+
 	router := gin.Default()
+	if err := router.SetTrustedProxies(options.trustedProxies); err != nil {
+		log.Fatalf("Trusted proxies are invalid. %v\n", err)
+	}
+	eventLog := event.NewPostgresLog(pool)
 	ginAdapter := ginAdapter{
-		userHandler: user.IntializeHandler(pool),
+		userHandler:      user.IntializeHandler(pool),
+		settingsHandler:  user.InitializeSettingsHandler(pool),
+		ticketHandler:    user.InitializeTicketHandler(),
+		todoHandler:      todo.InitializeHandler(pool, eventLog),
+		projectHandler:   project.InitializeHandler(pool, eventLog),
+		tagHandler:       tag.InitializeHandler(pool, eventLog),
+		clarifyHandler:   clarify.InitializeHandler(pool, eventLog),
+		reviewHandler:    review.InitializeHandler(pool, eventLog),
+		attentionHandler: attention.InitializeHandler(pool),
+		nextHandler:      next.InitializeHandler(pool, options.nextWeights),
+		syncHandler:      delta.InitializeHandler(pool, eventLog),
+		searchHandler:    search.InitializeHandler(pool),
+		healthHandler:    health.NewHealthHandler(options.probe),
+		eventStreamer:    event.NewStreamer(eventLog, options.eventBroker, options.eventHeartbeat),
+		activity:         event.NewActivityHandler(eventLog),
+		collabHub:        collab.NewHub(collab.NewAccessRepository(pool), options.collabHeartbeat),
 	}
-	router.GET("/api/health", healthHandler)
-	router.POST("/api/auth/signup", ginAdapter.signUp)
-	router.POST("/api/auth/login", ginAdapter.login)
+	registerRoutes(router, ginAdapter.routes(), options)
 
 	return router
 }
 
-func healthHandler(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"status": "ok"})
-}
\ No newline at end of file
+func newProbe(pool *sqlx.DB, options routerOptions) *health.Probe {
+	migrator, err := migrate.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		log.Fatalf("Migrator init failed. %v\n", err)
+	}
+	return health.NewProbe(
+		options.readinessTimeout,
+		health.NewDBChecker(pool),
+		health.NewMigrationChecker(migrator),
+	)
+}
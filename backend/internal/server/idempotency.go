@@ -0,0 +1,134 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/idempotency"
+	"yangdongju/gtd_todo/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyStoreKey = "idempotencyStore"
+	maxIdempotencyKey   = 255
+)
+
+func useIdempotencyStore(store idempotency.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(idempotencyStoreKey, store)
+		c.Next()
+	}
+}
+
+func supportsIdempotency(method string, path string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodDelete:
+		return strings.HasPrefix(path, "/api/")
+	}
+	return false
+}
+
+var idempotencyKeyParameter = openapi.Parameter{
+	Name: idempotency.HeaderName, In: "header", Schema: &openapi.Schema{Type: "string"},
+}
+
+// respondIdempotently runs the handler at most once per Idempotency-Key. Retries with the
+// same request get the stored response; the key is released on 5xx so the retry can run.
+func respondIdempotently(c *gin.Context, run func() (int, any)) {
+	key := c.GetHeader(idempotency.HeaderName)
+	store, ok := c.Value(idempotencyStoreKey).(idempotency.Store)
+	if key == "" || !ok || !supportsIdempotency(c.Request.Method, c.Request.URL.Path) {
+		respond(c, run)
+		return
+	}
+	if len(key) > maxIdempotencyKey {
+		c.JSON(http.StatusBadRequest, apperror.ErrorResponse{Error: "Idempotency-Key must be at most 255 characters"})
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	// The client may disconnect while we work, which is exactly when it will retry.
+	ctx := context.WithoutCancel(c.Request.Context())
+	scope := clientKey(c)
+	hash := idempotency.HashRequest(c.Request.Method, c.Request.URL.Path, body)
+	outcome, stored, err := store.Begin(ctx, scope, key, hash)
+	if err != nil {
+		log.Printf("Idempotency key lookup failed. scope=%v key=%v err=%v", scope, key, err)
+		c.JSON(http.StatusInternalServerError, apperror.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	switch outcome {
+	case idempotency.Replay:
+		for name, values := range stored.Header {
+			for _, value := range values {
+				c.Writer.Header().Add(name, value)
+			}
+		}
+		c.Header(idempotency.ReplayedHeaderName, "true")
+		c.Data(stored.StatusCode, jsonContentType, stored.Body)
+	case idempotency.Mismatch:
+		c.JSON(http.StatusUnprocessableEntity, apperror.ErrorResponse{Error: "Idempotency-Key was already used with a different request"})
+	case idempotency.InProgress:
+		c.JSON(http.StatusConflict, apperror.ErrorResponse{Error: "A request with this Idempotency-Key is still in progress"})
+	default:
+		code, res := runOrRelease(ctx, store, scope, key, run)
+		encoded, err := json.Marshal(res)
+		if err != nil {
+			_ = store.Release(ctx, scope, key)
+			c.JSON(http.StatusInternalServerError, apperror.ErrorResponse{Error: "Internal server error"})
+			return
+		}
+
+		if code >= http.StatusInternalServerError {
+			err = store.Release(ctx, scope, key)
+		} else {
+			setETag(c, code, res, encoded)
+			err = store.Complete(ctx, scope, key, idempotency.Response{
+				StatusCode: code, Body: encoded, Header: replayedHeaders(c.Writer.Header()),
+			})
+		}
+		if err != nil {
+			log.Printf("Idempotency key update failed. scope=%v key=%v err=%v", scope, key, err)
+		}
+		writeResponse(c, code, res, encoded)
+	}
+}
+
+// runOrRelease runs the handler and releases the key if it panics, before the panic goes
+// on to the recovery middleware. A retry can then run instead of finding it in progress.
+func runOrRelease(ctx context.Context, store idempotency.Store, scope string, key string, run func() (int, any)) (int, any) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if err := store.Release(ctx, scope, key); err != nil {
+				log.Printf("Idempotency key release failed. scope=%v key=%v err=%v", scope, key, err)
+			}
+			panic(recovered)
+		}
+	}()
+	return run()
+}
+
+// replayedHeaders picks the headers worth storing from those already set on the response.
+func replayedHeaders(header http.Header) http.Header {
+	replayed := http.Header{}
+	for _, name := range idempotency.ReplayedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			replayed[name] = values
+		}
+	}
+	return replayed
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	rateLimitAuth  = "auth"
+	rateLimitRead  = "read"
+	rateLimitWrite = "write"
+)
+
+func defaultRateLimitPolicies() map[string]ratelimit.Policy {
+	return map[string]ratelimit.Policy{
+		rateLimitAuth:  {Name: rateLimitAuth, Limit: 10, Window: time.Minute},
+		rateLimitRead:  {Name: rateLimitRead, Limit: 300, Window: time.Minute},
+		rateLimitWrite: {Name: rateLimitWrite, Limit: 60, Window: time.Minute},
+	}
+}
+
+// rateLimit keys buckets by the authenticated user, falling back to the client IP.
+// Store failures let the request through rather than taking the API down.
+func rateLimit(limiter *ratelimit.Limiter, policy ratelimit.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := clientKey(c)
+		result, err := limiter.Allow(c.Request.Context(), key, policy)
+		if err != nil {
+			log.Printf("Rate limit check failed. policy=%v key=%v err=%v", policy.Name, key, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy.Header())
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(int(result.ResetAfter.Seconds())))
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, apperror.ErrorResponse{Error: "Too many requests"})
+			return
+		}
+		c.Next()
+	}
+}
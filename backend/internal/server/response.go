@@ -0,0 +1,49 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/etag"
+
+	"github.com/gin-gonic/gin"
+)
+
+const jsonContentType = gin.MIMEJSON + "; charset=utf-8"
+
+func respond(c *gin.Context, run func() (int, any)) {
+	code, res := run()
+	body, err := json.Marshal(res)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, apperror.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+	writeResponse(c, code, res, body)
+}
+
+// writeResponse adds the ETag of versioned resources, or a content hash for other GET
+// responses, and answers a matching If-None-Match with 304 and no body.
+func writeResponse(c *gin.Context, code int, res any, body []byte) {
+	tag := setETag(c, code, res, body)
+
+	if c.Request.Method == http.MethodGet && code == http.StatusOK && etag.MatchesNoneMatch(c.GetHeader("If-None-Match"), tag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(code, jsonContentType, body)
+	log.Printf("API response : status=%v / path=%v / res=%v", code, c.Request.RequestURI, res)
+}
+
+func setETag(c *gin.Context, code int, res any, body []byte) string {
+	tag := ""
+	if tagged, ok := res.(etag.Tagged); ok {
+		tag = tagged.ETag()
+	} else if c.Request.Method == http.MethodGet && code == http.StatusOK {
+		tag = etag.FromContent(body)
+	}
+	if tag != "" {
+		c.Header("ETag", tag)
+	}
+	return tag
+}
@@ -0,0 +1,978 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/attention"
+	"yangdongju/gtd_todo/internal/clarify"
+	"yangdongju/gtd_todo/internal/delta"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/health"
+	"yangdongju/gtd_todo/internal/next"
+	"yangdongju/gtd_todo/internal/openapi"
+	"yangdongju/gtd_todo/internal/project"
+	"yangdongju/gtd_todo/internal/review"
+	"yangdongju/gtd_todo/internal/search"
+	"yangdongju/gtd_todo/internal/tag"
+	"yangdongju/gtd_todo/internal/todo"
+	"yangdongju/gtd_todo/internal/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiVersion = "1.0.0"
+
+var ifNoneMatchParameter = openapi.Parameter{
+	Name: "If-None-Match", In: "header", Schema: &openapi.Schema{Type: "string"},
+}
+
+type route struct {
+	openapi.Route
+	handler gin.HandlerFunc
+	// rateLimit names the policy group. Empty means read or write by method for /api routes.
+	rateLimit string
+}
+
+func (a *ginAdapter) routes() []route {
+	return []route{
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/livez", Tag: "health",
+				Summary:   "Liveness probe",
+				Responses: map[int]any{http.StatusOK: health.LivenessResponse{}},
+			},
+			handler: a.liveness,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/readyz", Tag: "health",
+				Summary: "Readiness probe with dependency checks",
+				Responses: map[int]any{
+					http.StatusOK:                 health.ReadinessReport{},
+					http.StatusServiceUnavailable: health.ReadinessReport{},
+				},
+			},
+			handler: a.readiness,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/auth/signup", Tag: "auth",
+				Summary: "Sign up with email and password",
+				Request: user.SignUpRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             user.SignUpResponse{},
+					http.StatusBadRequest:          user.ErrorResponse{},
+					http.StatusInternalServerError: user.ErrorResponse{},
+				},
+			},
+			handler:   a.signUp,
+			rateLimit: rateLimitAuth,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/auth/login", Tag: "auth",
+				Summary: "Issue an access token",
+				Request: user.LoginRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  user.LoginResponse{},
+					http.StatusBadRequest:          user.ErrorResponse{},
+					http.StatusUnauthorized:        user.ErrorResponse{},
+					http.StatusInternalServerError: user.ErrorResponse{},
+				},
+			},
+			handler:   a.login,
+			rateLimit: rateLimitAuth,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/users/me", Tag: "users", Auth: true,
+				Summary: "Get the signed-in user's settings",
+				Query:   user.GetSettingsRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  user.SettingsResponse{},
+					http.StatusNotFound:            user.ErrorResponse{},
+					http.StatusInternalServerError: user.ErrorResponse{},
+				},
+			},
+			handler: a.getSettings,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/users/me", Tag: "users", Auth: true,
+				Summary: "Change the timezone due dates and reminders are read in",
+				Request: user.UpdateSettingsRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  user.SettingsResponse{},
+					http.StatusBadRequest:          user.ErrorResponse{},
+					http.StatusNotFound:            user.ErrorResponse{},
+					http.StatusInternalServerError: user.ErrorResponse{},
+				},
+			},
+			handler: a.updateSettings,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/todos", Tag: "todos", Auth: true,
+				Summary: "Create a todo",
+				Request: todo.CreateTodoRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             todo.TodoResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.createTodo,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/todos", Tag: "todos", Auth: true,
+				Summary: "List todos",
+				Query:   todo.ListTodosRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.TodoListResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.listTodos,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/todos/by-context", Tag: "todos", Auth: true,
+				Summary: "Group one status column, Next Actions by default, by context tag",
+				Query:   todo.ListByContextRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.TodosByContextResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.listTodosByContext,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/waiting", Tag: "todos", Auth: true,
+				Summary: "List delegated todos, overdue follow-ups first",
+				Query:   todo.ListWaitingRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.WaitingListResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.listWaiting,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/todos/:id", Tag: "todos", Auth: true,
+				Summary: "Get a todo",
+				Query:   todo.GetTodoRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.TodoResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.getTodo,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/todos/:id", Tag: "todos", Auth: true,
+				Summary: "Update a todo if it still has the version in If-Match",
+				Request: todo.UpdateTodoRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   todo.TodoResponse{},
+					http.StatusBadRequest:           todo.ErrorResponse{},
+					http.StatusNotFound:             todo.ErrorResponse{},
+					http.StatusPreconditionFailed:   todo.TodoResponse{},
+					http.StatusPreconditionRequired: todo.ErrorResponse{},
+					http.StatusInternalServerError:  todo.ErrorResponse{},
+				},
+			},
+			handler: a.updateTodo,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/todos/:id/status", Tag: "todos", Auth: true,
+				Summary: "Move a todo to another status; completing a recurring todo creates its next instance",
+				Request: todo.ChangeStatusRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   todo.StatusChangeResponse{},
+					http.StatusBadRequest:           todo.ErrorResponse{},
+					http.StatusNotFound:             todo.ErrorResponse{},
+					http.StatusPreconditionFailed:   todo.TodoResponse{},
+					http.StatusPreconditionRequired: todo.ErrorResponse{},
+					http.StatusInternalServerError:  todo.ErrorResponse{},
+				},
+			},
+			handler: a.changeTodoStatus,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/todos/:id/clarify", Tag: "todos", Auth: true,
+				Summary: "Decide what an inbox item is and turn it into an action, a project or something else",
+				Request: clarify.ClarifyRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   clarify.ClarifyResponse{},
+					http.StatusBadRequest:           clarify.ErrorResponse{},
+					http.StatusNotFound:             clarify.ErrorResponse{},
+					http.StatusConflict:             clarify.ErrorResponse{},
+					http.StatusPreconditionFailed:   todo.TodoResponse{},
+					http.StatusPreconditionRequired: clarify.ErrorResponse{},
+					http.StatusInternalServerError:  clarify.ErrorResponse{},
+				},
+			},
+			handler: a.clarifyTodo,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodDelete, Path: "/api/todos/:id", Tag: "todos", Auth: true,
+				Summary: "Delete a todo if it still has the version in If-Match",
+				Query:   todo.DeleteTodoRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   todo.DeleteTodoResponse{},
+					http.StatusBadRequest:           todo.ErrorResponse{},
+					http.StatusNotFound:             todo.ErrorResponse{},
+					http.StatusPreconditionFailed:   todo.TodoResponse{},
+					http.StatusPreconditionRequired: todo.ErrorResponse{},
+					http.StatusInternalServerError:  todo.ErrorResponse{},
+				},
+			},
+			handler: a.deleteTodo,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/todos/:id/checklist", Tag: "todos", Auth: true,
+				Summary: "Add a checklist item to a todo",
+				Request: todo.AddChecklistItemRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             todo.TodoResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.addChecklistItem,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPut, Path: "/api/todos/:id/checklist/order", Tag: "todos", Auth: true,
+				Summary: "Reorder a todo's checklist",
+				Request: todo.ReorderChecklistRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.TodoResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.reorderChecklist,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/todos/:id/checklist/:item_id", Tag: "todos", Auth: true,
+				Summary: "Rename, check or uncheck a checklist item; checking the last one can complete the todo",
+				Request: todo.UpdateChecklistItemRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.TodoResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.updateChecklistItem,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodDelete, Path: "/api/todos/:id/checklist/:item_id", Tag: "todos", Auth: true,
+				Summary: "Remove a checklist item",
+				Query:   todo.DeleteChecklistItemRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.TodoResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.deleteChecklistItem,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/todos/:id/dependencies", Tag: "todos", Auth: true,
+				Summary: "Get the graph of todos a todo waits on and holds up",
+				Query:   todo.GetDependenciesRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.DependencyGraphResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.getTodoDependencies,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/todos/:id/dependencies", Tag: "todos", Auth: true,
+				Summary: "Mark a todo as blocked by another todo",
+				Request: todo.AddDependencyRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             todo.TodoResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusConflict:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.addTodoDependency,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodDelete, Path: "/api/todos/:id/dependencies/:blocker_id", Tag: "todos", Auth: true,
+				Summary: "Stop a todo waiting on a blocker",
+				Query:   todo.RemoveDependencyRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  todo.TodoResponse{},
+					http.StatusBadRequest:          todo.ErrorResponse{},
+					http.StatusNotFound:            todo.ErrorResponse{},
+					http.StatusInternalServerError: todo.ErrorResponse{},
+				},
+			},
+			handler: a.removeTodoDependency,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/projects", Tag: "projects", Auth: true,
+				Summary: "Create a project",
+				Request: project.CreateProjectRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             project.ProjectResponse{},
+					http.StatusBadRequest:          project.ErrorResponse{},
+					http.StatusConflict:            project.ErrorResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.createProject,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/projects", Tag: "projects", Auth: true,
+				Summary: "List projects, archived ones only when filtered by status",
+				Query:   project.ListProjectsRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  project.ProjectListResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          project.ErrorResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.listProjects,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/projects/:id", Tag: "projects", Auth: true,
+				Summary: "Get a project",
+				Query:   project.GetProjectRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  project.ProjectResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          project.ErrorResponse{},
+					http.StatusNotFound:            project.ErrorResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.getProject,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/projects/:id", Tag: "projects", Auth: true,
+				Summary: "Update a project if it still has the version in If-Match",
+				Request: project.UpdateProjectRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   project.ProjectResponse{},
+					http.StatusBadRequest:           project.ErrorResponse{},
+					http.StatusNotFound:             project.ErrorResponse{},
+					http.StatusPreconditionFailed:   project.ProjectResponse{},
+					http.StatusPreconditionRequired: project.ErrorResponse{},
+					http.StatusInternalServerError:  project.ErrorResponse{},
+				},
+			},
+			handler: a.updateProject,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodDelete, Path: "/api/projects/:id", Tag: "projects", Auth: true,
+				Summary: "Delete a project if it still has the version in If-Match",
+				Query:   project.DeleteProjectRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   project.DeleteProjectResponse{},
+					http.StatusBadRequest:           project.ErrorResponse{},
+					http.StatusNotFound:             project.ErrorResponse{},
+					http.StatusPreconditionFailed:   project.ProjectResponse{},
+					http.StatusPreconditionRequired: project.ErrorResponse{},
+					http.StatusInternalServerError:  project.ErrorResponse{},
+				},
+			},
+			handler: a.deleteProject,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/projects/tree", Tag: "projects", Auth: true,
+				Summary: "List projects as trees under their areas, with progress rolled up",
+				Query:   project.ProjectTreeRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  project.ProjectTreeResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.getProjectTree,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/projects/:id/move", Tag: "projects", Auth: true,
+				Summary: "Move a project and its sub-projects to another area or parent",
+				Request: project.MoveProjectRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   project.ProjectResponse{},
+					http.StatusBadRequest:           project.ErrorResponse{},
+					http.StatusNotFound:             project.ErrorResponse{},
+					http.StatusConflict:             project.ErrorResponse{},
+					http.StatusPreconditionFailed:   project.ProjectResponse{},
+					http.StatusPreconditionRequired: project.ErrorResponse{},
+					http.StatusInternalServerError:  project.ErrorResponse{},
+				},
+			},
+			handler: a.moveProject,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/projects/:id/status", Tag: "projects", Auth: true,
+				Summary: "Change a project's status, optionally completing its open todos",
+				Request: project.ChangeProjectStatusRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   project.ProjectStatusChangeResponse{},
+					http.StatusBadRequest:           project.ErrorResponse{},
+					http.StatusNotFound:             project.ErrorResponse{},
+					http.StatusConflict:             project.ErrorResponse{},
+					http.StatusPreconditionFailed:   project.ProjectResponse{},
+					http.StatusPreconditionRequired: project.ErrorResponse{},
+					http.StatusInternalServerError:  project.ErrorResponse{},
+				},
+			},
+			handler: a.changeProjectStatus,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/projects/archive", Tag: "projects", Auth: true,
+				Summary: "List archived projects, most recently archived first",
+				Query:   project.ListArchivedProjectsRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  project.ArchivedProjectListResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.listArchivedProjects,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/areas", Tag: "areas", Auth: true,
+				Summary: "Create an area of responsibility",
+				Request: project.CreateAreaRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             project.AreaResponse{},
+					http.StatusBadRequest:          project.ErrorResponse{},
+					http.StatusConflict:            project.ErrorResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.createArea,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/areas", Tag: "areas", Auth: true,
+				Summary: "List areas by name",
+				Query:   project.ListAreasRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  project.AreaListResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.listAreas,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/areas/:id", Tag: "areas", Auth: true,
+				Summary: "Get an area with its project tree",
+				Query:   project.GetAreaRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  project.AreaTreeResponse{},
+					http.StatusBadRequest:          project.ErrorResponse{},
+					http.StatusNotFound:            project.ErrorResponse{},
+					http.StatusInternalServerError: project.ErrorResponse{},
+				},
+			},
+			handler: a.getArea,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/areas/:id", Tag: "areas", Auth: true,
+				Summary: "Update an area if it still has the version in If-Match",
+				Request: project.UpdateAreaRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   project.AreaResponse{},
+					http.StatusBadRequest:           project.ErrorResponse{},
+					http.StatusNotFound:             project.ErrorResponse{},
+					http.StatusConflict:             project.ErrorResponse{},
+					http.StatusPreconditionFailed:   project.AreaResponse{},
+					http.StatusPreconditionRequired: project.ErrorResponse{},
+					http.StatusInternalServerError:  project.ErrorResponse{},
+				},
+			},
+			handler: a.updateArea,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodDelete, Path: "/api/areas/:id", Tag: "areas", Auth: true,
+				Summary: "Delete an area if it still has the version in If-Match, keeping its projects",
+				Query:   project.DeleteAreaRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   project.DeleteAreaResponse{},
+					http.StatusBadRequest:           project.ErrorResponse{},
+					http.StatusNotFound:             project.ErrorResponse{},
+					http.StatusPreconditionFailed:   project.AreaResponse{},
+					http.StatusPreconditionRequired: project.ErrorResponse{},
+					http.StatusInternalServerError:  project.ErrorResponse{},
+				},
+			},
+			handler: a.deleteArea,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/tags", Tag: "tags", Auth: true,
+				Summary: "Create a tag or context",
+				Request: tag.CreateTagRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             tag.TagResponse{},
+					http.StatusBadRequest:          tag.ErrorResponse{},
+					http.StatusConflict:            tag.ErrorResponse{},
+					http.StatusInternalServerError: tag.ErrorResponse{},
+				},
+			},
+			handler: a.createTag,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/tags", Tag: "tags", Auth: true,
+				Summary: "List tags by name",
+				Query:   tag.ListTagsRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  tag.TagListResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          tag.ErrorResponse{},
+					http.StatusInternalServerError: tag.ErrorResponse{},
+				},
+			},
+			handler: a.listTags,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/tags/:id", Tag: "tags", Auth: true,
+				Summary: "Get a tag",
+				Query:   tag.GetTagRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  tag.TagResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          tag.ErrorResponse{},
+					http.StatusNotFound:            tag.ErrorResponse{},
+					http.StatusInternalServerError: tag.ErrorResponse{},
+				},
+			},
+			handler: a.getTag,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/tags/:id", Tag: "tags", Auth: true,
+				Summary: "Rename or recolor a tag if it still has the version in If-Match",
+				Request: tag.UpdateTagRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   tag.TagResponse{},
+					http.StatusBadRequest:           tag.ErrorResponse{},
+					http.StatusNotFound:             tag.ErrorResponse{},
+					http.StatusConflict:             tag.ErrorResponse{},
+					http.StatusPreconditionFailed:   tag.TagResponse{},
+					http.StatusPreconditionRequired: tag.ErrorResponse{},
+					http.StatusInternalServerError:  tag.ErrorResponse{},
+				},
+			},
+			handler: a.updateTag,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/tags/:id/merge", Tag: "tags", Auth: true,
+				Summary: "Move every todo to another tag and delete this one",
+				Request: tag.MergeTagRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   tag.TagResponse{},
+					http.StatusBadRequest:           tag.ErrorResponse{},
+					http.StatusNotFound:             tag.ErrorResponse{},
+					http.StatusPreconditionFailed:   tag.TagResponse{},
+					http.StatusPreconditionRequired: tag.ErrorResponse{},
+					http.StatusInternalServerError:  tag.ErrorResponse{},
+				},
+			},
+			handler: a.mergeTag,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodDelete, Path: "/api/tags/:id", Tag: "tags", Auth: true,
+				Summary: "Delete a tag if it still has the version in If-Match",
+				Query:   tag.DeleteTagRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                   tag.DeleteTagResponse{},
+					http.StatusBadRequest:           tag.ErrorResponse{},
+					http.StatusNotFound:             tag.ErrorResponse{},
+					http.StatusPreconditionFailed:   tag.TagResponse{},
+					http.StatusPreconditionRequired: tag.ErrorResponse{},
+					http.StatusInternalServerError:  tag.ErrorResponse{},
+				},
+			},
+			handler: a.deleteTag,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/reviews", Tag: "reviews", Auth: true,
+				Summary: "Start a review at its first step",
+				Request: review.StartReviewRequest{},
+				Responses: map[int]any{
+					http.StatusCreated:             review.ReviewResponse{},
+					http.StatusBadRequest:          review.ErrorResponse{},
+					http.StatusConflict:            review.ErrorResponse{},
+					http.StatusInternalServerError: review.ErrorResponse{},
+				},
+			},
+			handler: a.startReview,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/reviews", Tag: "reviews", Auth: true,
+				Summary: "List reviews, newest first",
+				Query:   review.ListReviewsRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  review.ReviewListResponse{},
+					http.StatusBadRequest:          review.ErrorResponse{},
+					http.StatusInternalServerError: review.ErrorResponse{},
+				},
+			},
+			handler: a.listReviews,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/reviews/:id", Tag: "reviews", Auth: true,
+				Summary: "Get a review with the decisions made in it",
+				Query:   review.GetReviewRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  review.ReviewDetailResponse{},
+					http.StatusNotFound:            review.ErrorResponse{},
+					http.StatusInternalServerError: review.ErrorResponse{},
+				},
+			},
+			handler: a.getReview,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/reviews/:id/steps/:step", Tag: "reviews", Auth: true,
+				Summary: "List the items a review step needs attention for",
+				Query:   review.GetStepRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  review.StepResponse{},
+					http.StatusBadRequest:          review.ErrorResponse{},
+					http.StatusNotFound:            review.ErrorResponse{},
+					http.StatusInternalServerError: review.ErrorResponse{},
+				},
+			},
+			handler: a.getReviewStep,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/reviews/:id/steps/:step/decisions", Tag: "reviews", Auth: true,
+				Summary: "Decide about an item of the current step and apply the decision",
+				Request: review.DecideRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  review.DecideResponse{},
+					http.StatusBadRequest:          review.ErrorResponse{},
+					http.StatusNotFound:            review.ErrorResponse{},
+					http.StatusConflict:            review.ErrorResponse{},
+					http.StatusInternalServerError: review.ErrorResponse{},
+				},
+			},
+			handler: a.decideReviewItem,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/reviews/:id/advance", Tag: "reviews", Auth: true,
+				Summary: "Move a review to its next step, completing it after the last",
+				Query:   review.AdvanceReviewRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  review.ReviewResponse{},
+					http.StatusNotFound:            review.ErrorResponse{},
+					http.StatusConflict:            review.ErrorResponse{},
+					http.StatusInternalServerError: review.ErrorResponse{},
+				},
+			},
+			handler: a.advanceReview,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/attention", Tag: "attention", Auth: true,
+				Summary: "List items that drifted past the user's thresholds, most overdue first",
+				Query:   attention.ListAttentionRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  attention.AttentionResponse{},
+					http.StatusBadRequest:          attention.ErrorResponse{},
+					http.StatusInternalServerError: attention.ErrorResponse{},
+				},
+			},
+			handler: a.listAttention,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/attention/settings", Tag: "attention", Auth: true,
+				Summary: "Get the attention thresholds and digest schedule",
+				Query:   attention.GetSettingsRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  attention.SettingsResponse{},
+					http.StatusInternalServerError: attention.ErrorResponse{},
+				},
+			},
+			handler: a.getAttentionSettings,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPatch, Path: "/api/attention/settings", Tag: "attention", Auth: true,
+				Summary: "Change the attention thresholds and digest schedule",
+				Request: attention.UpdateSettingsRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  attention.SettingsResponse{},
+					http.StatusBadRequest:          attention.ErrorResponse{},
+					http.StatusInternalServerError: attention.ErrorResponse{},
+				},
+			},
+			handler: a.updateAttentionSettings,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/next", Tag: "next", Auth: true,
+				Summary: "Rank the next actions that fit a context, the time available and energy",
+				Query:   next.NextRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  next.NextResponse{},
+					http.StatusBadRequest:          next.ErrorResponse{},
+					http.StatusInternalServerError: next.ErrorResponse{},
+				},
+			},
+			handler: a.listNext,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/events/ticket", Tag: "events", Auth: true,
+				Summary: "Issue a one-minute ticket for opening the event stream without an Authorization header",
+				Responses: map[int]any{
+					http.StatusOK:                  user.TicketResponse{},
+					http.StatusInternalServerError: user.ErrorResponse{},
+				},
+			},
+			handler: a.streamTicket,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: eventStreamPath, Tag: "events", Auth: true,
+				Summary: "Stream todo and project changes as Server-Sent Events",
+				Query:   event.StreamRequest{},
+				Responses: map[int]any{
+					http.StatusOK:         "",
+					http.StatusBadRequest: apperror.ErrorResponse{},
+				},
+				ContentType: "text/event-stream",
+			},
+			handler: a.events,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/activity", Tag: "events", Auth: true,
+				Summary: "List recent todo and project changes, newest first",
+				Query:   event.ListActivityRequest{},
+				Headers: []openapi.Parameter{ifNoneMatchParameter},
+				Responses: map[int]any{
+					http.StatusOK:                  event.ActivityListResponse{},
+					http.StatusNotModified:         nil,
+					http.StatusBadRequest:          apperror.ErrorResponse{},
+					http.StatusInternalServerError: apperror.ErrorResponse{},
+				},
+			},
+			handler: a.listActivity,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/search", Tag: "search", Auth: true,
+				Summary: "Search todos and projects",
+				Query:   search.SearchRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  search.SearchResponse{},
+					http.StatusBadRequest:          search.ErrorResponse{},
+					http.StatusInternalServerError: search.ErrorResponse{},
+				},
+			},
+			handler: a.search,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/sync", Tag: "sync", Auth: true,
+				Summary: "List todos, projects and tombstones changed after a sync cursor",
+				Query:   delta.PullRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  delta.PullResponse{},
+					http.StatusBadRequest:          delta.ErrorResponse{},
+					http.StatusInternalServerError: delta.ErrorResponse{},
+				},
+			},
+			handler: a.pullChanges,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodPost, Path: "/api/sync", Tag: "sync", Auth: true,
+				Summary: "Apply a batch of client mutations with per-item version checks",
+				Request: delta.PushRequest{},
+				Responses: map[int]any{
+					http.StatusOK:                  delta.PushResponse{},
+					http.StatusBadRequest:          delta.ErrorResponse{},
+					http.StatusInternalServerError: delta.ErrorResponse{},
+				},
+			},
+			handler: a.pushMutations,
+		},
+		{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/collab", Tag: "collab", Auth: true,
+				Summary: "Open a WebSocket for presence and drag previews on project boards",
+				Responses: map[int]any{
+					http.StatusSwitchingProtocols: nil,
+					http.StatusBadRequest:         nil,
+					http.StatusForbidden:          nil,
+				},
+			},
+			handler: a.collaborate,
+		},
+	}
+}
+
+// registerRoutes registers every route on the router and builds the OpenAPI document
+// from the same list, so the served spec cannot drift from the registered handlers.
+func registerRoutes(router *gin.Engine, routes []route, options routerOptions) *openapi.Document {
+	builder := openapi.NewBuilder("GTD-TODO API", apiVersion)
+	var document *openapi.Document
+
+	routes = append(routes,
+		route{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/openapi.json", Tag: "docs",
+				Summary:   "OpenAPI document of this API",
+				Responses: map[int]any{http.StatusOK: openapi.Document{}},
+			},
+			handler: func(c *gin.Context) { c.JSON(http.StatusOK, document) },
+		},
+		route{
+			Route: openapi.Route{
+				Method: http.MethodGet, Path: "/api/docs", Tag: "docs",
+				Summary:     "API documentation page",
+				Responses:   map[int]any{http.StatusOK: ""},
+				ContentType: "text/html",
+			},
+			handler: func(c *gin.Context) { c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsPage) },
+		},
+	)
+
+	for i := range routes {
+		routes[i].rateLimit = resolveRateLimit(routes[i], options)
+		if supportsIdempotency(routes[i].Method, routes[i].Path) {
+			routes[i].Headers = append(routes[i].Headers, idempotencyKeyParameter)
+		}
+		documentCommonResponses(routes[i], options)
+		builder.Add(routes[i].Route)
+	}
+	document = builder.Document()
+
+	router.Use(authenticate(options.tokenParser), useIdempotencyStore(options.idempotencyStore))
+	var validator *openapi.Validator
+	if options.requestValidation {
+		validator = openapi.NewValidator(document)
+	}
+	for _, r := range routes {
+		router.Handle(r.Method, r.Path, middlewareChain(r, options, validator)...)
+	}
+	return document
+}
+
+// middlewareChain throttles before anything else so floods of invalid or
+// unauthenticated requests are limited too.
+func middlewareChain(r route, options routerOptions, validator *openapi.Validator) []gin.HandlerFunc {
+	var chain []gin.HandlerFunc
+	if r.rateLimit != "" {
+		chain = append(chain, rateLimit(options.limiter, options.rateLimitPolicies[r.rateLimit]))
+	}
+	if r.Auth {
+		chain = append(chain, requireAuth)
+	}
+	if validator != nil {
+		chain = append(chain, contractValidation(validator))
+	}
+	return append(chain, r.handler)
+}
+
+func resolveRateLimit(r route, options routerOptions) string {
+	switch {
+	case options.limiter == nil:
+		return ""
+	case r.rateLimit != "":
+		return r.rateLimit
+	case !strings.HasPrefix(r.Path, "/api/"):
+		return ""
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return rateLimitRead
+	default:
+		return rateLimitWrite
+	}
+}
+
+func documentCommonResponses(r route, options routerOptions) {
+	addResponse := func(status int) {
+		if _, ok := r.Responses[status]; !ok {
+			r.Responses[status] = apperror.ErrorResponse{}
+		}
+	}
+	if options.requestValidation {
+		addResponse(http.StatusBadRequest)
+	}
+	if r.Auth {
+		addResponse(http.StatusUnauthorized)
+	}
+	if r.rateLimit != "" {
+		addResponse(http.StatusTooManyRequests)
+	}
+	if supportsIdempotency(r.Method, r.Path) {
+		addResponse(http.StatusConflict)
+		addResponse(http.StatusUnprocessableEntity)
+	}
+}
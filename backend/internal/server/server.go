@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"yangdongju/gtd_todo/internal/attention"
+	"yangdongju/gtd_todo/internal/config"
+	"yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/delta"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/health"
+	"yangdongju/gtd_todo/internal/idempotency"
+	"yangdongju/gtd_todo/internal/next"
+	"yangdongju/gtd_todo/internal/ratelimit"
+	"yangdongju/gtd_todo/internal/reminder"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const maintenanceInterval = time.Hour
+
+// maintenanceTask deletes expired rows and reports how many it removed.
+type maintenanceTask struct {
+	name string
+	run  func(ctx context.Context) (int64, error)
+}
+
+type Server struct {
+	httpServer      *http.Server
+	probe           *health.Probe
+	eventBroker     *event.Broker
+	eventDSN        string
+	maintenance     []maintenanceTask
+	reminders       *reminder.Scheduler
+	digests         *attention.Digester
+	cancelStreams   context.CancelFunc
+	drainDelay      time.Duration
+	shutdownTimeout time.Duration
+}
+
+func NewServer(cfg *config.Config, pool *sqlx.DB) *Server {
+	readinessTimeout := time.Duration(cfg.ReadinessTimeoutMillis) * time.Millisecond
+	probe := newProbe(pool, routerOptions{
+		readinessTimeout: readinessTimeout,
+	})
+	idempotencyKeys := idempotency.NewPostgresStore(pool, time.Duration(cfg.IdempotencyTTLHours)*time.Hour, time.Now)
+	eventLog := event.NewPostgresLog(pool)
+	eventRetention := time.Duration(cfg.EventRetentionHours) * time.Hour
+	syncRepository := delta.NewSyncRepository(pool)
+	tombstoneRetention := time.Duration(cfg.TombstoneRetentionHours) * time.Hour
+	broker := event.NewBroker()
+	limiter, policies, rateLimitMaintenance := newRateLimiter(cfg, pool)
+	router := SetupRouter(pool,
+		WithProbe(probe),
+		WithRequestValidation(cfg.RequestValidationEnabled),
+		WithTrustedProxies(cfg.TrustedProxies),
+		WithRateLimiter(limiter, policies),
+		WithIdempotencyStore(idempotencyKeys),
+		WithEventBroker(broker),
+		WithNextWeights(next.Weights{
+			Due:     cfg.NextWeightDue,
+			Age:     cfg.NextWeightAge,
+			Project: cfg.NextWeightProject,
+			Blocked: cfg.NextWeightBlocked,
+		}),
+	)
+
+	// Event streams never finish on their own, so they get a context that shutdown cancels.
+	streamCtx, cancelStreams := context.WithCancel(context.Background())
+	return &Server{
+		httpServer: &http.Server{
+			Addr:        ":" + cfg.ServerPort,
+			Handler:     router,
+			BaseContext: func(net.Listener) context.Context { return streamCtx },
+		},
+		probe:         probe,
+		eventBroker:   broker,
+		eventDSN:      db.DSN(cfg),
+		cancelStreams: cancelStreams,
+		maintenance: append([]maintenanceTask{
+			{name: "idempotency keys", run: idempotencyKeys.DeleteExpired},
+			{name: "events", run: func(ctx context.Context) (int64, error) {
+				return eventLog.Prune(ctx, time.Now().Add(-eventRetention))
+			}},
+			{name: "tombstones", run: func(ctx context.Context) (int64, error) {
+				return syncRepository.PruneTombstones(ctx, time.Now().Add(-tombstoneRetention))
+			}},
+		}, rateLimitMaintenance...),
+		reminders:       newReminderScheduler(cfg, pool),
+		digests:         newAttentionDigester(cfg, pool),
+		drainDelay:      time.Duration(cfg.ShutdownDrainSeconds) * time.Second,
+		shutdownTimeout: time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second,
+	}
+}
+
+// newRateLimiter also returns the maintenance the store needs. The memory store sweeps
+// itself; Postgres buckets are pruned once idle for longer than the longest window.
+func newRateLimiter(cfg *config.Config, pool *sqlx.DB) (*ratelimit.Limiter, map[string]ratelimit.Policy, []maintenanceTask) {
+	policies := map[string]ratelimit.Policy{
+		rateLimitAuth:  {Name: rateLimitAuth, Limit: cfg.RateLimitAuthPerMinute, Window: time.Minute},
+		rateLimitRead:  {Name: rateLimitRead, Limit: cfg.RateLimitReadPerMinute, Window: time.Minute},
+		rateLimitWrite: {Name: rateLimitWrite, Limit: cfg.RateLimitWritePerMinute, Window: time.Minute},
+	}
+	for _, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			log.Fatalf("Rate limit config is invalid. %v", err)
+		}
+	}
+
+	if cfg.RateLimitStore != "postgres" {
+		return ratelimit.NewLimiter(ratelimit.NewMemoryStore(), time.Now), policies, nil
+	}
+	store := ratelimit.NewPostgresStore(pool)
+	var idle time.Duration
+	for _, policy := range policies {
+		idle = max(idle, policy.Window)
+	}
+	return ratelimit.NewLimiter(store, time.Now), policies, []maintenanceTask{
+		{name: "rate limit buckets", run: func(ctx context.Context) (int64, error) {
+			return store.Prune(ctx, time.Now().Add(-idle))
+		}},
+	}
+}
+
+// newReminderScheduler returns nil when REMINDER_NOTIFIER is unset, which leaves
+// reminders undelivered.
+func newReminderScheduler(cfg *config.Config, pool *sqlx.DB) *reminder.Scheduler {
+	var notifier reminder.Notifier
+	switch cfg.ReminderNotifier {
+	case "":
+		return nil
+	case "smtp":
+		notifier = reminder.NewSMTPNotifier(reminder.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}, time.Now)
+	case "webhook":
+		notifier = reminder.NewWebhookNotifier(cfg.ReminderWebhookURL, cfg.ReminderWebhookSecret, &http.Client{Timeout: 10 * time.Second})
+	default:
+		log.Fatalf("REMINDER_NOTIFIER must be smtp or webhook. got=%v", cfg.ReminderNotifier)
+	}
+	return reminder.InitializeScheduler(pool, notifier, time.Duration(cfg.ReminderPollSeconds)*time.Second)
+}
+
+// newAttentionDigester returns nil when ATTENTION_DIGEST_NOTIFIER is unset. Users can still
+// turn the digest on; it stays scheduled and goes out once a notifier is configured.
+func newAttentionDigester(cfg *config.Config, pool *sqlx.DB) *attention.Digester {
+	var notifier attention.Notifier
+	switch cfg.AttentionDigestNotifier {
+	case "":
+		return nil
+	case "webhook":
+		notifier = attention.NewWebhookNotifier(cfg.AttentionWebhookURL, cfg.AttentionWebhookSecret, &http.Client{Timeout: 10 * time.Second})
+	default:
+		log.Fatalf("ATTENTION_DIGEST_NOTIFIER must be webhook. got=%v", cfg.AttentionDigestNotifier)
+	}
+	return attention.InitializeDigester(pool, notifier, time.Duration(cfg.AttentionDigestPollSeconds)*time.Second)
+}
+
+// Run serves until SIGINT or SIGTERM is received. On shutdown, readiness is failed first and
+// the server keeps serving for the drain delay so load balancers stop sending new traffic.
+func (s *Server) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go s.runMaintenance(ctx)
+	if s.reminders != nil {
+		go s.reminders.Run(ctx)
+	}
+	if s.digests != nil {
+		go s.digests.Run(ctx)
+	}
+	go func() {
+		if err := event.Listen(ctx, s.eventDSN, s.eventBroker); err != nil {
+			log.Printf("Event listener stopped. %v", err)
+		}
+	}()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server listening on %s", s.httpServer.Addr)
+		serveErr <- s.httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	s.probe.MarkShuttingDown()
+	log.Printf("Shutdown requested. Draining traffic for %v", s.drainDelay)
+	time.Sleep(s.drainDelay)
+
+	s.cancelStreams()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	return s.httpServer.Shutdown(shutdownCtx)
+}
+
+func (s *Server) runMaintenance(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, task := range s.maintenance {
+				if deleted, err := task.run(ctx); err != nil {
+					log.Printf("Maintenance failed. task=%v err=%v", task.name, err)
+				} else if deleted > 0 {
+					log.Printf("Maintenance removed %d expired %v", deleted, task.name)
+				}
+			}
+		}
+	}
+}
@@ -1,9 +1,17 @@
 package server_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/health"
+	"yangdongju/gtd_todo/internal/openapi"
+	"yangdongju/gtd_todo/internal/ratelimit"
 	"yangdongju/gtd_todo/internal/server"
 	"yangdongju/gtd_todo/testhelper"
 
@@ -16,7 +24,7 @@ func TestMain(m *testing.M) {
 	testhelper.TestMain(m)
 }
 
-func TestHealthCheck(t *testing.T) {
+func TestLiveness(t *testing.T) {
 	// given
 	testhelper.CleanUp()
 	gin.SetMode(gin.TestMode)
@@ -24,7 +32,7 @@ func TestHealthCheck(t *testing.T) {
 
 	// when
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/health", nil)
+	req, _ := http.NewRequest("GET", "/livez", nil)
 	router.ServeHTTP(w, req)
 
 	// then
@@ -32,4 +40,421 @@ func TestHealthCheck(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `{"status":"ok"}`)
 }
 
+func TestReadiness_Ready(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB())
+
+	// when
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	// then
+	var report health.ReadinessReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ready", report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+	assert.Equal(t, health.StatusUp, report.Checks["migrations"].Status)
+}
+
+func TestReadiness_ShuttingDown(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	probe := health.NewProbe(time.Second, health.NewDBChecker(testhelper.GetTestDB()))
+	router := server.SetupRouter(testhelper.GetTestDB(), server.WithProbe(probe))
+	probe.MarkShuttingDown()
+
+	// when
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"shutting_down":true`)
+}
 
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	// given
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB())
+
+	// when
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/openapi.json", nil)
+	router.ServeHTTP(w, req)
+
+	// then
+	var document openapi.Document
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+
+	registered := map[string]bool{}
+	for _, info := range router.Routes() {
+		registered[strings.ToLower(info.Method)+" "+openapi.ToOpenAPIPath(info.Path)] = true
+	}
+	documented := map[string]bool{}
+	for path, item := range document.Paths {
+		for method := range item {
+			documented[method+" "+path] = true
+		}
+	}
+	assert.Equal(t, registered, documented, "OpenAPI document and registered routes diverged")
+}
+
+func TestDocsPage(t *testing.T) {
+	// given
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB())
+
+	// when
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/docs", nil)
+	router.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "/api/openapi.json")
+}
+
+func TestRequestValidation_RejectsContractViolation(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB(), server.WithRequestValidation(true))
+
+	// when
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"email":"hello@example.com","password":"password1234","role":"admin"}`)
+	req, _ := http.NewRequest("POST", "/api/auth/signup", body)
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// then
+	var res apperror.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []apperror.Violation{{Location: "body.role", Message: "is not documented"}}, res.Violations)
+}
+
+func TestRequestValidation_PassesValidRequest(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB(), server.WithRequestValidation(true))
+
+	// when
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"email":"hello@example.com","password":"password1234"}`)
+	req, _ := http.NewRequest("POST", "/api/auth/signup", body)
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestRateLimit_AuthRoutes(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), time.Now)
+	policies := map[string]ratelimit.Policy{
+		"auth":  {Name: "auth", Limit: 1, Window: time.Minute},
+		"read":  {Name: "read", Limit: 100, Window: time.Minute},
+		"write": {Name: "write", Limit: 100, Window: time.Minute},
+	}
+	router := server.SetupRouter(testhelper.GetTestDB(), server.WithRateLimiter(limiter, policies))
+	login := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/login", strings.NewReader(`{"email":"a@example.com","password":"password1234"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// when
+	first := login()
+	second := login()
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, first.Code)
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1;w=60", first.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, "60", second.Header().Get("Retry-After"))
+}
+
+func TestRateLimit_ProbesAreNotLimited(t *testing.T) {
+	// given
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB())
+
+	// when
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/livez", nil)
+	router.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB())
+	signUp := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/signup", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "signup-1")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// when
+	first := signUp(`{"email":"hello@example.com","password":"password1234"}`)
+	retry := signUp(`{"email":"hello@example.com","password":"password1234"}`)
+
+	// then
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_ReplaysETag(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	send := authenticatedRequest(t, server.SetupRouter(testhelper.GetTestDB()))
+	create := func() *httptest.ResponseRecorder {
+		return send("POST", "/api/todos", `{"title":"Buy milk"}`, map[string]string{"Idempotency-Key": "todo-1"})
+	}
+
+	// when
+	first := create()
+	retry := create()
+
+	// then
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, `"1"`, first.Header().Get("ETag"))
+	assert.Equal(t, first.Header().Get("ETag"), retry.Header().Get("ETag"))
+}
+
+func TestIdempotency_RejectsDifferentBodyWithSameKey(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB())
+	signUp := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/signup", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "signup-1")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// when
+	first := signUp(`{"email":"hello@example.com","password":"password1234"}`)
+	second := signUp(`{"email":"other@example.com","password":"password1234"}`)
+
+	// then
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, second.Code)
+}
+
+func authenticatedRequest(t *testing.T, router *gin.Engine) func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	send := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	credentials := `{"email":"hello@example.com","password":"password1234"}`
+	send("POST", "/api/auth/signup", credentials, nil)
+	var login struct {
+		Token string `json:"token"`
+	}
+	assert.NoError(t, json.Unmarshal(send("POST", "/api/auth/login", credentials, nil).Body.Bytes(), &login))
+
+	return func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		withToken := map[string]string{"Authorization": "Bearer " + login.Token}
+		for key, value := range headers {
+			withToken[key] = value
+		}
+		return send(method, path, body, withToken)
+	}
+}
+
+func TestConditionalRequests_Todo(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	send := authenticatedRequest(t, server.SetupRouter(testhelper.GetTestDB()))
+	created := send("POST", "/api/todos", `{"title":"Buy milk"}`, nil)
+
+	// when
+	notModified := send("GET", "/api/todos/1", "", map[string]string{"If-None-Match": created.Header().Get("ETag")})
+	missing := send("PATCH", "/api/todos/1", `{"title":"Buy oat milk"}`, nil)
+	updated := send("PATCH", "/api/todos/1", `{"title":"Buy oat milk"}`, map[string]string{"If-Match": `"1"`})
+	stale := send("DELETE", "/api/todos/1", "", map[string]string{"If-Match": `"1"`})
+
+	// then
+	assert.Equal(t, http.StatusCreated, created.Code)
+	assert.Equal(t, `"1"`, created.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.String())
+	assert.Equal(t, http.StatusPreconditionRequired, missing.Code)
+	assert.Equal(t, http.StatusOK, updated.Code)
+	assert.Equal(t, `"2"`, updated.Header().Get("ETag"))
+	assert.Equal(t, http.StatusPreconditionFailed, stale.Code)
+	assert.Equal(t, `"2"`, stale.Header().Get("ETag"))
+	assert.Contains(t, stale.Body.String(), `"title":"Buy oat milk"`)
+}
+
+func TestConditionalRequests_ProjectListIsNotModified(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	send := authenticatedRequest(t, server.SetupRouter(testhelper.GetTestDB()))
+	send("POST", "/api/projects", `{"name":"Home"}`, nil)
+	first := send("GET", "/api/projects", "", nil)
+
+	// when
+	unchanged := send("GET", "/api/projects", "", map[string]string{"If-None-Match": first.Header().Get("ETag")})
+	send("POST", "/api/projects", `{"name":"Work"}`, nil)
+	changed := send("GET", "/api/projects", "", map[string]string{"If-None-Match": first.Header().Get("ETag")})
+
+	// then
+	assert.Equal(t, http.StatusNotModified, unchanged.Code)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, first.Header().Get("ETag"), changed.Header().Get("ETag"))
+}
+
+func TestEvents_RejectsInvalidLastEventID(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	send := authenticatedRequest(t, server.SetupRouter(testhelper.GetTestDB()))
+
+	// when
+	w := send("GET", "/api/events", "", map[string]string{"Last-Event-ID": "abc"})
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
+
+func TestEvents_AcceptsTicketInQuery(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(testhelper.GetTestDB())
+	send := authenticatedRequest(t, router)
+	var ticket struct {
+		Ticket string `json:"ticket"`
+	}
+	assert.NoError(t, json.Unmarshal(send("POST", "/api/events/ticket", "", nil).Body.Bytes(), &ticket))
+	open := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/events?ticket="+query, nil)
+		req.Header.Set("Last-Event-ID", "abc")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// when
+	withTicket := open(ticket.Ticket)
+	withoutTicket := open("")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, withTicket.Code)
+	assert.Equal(t, http.StatusUnauthorized, withoutTicket.Code)
+}
+
+func TestSync_PushThenPullSinceCursor(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	gin.SetMode(gin.TestMode)
+	send := authenticatedRequest(t, server.SetupRouter(testhelper.GetTestDB()))
+	send("POST", "/api/todos", `{"title":"Buy milk"}`, nil)
+	var initial struct {
+		Cursor int64 `json:"cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(send("GET", "/api/sync", "", nil).Body.Bytes(), &initial))
+
+	// when
+	pushed := send("POST", "/api/sync", `{"mutations":[
+		{"entity":"todo","op":"create","client_id":"6f1c2b9e-3d4a-4e5f-8a7b-9c0d1e2f3a4b","data":{"title":"Offline"}},
+		{"entity":"todo","op":"delete","id":1,"version":1}
+	]}`, nil)
+	pulled := send("GET", "/api/sync?since="+strconv.FormatInt(initial.Cursor, 10), "", nil)
+
+	// then
+	assert.Equal(t, http.StatusOK, pushed.Code)
+	assert.Contains(t, pushed.Body.String(), `"status":"applied"`)
+	assert.NotContains(t, pushed.Body.String(), `"status":"conflict"`)
+	var changes struct {
+		Cursor int64 `json:"cursor"`
+		Todos  []struct {
+			ClientID *string `json:"client_id"`
+		} `json:"todos"`
+		Tombstones []struct {
+			Entity string `json:"entity"`
+			ID     int    `json:"id"`
+		} `json:"tombstones"`
+	}
+	assert.NoError(t, json.Unmarshal(pulled.Body.Bytes(), &changes))
+	assert.Equal(t, initial.Cursor+2, changes.Cursor)
+	assert.Len(t, changes.Todos, 1)
+	assert.Equal(t, "6f1c2b9e-3d4a-4e5f-8a7b-9c0d1e2f3a4b", *changes.Todos[0].ClientID)
+	assert.Len(t, changes.Tombstones, 1)
+	assert.Equal(t, "todo", changes.Tombstones[0].Entity)
+	assert.Equal(t, 1, changes.Tombstones[0].ID)
+}
+
+func TestRateLimit_IgnoresForwardedForFromUntrustedPeers(t *testing.T) {
+	// given
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), time.Now)
+	policies := map[string]ratelimit.Policy{
+		"auth":  {Name: "auth", Limit: 1, Window: time.Minute},
+		"read":  {Name: "read", Limit: 100, Window: time.Minute},
+		"write": {Name: "write", Limit: 100, Window: time.Minute},
+	}
+	router := server.SetupRouter(testhelper.GetTestDB(), server.WithRateLimiter(limiter, policies))
+	login := func(forwardedFor string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/login", strings.NewReader(`{"email":"a@example.com","password":"password1234"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.RemoteAddr = "203.0.113.7:41000"
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// when
+	first := login("198.51.100.1")
+	spoofed := login("198.51.100.2")
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, spoofed.Code)
+}
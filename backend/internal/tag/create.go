@@ -0,0 +1,40 @@
+package tag
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+const defaultColor = "#6B7280"
+
+func (s *tagService) Create(ctx context.Context, req CreateTagRequest) (*TagResponse, error) {
+	color := defaultColor
+	if req.Color != nil {
+		color = *req.Color
+	}
+	kind := KindTag
+	if req.Kind != nil {
+		kind = *req.Kind
+	}
+
+	var saved *Tag
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.tagRepository.Save(ctx, &Tag{UserID: req.UserID, Name: req.Name, Color: color, Kind: kind})
+		if err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.TagCreated, saved.ID, toTagResponse(saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTagResponse(saved), nil
+}
+
+type CreateTagRequest struct {
+	UserID int     `json:"-" auth:"user_id"`
+	Name   string  `json:"name" binding:"required,max=50"`
+	Color  *string `json:"color" binding:"omitempty,hexcolor"`
+	Kind   *string `json:"kind" binding:"omitempty,oneof=tag context"`
+}
@@ -0,0 +1,52 @@
+package tag
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// Delete removes the tag from every todo that carries it.
+func (s *tagService) Delete(ctx context.Context, req DeleteTagRequest) (*DeleteTagResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		touched, err := s.tagRepository.TouchTodos(ctx, tag.ID)
+		if err != nil {
+			return err
+		}
+		deleted, err := s.tagRepository.Delete(ctx, tag)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return NewVersionConflictError(tag, expectedVersion)
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, event.TagDeleted, tag.ID, event.Deleted{ID: tag.ID}); err != nil {
+			return err
+		}
+		return s.publishTouchedTodos(ctx, req.UserID, touched)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteTagResponse{Message: "Tag deleted"}, nil
+}
+
+type DeleteTagRequest struct {
+	UserID  int    `json:"-" auth:"user_id"`
+	ID      int    `json:"-" uri:"id"`
+	IfMatch string `json:"-" header:"If-Match"`
+}
+
+type DeleteTagResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,81 @@
+package tag
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type TagNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e TagNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewTagNotFoundError(id int) *TagNotFoundError {
+	return &TagNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("Tag not found. id=%v", id),
+		NestedErr: nil,
+	}
+}
+
+type TagNameTakenError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e TagNameTakenError) Error() string {
+	return e.Message
+}
+
+func NewTagNameTakenError(name string) *TagNameTakenError {
+	return &TagNameTakenError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Tag name already in use. name=%v", name),
+		NestedErr: nil,
+	}
+}
+
+type InvalidMergeError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidMergeError) Error() string {
+	return e.Message
+}
+
+func NewInvalidMergeError(id int) *InvalidMergeError {
+	return &InvalidMergeError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Tag cannot be merged into itself. id=%v", id),
+		NestedErr: nil,
+	}
+}
+
+// VersionConflictError carries the current tag so the client can merge and retry.
+type VersionConflictError struct {
+	Code      int
+	Message   string
+	Current   *Tag
+	NestedErr error
+}
+
+func (e VersionConflictError) Error() string {
+	return e.Message
+}
+
+func NewVersionConflictError(current *Tag, expectedVersion int) *VersionConflictError {
+	return &VersionConflictError{
+		Code:      http.StatusPreconditionFailed,
+		Message:   fmt.Sprintf("Tag was modified. id=%v & version=%v & expected=%v", current.ID, current.Version, expectedVersion),
+		Current:   current,
+		NestedErr: nil,
+	}
+}
@@ -0,0 +1,37 @@
+package tag
+
+import "context"
+
+func (s *tagService) Get(ctx context.Context, req GetTagRequest) (*TagResponse, error) {
+	tag, err := s.tagRepository.FindByID(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, NewTagNotFoundError(req.ID)
+	}
+	return toTagResponse(tag), nil
+}
+
+func (s *tagService) List(ctx context.Context, req ListTagsRequest) (*TagListResponse, error) {
+	tags, err := s.tagRepository.FindAllByUserID(ctx, req.UserID, req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TagListResponse{Tags: make([]TagResponse, 0, len(tags))}
+	for i := range tags {
+		res.Tags = append(res.Tags, *toTagResponse(&tags[i]))
+	}
+	return res, nil
+}
+
+type GetTagRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+}
+
+type ListTagsRequest struct {
+	UserID int     `json:"-" auth:"user_id"`
+	Kind   *string `json:"-" form:"kind" binding:"omitempty,oneof=tag context"`
+}
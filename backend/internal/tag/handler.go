@@ -0,0 +1,93 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/etag"
+)
+
+type TagHandler struct {
+	tagUsecase TagUsecase
+}
+
+func NewTagHandler(tagUsecase TagUsecase) *TagHandler {
+	return &TagHandler{tagUsecase: tagUsecase}
+}
+
+func (h *TagHandler) HandleCreate(ctx context.Context, req CreateTagRequest) (int, any) {
+	res, err := h.tagUsecase.Create(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusCreated, res
+}
+
+func (h *TagHandler) HandleGet(ctx context.Context, req GetTagRequest) (int, any) {
+	res, err := h.tagUsecase.Get(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TagHandler) HandleList(ctx context.Context, req ListTagsRequest) (int, any) {
+	res, err := h.tagUsecase.List(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TagHandler) HandleUpdate(ctx context.Context, req UpdateTagRequest) (int, any) {
+	res, err := h.tagUsecase.Update(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TagHandler) HandleMerge(ctx context.Context, req MergeTagRequest) (int, any) {
+	res, err := h.tagUsecase.Merge(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TagHandler) HandleDelete(ctx context.Context, req DeleteTagRequest) (int, any) {
+	res, err := h.tagUsecase.Delete(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError answers a version conflict with the current tag, so the body of a 412
+// is a TagResponse rather than an ErrorResponse.
+func handleError(err error) (int, any) {
+	var notFoundError *TagNotFoundError
+	var nameTakenError *TagNameTakenError
+	var invalidMergeError *InvalidMergeError
+	var versionConflictError *VersionConflictError
+
+	switch {
+	case errors.As(err, &notFoundError):
+		return http.StatusNotFound, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &nameTakenError):
+		return http.StatusConflict, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &invalidMergeError):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &versionConflictError):
+		return http.StatusPreconditionFailed, toTagResponse(versionConflictError.Current)
+	case errors.Is(err, etag.ErrMissingIfMatch):
+		return http.StatusPreconditionRequired, ErrorResponse{Error: err.Error()}
+	case errors.Is(err, etag.ErrInvalidVersion):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	default:
+		return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+	}
+}
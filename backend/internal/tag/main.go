@@ -0,0 +1,17 @@
+//go:generate mockery
+package tag
+
+import (
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func InitializeHandler(pool *sqlx.DB, publisher event.Publisher) *TagHandler {
+	txManager := database.NewTxManager(pool)
+	todoService := todo.NewTodoService(todo.NewTodoRepository(pool), txManager, publisher, time.Now)
+	return NewTagHandler(NewTagService(NewTagRepository(pool), txManager, publisher, todoService))
+}
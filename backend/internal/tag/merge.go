@@ -0,0 +1,75 @@
+package tag
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// Merge moves every todo from the tag in the path to the target tag and deletes the
+// source, all in one transaction. If-Match names the version of the source tag.
+func (s *tagService) Merge(ctx context.Context, req MergeTagRequest) (*TagResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	if req.ID == req.IntoID {
+		return nil, NewInvalidMergeError(req.ID)
+	}
+
+	var target *Tag
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		source, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		target, err = s.tagRepository.FindByID(ctx, req.UserID, req.IntoID)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			return NewTagNotFoundError(req.IntoID)
+		}
+
+		touched, err := s.tagRepository.TouchTodos(ctx, source.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.tagRepository.MoveTodos(ctx, source.ID, target.ID); err != nil {
+			return err
+		}
+		deleted, err := s.tagRepository.Delete(ctx, source)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return NewVersionConflictError(source, expectedVersion)
+		}
+
+		target, err = s.tagRepository.FindByID(ctx, req.UserID, target.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, event.TagMerged, source.ID, MergedTag{ID: source.ID, Into: *toTagResponse(target)}); err != nil {
+			return err
+		}
+		return s.publishTouchedTodos(ctx, req.UserID, touched)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTagResponse(target), nil
+}
+
+type MergeTagRequest struct {
+	UserID  int    `json:"-" auth:"user_id"`
+	ID      int    `json:"-" uri:"id"`
+	IfMatch string `json:"-" header:"If-Match"`
+	IntoID  int    `json:"into_id" binding:"required"`
+}
+
+// MergedTag is the payload of tag.merged events.
+type MergedTag struct {
+	ID   int         `json:"id"`
+	Into TagResponse `json:"into"`
+}
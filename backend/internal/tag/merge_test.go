@@ -0,0 +1,118 @@
+package tag_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/tag"
+	tagmocks "yangdongju/gtd_todo/internal/tag/mocks"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type recordingPublisher struct {
+	types []string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, userID int, eventType string, entityID int, payload any) error {
+	p.types = append(p.types, eventType)
+	return nil
+}
+
+// ============ Test Cases ============
+
+func TestMerge_MovesTodosAndDeletesSource(t *testing.T) {
+	// given
+	source := &tag.Tag{ID: 1, UserID: 7, Name: "errand", Kind: tag.KindContext, Version: 2, TodoCount: 3}
+	mockRepo := tagmocks.NewTagRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(source, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 2).Return(&tag.Tag{ID: 2, UserID: 7, Name: "errands", Kind: tag.KindContext, Version: 1, TodoCount: 1}, nil).Once()
+	mockRepo.EXPECT().TouchTodos(mock.Anything, 1).Return([]int{10, 11, 12}, nil)
+	mockRepo.EXPECT().MoveTodos(mock.Anything, 1, 2).Return(nil)
+	mockRepo.EXPECT().Delete(mock.Anything, source).Return(true, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 2).Return(&tag.Tag{ID: 2, UserID: 7, Name: "errands", Kind: tag.KindContext, Version: 1, TodoCount: 4}, nil).Once()
+
+	mockTodo := todomocks.NewTodoUsecase(t)
+	mockTodo.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req todo.GetTodoRequest) (*todo.TodoResponse, error) {
+		return &todo.TodoResponse{ID: req.ID}, nil
+	}).Times(3)
+
+	publisher := &recordingPublisher{}
+	service := tag.NewTagService(mockRepo, passThroughTxManager{}, publisher, mockTodo)
+
+	// when
+	res, err := service.Merge(context.Background(), tag.MergeTagRequest{UserID: 7, ID: 1, IfMatch: `"2"`, IntoID: 2})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.ID)
+	assert.Equal(t, 4, res.TodoCount)
+	assert.Equal(t, []string{event.TagMerged, event.TodoUpdated, event.TodoUpdated, event.TodoUpdated}, publisher.types)
+}
+
+func TestMerge_IntoItselfIsRejected(t *testing.T) {
+	// given
+	handler := tag.NewTagHandler(tag.NewTagService(tagmocks.NewTagRepository(t), passThroughTxManager{}, &recordingPublisher{}, nil))
+
+	// when
+	code, _ := handler.HandleMerge(context.Background(), tag.MergeTagRequest{UserID: 7, ID: 1, IfMatch: `"1"`, IntoID: 1})
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestMerge_StaleVersionReturnsCurrentTag(t *testing.T) {
+	// given
+	mockRepo := tagmocks.NewTagRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&tag.Tag{ID: 1, UserID: 7, Name: "renamed", Version: 5}, nil)
+
+	handler := tag.NewTagHandler(tag.NewTagService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, nil))
+
+	// when
+	code, res := handler.HandleMerge(context.Background(), tag.MergeTagRequest{UserID: 7, ID: 1, IfMatch: `"4"`, IntoID: 2})
+	current, ok := res.(*tag.TagResponse)
+
+	// then
+	assert.Equal(t, http.StatusPreconditionFailed, code)
+	assert.True(t, ok, "Expected *tag.TagResponse type")
+	assert.Equal(t, `"5"`, current.ETag())
+}
+
+func TestUpdate_RenameTouchesTaggedTodos(t *testing.T) {
+	// given
+	mockRepo := tagmocks.NewTagRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&tag.Tag{ID: 1, UserID: 7, Name: "home", Kind: tag.KindContext, Version: 1}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *tag.Tag) (*tag.Tag, error) {
+		updated := *t
+		updated.Version++
+		return &updated, nil
+	})
+	mockRepo.EXPECT().TouchTodos(mock.Anything, 1).Return([]int{10, 11}, nil)
+
+	mockTodo := todomocks.NewTodoUsecase(t)
+	mockTodo.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 10}).Return(&todo.TodoResponse{ID: 10}, nil)
+	mockTodo.EXPECT().Get(mock.Anything, todo.GetTodoRequest{UserID: 7, ID: 11}).Return(&todo.TodoResponse{ID: 11}, nil)
+
+	publisher := &recordingPublisher{}
+	service := tag.NewTagService(mockRepo, passThroughTxManager{}, publisher, mockTodo)
+	name := "@home"
+
+	// when
+	res, err := service.Update(context.Background(), tag.UpdateTagRequest{UserID: 7, ID: 1, IfMatch: `"1"`, Name: &name})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "@home", res.Name)
+	assert.Equal(t, 2, res.Version)
+	assert.Equal(t, []string{event.TagUpdated, event.TodoUpdated, event.TodoUpdated}, publisher.types)
+}
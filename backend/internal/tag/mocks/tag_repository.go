@@ -0,0 +1,439 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package tagmocks
+
+import (
+	context "context"
+	tag "yangdongju/gtd_todo/internal/tag"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TagRepository is an autogenerated mock type for the TagRepository type
+type TagRepository struct {
+	mock.Mock
+}
+
+type TagRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TagRepository) EXPECT() *TagRepository_Expecter {
+	return &TagRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, _a1
+func (_m *TagRepository) Delete(ctx context.Context, _a1 *tag.Tag) (bool, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *tag.Tag) (bool, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *tag.Tag) bool); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *tag.Tag) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type TagRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *tag.Tag
+func (_e *TagRepository_Expecter) Delete(ctx interface{}, _a1 interface{}) *TagRepository_Delete_Call {
+	return &TagRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, _a1)}
+}
+
+func (_c *TagRepository_Delete_Call) Run(run func(ctx context.Context, _a1 *tag.Tag)) *TagRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*tag.Tag))
+	})
+	return _c
+}
+
+func (_c *TagRepository_Delete_Call) Return(_a0 bool, _a1 error) *TagRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepository_Delete_Call) RunAndReturn(run func(context.Context, *tag.Tag) (bool, error)) *TagRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAllByUserID provides a mock function with given fields: ctx, userID, kind
+func (_m *TagRepository) FindAllByUserID(ctx context.Context, userID int, kind *string) ([]tag.Tag, error) {
+	ret := _m.Called(ctx, userID, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllByUserID")
+	}
+
+	var r0 []tag.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *string) ([]tag.Tag, error)); ok {
+		return rf(ctx, userID, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *string) []tag.Tag); ok {
+		r0 = rf(ctx, userID, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tag.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *string) error); ok {
+		r1 = rf(ctx, userID, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepository_FindAllByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAllByUserID'
+type TagRepository_FindAllByUserID_Call struct {
+	*mock.Call
+}
+
+// FindAllByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - kind *string
+func (_e *TagRepository_Expecter) FindAllByUserID(ctx interface{}, userID interface{}, kind interface{}) *TagRepository_FindAllByUserID_Call {
+	return &TagRepository_FindAllByUserID_Call{Call: _e.mock.On("FindAllByUserID", ctx, userID, kind)}
+}
+
+func (_c *TagRepository_FindAllByUserID_Call) Run(run func(ctx context.Context, userID int, kind *string)) *TagRepository_FindAllByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*string))
+	})
+	return _c
+}
+
+func (_c *TagRepository_FindAllByUserID_Call) Return(_a0 []tag.Tag, _a1 error) *TagRepository_FindAllByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepository_FindAllByUserID_Call) RunAndReturn(run func(context.Context, int, *string) ([]tag.Tag, error)) *TagRepository_FindAllByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *TagRepository) FindByID(ctx context.Context, userID int, id int) (*tag.Tag, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *tag.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*tag.Tag, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *tag.Tag); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type TagRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int
+func (_e *TagRepository_Expecter) FindByID(ctx interface{}, userID interface{}, id interface{}) *TagRepository_FindByID_Call {
+	return &TagRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, userID, id)}
+}
+
+func (_c *TagRepository_FindByID_Call) Run(run func(ctx context.Context, userID int, id int)) *TagRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TagRepository_FindByID_Call) Return(_a0 *tag.Tag, _a1 error) *TagRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepository_FindByID_Call) RunAndReturn(run func(context.Context, int, int) (*tag.Tag, error)) *TagRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveTodos provides a mock function with given fields: ctx, fromID, toID
+func (_m *TagRepository) MoveTodos(ctx context.Context, fromID int, toID int) error {
+	ret := _m.Called(ctx, fromID, toID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTodos")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, fromID, toID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TagRepository_MoveTodos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveTodos'
+type TagRepository_MoveTodos_Call struct {
+	*mock.Call
+}
+
+// MoveTodos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromID int
+//   - toID int
+func (_e *TagRepository_Expecter) MoveTodos(ctx interface{}, fromID interface{}, toID interface{}) *TagRepository_MoveTodos_Call {
+	return &TagRepository_MoveTodos_Call{Call: _e.mock.On("MoveTodos", ctx, fromID, toID)}
+}
+
+func (_c *TagRepository_MoveTodos_Call) Run(run func(ctx context.Context, fromID int, toID int)) *TagRepository_MoveTodos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TagRepository_MoveTodos_Call) Return(_a0 error) *TagRepository_MoveTodos_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TagRepository_MoveTodos_Call) RunAndReturn(run func(context.Context, int, int) error) *TagRepository_MoveTodos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *TagRepository) Save(ctx context.Context, _a1 *tag.Tag) (*tag.Tag, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *tag.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *tag.Tag) (*tag.Tag, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *tag.Tag) *tag.Tag); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *tag.Tag) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type TagRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *tag.Tag
+func (_e *TagRepository_Expecter) Save(ctx interface{}, _a1 interface{}) *TagRepository_Save_Call {
+	return &TagRepository_Save_Call{Call: _e.mock.On("Save", ctx, _a1)}
+}
+
+func (_c *TagRepository_Save_Call) Run(run func(ctx context.Context, _a1 *tag.Tag)) *TagRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*tag.Tag))
+	})
+	return _c
+}
+
+func (_c *TagRepository_Save_Call) Return(_a0 *tag.Tag, _a1 error) *TagRepository_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepository_Save_Call) RunAndReturn(run func(context.Context, *tag.Tag) (*tag.Tag, error)) *TagRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchTodos provides a mock function with given fields: ctx, tagID
+func (_m *TagRepository) TouchTodos(ctx context.Context, tagID int) ([]int, error) {
+	ret := _m.Called(ctx, tagID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchTodos")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]int, error)); ok {
+		return rf(ctx, tagID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, tagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepository_TouchTodos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchTodos'
+type TagRepository_TouchTodos_Call struct {
+	*mock.Call
+}
+
+// TouchTodos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tagID int
+func (_e *TagRepository_Expecter) TouchTodos(ctx interface{}, tagID interface{}) *TagRepository_TouchTodos_Call {
+	return &TagRepository_TouchTodos_Call{Call: _e.mock.On("TouchTodos", ctx, tagID)}
+}
+
+func (_c *TagRepository_TouchTodos_Call) Run(run func(ctx context.Context, tagID int)) *TagRepository_TouchTodos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *TagRepository_TouchTodos_Call) Return(_a0 []int, _a1 error) *TagRepository_TouchTodos_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepository_TouchTodos_Call) RunAndReturn(run func(context.Context, int) ([]int, error)) *TagRepository_TouchTodos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *TagRepository) Update(ctx context.Context, _a1 *tag.Tag) (*tag.Tag, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *tag.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *tag.Tag) (*tag.Tag, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *tag.Tag) *tag.Tag); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *tag.Tag) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TagRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *tag.Tag
+func (_e *TagRepository_Expecter) Update(ctx interface{}, _a1 interface{}) *TagRepository_Update_Call {
+	return &TagRepository_Update_Call{Call: _e.mock.On("Update", ctx, _a1)}
+}
+
+func (_c *TagRepository_Update_Call) Run(run func(ctx context.Context, _a1 *tag.Tag)) *TagRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*tag.Tag))
+	})
+	return _c
+}
+
+func (_c *TagRepository_Update_Call) Return(_a0 *tag.Tag, _a1 error) *TagRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagRepository_Update_Call) RunAndReturn(run func(context.Context, *tag.Tag) (*tag.Tag, error)) *TagRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTagRepository creates a new instance of TagRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagRepository {
+	mock := &TagRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,391 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package tagmocks
+
+import (
+	context "context"
+	tag "yangdongju/gtd_todo/internal/tag"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TagUsecase is an autogenerated mock type for the TagUsecase type
+type TagUsecase struct {
+	mock.Mock
+}
+
+type TagUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TagUsecase) EXPECT() *TagUsecase_Expecter {
+	return &TagUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *TagUsecase) Create(ctx context.Context, request tag.CreateTagRequest) (*tag.TagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *tag.TagResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tag.CreateTagRequest) (*tag.TagResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tag.CreateTagRequest) *tag.TagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.TagResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tag.CreateTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagUsecase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type TagUsecase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request tag.CreateTagRequest
+func (_e *TagUsecase_Expecter) Create(ctx interface{}, request interface{}) *TagUsecase_Create_Call {
+	return &TagUsecase_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *TagUsecase_Create_Call) Run(run func(ctx context.Context, request tag.CreateTagRequest)) *TagUsecase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tag.CreateTagRequest))
+	})
+	return _c
+}
+
+func (_c *TagUsecase_Create_Call) Return(_a0 *tag.TagResponse, _a1 error) *TagUsecase_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagUsecase_Create_Call) RunAndReturn(run func(context.Context, tag.CreateTagRequest) (*tag.TagResponse, error)) *TagUsecase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, request
+func (_m *TagUsecase) Delete(ctx context.Context, request tag.DeleteTagRequest) (*tag.DeleteTagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *tag.DeleteTagResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tag.DeleteTagRequest) (*tag.DeleteTagResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tag.DeleteTagRequest) *tag.DeleteTagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.DeleteTagResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tag.DeleteTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagUsecase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type TagUsecase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request tag.DeleteTagRequest
+func (_e *TagUsecase_Expecter) Delete(ctx interface{}, request interface{}) *TagUsecase_Delete_Call {
+	return &TagUsecase_Delete_Call{Call: _e.mock.On("Delete", ctx, request)}
+}
+
+func (_c *TagUsecase_Delete_Call) Run(run func(ctx context.Context, request tag.DeleteTagRequest)) *TagUsecase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tag.DeleteTagRequest))
+	})
+	return _c
+}
+
+func (_c *TagUsecase_Delete_Call) Return(_a0 *tag.DeleteTagResponse, _a1 error) *TagUsecase_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagUsecase_Delete_Call) RunAndReturn(run func(context.Context, tag.DeleteTagRequest) (*tag.DeleteTagResponse, error)) *TagUsecase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, request
+func (_m *TagUsecase) Get(ctx context.Context, request tag.GetTagRequest) (*tag.TagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *tag.TagResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tag.GetTagRequest) (*tag.TagResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tag.GetTagRequest) *tag.TagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.TagResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tag.GetTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagUsecase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type TagUsecase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request tag.GetTagRequest
+func (_e *TagUsecase_Expecter) Get(ctx interface{}, request interface{}) *TagUsecase_Get_Call {
+	return &TagUsecase_Get_Call{Call: _e.mock.On("Get", ctx, request)}
+}
+
+func (_c *TagUsecase_Get_Call) Run(run func(ctx context.Context, request tag.GetTagRequest)) *TagUsecase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tag.GetTagRequest))
+	})
+	return _c
+}
+
+func (_c *TagUsecase_Get_Call) Return(_a0 *tag.TagResponse, _a1 error) *TagUsecase_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagUsecase_Get_Call) RunAndReturn(run func(context.Context, tag.GetTagRequest) (*tag.TagResponse, error)) *TagUsecase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *TagUsecase) List(ctx context.Context, request tag.ListTagsRequest) (*tag.TagListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *tag.TagListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tag.ListTagsRequest) (*tag.TagListResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tag.ListTagsRequest) *tag.TagListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.TagListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tag.ListTagsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type TagUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request tag.ListTagsRequest
+func (_e *TagUsecase_Expecter) List(ctx interface{}, request interface{}) *TagUsecase_List_Call {
+	return &TagUsecase_List_Call{Call: _e.mock.On("List", ctx, request)}
+}
+
+func (_c *TagUsecase_List_Call) Run(run func(ctx context.Context, request tag.ListTagsRequest)) *TagUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tag.ListTagsRequest))
+	})
+	return _c
+}
+
+func (_c *TagUsecase_List_Call) Return(_a0 *tag.TagListResponse, _a1 error) *TagUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagUsecase_List_Call) RunAndReturn(run func(context.Context, tag.ListTagsRequest) (*tag.TagListResponse, error)) *TagUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function with given fields: ctx, request
+func (_m *TagUsecase) Merge(ctx context.Context, request tag.MergeTagRequest) (*tag.TagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 *tag.TagResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tag.MergeTagRequest) (*tag.TagResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tag.MergeTagRequest) *tag.TagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.TagResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tag.MergeTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagUsecase_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type TagUsecase_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request tag.MergeTagRequest
+func (_e *TagUsecase_Expecter) Merge(ctx interface{}, request interface{}) *TagUsecase_Merge_Call {
+	return &TagUsecase_Merge_Call{Call: _e.mock.On("Merge", ctx, request)}
+}
+
+func (_c *TagUsecase_Merge_Call) Run(run func(ctx context.Context, request tag.MergeTagRequest)) *TagUsecase_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tag.MergeTagRequest))
+	})
+	return _c
+}
+
+func (_c *TagUsecase_Merge_Call) Return(_a0 *tag.TagResponse, _a1 error) *TagUsecase_Merge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagUsecase_Merge_Call) RunAndReturn(run func(context.Context, tag.MergeTagRequest) (*tag.TagResponse, error)) *TagUsecase_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, request
+func (_m *TagUsecase) Update(ctx context.Context, request tag.UpdateTagRequest) (*tag.TagResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *tag.TagResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tag.UpdateTagRequest) (*tag.TagResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tag.UpdateTagRequest) *tag.TagResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tag.TagResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tag.UpdateTagRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagUsecase_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TagUsecase_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request tag.UpdateTagRequest
+func (_e *TagUsecase_Expecter) Update(ctx interface{}, request interface{}) *TagUsecase_Update_Call {
+	return &TagUsecase_Update_Call{Call: _e.mock.On("Update", ctx, request)}
+}
+
+func (_c *TagUsecase_Update_Call) Run(run func(ctx context.Context, request tag.UpdateTagRequest)) *TagUsecase_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tag.UpdateTagRequest))
+	})
+	return _c
+}
+
+func (_c *TagUsecase_Update_Call) Return(_a0 *tag.TagResponse, _a1 error) *TagUsecase_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TagUsecase_Update_Call) RunAndReturn(run func(context.Context, tag.UpdateTagRequest) (*tag.TagResponse, error)) *TagUsecase_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTagUsecase creates a new instance of TagUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagUsecase {
+	mock := &TagUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,163 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	KindTag     = "tag"
+	KindContext = "context"
+)
+
+const uniqueNameIndex = "idx_tags_user_name"
+
+type TagRepository interface {
+	Save(ctx context.Context, tag *Tag) (*Tag, error)
+	FindByID(ctx context.Context, userID int, id int) (*Tag, error)
+	FindAllByUserID(ctx context.Context, userID int, kind *string) ([]Tag, error)
+	Update(ctx context.Context, tag *Tag) (*Tag, error)
+	Delete(ctx context.Context, tag *Tag) (bool, error)
+	MoveTodos(ctx context.Context, fromID int, toID int) error
+	TouchTodos(ctx context.Context, tagID int) ([]int, error)
+}
+
+type tagRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+type Tag struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	Name      string    `db:"name"`
+	Color     string    `db:"color"`
+	Kind      string    `db:"kind"`
+	Version   int       `db:"version"`
+	TodoCount int       `db:"todo_count"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+const tagColumns = "id, user_id, name, color, kind, version, created_at, updated_at"
+
+const tagColumnsWithCount = tagColumns + ", (SELECT COUNT(*) FROM todo_tags WHERE tag_id = tags.id) AS todo_count"
+
+func NewTagRepository(db *sqlx.DB) *tagRepositoryImpl {
+	return &tagRepositoryImpl{db: db}
+}
+
+// Save returns a TagNameTakenError when the user already has a tag with that name,
+// compared case-insensitively.
+func (r *tagRepositoryImpl) Save(ctx context.Context, tag *Tag) (*Tag, error) {
+	var saved Tag
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO tags (user_id, name, color, kind)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+tagColumns,
+		tag.UserID, tag.Name, tag.Color, tag.Kind)
+	if database.IsUniqueViolation(err, uniqueNameIndex) {
+		return nil, NewTagNameTakenError(tag.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *tagRepositoryImpl) FindByID(ctx context.Context, userID int, id int) (*Tag, error) {
+	var tag Tag
+	err := database.Conn(ctx, r.db).GetContext(ctx, &tag,
+		"SELECT "+tagColumnsWithCount+" FROM tags WHERE id = $1 AND user_id = $2", id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindAllByUserID returns the user's tags by name, only those of kind when it is set.
+func (r *tagRepositoryImpl) FindAllByUserID(ctx context.Context, userID int, kind *string) ([]Tag, error) {
+	tags := []Tag{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &tags, `
+		SELECT `+tagColumnsWithCount+` FROM tags
+		WHERE user_id = $1 AND ($2::varchar IS NULL OR kind = $2)
+		ORDER BY LOWER(name), id`,
+		userID, kind)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Update writes the tag only if its version is still the one that was read, and bumps
+// the version. It returns nil when another writer got there first.
+func (r *tagRepositoryImpl) Update(ctx context.Context, tag *Tag) (*Tag, error) {
+	var updated Tag
+	err := database.Conn(ctx, r.db).GetContext(ctx, &updated, `
+		UPDATE tags
+		SET name = $4, color = $5, kind = $6, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND version = $3
+		RETURNING `+tagColumnsWithCount,
+		tag.ID, tag.UserID, tag.Version, tag.Name, tag.Color, tag.Kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if database.IsUniqueViolation(err, uniqueNameIndex) {
+		return nil, NewTagNameTakenError(tag.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *tagRepositoryImpl) Delete(ctx context.Context, tag *Tag) (bool, error) {
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM tags WHERE id = $1 AND user_id = $2 AND version = $3",
+		tag.ID, tag.UserID, tag.Version)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	return deleted == 1, err
+}
+
+// MoveTodos tags every todo tagged fromID with toID instead. Todos that already had both
+// keep a single link.
+func (r *tagRepositoryImpl) MoveTodos(ctx context.Context, fromID int, toID int) error {
+	conn := database.Conn(ctx, r.db)
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO todo_tags (todo_id, tag_id)
+		SELECT todo_id, $2 FROM todo_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING`,
+		fromID, toID)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, "DELETE FROM todo_tags WHERE tag_id = $1", fromID)
+	return err
+}
+
+// TouchTodos bumps the version of every todo carrying the tag, because todos embed their
+// tags: ETags and sync cursors must change when a tag does. It returns the touched ids.
+func (r *tagRepositoryImpl) TouchTodos(ctx context.Context, tagID int) ([]int, error) {
+	touched := []int{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &touched, `
+		UPDATE todos SET version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT todo_id FROM todo_tags WHERE tag_id = $1)
+		RETURNING id`,
+		tagID)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(touched)
+	return touched, nil
+}
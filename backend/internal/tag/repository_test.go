@@ -0,0 +1,59 @@
+package tag_test
+
+import (
+	"context"
+	"testing"
+	"yangdongju/gtd_todo/internal/tag"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagRepository_NamesAreUniquePerUserIgnoringCase(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	var userID int
+	_ = testhelper.GetTestDB().Get(&userID,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	repository := tag.NewTagRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	_, err := repository.Save(ctx, &tag.Tag{UserID: userID, Name: "Errands", Color: "#6B7280", Kind: tag.KindContext})
+	assert.NoError(t, err)
+
+	// when
+	_, err = repository.Save(ctx, &tag.Tag{UserID: userID, Name: "errands", Color: "#6B7280", Kind: tag.KindTag})
+
+	// then
+	var nameTaken *tag.TagNameTakenError
+	assert.ErrorAs(t, err, &nameTaken)
+}
+
+func TestTagRepository_MoveTodosKeepsOneLinkAndTouchesTodos(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	db := testhelper.GetTestDB()
+	var userID int
+	_ = db.Get(&userID, "INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	repository := tag.NewTagRepository(db)
+	ctx := context.Background()
+	from, _ := repository.Save(ctx, &tag.Tag{UserID: userID, Name: "errand", Color: "#6B7280", Kind: tag.KindContext})
+	into, _ := repository.Save(ctx, &tag.Tag{UserID: userID, Name: "errands", Color: "#6B7280", Kind: tag.KindContext})
+	var both, onlyFrom int
+	_ = db.Get(&both, "INSERT INTO todos (user_id, title) VALUES ($1, 'Post office') RETURNING id", userID)
+	_ = db.Get(&onlyFrom, "INSERT INTO todos (user_id, title) VALUES ($1, 'Bank') RETURNING id", userID)
+	db.MustExec("INSERT INTO todo_tags (todo_id, tag_id) VALUES ($1, $2), ($1, $3), ($4, $2)", both, from.ID, into.ID, onlyFrom)
+
+	// when
+	touched, touchErr := repository.TouchTodos(ctx, from.ID)
+	moveErr := repository.MoveTodos(ctx, from.ID, into.ID)
+	merged, _ := repository.FindByID(ctx, userID, into.ID)
+	var versions []int
+	_ = db.Select(&versions, "SELECT version FROM todos WHERE user_id = $1 ORDER BY id", userID)
+
+	// then
+	assert.NoError(t, touchErr)
+	assert.NoError(t, moveErr)
+	assert.Equal(t, []int{both, onlyFrom}, touched)
+	assert.Equal(t, 2, merged.TodoCount)
+	assert.Equal(t, []int{2, 2}, versions)
+}
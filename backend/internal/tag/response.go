@@ -0,0 +1,38 @@
+package tag
+
+import (
+	"time"
+	"yangdongju/gtd_todo/internal/etag"
+)
+
+type TagResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	Kind      string    `json:"kind"`
+	TodoCount int       `json:"todo_count"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (r TagResponse) ETag() string {
+	return etag.FromVersion(r.Version)
+}
+
+type TagListResponse struct {
+	Tags []TagResponse `json:"tags"`
+}
+
+func toTagResponse(tag *Tag) *TagResponse {
+	return &TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		Color:     tag.Color,
+		Kind:      tag.Kind,
+		TodoCount: tag.TodoCount,
+		Version:   tag.Version,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
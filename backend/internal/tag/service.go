@@ -0,0 +1,66 @@
+package tag
+
+import (
+	"context"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+)
+
+type TagUsecase interface {
+	Create(ctx context.Context, request CreateTagRequest) (*TagResponse, error)
+	Get(ctx context.Context, request GetTagRequest) (*TagResponse, error)
+	List(ctx context.Context, request ListTagsRequest) (*TagListResponse, error)
+	Update(ctx context.Context, request UpdateTagRequest) (*TagResponse, error)
+	Merge(ctx context.Context, request MergeTagRequest) (*TagResponse, error)
+	Delete(ctx context.Context, request DeleteTagRequest) (*DeleteTagResponse, error)
+}
+
+type tagService struct {
+	tagRepository TagRepository
+	txManager     database.TxManager
+	publisher     event.Publisher
+	todoUsecase   todo.TodoUsecase
+}
+
+// NewTagService reads the todos a tag change reaches through the todo use case, so their
+// todo.updated events carry the same body as any other todo change.
+func NewTagService(repository TagRepository, txManager database.TxManager, publisher event.Publisher, todoUsecase todo.TodoUsecase) *tagService {
+	return &tagService{
+		tagRepository: repository,
+		txManager:     txManager,
+		publisher:     publisher,
+		todoUsecase:   todoUsecase,
+	}
+}
+
+// findForWrite loads the tag a conditional write targets and checks the version
+// the client based its change on.
+func (s *tagService) findForWrite(ctx context.Context, userID int, id int, expectedVersion int) (*Tag, error) {
+	tag, err := s.tagRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, NewTagNotFoundError(id)
+	}
+	if tag.Version != expectedVersion {
+		return nil, NewVersionConflictError(tag, expectedVersion)
+	}
+	return tag, nil
+}
+
+// publishTouchedTodos announces the todos TouchTodos reached as todo.updated. Call it once
+// the tag change is written, so each todo is read with its tags as they now are.
+func (s *tagService) publishTouchedTodos(ctx context.Context, userID int, ids []int) error {
+	for _, id := range ids {
+		touched, err := s.todoUsecase.Get(ctx, todo.GetTodoRequest{UserID: userID, ID: id})
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, userID, event.TodoUpdated, id, touched); err != nil {
+			return err
+		}
+	}
+	return nil
+}
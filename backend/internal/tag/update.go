@@ -0,0 +1,64 @@
+package tag
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// Update applies a partial change on top of the version named in If-Match. Todos carrying
+// the tag get a new version in the same transaction, so their ETags and sync state move
+// together with the tag they show.
+func (s *tagService) Update(ctx context.Context, req UpdateTagRequest) (*TagResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Tag
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		tag, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			tag.Name = *req.Name
+		}
+		if req.Color != nil {
+			tag.Color = *req.Color
+		}
+		if req.Kind != nil {
+			tag.Kind = *req.Kind
+		}
+
+		updated, err = s.tagRepository.Update(ctx, tag)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return NewVersionConflictError(tag, expectedVersion)
+		}
+		touched, err := s.tagRepository.TouchTodos(ctx, updated.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, event.TagUpdated, updated.ID, toTagResponse(updated)); err != nil {
+			return err
+		}
+		return s.publishTouchedTodos(ctx, req.UserID, touched)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTagResponse(updated), nil
+}
+
+type UpdateTagRequest struct {
+	UserID  int     `json:"-" auth:"user_id"`
+	ID      int     `json:"-" uri:"id"`
+	IfMatch string  `json:"-" header:"If-Match"`
+	Name    *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Color   *string `json:"color" binding:"omitempty,hexcolor"`
+	Kind    *string `json:"kind" binding:"omitempty,oneof=tag context"`
+}
@@ -0,0 +1,171 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+const maxChecklistItems = 100
+
+// AddChecklistItem adds a step to the todo's checklist, at the end unless a position is given.
+func (s *todoService) AddChecklistItem(ctx context.Context, req AddChecklistItemRequest) (*TodoResponse, error) {
+	return s.changeChecklist(ctx, req.UserID, req.ID, func(ctx context.Context, todo *Todo) (bool, error) {
+		if len(todo.Checklist) >= maxChecklistItems {
+			return false, NewInvalidChecklistError(fmt.Sprintf("a todo holds at most %d items", maxChecklistItems))
+		}
+		position := len(todo.Checklist)
+		if req.Position != nil {
+			position = min(*req.Position, position)
+		}
+		item := &ChecklistItem{TodoID: todo.ID, Title: req.Title, Checked: req.Checked, Position: position}
+		return false, s.todoRepository.SaveChecklistItem(ctx, item)
+	})
+}
+
+// UpdateChecklistItem renames, checks or unchecks a step. Checking the last open step of a
+// todo with checklist_auto_complete completes the todo.
+func (s *todoService) UpdateChecklistItem(ctx context.Context, req UpdateChecklistItemRequest) (*TodoResponse, error) {
+	return s.changeChecklist(ctx, req.UserID, req.ID, func(ctx context.Context, todo *Todo) (bool, error) {
+		item, err := s.findChecklistItem(ctx, todo.ID, req.ItemID)
+		if err != nil {
+			return false, err
+		}
+		closed := req.Checked != nil && *req.Checked && !item.Checked
+		if req.Title != nil {
+			item.Title = *req.Title
+		}
+		if req.Checked != nil {
+			item.Checked = *req.Checked
+		}
+		return closed, s.todoRepository.UpdateChecklistItem(ctx, item)
+	})
+}
+
+// DeleteChecklistItem removes a step. Removing the last open step completes the todo like
+// checking it would.
+func (s *todoService) DeleteChecklistItem(ctx context.Context, req DeleteChecklistItemRequest) (*TodoResponse, error) {
+	return s.changeChecklist(ctx, req.UserID, req.ID, func(ctx context.Context, todo *Todo) (bool, error) {
+		item, err := s.findChecklistItem(ctx, todo.ID, req.ItemID)
+		if err != nil {
+			return false, err
+		}
+		return !item.Checked, s.todoRepository.DeleteChecklistItem(ctx, item)
+	})
+}
+
+// ReorderChecklist puts the steps in the order given, which must list every step once.
+func (s *todoService) ReorderChecklist(ctx context.Context, req ReorderChecklistRequest) (*TodoResponse, error) {
+	return s.changeChecklist(ctx, req.UserID, req.ID, func(ctx context.Context, todo *Todo) (bool, error) {
+		current := make([]int, len(todo.Checklist))
+		for i, item := range todo.Checklist {
+			current[i] = item.ID
+		}
+		wanted := slices.Clone(req.ItemIDs)
+		slices.Sort(current)
+		slices.Sort(wanted)
+		if !slices.Equal(current, wanted) {
+			return false, NewInvalidChecklistError("item_ids must list every item of the checklist once")
+		}
+		return false, s.todoRepository.ReorderChecklist(ctx, todo.ID, req.ItemIDs)
+	})
+}
+
+// changeChecklist applies change to the todo's checklist and records it on the todo. change
+// reports whether it closed an open step; when that leaves every step checked and the todo
+// asks for it, the todo is completed as ChangeStatus would, continuing a recurring series.
+// Checklist writes need no If-Match, but they do move the todo's version on.
+func (s *todoService) changeChecklist(ctx context.Context, userID int, id int, change func(ctx context.Context, todo *Todo) (bool, error)) (*TodoResponse, error) {
+	var res *TodoResponse
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		todo, err := s.todoRepository.FindByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if todo == nil {
+			return NewTodoNotFoundError(id)
+		}
+		closed, err := change(ctx, todo)
+		if err != nil {
+			return err
+		}
+		touched, err := s.todoRepository.TouchChecklist(ctx, todo.ID)
+		if err != nil {
+			return err
+		}
+
+		if closed && touched.ChecklistAutoComplete && touched.Status != StatusDone && checklistDone(touched.Checklist) {
+			moved, err := s.ChangeStatus(ctx, ChangeStatusRequest{
+				UserID:  userID,
+				ID:      touched.ID,
+				IfMatch: etag.FromVersion(touched.Version),
+				Status:  StatusDone,
+			})
+			if err != nil {
+				return err
+			}
+			res = moved.Todo
+			return nil
+		}
+		res = toTodoResponse(touched)
+		return s.publisher.Publish(ctx, userID, event.TodoUpdated, touched.ID, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *todoService) findChecklistItem(ctx context.Context, todoID int, id int) (*ChecklistItem, error) {
+	item, err := s.todoRepository.FindChecklistItem(ctx, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, NewChecklistItemNotFoundError(todoID, id)
+	}
+	return item, nil
+}
+
+// checklistDone reports whether a non-empty checklist has every step checked.
+func checklistDone(items []ChecklistItem) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !item.Checked {
+			return false
+		}
+	}
+	return true
+}
+
+type AddChecklistItemRequest struct {
+	UserID   int    `json:"-" auth:"user_id"`
+	ID       int    `json:"-" uri:"id"`
+	Title    string `json:"title" binding:"required,max=500"`
+	Checked  bool   `json:"checked"`
+	Position *int   `json:"position" binding:"omitempty,gte=0"`
+}
+
+type UpdateChecklistItemRequest struct {
+	UserID  int     `json:"-" auth:"user_id"`
+	ID      int     `json:"-" uri:"id"`
+	ItemID  int     `json:"-" uri:"item_id"`
+	Title   *string `json:"title" binding:"omitempty,min=1,max=500"`
+	Checked *bool   `json:"checked"`
+}
+
+type DeleteChecklistItemRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+	ItemID int `json:"-" uri:"item_id"`
+}
+
+type ReorderChecklistRequest struct {
+	UserID  int   `json:"-" auth:"user_id"`
+	ID      int   `json:"-" uri:"id"`
+	ItemIDs []int `json:"item_ids" binding:"required,max=100"`
+}
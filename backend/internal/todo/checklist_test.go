@@ -0,0 +1,135 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateChecklistItem_CheckingTheLastOpenItemCompletesTheTodo(t *testing.T) {
+	// given
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Title: "Prepare release", Status: todo.StatusNextActions, Version: 3, ChecklistAutoComplete: true,
+		Checklist: []todo.ChecklistItem{
+			{ID: 10, TodoID: 1, Title: "Tag the commit", Checked: true, Position: 0},
+			{ID: 11, TodoID: 1, Title: "Write notes", Position: 1},
+		},
+	}
+	touched := *current
+	touched.Version = 4
+	touched.Checklist = []todo.ChecklistItem{
+		{ID: 10, TodoID: 1, Title: "Tag the commit", Checked: true, Position: 0},
+		{ID: 11, TodoID: 1, Title: "Write notes", Checked: true, Position: 1},
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil).Once()
+	mockRepo.EXPECT().FindChecklistItem(mock.Anything, 1, 11).Return(&current.Checklist[1], nil)
+	mockRepo.EXPECT().UpdateChecklistItem(mock.Anything, mock.MatchedBy(func(item *todo.ChecklistItem) bool {
+		return item.ID == 11 && item.Checked
+	})).Return(nil)
+	mockRepo.EXPECT().TouchChecklist(mock.Anything, 1).Return(&touched, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&touched, nil).Once()
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(0, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.Status == todo.StatusDone && t.Version == 4
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		updated := *t
+		updated.Version++
+		return &updated, nil
+	})
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.UpdateChecklistItem(context.Background(), todo.UpdateChecklistItemRequest{
+		UserID: 7, ID: 1, ItemID: 11, Checked: ptr(true),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, todo.StatusDone, res.Status)
+	assert.Equal(t, todo.ChecklistProgressResponse{Checked: 2, Total: 2}, res.ChecklistProgress)
+	assert.Equal(t, 5, res.Version)
+	assert.Equal(t, []string{event.TodoMoved}, publisher.types)
+}
+
+func TestUpdateChecklistItem_RenamingKeepsTheTodoWhereItIs(t *testing.T) {
+	// given
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Status: todo.StatusNextActions, Version: 3, ChecklistAutoComplete: true,
+		Checklist: []todo.ChecklistItem{{ID: 10, TodoID: 1, Title: "Tag", Checked: true}},
+	}
+	touched := *current
+	touched.Version = 4
+	touched.Checklist = []todo.ChecklistItem{{ID: 10, TodoID: 1, Title: "Tag the commit", Checked: true}}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().FindChecklistItem(mock.Anything, 1, 10).Return(&current.Checklist[0], nil)
+	mockRepo.EXPECT().UpdateChecklistItem(mock.Anything, mock.Anything).Return(nil)
+	mockRepo.EXPECT().TouchChecklist(mock.Anything, 1).Return(&touched, nil)
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.UpdateChecklistItem(context.Background(), todo.UpdateChecklistItemRequest{
+		UserID: 7, ID: 1, ItemID: 10, Title: ptr("Tag the commit"),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, todo.StatusNextActions, res.Status, "only closing an open item completes the todo")
+	assert.Equal(t, `"4"`, res.ETag())
+	assert.Equal(t, []string{event.TodoUpdated}, publisher.types)
+}
+
+func TestAddChecklistItem_PositionPastTheEndAppends(t *testing.T) {
+	// given
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Status: todo.StatusInbox, Version: 1,
+		Checklist: []todo.ChecklistItem{{ID: 10, TodoID: 1, Title: "First"}},
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().SaveChecklistItem(mock.Anything, mock.MatchedBy(func(item *todo.ChecklistItem) bool {
+		return item.TodoID == 1 && item.Title == "Second" && item.Position == 1
+	})).Return(nil)
+	mockRepo.EXPECT().TouchChecklist(mock.Anything, 1).Return(current, nil)
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	_, err := service.AddChecklistItem(context.Background(), todo.AddChecklistItemRequest{
+		UserID: 7, ID: 1, Title: "Second", Position: ptr(5),
+	})
+
+	// then
+	assert.NoError(t, err)
+}
+
+func TestReorderChecklist_MustListEveryItemOnce(t *testing.T) {
+	// given
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Version: 1,
+		Checklist: []todo.ChecklistItem{{ID: 10, TodoID: 1}, {ID: 11, TodoID: 1}},
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	handler := todo.NewTodoHandler(todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now))
+
+	// when
+	status, body := handler.HandleReorderChecklist(context.Background(), todo.ReorderChecklistRequest{
+		UserID: 7, ID: 1, ItemIDs: []int{11, 11},
+	})
+
+	// then
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body.(todo.ErrorResponse).Error, "every item")
+}
@@ -0,0 +1,114 @@
+package todo
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// Create adds a todo at the end of its status column. A client_id that was already used
+// returns the todo created with it, so offline clients can safely resend a create.
+// Reminders count minutes back from the due date, read in the user's timezone. A recurrence
+// rule makes the todo the first instance of a series. A waiting_for todo can say who it
+// waits on and when to follow up.
+func (s *todoService) Create(ctx context.Context, req CreateTodoRequest) (*TodoResponse, error) {
+	status := StatusInbox
+	if req.Status != nil {
+		status = *req.Status
+	}
+
+	var saved *Todo
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if req.ClientID != nil {
+			existing, err := s.todoRepository.FindByClientID(ctx, req.UserID, *req.ClientID)
+			if err != nil || existing != nil {
+				saved = existing
+				return err
+			}
+		}
+		if err := s.checkProject(ctx, req.UserID, req.ProjectID); err != nil {
+			return err
+		}
+		if err := s.checkTags(ctx, req.UserID, req.TagIDs); err != nil {
+			return err
+		}
+
+		todo := &Todo{
+			UserID:                req.UserID,
+			ClientID:              req.ClientID,
+			ProjectID:             req.ProjectID,
+			Title:                 req.Title,
+			Description:           req.Description,
+			Status:                status,
+			RecurrenceMode:        RecurrenceSchedule,
+			Occurrence:            1,
+			ChecklistAutoComplete: req.ChecklistAutoComplete,
+			EstimateMinutes:       req.EstimateMinutes,
+			Energy:                req.Energy,
+		}
+		if _, err := applyDue(todo, req.DueDate, req.DueTime); err != nil {
+			return err
+		}
+		if err := applyRecurrence(todo, req.Recurrence, req.RecurrenceMode); err != nil {
+			return err
+		}
+		followUp, err := s.applyDelegation(ctx, todo, "", req.DelegatedTo, req.DelegateContact, req.DelegatedDate, req.FollowUpDate)
+		if err != nil {
+			return err
+		}
+		if todo.DueDate == nil && len(req.Reminders) > 0 {
+			return NewInvalidDueDateError("reminders need a due_date")
+		}
+
+		todo.Position, err = s.todoRepository.NextPosition(ctx, req.UserID, status)
+		if err != nil {
+			return err
+		}
+
+		saved, err = s.todoRepository.Save(ctx, todo)
+		if err != nil {
+			return err
+		}
+		if len(req.TagIDs) > 0 {
+			if saved.Tags, err = s.todoRepository.ReplaceTags(ctx, saved.ID, req.TagIDs); err != nil {
+				return err
+			}
+		}
+		if len(req.Reminders) > 0 {
+			if saved.Reminders, err = s.todoRepository.ReplaceReminders(ctx, saved.ID, req.Reminders); err != nil {
+				return err
+			}
+		}
+		if followUp {
+			if err := s.todoRepository.ScheduleFollowUp(ctx, saved.ID); err != nil {
+				return err
+			}
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.TodoCreated, saved.ID, toTodoResponse(saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTodoResponse(saved), nil
+}
+
+type CreateTodoRequest struct {
+	UserID                int     `json:"-" auth:"user_id"`
+	ClientID              *string `json:"client_id" binding:"omitempty,uuid"`
+	Title                 string  `json:"title" binding:"required,max=500"`
+	Description           *string `json:"description"`
+	ProjectID             *int    `json:"project_id"`
+	Status                *string `json:"status" binding:"omitempty,oneof=inbox next_actions in_progress done someday waiting_for"`
+	TagIDs                []int   `json:"tag_ids" binding:"omitempty,max=20"`
+	DueDate               *string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	DueTime               *string `json:"due_time" binding:"omitempty,datetime=15:04"`
+	Reminders             []int   `json:"reminders" binding:"omitempty,max=10,dive,gte=0,lte=40320"`
+	Recurrence            *string `json:"recurrence" binding:"omitempty,max=255"`
+	RecurrenceMode        *string `json:"recurrence_mode" binding:"omitempty,oneof=schedule after_completion"`
+	DelegatedTo           *string `json:"delegated_to" binding:"omitempty,max=255"`
+	DelegateContact       *string `json:"delegate_contact" binding:"omitempty,max=255"`
+	DelegatedDate         *string `json:"delegated_date" binding:"omitempty,datetime=2006-01-02"`
+	FollowUpDate          *string `json:"follow_up_date" binding:"omitempty,datetime=2006-01-02"`
+	ChecklistAutoComplete bool    `json:"checklist_auto_complete"`
+	EstimateMinutes       *int    `json:"estimate_minutes" binding:"omitempty,min=1,max=1440"`
+	Energy                *string `json:"energy" binding:"omitempty,oneof=low med high"`
+}
@@ -0,0 +1,74 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreate_DefaultsToEndOfInbox(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusInbox).Return(2, nil)
+	mockRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.UserID == 7 && t.Status == todo.StatusInbox && t.Position == 2
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		saved := *t
+		saved.ID = 1
+		saved.Version = 1
+		return &saved, nil
+	})
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.Create(context.Background(), todo.CreateTodoRequest{UserID: 7, Title: "Buy milk"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.ID)
+	assert.Equal(t, "Buy milk", res.Title)
+	assert.Equal(t, 1, res.Version)
+}
+
+func TestCreate_UnknownProject(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().ProjectExists(mock.Anything, 7, 3).Return(false, nil)
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.Create(context.Background(), todo.CreateTodoRequest{UserID: 7, Title: "x", ProjectID: ptr(3)})
+
+	// then
+	var invalidProject *todo.InvalidProjectError
+	assert.Nil(t, res)
+	assert.ErrorAs(t, err, &invalidProject)
+}
+
+func TestCreate_ReusedClientIDReturnsExistingTodo(t *testing.T) {
+	// given
+	clientID := "6f1c2b9e-3d4a-4e5f-8a7b-9c0d1e2f3a4b"
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByClientID(mock.Anything, 7, clientID).Return(&todo.Todo{
+		ID: 4, UserID: 7, ClientID: &clientID, Title: "Buy milk", Status: todo.StatusInbox, Version: 1,
+	}, nil)
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.Create(context.Background(), todo.CreateTodoRequest{UserID: 7, ClientID: &clientID, Title: "Buy milk"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 4, res.ID)
+	assert.Equal(t, &clientID, res.ClientID)
+	assert.Empty(t, publisher.types)
+}
@@ -0,0 +1,106 @@
+package todo
+
+import (
+	"context"
+	"time"
+)
+
+// applyDelegation sets the delegation fields a request sends on a todo already moved to
+// its new status. An empty string clears a value. Only waiting_for todos are delegated: a
+// todo leaving waiting_for loses its delegation, and one in it without a delegated_date
+// was delegated today. It reports whether the follow-up reminder has to be rescheduled.
+func (s *todoService) applyDelegation(ctx context.Context, todo *Todo, previousStatus string, delegatedTo *string, delegateContact *string, delegatedDate *string, followUpDate *string) (bool, error) {
+	before := followUpKey(previousStatus, todo.FollowUpDate)
+
+	setText(&todo.DelegatedTo, delegatedTo)
+	setText(&todo.DelegateContact, delegateContact)
+	if err := setDate(&todo.DelegatedDate, delegatedDate, "delegated_date"); err != nil {
+		return false, err
+	}
+	if err := setDate(&todo.FollowUpDate, followUpDate, "follow_up_date"); err != nil {
+		return false, err
+	}
+
+	if todo.Status != StatusWaitingFor {
+		for _, value := range []*string{delegatedTo, delegateContact, delegatedDate, followUpDate} {
+			if value != nil && *value != "" {
+				return false, NewInvalidDelegationError("only waiting_for todos are delegated")
+			}
+		}
+		todo.DelegatedTo, todo.DelegateContact, todo.DelegatedDate, todo.FollowUpDate = nil, nil, nil, nil
+	} else if todo.DelegatedDate == nil {
+		today, err := s.today(ctx, todo.UserID)
+		if err != nil {
+			return false, err
+		}
+		todo.DelegatedDate = &today
+	}
+	if todo.FollowUpDate != nil && todo.DelegatedDate != nil && todo.FollowUpDate.Before(*todo.DelegatedDate) {
+		return false, NewInvalidDelegationError("follow_up_date cannot be before delegated_date")
+	}
+	return followUpKey(todo.Status, todo.FollowUpDate) != before, nil
+}
+
+// followUpKey identifies the follow-up reminder a todo should have, or none.
+func followUpKey(status string, followUpDate *time.Time) string {
+	if status != StatusWaitingFor || followUpDate == nil {
+		return ""
+	}
+	return followUpDate.Format(dueDateLayout)
+}
+
+// today is the user's current date, which delegated_date defaults to.
+func (s *todoService) today(ctx context.Context, userID int) (time.Time, error) {
+	loc, err := s.location(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dateIn(s.now(), loc), nil
+}
+
+// location is the user's timezone. A timezone the runtime does not know falls back to
+// UTC, as it does for next actions and attention.
+func (s *todoService) location(ctx context.Context, userID int) (*time.Location, error) {
+	timezone, err := s.todoRepository.UserTimezone(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// dateIn is the date at t on the clock of loc, as a date column reads it.
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func setText(field **string, value *string) {
+	if value == nil {
+		return
+	}
+	if *value == "" {
+		*field = nil
+		return
+	}
+	*field = value
+}
+
+func setDate(field **time.Time, value *string, name string) error {
+	if value == nil {
+		return nil
+	}
+	if *value == "" {
+		*field = nil
+		return nil
+	}
+	date, err := time.Parse(dueDateLayout, *value)
+	if err != nil {
+		return NewInvalidDelegationError(name + " must look like 2026-01-31")
+	}
+	*field = &date
+	return nil
+}
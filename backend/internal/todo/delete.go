@@ -0,0 +1,60 @@
+package todo
+
+import (
+	"context"
+	"slices"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+func (s *todoService) Delete(ctx context.Context, req DeleteTodoRequest) (*DeleteTodoResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		todo, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		// The todos it blocks lose a blocker with it. They are touched first, while the
+		// dependencies that name them still exist.
+		dependents, err := s.todoRepository.TouchDependents(ctx, todo.ID)
+		if err != nil {
+			return err
+		}
+		deleted, err := s.todoRepository.Delete(ctx, todo)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return NewVersionConflictError(todo, expectedVersion)
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, event.TodoDeleted, todo.ID, event.Deleted{ID: todo.ID}); err != nil {
+			return err
+		}
+		for i := range dependents {
+			dependents[i].Blockers = slices.DeleteFunc(dependents[i].Blockers, func(blocker TodoBlocker) bool {
+				return blocker.ID == todo.ID
+			})
+		}
+		_, err = s.publishDependents(ctx, req.UserID, dependents, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteTodoResponse{Message: "Todo deleted"}, nil
+}
+
+type DeleteTodoRequest struct {
+	UserID  int    `json:"-" auth:"user_id"`
+	ID      int    `json:"-" uri:"id"`
+	IfMatch string `json:"-" header:"If-Match"`
+}
+
+type DeleteTodoResponse struct {
+	Message string `json:"message"`
+}
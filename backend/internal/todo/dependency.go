@@ -0,0 +1,209 @@
+package todo
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// GetDependencies returns the todo's dependency graph: what it waits on and what waits on
+// it, directly or through other todos.
+func (s *todoService) GetDependencies(ctx context.Context, req GetDependenciesRequest) (*DependencyGraphResponse, error) {
+	todo, err := s.todoRepository.FindByID(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		return nil, NewTodoNotFoundError(req.ID)
+	}
+	graph, err := s.todoRepository.FindDependencyGraph(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toDependencyGraphResponse(req.ID, graph), nil
+}
+
+// AddDependency makes the todo wait on another todo of the same user. A dependency that
+// would close a loop is refused.
+func (s *todoService) AddDependency(ctx context.Context, req AddDependencyRequest) (*TodoResponse, error) {
+	if req.BlockerID == req.ID {
+		return nil, NewInvalidDependencyError("a todo cannot block itself")
+	}
+
+	var updated *Todo
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		todo, err := s.todoRepository.FindByID(ctx, req.UserID, req.ID)
+		if err != nil {
+			return err
+		}
+		if todo == nil {
+			return NewTodoNotFoundError(req.ID)
+		}
+		blocker, err := s.todoRepository.FindByID(ctx, req.UserID, req.BlockerID)
+		if err != nil {
+			return err
+		}
+		if blocker == nil {
+			return NewInvalidDependencyError("blocker does not exist")
+		}
+		cycle, err := s.todoRepository.CreatesCycle(ctx, todo.ID, blocker.ID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return NewDependencyCycleError(todo.ID, blocker.ID)
+		}
+
+		if err := s.todoRepository.AddDependency(ctx, todo.ID, blocker.ID); err != nil {
+			return err
+		}
+		if updated, err = s.todoRepository.FindByID(ctx, req.UserID, todo.ID); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.TodoUpdated, updated.ID, toTodoResponse(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTodoResponse(updated), nil
+}
+
+// RemoveDependency stops the todo waiting on the blocker.
+func (s *todoService) RemoveDependency(ctx context.Context, req RemoveDependencyRequest) (*TodoResponse, error) {
+	var updated *Todo
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		todo, err := s.todoRepository.FindByID(ctx, req.UserID, req.ID)
+		if err != nil {
+			return err
+		}
+		if todo == nil {
+			return NewTodoNotFoundError(req.ID)
+		}
+		removed, err := s.todoRepository.RemoveDependency(ctx, todo.ID, req.BlockerID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return NewDependencyNotFoundError(todo.ID, req.BlockerID)
+		}
+		if updated, err = s.todoRepository.FindByID(ctx, req.UserID, todo.ID); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.TodoUpdated, updated.ID, toTodoResponse(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTodoResponse(updated), nil
+}
+
+// refreshDependents runs after todo moved in or out of done, which blocks or unblocks the
+// todos waiting on it. Their versions move on, and when todo's completion left one without
+// open blockers while it sat in inbox or someday, it surfaces to the end of next_actions.
+// It returns the todos it surfaced.
+func (s *todoService) refreshDependents(ctx context.Context, todo *Todo, previousStatus string) ([]*Todo, error) {
+	completed := todo.Status == StatusDone
+	if completed == (previousStatus == StatusDone) {
+		return nil, nil
+	}
+	dependents, err := s.todoRepository.TouchDependents(ctx, todo.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.publishDependents(ctx, todo.UserID, dependents, completed)
+}
+
+// publishDependents announces the touched dependents as todo.updated. When released, the
+// blocker no longer holds them up, and one left without open blockers while it sat in
+// inbox or someday surfaces to the end of next_actions as todo.moved instead.
+func (s *todoService) publishDependents(ctx context.Context, userID int, dependents []Todo, released bool) ([]*Todo, error) {
+	var surfaced []*Todo
+	for i := range dependents {
+		dependent := &dependents[i]
+		if !released || dependent.IsBlocked() || (dependent.Status != StatusInbox && dependent.Status != StatusSomeday) {
+			if err := s.publisher.Publish(ctx, userID, event.TodoUpdated, dependent.ID, toTodoResponse(dependent)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var err error
+		dependent.Status = StatusNextActions
+		if dependent.Position, err = s.todoRepository.NextPosition(ctx, userID, StatusNextActions); err != nil {
+			return nil, err
+		}
+		moved, err := s.todoRepository.Update(ctx, dependent)
+		if err != nil {
+			return nil, err
+		}
+		if moved == nil {
+			return nil, NewVersionConflictError(dependent, dependent.Version)
+		}
+		if err := s.publisher.Publish(ctx, userID, event.TodoMoved, moved.ID, toTodoResponse(moved)); err != nil {
+			return nil, err
+		}
+		surfaced = append(surfaced, moved)
+	}
+	return surfaced, nil
+}
+
+// IsBlocked reports whether the todo waits on a todo that is not done yet.
+func (t *Todo) IsBlocked() bool {
+	for _, blocker := range t.Blockers {
+		if blocker.Status != StatusDone {
+			return true
+		}
+	}
+	return false
+}
+
+type GetDependenciesRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+}
+
+type AddDependencyRequest struct {
+	UserID    int `json:"-" auth:"user_id"`
+	ID        int `json:"-" uri:"id"`
+	BlockerID int `json:"blocker_id" binding:"required"`
+}
+
+type RemoveDependencyRequest struct {
+	UserID    int `json:"-" auth:"user_id"`
+	ID        int `json:"-" uri:"id"`
+	BlockerID int `json:"-" uri:"blocker_id"`
+}
+
+// DependencyGraphResponse lists the todos connected to TodoID by blocked-by edges. Each
+// edge reads "todo_id is blocked by blocker_id".
+type DependencyGraphResponse struct {
+	TodoID int                      `json:"todo_id"`
+	Nodes  []DependencyNodeResponse `json:"nodes"`
+	Edges  []DependencyEdgeResponse `json:"edges"`
+}
+
+type DependencyNodeResponse struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	IsBlocked bool   `json:"is_blocked"`
+}
+
+type DependencyEdgeResponse struct {
+	TodoID    int `json:"todo_id"`
+	BlockerID int `json:"blocker_id"`
+}
+
+func toDependencyGraphResponse(todoID int, graph *DependencyGraph) *DependencyGraphResponse {
+	res := &DependencyGraphResponse{
+		TodoID: todoID,
+		Nodes:  make([]DependencyNodeResponse, 0, len(graph.Nodes)),
+		Edges:  make([]DependencyEdgeResponse, 0, len(graph.Edges)),
+	}
+	for _, node := range graph.Nodes {
+		res.Nodes = append(res.Nodes, DependencyNodeResponse{ID: node.ID, Title: node.Title, Status: node.Status, IsBlocked: node.IsBlocked})
+	}
+	for _, edge := range graph.Edges {
+		res.Edges = append(res.Edges, DependencyEdgeResponse{TodoID: edge.TodoID, BlockerID: edge.BlockerID})
+	}
+	return res
+}
@@ -0,0 +1,117 @@
+package todo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddDependency_RefusesACycle(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7}, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 2).Return(&todo.Todo{ID: 2, UserID: 7}, nil)
+	mockRepo.EXPECT().CreatesCycle(mock.Anything, 1, 2).Return(true, nil)
+	handler := todo.NewTodoHandler(todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now))
+
+	// when
+	status, body := handler.HandleAddDependency(context.Background(), todo.AddDependencyRequest{UserID: 7, ID: 1, BlockerID: 2})
+
+	// then
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Contains(t, body.(todo.ErrorResponse).Error, "cycle")
+}
+
+func TestAddDependency_BlockerMustBelongToTheUser(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7}, nil)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 9).Return(nil, nil)
+	handler := todo.NewTodoHandler(todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now))
+
+	// when
+	status, _ := handler.HandleAddDependency(context.Background(), todo.AddDependencyRequest{UserID: 7, ID: 1, BlockerID: 9})
+	selfStatus, _ := handler.HandleAddDependency(context.Background(), todo.AddDependencyRequest{UserID: 7, ID: 1, BlockerID: 1})
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, http.StatusBadRequest, selfStatus)
+}
+
+func TestChangeStatus_CompletingTheLastBlockerSurfacesParkedDependents(t *testing.T) {
+	// given
+	done := todo.TodoBlocker{ID: 1, Status: todo.StatusDone}
+	open := todo.TodoBlocker{ID: 5, Status: todo.StatusNextActions}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7, Status: todo.StatusInProgress, Version: 1}, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(0, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool { return t.ID == 1 })).
+		Return(&todo.Todo{ID: 1, UserID: 7, Status: todo.StatusDone, Version: 2}, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{
+		{ID: 2, UserID: 7, Status: todo.StatusSomeday, Version: 4, Blockers: []todo.TodoBlocker{done}},
+		{ID: 3, UserID: 7, Status: todo.StatusSomeday, Version: 2, Blockers: []todo.TodoBlocker{done, open}},
+		{ID: 4, UserID: 7, Status: todo.StatusWaitingFor, Version: 2, Blockers: []todo.TodoBlocker{done}},
+	}, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusNextActions).Return(6, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.ID == 2 && t.Status == todo.StatusNextActions && t.Position == 6 && t.Version == 4
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		moved := *t
+		moved.Version++
+		return &moved, nil
+	})
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: todo.StatusDone,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Unblocked, 1)
+	assert.Equal(t, 2, res.Unblocked[0].ID)
+	assert.False(t, res.Unblocked[0].IsBlocked)
+	assert.Equal(t, []string{event.TodoMoved, event.TodoMoved, event.TodoUpdated, event.TodoUpdated}, publisher.types,
+		"one still blocked and one waiting on someone are only updated")
+}
+
+func TestDelete_RemovingTheLastBlockerSurfacesParkedDependents(t *testing.T) {
+	// given
+	deleted := todo.TodoBlocker{ID: 1, Status: todo.StatusNextActions}
+	open := todo.TodoBlocker{ID: 5, Status: todo.StatusNextActions}
+	blocker := &todo.Todo{ID: 1, UserID: 7, Status: todo.StatusNextActions, Version: 1}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(blocker, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{
+		{ID: 2, UserID: 7, Status: todo.StatusSomeday, Version: 4, Blockers: []todo.TodoBlocker{deleted}},
+		{ID: 3, UserID: 7, Status: todo.StatusSomeday, Version: 2, Blockers: []todo.TodoBlocker{deleted, open}},
+	}, nil)
+	mockRepo.EXPECT().Delete(mock.Anything, blocker).Return(true, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusNextActions).Return(6, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.ID == 2 && t.Status == todo.StatusNextActions && len(t.Blockers) == 0
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		moved := *t
+		moved.Version++
+		return &moved, nil
+	})
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	_, err := service.Delete(context.Background(), todo.DeleteTodoRequest{UserID: 7, ID: 1, IfMatch: `"1"`})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{event.TodoDeleted, event.TodoMoved, event.TodoUpdated}, publisher.types,
+		"the one still waiting on another blocker is only updated")
+}
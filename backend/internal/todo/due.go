@@ -0,0 +1,88 @@
+package todo
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	dueDateLayout = "2006-01-02"
+	dueTimeLayout = "15:04"
+)
+
+// applyDue sets the due date and time a request sends. An empty string clears a value, and
+// clearing the date clears the time with it. It reports whether the due moment changed.
+func applyDue(todo *Todo, dueDate *string, dueTime *string) (bool, error) {
+	before := dueKey(todo)
+	if dueDate != nil {
+		if *dueDate == "" {
+			todo.DueDate, todo.DueTime = nil, nil
+		} else {
+			date, err := time.Parse(dueDateLayout, *dueDate)
+			if err != nil {
+				return false, NewInvalidDueDateError("due_date must look like 2026-01-31")
+			}
+			todo.DueDate = &date
+		}
+	}
+	if dueTime != nil {
+		if *dueTime == "" {
+			todo.DueTime = nil
+		} else {
+			clock, err := time.Parse(dueTimeLayout, *dueTime)
+			if err != nil {
+				return false, NewInvalidDueDateError("due_time must look like 14:30")
+			}
+			formatted := clock.Format(dueTimeLayout)
+			todo.DueTime = &formatted
+		}
+	}
+	if todo.DueTime != nil && todo.DueDate == nil {
+		return false, NewInvalidDueDateError("due_time needs a due_date")
+	}
+	return dueKey(todo) != before, nil
+}
+
+func dueKey(todo *Todo) string {
+	key := ""
+	if todo.DueDate != nil {
+		key = todo.DueDate.Format(dueDateLayout)
+	}
+	if todo.DueTime != nil {
+		key += " " + formatDueTime(*todo.DueTime)
+	}
+	return key
+}
+
+// formatDueTime drops the seconds Postgres adds to TIME values.
+func formatDueTime(value string) string {
+	if clock, err := time.Parse("15:04:05", value); err == nil {
+		return clock.Format(dueTimeLayout)
+	}
+	return value
+}
+
+// scheduleReminders brings the todo's reminders in line after a write. Reminders move with
+// the due date, replacing the list keeps the ones still in it, and a todo without a due
+// date has none.
+func (s *todoService) scheduleReminders(ctx context.Context, todo *Todo, dueChanged bool, minutesBefore *[]int) error {
+	var err error
+	if todo.DueDate == nil {
+		if minutesBefore != nil && len(*minutesBefore) > 0 {
+			return NewInvalidDueDateError("reminders need a due_date")
+		}
+		if dueChanged || minutesBefore != nil {
+			todo.Reminders, err = s.todoRepository.ReplaceReminders(ctx, todo.ID, []int{})
+		}
+		return err
+	}
+	if dueChanged {
+		if todo.Reminders, err = s.todoRepository.RescheduleReminders(ctx, todo.ID); err != nil {
+			return err
+		}
+	}
+	if minutesBefore != nil {
+		todo.Reminders, err = s.todoRepository.ReplaceReminders(ctx, todo.ID, *minutesBefore)
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+package todo
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type TodoNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e TodoNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewTodoNotFoundError(id int) *TodoNotFoundError {
+	return &TodoNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("Todo not found. id=%v", id),
+		NestedErr: nil,
+	}
+}
+
+type InvalidProjectError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidProjectError) Error() string {
+	return e.Message
+}
+
+func NewInvalidProjectError(projectID int) *InvalidProjectError {
+	return &InvalidProjectError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Project does not exist. project_id=%v", projectID),
+		NestedErr: nil,
+	}
+}
+
+type InvalidTagError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidTagError) Error() string {
+	return e.Message
+}
+
+func NewInvalidTagError(tagIDs []int) *InvalidTagError {
+	return &InvalidTagError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Tag does not exist. tag_ids=%v", tagIDs),
+		NestedErr: nil,
+	}
+}
+
+type InvalidDueDateError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidDueDateError) Error() string {
+	return e.Message
+}
+
+func NewInvalidDueDateError(reason string) *InvalidDueDateError {
+	return &InvalidDueDateError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid due date. %v", reason),
+		NestedErr: nil,
+	}
+}
+
+type InvalidDelegationError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidDelegationError) Error() string {
+	return e.Message
+}
+
+func NewInvalidDelegationError(reason string) *InvalidDelegationError {
+	return &InvalidDelegationError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid delegation. %v", reason),
+		NestedErr: nil,
+	}
+}
+
+type InvalidRecurrenceError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidRecurrenceError) Error() string {
+	return e.Message
+}
+
+func NewInvalidRecurrenceError(reason string) *InvalidRecurrenceError {
+	return &InvalidRecurrenceError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid recurrence. %v", reason),
+		NestedErr: nil,
+	}
+}
+
+type ChecklistItemNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e ChecklistItemNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewChecklistItemNotFoundError(todoID int, id int) *ChecklistItemNotFoundError {
+	return &ChecklistItemNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("Checklist item not found. todo_id=%v & id=%v", todoID, id),
+		NestedErr: nil,
+	}
+}
+
+type InvalidChecklistError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidChecklistError) Error() string {
+	return e.Message
+}
+
+func NewInvalidChecklistError(reason string) *InvalidChecklistError {
+	return &InvalidChecklistError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid checklist. %v", reason),
+		NestedErr: nil,
+	}
+}
+
+type InvalidDependencyError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e InvalidDependencyError) Error() string {
+	return e.Message
+}
+
+func NewInvalidDependencyError(reason string) *InvalidDependencyError {
+	return &InvalidDependencyError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("Invalid dependency. %v", reason),
+		NestedErr: nil,
+	}
+}
+
+type DependencyNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e DependencyNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewDependencyNotFoundError(todoID int, blockerID int) *DependencyNotFoundError {
+	return &DependencyNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("Dependency not found. todo_id=%v & blocker_id=%v", todoID, blockerID),
+		NestedErr: nil,
+	}
+}
+
+type DependencyCycleError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e DependencyCycleError) Error() string {
+	return e.Message
+}
+
+func NewDependencyCycleError(todoID int, blockerID int) *DependencyCycleError {
+	return &DependencyCycleError{
+		Code:      http.StatusConflict,
+		Message:   fmt.Sprintf("Dependency would create a cycle. todo_id=%v & blocker_id=%v", todoID, blockerID),
+		NestedErr: nil,
+	}
+}
+
+// VersionConflictError carries the current todo so the client can merge and retry.
+type VersionConflictError struct {
+	Code      int
+	Message   string
+	Current   *Todo
+	NestedErr error
+}
+
+func (e VersionConflictError) Error() string {
+	return e.Message
+}
+
+func NewVersionConflictError(current *Todo, expectedVersion int) *VersionConflictError {
+	return &VersionConflictError{
+		Code:      http.StatusPreconditionFailed,
+		Message:   fmt.Sprintf("Todo was modified. id=%v & version=%v & expected=%v", current.ID, current.Version, expectedVersion),
+		Current:   current,
+		NestedErr: nil,
+	}
+}
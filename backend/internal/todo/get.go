@@ -0,0 +1,194 @@
+package todo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"yangdongju/gtd_todo/internal/query"
+
+	"github.com/lib/pq"
+)
+
+// todoSorts are the orders a todo list can be read in. The default is the board order.
+var todoSorts = query.Sorts{
+	{Name: "position", Columns: []string{"status", "position"}},
+	{Name: "created_at", Columns: []string{"created_at"}},
+	{Name: "updated_at", Columns: []string{"updated_at"}},
+	{Name: "title", Columns: []string{"title"}},
+}
+
+func (s *todoService) Get(ctx context.Context, req GetTodoRequest) (*TodoResponse, error) {
+	todo, err := s.todoRepository.FindByID(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		return nil, NewTodoNotFoundError(req.ID)
+	}
+	return toTodoResponse(todo), nil
+}
+
+// List returns one page of the todos matching the request's filters. Total counts every
+// match, and NextCursor reads the page after this one.
+func (s *todoService) List(ctx context.Context, req ListTodosRequest) (*TodoListResponse, error) {
+	spec, err := query.NewSpec(todoSorts, req.Sort, req.Order, req.Cursor, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	spec.Filters = []query.Filter{
+		query.In("status", req.Status),
+		query.EqualIfSet("project_id", req.ProjectID),
+		query.Between("created_at", req.CreatedAfter, req.CreatedBefore),
+		query.Between("updated_at", req.UpdatedAfter, req.UpdatedBefore),
+		tagFilter(req.TagID, req.TagMode),
+		query.Present("due_date", req.HasDueDate),
+		overdueFilter(req.Overdue),
+	}
+
+	todos, total, err := s.todoRepository.FindPage(ctx, req.UserID, spec)
+	if err != nil {
+		return nil, err
+	}
+	todos, next := query.Page(spec, todos, todoSortValue)
+
+	res := &TodoListResponse{Todos: make([]TodoResponse, 0, len(todos)), Total: total, NextCursor: next}
+	for i := range todos {
+		res.Todos = append(res.Todos, *toTodoResponse(&todos[i]))
+	}
+	return res, nil
+}
+
+// tagFilter matches todos carrying any of the tags, or all of them when mode is "all".
+func tagFilter(tagIDs []int, mode string) query.Filter {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	if mode == "all" {
+		distinct := slices.Compact(slices.Sorted(slices.Values(tagIDs)))
+		return query.Raw("id IN (SELECT todo_id FROM todo_tags WHERE tag_id = ANY(?) GROUP BY todo_id HAVING COUNT(*) = ?)",
+			pq.Array(distinct), len(distinct))
+	}
+	return query.Raw("id IN (SELECT todo_id FROM todo_tags WHERE tag_id = ANY(?))", pq.Array(tagIDs))
+}
+
+// overdueFilter matches open todos whose due moment, read in the owner's timezone, has
+// passed, or every other todo when want is false.
+func overdueFilter(want *bool) query.Filter {
+	if want == nil {
+		return nil
+	}
+	overdue := "COALESCE(status <> 'done' AND todo_due_at(due_date, due_time, (SELECT timezone FROM users WHERE users.id = todos.user_id)) < CURRENT_TIMESTAMP, false)"
+	if *want {
+		return query.Raw(overdue)
+	}
+	return query.Raw("NOT " + overdue)
+}
+
+// ListByContext groups one status column, Next Actions by default, by context tag. A todo
+// with several contexts appears in each of their groups; todos without one come last.
+func (s *todoService) ListByContext(ctx context.Context, req ListByContextRequest) (*TodosByContextResponse, error) {
+	status := StatusNextActions
+	if req.Status != nil {
+		status = *req.Status
+	}
+	todos, err := s.todoRepository.FindByStatus(ctx, req.UserID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TodosByContextResponse{Status: status, Groups: []ContextGroupResponse{}}
+	groups := map[int]int{}
+	noContext := ContextGroupResponse{Todos: []TodoResponse{}}
+	for i := range todos {
+		todo := toTodoResponse(&todos[i])
+		grouped := false
+		for _, tag := range todo.Tags {
+			if tag.Kind != TagKindContext {
+				continue
+			}
+			index, ok := groups[tag.ID]
+			if !ok {
+				index = len(res.Groups)
+				groups[tag.ID] = index
+				res.Groups = append(res.Groups, ContextGroupResponse{Context: &tag, Todos: []TodoResponse{}})
+			}
+			res.Groups[index].Todos = append(res.Groups[index].Todos, *todo)
+			grouped = true
+		}
+		if !grouped {
+			noContext.Todos = append(noContext.Todos, *todo)
+		}
+	}
+	slices.SortFunc(res.Groups, func(a, b ContextGroupResponse) int {
+		return strings.Compare(strings.ToLower(a.Context.Name), strings.ToLower(b.Context.Name))
+	})
+	if len(noContext.Todos) > 0 {
+		res.Groups = append(res.Groups, noContext)
+	}
+	return res, nil
+}
+
+func todoSortValue(todo Todo, column string) any {
+	switch column {
+	case "status":
+		return todo.Status
+	case "position":
+		return todo.Position
+	case "created_at":
+		return todo.CreatedAt
+	case "updated_at":
+		return todo.UpdatedAt
+	case "title":
+		return todo.Title
+	}
+	return todo.ID
+}
+
+// ListChanged returns the todos written after the sync cursor in Since, oldest change first.
+func (s *todoService) ListChanged(ctx context.Context, req ListChangedTodosRequest) ([]TodoResponse, error) {
+	todos, err := s.todoRepository.FindChangedSince(ctx, req.UserID, req.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]TodoResponse, 0, len(todos))
+	for i := range todos {
+		res = append(res, *toTodoResponse(&todos[i]))
+	}
+	return res, nil
+}
+
+type GetTodoRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+	ID     int `json:"-" uri:"id"`
+}
+
+// ListTodosRequest filters with half-open ranges: created_after <= created_at < created_before.
+type ListTodosRequest struct {
+	UserID        int        `json:"-" auth:"user_id"`
+	Status        []string   `json:"-" form:"status" binding:"omitempty,dive,oneof=inbox next_actions in_progress done someday waiting_for"`
+	ProjectID     *int       `json:"-" form:"project_id"`
+	CreatedAfter  *time.Time `json:"-" form:"created_after"`
+	CreatedBefore *time.Time `json:"-" form:"created_before"`
+	UpdatedAfter  *time.Time `json:"-" form:"updated_after"`
+	UpdatedBefore *time.Time `json:"-" form:"updated_before"`
+	TagID         []int      `json:"-" form:"tag_id" binding:"omitempty,max=20"`
+	TagMode       string     `json:"-" form:"tag_mode" binding:"omitempty,oneof=any all"`
+	HasDueDate    *bool      `json:"-" form:"has_due_date"`
+	Overdue       *bool      `json:"-" form:"overdue"`
+	Sort          string     `json:"-" form:"sort" binding:"omitempty,oneof=position created_at updated_at title"`
+	Order         string     `json:"-" form:"order" binding:"omitempty,oneof=asc desc"`
+	Cursor        string     `json:"-" form:"cursor"`
+	Limit         int        `json:"-" form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+type ListByContextRequest struct {
+	UserID int     `json:"-" auth:"user_id"`
+	Status *string `json:"-" form:"status" binding:"omitempty,oneof=inbox next_actions in_progress done someday waiting_for"`
+}
+
+type ListChangedTodosRequest struct {
+	UserID int
+	Since  int64
+}
@@ -0,0 +1,104 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/query"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestList_ReturnsCursorForNextPage(t *testing.T) {
+	// given
+	createdAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindPage(mock.Anything, 7, mock.MatchedBy(func(spec *query.Spec) bool {
+		page, _ := spec.Build("id", "todos")
+		return spec.Limit() == 2 && page.SQL == "SELECT id FROM todos WHERE status IN ($1) ORDER BY created_at DESC, id DESC LIMIT 3"
+	})).Return([]todo.Todo{
+		{ID: 3, Title: "c", CreatedAt: createdAt.Add(2 * time.Hour)},
+		{ID: 2, Title: "b", CreatedAt: createdAt.Add(time.Hour)},
+		{ID: 1, Title: "a", CreatedAt: createdAt},
+	}, 3, nil)
+	mockRepo.EXPECT().FindPage(mock.Anything, 7, mock.MatchedBy(func(spec *query.Spec) bool {
+		page, _ := spec.Build("id", "todos")
+		return page.SQL == "SELECT id FROM todos WHERE status IN ($1) AND (created_at, id) < ($2, $3) ORDER BY created_at DESC, id DESC LIMIT 3"
+	})).Return([]todo.Todo{{ID: 1, Title: "a", CreatedAt: createdAt}}, 3, nil)
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+	req := todo.ListTodosRequest{UserID: 7, Status: []string{todo.StatusInbox}, Sort: "created_at", Order: "desc", Limit: 2}
+
+	// when
+	first, firstErr := service.List(context.Background(), req)
+	req.Cursor = *first.NextCursor
+	second, secondErr := service.List(context.Background(), req)
+
+	// then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, 3, first.Total)
+	assert.Len(t, first.Todos, 2)
+	assert.Equal(t, 2, first.Todos[1].ID)
+	assert.Len(t, second.Todos, 1)
+	assert.Nil(t, second.NextCursor)
+}
+
+func TestList_RejectsCursorOfAnotherSort(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindPage(mock.Anything, 7, mock.Anything).
+		Return([]todo.Todo{{ID: 1}, {ID: 2}}, 2, nil).Once()
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+	first, _ := service.List(context.Background(), todo.ListTodosRequest{UserID: 7, Limit: 1})
+
+	// when
+	res, err := service.List(context.Background(), todo.ListTodosRequest{UserID: 7, Sort: "title", Cursor: *first.NextCursor})
+	status, _ := todo.NewTodoHandler(service).HandleList(context.Background(), todo.ListTodosRequest{UserID: 7, Sort: "title", Cursor: *first.NextCursor})
+
+	// then
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, query.ErrInvalidCursor)
+	assert.Equal(t, 400, status)
+}
+
+func TestListByContext_GroupsByContextWithUngroupedLast(t *testing.T) {
+	// given
+	home := todo.TodoTag{ID: 1, Name: "home", Kind: todo.TagKindContext}
+	errands := todo.TodoTag{ID: 2, Name: "Errands", Kind: todo.TagKindContext}
+	urgent := todo.TodoTag{ID: 3, Name: "urgent", Kind: "tag"}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByStatus(mock.Anything, 7, todo.StatusNextActions).Return([]todo.Todo{
+		{ID: 1, Title: "Fix sink", Tags: []todo.TodoTag{home}},
+		{ID: 2, Title: "Buy bulbs", Tags: []todo.TodoTag{errands, home}},
+		{ID: 3, Title: "Call bank", Tags: []todo.TodoTag{urgent}},
+	}, nil)
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.ListByContext(context.Background(), todo.ListByContextRequest{UserID: 7})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, todo.StatusNextActions, res.Status)
+	assert.Len(t, res.Groups, 3)
+	assert.Equal(t, "Errands", res.Groups[0].Context.Name)
+	assert.Equal(t, []int{2}, todoIDs(res.Groups[0].Todos))
+	assert.Equal(t, "home", res.Groups[1].Context.Name)
+	assert.Equal(t, []int{1, 2}, todoIDs(res.Groups[1].Todos))
+	assert.Nil(t, res.Groups[2].Context)
+	assert.Equal(t, []int{3}, todoIDs(res.Groups[2].Todos))
+}
+
+func todoIDs(todos []todo.TodoResponse) []int {
+	ids := make([]int, 0, len(todos))
+	for _, t := range todos {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
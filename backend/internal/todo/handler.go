@@ -0,0 +1,177 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/query"
+)
+
+type TodoHandler struct {
+	todoUsecase TodoUsecase
+}
+
+func NewTodoHandler(todoUsecase TodoUsecase) *TodoHandler {
+	return &TodoHandler{todoUsecase: todoUsecase}
+}
+
+func (h *TodoHandler) HandleCreate(ctx context.Context, req CreateTodoRequest) (int, any) {
+	res, err := h.todoUsecase.Create(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusCreated, res
+}
+
+func (h *TodoHandler) HandleGet(ctx context.Context, req GetTodoRequest) (int, any) {
+	res, err := h.todoUsecase.Get(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleList(ctx context.Context, req ListTodosRequest) (int, any) {
+	res, err := h.todoUsecase.List(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleListByContext(ctx context.Context, req ListByContextRequest) (int, any) {
+	res, err := h.todoUsecase.ListByContext(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleListWaiting(ctx context.Context, req ListWaitingRequest) (int, any) {
+	res, err := h.todoUsecase.ListWaiting(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleUpdate(ctx context.Context, req UpdateTodoRequest) (int, any) {
+	res, err := h.todoUsecase.Update(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleChangeStatus(ctx context.Context, req ChangeStatusRequest) (int, any) {
+	res, err := h.todoUsecase.ChangeStatus(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleDelete(ctx context.Context, req DeleteTodoRequest) (int, any) {
+	res, err := h.todoUsecase.Delete(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleAddChecklistItem(ctx context.Context, req AddChecklistItemRequest) (int, any) {
+	res, err := h.todoUsecase.AddChecklistItem(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusCreated, res
+}
+
+func (h *TodoHandler) HandleUpdateChecklistItem(ctx context.Context, req UpdateChecklistItemRequest) (int, any) {
+	res, err := h.todoUsecase.UpdateChecklistItem(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleDeleteChecklistItem(ctx context.Context, req DeleteChecklistItemRequest) (int, any) {
+	res, err := h.todoUsecase.DeleteChecklistItem(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleReorderChecklist(ctx context.Context, req ReorderChecklistRequest) (int, any) {
+	res, err := h.todoUsecase.ReorderChecklist(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleGetDependencies(ctx context.Context, req GetDependenciesRequest) (int, any) {
+	res, err := h.todoUsecase.GetDependencies(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *TodoHandler) HandleAddDependency(ctx context.Context, req AddDependencyRequest) (int, any) {
+	res, err := h.todoUsecase.AddDependency(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusCreated, res
+}
+
+func (h *TodoHandler) HandleRemoveDependency(ctx context.Context, req RemoveDependencyRequest) (int, any) {
+	res, err := h.todoUsecase.RemoveDependency(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
+// handleError answers a version conflict with the current todo, so the body of a 412
+// is a TodoResponse rather than an ErrorResponse.
+func handleError(err error) (int, any) {
+	var notFoundError *TodoNotFoundError
+	var invalidProjectError *InvalidProjectError
+	var invalidTagError *InvalidTagError
+	var invalidDueDateError *InvalidDueDateError
+	var invalidRecurrenceError *InvalidRecurrenceError
+	var invalidDelegationError *InvalidDelegationError
+	var checklistItemNotFoundError *ChecklistItemNotFoundError
+	var invalidChecklistError *InvalidChecklistError
+	var invalidDependencyError *InvalidDependencyError
+	var dependencyNotFoundError *DependencyNotFoundError
+	var dependencyCycleError *DependencyCycleError
+	var versionConflictError *VersionConflictError
+
+	switch {
+	case errors.As(err, &notFoundError), errors.As(err, &checklistItemNotFoundError), errors.As(err, &dependencyNotFoundError):
+		return http.StatusNotFound, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &invalidProjectError), errors.As(err, &invalidTagError),
+		errors.As(err, &invalidDueDateError), errors.As(err, &invalidRecurrenceError),
+		errors.As(err, &invalidDelegationError), errors.As(err, &invalidChecklistError),
+		errors.As(err, &invalidDependencyError):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &dependencyCycleError):
+		return http.StatusConflict, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &versionConflictError):
+		return http.StatusPreconditionFailed, toTodoResponse(versionConflictError.Current)
+	case errors.Is(err, etag.ErrMissingIfMatch):
+		return http.StatusPreconditionRequired, ErrorResponse{Error: err.Error()}
+	case errors.Is(err, etag.ErrInvalidVersion), errors.Is(err, query.ErrInvalidCursor), errors.Is(err, query.ErrUnknownSort):
+		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
+	default:
+		return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
+	}
+}
@@ -0,0 +1,60 @@
+package todo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleUpdate_VersionConflictReturnsCurrentTodo(t *testing.T) {
+	// given
+	mockUsecase := todomocks.NewTodoUsecase(t)
+	current := &todo.Todo{ID: 1, Title: "theirs", Version: 3}
+	mockUsecase.EXPECT().Update(mock.Anything, mock.Anything).Return(nil, todo.NewVersionConflictError(current, 2))
+
+	handler := todo.NewTodoHandler(mockUsecase)
+
+	// when
+	code, res := handler.HandleUpdate(context.Background(), todo.UpdateTodoRequest{ID: 1, IfMatch: `"2"`})
+	todoRes, ok := res.(*todo.TodoResponse)
+
+	// then
+	assert.Equal(t, http.StatusPreconditionFailed, code)
+	assert.True(t, ok, "Expected *todo.TodoResponse type")
+	assert.Equal(t, "theirs", todoRes.Title)
+	assert.Equal(t, `"3"`, todoRes.ETag())
+}
+
+func TestHandleDelete_Preconditions(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "missing If-Match", err: etag.ErrMissingIfMatch, code: http.StatusPreconditionRequired},
+		{name: "malformed If-Match", err: etag.ErrInvalidVersion, code: http.StatusBadRequest},
+		{name: "not found", err: todo.NewTodoNotFoundError(1), code: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockUsecase := todomocks.NewTodoUsecase(t)
+			mockUsecase.EXPECT().Delete(mock.Anything, mock.Anything).Return(nil, tt.err)
+			handler := todo.NewTodoHandler(mockUsecase)
+
+			// when
+			code, res := handler.HandleDelete(context.Background(), todo.DeleteTodoRequest{ID: 1})
+
+			// then
+			assert.Equal(t, tt.code, code)
+			assert.IsType(t, todo.ErrorResponse{}, res)
+		})
+	}
+}
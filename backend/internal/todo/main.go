@@ -0,0 +1,14 @@
+//go:generate mockery
+package todo
+
+import (
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func InitializeHandler(pool *sqlx.DB, publisher event.Publisher) *TodoHandler {
+	return NewTodoHandler(NewTodoService(NewTodoRepository(pool), database.NewTxManager(pool), publisher, time.Now))
+}
@@ -0,0 +1,1629 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package todomocks
+
+import (
+	context "context"
+	query "yangdongju/gtd_todo/internal/query"
+
+	mock "github.com/stretchr/testify/mock"
+
+	todo "yangdongju/gtd_todo/internal/todo"
+)
+
+// TodoRepository is an autogenerated mock type for the TodoRepository type
+type TodoRepository struct {
+	mock.Mock
+}
+
+type TodoRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TodoRepository) EXPECT() *TodoRepository_Expecter {
+	return &TodoRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddDependency provides a mock function with given fields: ctx, todoID, blockerID
+func (_m *TodoRepository) AddDependency(ctx context.Context, todoID int, blockerID int) error {
+	ret := _m.Called(ctx, todoID, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, todoID, blockerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TodoRepository_AddDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDependency'
+type TodoRepository_AddDependency_Call struct {
+	*mock.Call
+}
+
+// AddDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+//   - blockerID int
+func (_e *TodoRepository_Expecter) AddDependency(ctx interface{}, todoID interface{}, blockerID interface{}) *TodoRepository_AddDependency_Call {
+	return &TodoRepository_AddDependency_Call{Call: _e.mock.On("AddDependency", ctx, todoID, blockerID)}
+}
+
+func (_c *TodoRepository_AddDependency_Call) Run(run func(ctx context.Context, todoID int, blockerID int)) *TodoRepository_AddDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_AddDependency_Call) Return(_a0 error) *TodoRepository_AddDependency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TodoRepository_AddDependency_Call) RunAndReturn(run func(context.Context, int, int) error) *TodoRepository_AddDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatesCycle provides a mock function with given fields: ctx, todoID, blockerID
+func (_m *TodoRepository) CreatesCycle(ctx context.Context, todoID int, blockerID int) (bool, error) {
+	ret := _m.Called(ctx, todoID, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatesCycle")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (bool, error)); ok {
+		return rf(ctx, todoID, blockerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, todoID, blockerID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, todoID, blockerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_CreatesCycle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatesCycle'
+type TodoRepository_CreatesCycle_Call struct {
+	*mock.Call
+}
+
+// CreatesCycle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+//   - blockerID int
+func (_e *TodoRepository_Expecter) CreatesCycle(ctx interface{}, todoID interface{}, blockerID interface{}) *TodoRepository_CreatesCycle_Call {
+	return &TodoRepository_CreatesCycle_Call{Call: _e.mock.On("CreatesCycle", ctx, todoID, blockerID)}
+}
+
+func (_c *TodoRepository_CreatesCycle_Call) Run(run func(ctx context.Context, todoID int, blockerID int)) *TodoRepository_CreatesCycle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_CreatesCycle_Call) Return(_a0 bool, _a1 error) *TodoRepository_CreatesCycle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_CreatesCycle_Call) RunAndReturn(run func(context.Context, int, int) (bool, error)) *TodoRepository_CreatesCycle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, _a1
+func (_m *TodoRepository) Delete(ctx context.Context, _a1 *todo.Todo) (bool, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.Todo) (bool, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.Todo) bool); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *todo.Todo) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type TodoRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *todo.Todo
+func (_e *TodoRepository_Expecter) Delete(ctx interface{}, _a1 interface{}) *TodoRepository_Delete_Call {
+	return &TodoRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, _a1)}
+}
+
+func (_c *TodoRepository_Delete_Call) Run(run func(ctx context.Context, _a1 *todo.Todo)) *TodoRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*todo.Todo))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_Delete_Call) Return(_a0 bool, _a1 error) *TodoRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_Delete_Call) RunAndReturn(run func(context.Context, *todo.Todo) (bool, error)) *TodoRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteChecklistItem provides a mock function with given fields: ctx, item
+func (_m *TodoRepository) DeleteChecklistItem(ctx context.Context, item *todo.ChecklistItem) error {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChecklistItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.ChecklistItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TodoRepository_DeleteChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChecklistItem'
+type TodoRepository_DeleteChecklistItem_Call struct {
+	*mock.Call
+}
+
+// DeleteChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *todo.ChecklistItem
+func (_e *TodoRepository_Expecter) DeleteChecklistItem(ctx interface{}, item interface{}) *TodoRepository_DeleteChecklistItem_Call {
+	return &TodoRepository_DeleteChecklistItem_Call{Call: _e.mock.On("DeleteChecklistItem", ctx, item)}
+}
+
+func (_c *TodoRepository_DeleteChecklistItem_Call) Run(run func(ctx context.Context, item *todo.ChecklistItem)) *TodoRepository_DeleteChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*todo.ChecklistItem))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_DeleteChecklistItem_Call) Return(_a0 error) *TodoRepository_DeleteChecklistItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TodoRepository_DeleteChecklistItem_Call) RunAndReturn(run func(context.Context, *todo.ChecklistItem) error) *TodoRepository_DeleteChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByClientID provides a mock function with given fields: ctx, userID, clientID
+func (_m *TodoRepository) FindByClientID(ctx context.Context, userID int, clientID string) (*todo.Todo, error) {
+	ret := _m.Called(ctx, userID, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByClientID")
+	}
+
+	var r0 *todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*todo.Todo, error)); ok {
+		return rf(ctx, userID, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *todo.Todo); ok {
+		r0 = rf(ctx, userID, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_FindByClientID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByClientID'
+type TodoRepository_FindByClientID_Call struct {
+	*mock.Call
+}
+
+// FindByClientID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - clientID string
+func (_e *TodoRepository_Expecter) FindByClientID(ctx interface{}, userID interface{}, clientID interface{}) *TodoRepository_FindByClientID_Call {
+	return &TodoRepository_FindByClientID_Call{Call: _e.mock.On("FindByClientID", ctx, userID, clientID)}
+}
+
+func (_c *TodoRepository_FindByClientID_Call) Run(run func(ctx context.Context, userID int, clientID string)) *TodoRepository_FindByClientID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindByClientID_Call) Return(_a0 *todo.Todo, _a1 error) *TodoRepository_FindByClientID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_FindByClientID_Call) RunAndReturn(run func(context.Context, int, string) (*todo.Todo, error)) *TodoRepository_FindByClientID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *TodoRepository) FindByID(ctx context.Context, userID int, id int) (*todo.Todo, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*todo.Todo, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *todo.Todo); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type TodoRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int
+func (_e *TodoRepository_Expecter) FindByID(ctx interface{}, userID interface{}, id interface{}) *TodoRepository_FindByID_Call {
+	return &TodoRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, userID, id)}
+}
+
+func (_c *TodoRepository_FindByID_Call) Run(run func(ctx context.Context, userID int, id int)) *TodoRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindByID_Call) Return(_a0 *todo.Todo, _a1 error) *TodoRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_FindByID_Call) RunAndReturn(run func(context.Context, int, int) (*todo.Todo, error)) *TodoRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByStatus provides a mock function with given fields: ctx, userID, status
+func (_m *TodoRepository) FindByStatus(ctx context.Context, userID int, status string) ([]todo.Todo, error) {
+	ret := _m.Called(ctx, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByStatus")
+	}
+
+	var r0 []todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]todo.Todo, error)); ok {
+		return rf(ctx, userID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []todo.Todo); ok {
+		r0 = rf(ctx, userID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_FindByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByStatus'
+type TodoRepository_FindByStatus_Call struct {
+	*mock.Call
+}
+
+// FindByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - status string
+func (_e *TodoRepository_Expecter) FindByStatus(ctx interface{}, userID interface{}, status interface{}) *TodoRepository_FindByStatus_Call {
+	return &TodoRepository_FindByStatus_Call{Call: _e.mock.On("FindByStatus", ctx, userID, status)}
+}
+
+func (_c *TodoRepository_FindByStatus_Call) Run(run func(ctx context.Context, userID int, status string)) *TodoRepository_FindByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindByStatus_Call) Return(_a0 []todo.Todo, _a1 error) *TodoRepository_FindByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_FindByStatus_Call) RunAndReturn(run func(context.Context, int, string) ([]todo.Todo, error)) *TodoRepository_FindByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindChangedSince provides a mock function with given fields: ctx, userID, cursor
+func (_m *TodoRepository) FindChangedSince(ctx context.Context, userID int, cursor int64) ([]todo.Todo, error) {
+	ret := _m.Called(ctx, userID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindChangedSince")
+	}
+
+	var r0 []todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) ([]todo.Todo, error)); ok {
+		return rf(ctx, userID, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) []todo.Todo); ok {
+		r0 = rf(ctx, userID, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_FindChangedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindChangedSince'
+type TodoRepository_FindChangedSince_Call struct {
+	*mock.Call
+}
+
+// FindChangedSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - cursor int64
+func (_e *TodoRepository_Expecter) FindChangedSince(ctx interface{}, userID interface{}, cursor interface{}) *TodoRepository_FindChangedSince_Call {
+	return &TodoRepository_FindChangedSince_Call{Call: _e.mock.On("FindChangedSince", ctx, userID, cursor)}
+}
+
+func (_c *TodoRepository_FindChangedSince_Call) Run(run func(ctx context.Context, userID int, cursor int64)) *TodoRepository_FindChangedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindChangedSince_Call) Return(_a0 []todo.Todo, _a1 error) *TodoRepository_FindChangedSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_FindChangedSince_Call) RunAndReturn(run func(context.Context, int, int64) ([]todo.Todo, error)) *TodoRepository_FindChangedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindChecklistItem provides a mock function with given fields: ctx, todoID, id
+func (_m *TodoRepository) FindChecklistItem(ctx context.Context, todoID int, id int) (*todo.ChecklistItem, error) {
+	ret := _m.Called(ctx, todoID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindChecklistItem")
+	}
+
+	var r0 *todo.ChecklistItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*todo.ChecklistItem, error)); ok {
+		return rf(ctx, todoID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *todo.ChecklistItem); ok {
+		r0 = rf(ctx, todoID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.ChecklistItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, todoID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_FindChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindChecklistItem'
+type TodoRepository_FindChecklistItem_Call struct {
+	*mock.Call
+}
+
+// FindChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+//   - id int
+func (_e *TodoRepository_Expecter) FindChecklistItem(ctx interface{}, todoID interface{}, id interface{}) *TodoRepository_FindChecklistItem_Call {
+	return &TodoRepository_FindChecklistItem_Call{Call: _e.mock.On("FindChecklistItem", ctx, todoID, id)}
+}
+
+func (_c *TodoRepository_FindChecklistItem_Call) Run(run func(ctx context.Context, todoID int, id int)) *TodoRepository_FindChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindChecklistItem_Call) Return(_a0 *todo.ChecklistItem, _a1 error) *TodoRepository_FindChecklistItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_FindChecklistItem_Call) RunAndReturn(run func(context.Context, int, int) (*todo.ChecklistItem, error)) *TodoRepository_FindChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDependencyGraph provides a mock function with given fields: ctx, userID, id
+func (_m *TodoRepository) FindDependencyGraph(ctx context.Context, userID int, id int) (*todo.DependencyGraph, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDependencyGraph")
+	}
+
+	var r0 *todo.DependencyGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*todo.DependencyGraph, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *todo.DependencyGraph); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.DependencyGraph)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_FindDependencyGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDependencyGraph'
+type TodoRepository_FindDependencyGraph_Call struct {
+	*mock.Call
+}
+
+// FindDependencyGraph is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int
+func (_e *TodoRepository_Expecter) FindDependencyGraph(ctx interface{}, userID interface{}, id interface{}) *TodoRepository_FindDependencyGraph_Call {
+	return &TodoRepository_FindDependencyGraph_Call{Call: _e.mock.On("FindDependencyGraph", ctx, userID, id)}
+}
+
+func (_c *TodoRepository_FindDependencyGraph_Call) Run(run func(ctx context.Context, userID int, id int)) *TodoRepository_FindDependencyGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindDependencyGraph_Call) Return(_a0 *todo.DependencyGraph, _a1 error) *TodoRepository_FindDependencyGraph_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_FindDependencyGraph_Call) RunAndReturn(run func(context.Context, int, int) (*todo.DependencyGraph, error)) *TodoRepository_FindDependencyGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPage provides a mock function with given fields: ctx, userID, spec
+func (_m *TodoRepository) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]todo.Todo, int, error) {
+	ret := _m.Called(ctx, userID, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []todo.Todo
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *query.Spec) ([]todo.Todo, int, error)); ok {
+		return rf(ctx, userID, spec)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *query.Spec) []todo.Todo); ok {
+		r0 = rf(ctx, userID, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *query.Spec) int); ok {
+		r1 = rf(ctx, userID, spec)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, *query.Spec) error); ok {
+		r2 = rf(ctx, userID, spec)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TodoRepository_FindPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPage'
+type TodoRepository_FindPage_Call struct {
+	*mock.Call
+}
+
+// FindPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - spec *query.Spec
+func (_e *TodoRepository_Expecter) FindPage(ctx interface{}, userID interface{}, spec interface{}) *TodoRepository_FindPage_Call {
+	return &TodoRepository_FindPage_Call{Call: _e.mock.On("FindPage", ctx, userID, spec)}
+}
+
+func (_c *TodoRepository_FindPage_Call) Run(run func(ctx context.Context, userID int, spec *query.Spec)) *TodoRepository_FindPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*query.Spec))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindPage_Call) Return(_a0 []todo.Todo, _a1 int, _a2 error) *TodoRepository_FindPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *TodoRepository_FindPage_Call) RunAndReturn(run func(context.Context, int, *query.Spec) ([]todo.Todo, int, error)) *TodoRepository_FindPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindWaiting provides a mock function with given fields: ctx, userID, delegatedTo
+func (_m *TodoRepository) FindWaiting(ctx context.Context, userID int, delegatedTo *string) ([]todo.Todo, error) {
+	ret := _m.Called(ctx, userID, delegatedTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWaiting")
+	}
+
+	var r0 []todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *string) ([]todo.Todo, error)); ok {
+		return rf(ctx, userID, delegatedTo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *string) []todo.Todo); ok {
+		r0 = rf(ctx, userID, delegatedTo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *string) error); ok {
+		r1 = rf(ctx, userID, delegatedTo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_FindWaiting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindWaiting'
+type TodoRepository_FindWaiting_Call struct {
+	*mock.Call
+}
+
+// FindWaiting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - delegatedTo *string
+func (_e *TodoRepository_Expecter) FindWaiting(ctx interface{}, userID interface{}, delegatedTo interface{}) *TodoRepository_FindWaiting_Call {
+	return &TodoRepository_FindWaiting_Call{Call: _e.mock.On("FindWaiting", ctx, userID, delegatedTo)}
+}
+
+func (_c *TodoRepository_FindWaiting_Call) Run(run func(ctx context.Context, userID int, delegatedTo *string)) *TodoRepository_FindWaiting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*string))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_FindWaiting_Call) Return(_a0 []todo.Todo, _a1 error) *TodoRepository_FindWaiting_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_FindWaiting_Call) RunAndReturn(run func(context.Context, int, *string) ([]todo.Todo, error)) *TodoRepository_FindWaiting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NextPosition provides a mock function with given fields: ctx, userID, status
+func (_m *TodoRepository) NextPosition(ctx context.Context, userID int, status string) (int, error) {
+	ret := _m.Called(ctx, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextPosition")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (int, error)); ok {
+		return rf(ctx, userID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) int); ok {
+		r0 = rf(ctx, userID, status)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_NextPosition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NextPosition'
+type TodoRepository_NextPosition_Call struct {
+	*mock.Call
+}
+
+// NextPosition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - status string
+func (_e *TodoRepository_Expecter) NextPosition(ctx interface{}, userID interface{}, status interface{}) *TodoRepository_NextPosition_Call {
+	return &TodoRepository_NextPosition_Call{Call: _e.mock.On("NextPosition", ctx, userID, status)}
+}
+
+func (_c *TodoRepository_NextPosition_Call) Run(run func(ctx context.Context, userID int, status string)) *TodoRepository_NextPosition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_NextPosition_Call) Return(_a0 int, _a1 error) *TodoRepository_NextPosition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_NextPosition_Call) RunAndReturn(run func(context.Context, int, string) (int, error)) *TodoRepository_NextPosition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProjectExists provides a mock function with given fields: ctx, userID, projectID
+func (_m *TodoRepository) ProjectExists(ctx context.Context, userID int, projectID int) (bool, error) {
+	ret := _m.Called(ctx, userID, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProjectExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (bool, error)); ok {
+		return rf(ctx, userID, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, userID, projectID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_ProjectExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectExists'
+type TodoRepository_ProjectExists_Call struct {
+	*mock.Call
+}
+
+// ProjectExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - projectID int
+func (_e *TodoRepository_Expecter) ProjectExists(ctx interface{}, userID interface{}, projectID interface{}) *TodoRepository_ProjectExists_Call {
+	return &TodoRepository_ProjectExists_Call{Call: _e.mock.On("ProjectExists", ctx, userID, projectID)}
+}
+
+func (_c *TodoRepository_ProjectExists_Call) Run(run func(ctx context.Context, userID int, projectID int)) *TodoRepository_ProjectExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_ProjectExists_Call) Return(_a0 bool, _a1 error) *TodoRepository_ProjectExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_ProjectExists_Call) RunAndReturn(run func(context.Context, int, int) (bool, error)) *TodoRepository_ProjectExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDependency provides a mock function with given fields: ctx, todoID, blockerID
+func (_m *TodoRepository) RemoveDependency(ctx context.Context, todoID int, blockerID int) (bool, error) {
+	ret := _m.Called(ctx, todoID, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDependency")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (bool, error)); ok {
+		return rf(ctx, todoID, blockerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, todoID, blockerID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, todoID, blockerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_RemoveDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDependency'
+type TodoRepository_RemoveDependency_Call struct {
+	*mock.Call
+}
+
+// RemoveDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+//   - blockerID int
+func (_e *TodoRepository_Expecter) RemoveDependency(ctx interface{}, todoID interface{}, blockerID interface{}) *TodoRepository_RemoveDependency_Call {
+	return &TodoRepository_RemoveDependency_Call{Call: _e.mock.On("RemoveDependency", ctx, todoID, blockerID)}
+}
+
+func (_c *TodoRepository_RemoveDependency_Call) Run(run func(ctx context.Context, todoID int, blockerID int)) *TodoRepository_RemoveDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_RemoveDependency_Call) Return(_a0 bool, _a1 error) *TodoRepository_RemoveDependency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_RemoveDependency_Call) RunAndReturn(run func(context.Context, int, int) (bool, error)) *TodoRepository_RemoveDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReorderChecklist provides a mock function with given fields: ctx, todoID, itemIDs
+func (_m *TodoRepository) ReorderChecklist(ctx context.Context, todoID int, itemIDs []int) error {
+	ret := _m.Called(ctx, todoID, itemIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderChecklist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, todoID, itemIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TodoRepository_ReorderChecklist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderChecklist'
+type TodoRepository_ReorderChecklist_Call struct {
+	*mock.Call
+}
+
+// ReorderChecklist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+//   - itemIDs []int
+func (_e *TodoRepository_Expecter) ReorderChecklist(ctx interface{}, todoID interface{}, itemIDs interface{}) *TodoRepository_ReorderChecklist_Call {
+	return &TodoRepository_ReorderChecklist_Call{Call: _e.mock.On("ReorderChecklist", ctx, todoID, itemIDs)}
+}
+
+func (_c *TodoRepository_ReorderChecklist_Call) Run(run func(ctx context.Context, todoID int, itemIDs []int)) *TodoRepository_ReorderChecklist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_ReorderChecklist_Call) Return(_a0 error) *TodoRepository_ReorderChecklist_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TodoRepository_ReorderChecklist_Call) RunAndReturn(run func(context.Context, int, []int) error) *TodoRepository_ReorderChecklist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceReminders provides a mock function with given fields: ctx, todoID, minutesBefore
+func (_m *TodoRepository) ReplaceReminders(ctx context.Context, todoID int, minutesBefore []int) ([]todo.TodoReminder, error) {
+	ret := _m.Called(ctx, todoID, minutesBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceReminders")
+	}
+
+	var r0 []todo.TodoReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) ([]todo.TodoReminder, error)); ok {
+		return rf(ctx, todoID, minutesBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) []todo.TodoReminder); ok {
+		r0 = rf(ctx, todoID, minutesBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.TodoReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, todoID, minutesBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_ReplaceReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceReminders'
+type TodoRepository_ReplaceReminders_Call struct {
+	*mock.Call
+}
+
+// ReplaceReminders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+//   - minutesBefore []int
+func (_e *TodoRepository_Expecter) ReplaceReminders(ctx interface{}, todoID interface{}, minutesBefore interface{}) *TodoRepository_ReplaceReminders_Call {
+	return &TodoRepository_ReplaceReminders_Call{Call: _e.mock.On("ReplaceReminders", ctx, todoID, minutesBefore)}
+}
+
+func (_c *TodoRepository_ReplaceReminders_Call) Run(run func(ctx context.Context, todoID int, minutesBefore []int)) *TodoRepository_ReplaceReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_ReplaceReminders_Call) Return(_a0 []todo.TodoReminder, _a1 error) *TodoRepository_ReplaceReminders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_ReplaceReminders_Call) RunAndReturn(run func(context.Context, int, []int) ([]todo.TodoReminder, error)) *TodoRepository_ReplaceReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceTags provides a mock function with given fields: ctx, todoID, tagIDs
+func (_m *TodoRepository) ReplaceTags(ctx context.Context, todoID int, tagIDs []int) ([]todo.TodoTag, error) {
+	ret := _m.Called(ctx, todoID, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceTags")
+	}
+
+	var r0 []todo.TodoTag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) ([]todo.TodoTag, error)); ok {
+		return rf(ctx, todoID, tagIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) []todo.TodoTag); ok {
+		r0 = rf(ctx, todoID, tagIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.TodoTag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, todoID, tagIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_ReplaceTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceTags'
+type TodoRepository_ReplaceTags_Call struct {
+	*mock.Call
+}
+
+// ReplaceTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+//   - tagIDs []int
+func (_e *TodoRepository_Expecter) ReplaceTags(ctx interface{}, todoID interface{}, tagIDs interface{}) *TodoRepository_ReplaceTags_Call {
+	return &TodoRepository_ReplaceTags_Call{Call: _e.mock.On("ReplaceTags", ctx, todoID, tagIDs)}
+}
+
+func (_c *TodoRepository_ReplaceTags_Call) Run(run func(ctx context.Context, todoID int, tagIDs []int)) *TodoRepository_ReplaceTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_ReplaceTags_Call) Return(_a0 []todo.TodoTag, _a1 error) *TodoRepository_ReplaceTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_ReplaceTags_Call) RunAndReturn(run func(context.Context, int, []int) ([]todo.TodoTag, error)) *TodoRepository_ReplaceTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RescheduleReminders provides a mock function with given fields: ctx, todoID
+func (_m *TodoRepository) RescheduleReminders(ctx context.Context, todoID int) ([]todo.TodoReminder, error) {
+	ret := _m.Called(ctx, todoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RescheduleReminders")
+	}
+
+	var r0 []todo.TodoReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]todo.TodoReminder, error)); ok {
+		return rf(ctx, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []todo.TodoReminder); ok {
+		r0 = rf(ctx, todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.TodoReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_RescheduleReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RescheduleReminders'
+type TodoRepository_RescheduleReminders_Call struct {
+	*mock.Call
+}
+
+// RescheduleReminders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+func (_e *TodoRepository_Expecter) RescheduleReminders(ctx interface{}, todoID interface{}) *TodoRepository_RescheduleReminders_Call {
+	return &TodoRepository_RescheduleReminders_Call{Call: _e.mock.On("RescheduleReminders", ctx, todoID)}
+}
+
+func (_c *TodoRepository_RescheduleReminders_Call) Run(run func(ctx context.Context, todoID int)) *TodoRepository_RescheduleReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_RescheduleReminders_Call) Return(_a0 []todo.TodoReminder, _a1 error) *TodoRepository_RescheduleReminders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_RescheduleReminders_Call) RunAndReturn(run func(context.Context, int) ([]todo.TodoReminder, error)) *TodoRepository_RescheduleReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *TodoRepository) Save(ctx context.Context, _a1 *todo.Todo) (*todo.Todo, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.Todo) (*todo.Todo, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.Todo) *todo.Todo); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *todo.Todo) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type TodoRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *todo.Todo
+func (_e *TodoRepository_Expecter) Save(ctx interface{}, _a1 interface{}) *TodoRepository_Save_Call {
+	return &TodoRepository_Save_Call{Call: _e.mock.On("Save", ctx, _a1)}
+}
+
+func (_c *TodoRepository_Save_Call) Run(run func(ctx context.Context, _a1 *todo.Todo)) *TodoRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*todo.Todo))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_Save_Call) Return(_a0 *todo.Todo, _a1 error) *TodoRepository_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_Save_Call) RunAndReturn(run func(context.Context, *todo.Todo) (*todo.Todo, error)) *TodoRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveChecklistItem provides a mock function with given fields: ctx, item
+func (_m *TodoRepository) SaveChecklistItem(ctx context.Context, item *todo.ChecklistItem) error {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveChecklistItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.ChecklistItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TodoRepository_SaveChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveChecklistItem'
+type TodoRepository_SaveChecklistItem_Call struct {
+	*mock.Call
+}
+
+// SaveChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *todo.ChecklistItem
+func (_e *TodoRepository_Expecter) SaveChecklistItem(ctx interface{}, item interface{}) *TodoRepository_SaveChecklistItem_Call {
+	return &TodoRepository_SaveChecklistItem_Call{Call: _e.mock.On("SaveChecklistItem", ctx, item)}
+}
+
+func (_c *TodoRepository_SaveChecklistItem_Call) Run(run func(ctx context.Context, item *todo.ChecklistItem)) *TodoRepository_SaveChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*todo.ChecklistItem))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_SaveChecklistItem_Call) Return(_a0 error) *TodoRepository_SaveChecklistItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TodoRepository_SaveChecklistItem_Call) RunAndReturn(run func(context.Context, *todo.ChecklistItem) error) *TodoRepository_SaveChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleFollowUp provides a mock function with given fields: ctx, todoID
+func (_m *TodoRepository) ScheduleFollowUp(ctx context.Context, todoID int) error {
+	ret := _m.Called(ctx, todoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleFollowUp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, todoID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TodoRepository_ScheduleFollowUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleFollowUp'
+type TodoRepository_ScheduleFollowUp_Call struct {
+	*mock.Call
+}
+
+// ScheduleFollowUp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+func (_e *TodoRepository_Expecter) ScheduleFollowUp(ctx interface{}, todoID interface{}) *TodoRepository_ScheduleFollowUp_Call {
+	return &TodoRepository_ScheduleFollowUp_Call{Call: _e.mock.On("ScheduleFollowUp", ctx, todoID)}
+}
+
+func (_c *TodoRepository_ScheduleFollowUp_Call) Run(run func(ctx context.Context, todoID int)) *TodoRepository_ScheduleFollowUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_ScheduleFollowUp_Call) Return(_a0 error) *TodoRepository_ScheduleFollowUp_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TodoRepository_ScheduleFollowUp_Call) RunAndReturn(run func(context.Context, int) error) *TodoRepository_ScheduleFollowUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagsExist provides a mock function with given fields: ctx, userID, tagIDs
+func (_m *TodoRepository) TagsExist(ctx context.Context, userID int, tagIDs []int) (bool, error) {
+	ret := _m.Called(ctx, userID, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagsExist")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) (bool, error)); ok {
+		return rf(ctx, userID, tagIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) bool); ok {
+		r0 = rf(ctx, userID, tagIDs)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, userID, tagIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_TagsExist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagsExist'
+type TodoRepository_TagsExist_Call struct {
+	*mock.Call
+}
+
+// TagsExist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - tagIDs []int
+func (_e *TodoRepository_Expecter) TagsExist(ctx interface{}, userID interface{}, tagIDs interface{}) *TodoRepository_TagsExist_Call {
+	return &TodoRepository_TagsExist_Call{Call: _e.mock.On("TagsExist", ctx, userID, tagIDs)}
+}
+
+func (_c *TodoRepository_TagsExist_Call) Run(run func(ctx context.Context, userID int, tagIDs []int)) *TodoRepository_TagsExist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_TagsExist_Call) Return(_a0 bool, _a1 error) *TodoRepository_TagsExist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_TagsExist_Call) RunAndReturn(run func(context.Context, int, []int) (bool, error)) *TodoRepository_TagsExist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchChecklist provides a mock function with given fields: ctx, todoID
+func (_m *TodoRepository) TouchChecklist(ctx context.Context, todoID int) (*todo.Todo, error) {
+	ret := _m.Called(ctx, todoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchChecklist")
+	}
+
+	var r0 *todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*todo.Todo, error)); ok {
+		return rf(ctx, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *todo.Todo); ok {
+		r0 = rf(ctx, todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_TouchChecklist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchChecklist'
+type TodoRepository_TouchChecklist_Call struct {
+	*mock.Call
+}
+
+// TouchChecklist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - todoID int
+func (_e *TodoRepository_Expecter) TouchChecklist(ctx interface{}, todoID interface{}) *TodoRepository_TouchChecklist_Call {
+	return &TodoRepository_TouchChecklist_Call{Call: _e.mock.On("TouchChecklist", ctx, todoID)}
+}
+
+func (_c *TodoRepository_TouchChecklist_Call) Run(run func(ctx context.Context, todoID int)) *TodoRepository_TouchChecklist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_TouchChecklist_Call) Return(_a0 *todo.Todo, _a1 error) *TodoRepository_TouchChecklist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_TouchChecklist_Call) RunAndReturn(run func(context.Context, int) (*todo.Todo, error)) *TodoRepository_TouchChecklist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchDependents provides a mock function with given fields: ctx, blockerID
+func (_m *TodoRepository) TouchDependents(ctx context.Context, blockerID int) ([]todo.Todo, error) {
+	ret := _m.Called(ctx, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchDependents")
+	}
+
+	var r0 []todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]todo.Todo, error)); ok {
+		return rf(ctx, blockerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []todo.Todo); ok {
+		r0 = rf(ctx, blockerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, blockerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_TouchDependents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchDependents'
+type TodoRepository_TouchDependents_Call struct {
+	*mock.Call
+}
+
+// TouchDependents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockerID int
+func (_e *TodoRepository_Expecter) TouchDependents(ctx interface{}, blockerID interface{}) *TodoRepository_TouchDependents_Call {
+	return &TodoRepository_TouchDependents_Call{Call: _e.mock.On("TouchDependents", ctx, blockerID)}
+}
+
+func (_c *TodoRepository_TouchDependents_Call) Run(run func(ctx context.Context, blockerID int)) *TodoRepository_TouchDependents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_TouchDependents_Call) Return(_a0 []todo.Todo, _a1 error) *TodoRepository_TouchDependents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_TouchDependents_Call) RunAndReturn(run func(context.Context, int) ([]todo.Todo, error)) *TodoRepository_TouchDependents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *TodoRepository) Update(ctx context.Context, _a1 *todo.Todo) (*todo.Todo, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *todo.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.Todo) (*todo.Todo, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.Todo) *todo.Todo); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *todo.Todo) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TodoRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *todo.Todo
+func (_e *TodoRepository_Expecter) Update(ctx interface{}, _a1 interface{}) *TodoRepository_Update_Call {
+	return &TodoRepository_Update_Call{Call: _e.mock.On("Update", ctx, _a1)}
+}
+
+func (_c *TodoRepository_Update_Call) Run(run func(ctx context.Context, _a1 *todo.Todo)) *TodoRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*todo.Todo))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_Update_Call) Return(_a0 *todo.Todo, _a1 error) *TodoRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_Update_Call) RunAndReturn(run func(context.Context, *todo.Todo) (*todo.Todo, error)) *TodoRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateChecklistItem provides a mock function with given fields: ctx, item
+func (_m *TodoRepository) UpdateChecklistItem(ctx context.Context, item *todo.ChecklistItem) error {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChecklistItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *todo.ChecklistItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TodoRepository_UpdateChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChecklistItem'
+type TodoRepository_UpdateChecklistItem_Call struct {
+	*mock.Call
+}
+
+// UpdateChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *todo.ChecklistItem
+func (_e *TodoRepository_Expecter) UpdateChecklistItem(ctx interface{}, item interface{}) *TodoRepository_UpdateChecklistItem_Call {
+	return &TodoRepository_UpdateChecklistItem_Call{Call: _e.mock.On("UpdateChecklistItem", ctx, item)}
+}
+
+func (_c *TodoRepository_UpdateChecklistItem_Call) Run(run func(ctx context.Context, item *todo.ChecklistItem)) *TodoRepository_UpdateChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*todo.ChecklistItem))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_UpdateChecklistItem_Call) Return(_a0 error) *TodoRepository_UpdateChecklistItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TodoRepository_UpdateChecklistItem_Call) RunAndReturn(run func(context.Context, *todo.ChecklistItem) error) *TodoRepository_UpdateChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserTimezone provides a mock function with given fields: ctx, userID
+func (_m *TodoRepository) UserTimezone(ctx context.Context, userID int) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserTimezone")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoRepository_UserTimezone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserTimezone'
+type TodoRepository_UserTimezone_Call struct {
+	*mock.Call
+}
+
+// UserTimezone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *TodoRepository_Expecter) UserTimezone(ctx interface{}, userID interface{}) *TodoRepository_UserTimezone_Call {
+	return &TodoRepository_UserTimezone_Call{Call: _e.mock.On("UserTimezone", ctx, userID)}
+}
+
+func (_c *TodoRepository_UserTimezone_Call) Run(run func(ctx context.Context, userID int)) *TodoRepository_UserTimezone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *TodoRepository_UserTimezone_Call) Return(_a0 string, _a1 error) *TodoRepository_UserTimezone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoRepository_UserTimezone_Call) RunAndReturn(run func(context.Context, int) (string, error)) *TodoRepository_UserTimezone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTodoRepository creates a new instance of TodoRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTodoRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TodoRepository {
+	mock := &TodoRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,981 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package todomocks
+
+import (
+	context "context"
+	todo "yangdongju/gtd_todo/internal/todo"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TodoUsecase is an autogenerated mock type for the TodoUsecase type
+type TodoUsecase struct {
+	mock.Mock
+}
+
+type TodoUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TodoUsecase) EXPECT() *TodoUsecase_Expecter {
+	return &TodoUsecase_Expecter{mock: &_m.Mock}
+}
+
+// AddChecklistItem provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) AddChecklistItem(ctx context.Context, request todo.AddChecklistItemRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddChecklistItem")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.AddChecklistItemRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.AddChecklistItemRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.AddChecklistItemRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_AddChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddChecklistItem'
+type TodoUsecase_AddChecklistItem_Call struct {
+	*mock.Call
+}
+
+// AddChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.AddChecklistItemRequest
+func (_e *TodoUsecase_Expecter) AddChecklistItem(ctx interface{}, request interface{}) *TodoUsecase_AddChecklistItem_Call {
+	return &TodoUsecase_AddChecklistItem_Call{Call: _e.mock.On("AddChecklistItem", ctx, request)}
+}
+
+func (_c *TodoUsecase_AddChecklistItem_Call) Run(run func(ctx context.Context, request todo.AddChecklistItemRequest)) *TodoUsecase_AddChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.AddChecklistItemRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_AddChecklistItem_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_AddChecklistItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_AddChecklistItem_Call) RunAndReturn(run func(context.Context, todo.AddChecklistItemRequest) (*todo.TodoResponse, error)) *TodoUsecase_AddChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddDependency provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) AddDependency(ctx context.Context, request todo.AddDependencyRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDependency")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.AddDependencyRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.AddDependencyRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.AddDependencyRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_AddDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDependency'
+type TodoUsecase_AddDependency_Call struct {
+	*mock.Call
+}
+
+// AddDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.AddDependencyRequest
+func (_e *TodoUsecase_Expecter) AddDependency(ctx interface{}, request interface{}) *TodoUsecase_AddDependency_Call {
+	return &TodoUsecase_AddDependency_Call{Call: _e.mock.On("AddDependency", ctx, request)}
+}
+
+func (_c *TodoUsecase_AddDependency_Call) Run(run func(ctx context.Context, request todo.AddDependencyRequest)) *TodoUsecase_AddDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.AddDependencyRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_AddDependency_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_AddDependency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_AddDependency_Call) RunAndReturn(run func(context.Context, todo.AddDependencyRequest) (*todo.TodoResponse, error)) *TodoUsecase_AddDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeStatus provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) ChangeStatus(ctx context.Context, request todo.ChangeStatusRequest) (*todo.StatusChangeResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeStatus")
+	}
+
+	var r0 *todo.StatusChangeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ChangeStatusRequest) (*todo.StatusChangeResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ChangeStatusRequest) *todo.StatusChangeResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.StatusChangeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.ChangeStatusRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_ChangeStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeStatus'
+type TodoUsecase_ChangeStatus_Call struct {
+	*mock.Call
+}
+
+// ChangeStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.ChangeStatusRequest
+func (_e *TodoUsecase_Expecter) ChangeStatus(ctx interface{}, request interface{}) *TodoUsecase_ChangeStatus_Call {
+	return &TodoUsecase_ChangeStatus_Call{Call: _e.mock.On("ChangeStatus", ctx, request)}
+}
+
+func (_c *TodoUsecase_ChangeStatus_Call) Run(run func(ctx context.Context, request todo.ChangeStatusRequest)) *TodoUsecase_ChangeStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.ChangeStatusRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_ChangeStatus_Call) Return(_a0 *todo.StatusChangeResponse, _a1 error) *TodoUsecase_ChangeStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_ChangeStatus_Call) RunAndReturn(run func(context.Context, todo.ChangeStatusRequest) (*todo.StatusChangeResponse, error)) *TodoUsecase_ChangeStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) Create(ctx context.Context, request todo.CreateTodoRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.CreateTodoRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.CreateTodoRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.CreateTodoRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type TodoUsecase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.CreateTodoRequest
+func (_e *TodoUsecase_Expecter) Create(ctx interface{}, request interface{}) *TodoUsecase_Create_Call {
+	return &TodoUsecase_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *TodoUsecase_Create_Call) Run(run func(ctx context.Context, request todo.CreateTodoRequest)) *TodoUsecase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.CreateTodoRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_Create_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_Create_Call) RunAndReturn(run func(context.Context, todo.CreateTodoRequest) (*todo.TodoResponse, error)) *TodoUsecase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) Delete(ctx context.Context, request todo.DeleteTodoRequest) (*todo.DeleteTodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *todo.DeleteTodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.DeleteTodoRequest) (*todo.DeleteTodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.DeleteTodoRequest) *todo.DeleteTodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.DeleteTodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.DeleteTodoRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type TodoUsecase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.DeleteTodoRequest
+func (_e *TodoUsecase_Expecter) Delete(ctx interface{}, request interface{}) *TodoUsecase_Delete_Call {
+	return &TodoUsecase_Delete_Call{Call: _e.mock.On("Delete", ctx, request)}
+}
+
+func (_c *TodoUsecase_Delete_Call) Run(run func(ctx context.Context, request todo.DeleteTodoRequest)) *TodoUsecase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.DeleteTodoRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_Delete_Call) Return(_a0 *todo.DeleteTodoResponse, _a1 error) *TodoUsecase_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_Delete_Call) RunAndReturn(run func(context.Context, todo.DeleteTodoRequest) (*todo.DeleteTodoResponse, error)) *TodoUsecase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteChecklistItem provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) DeleteChecklistItem(ctx context.Context, request todo.DeleteChecklistItemRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChecklistItem")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.DeleteChecklistItemRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.DeleteChecklistItemRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.DeleteChecklistItemRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_DeleteChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChecklistItem'
+type TodoUsecase_DeleteChecklistItem_Call struct {
+	*mock.Call
+}
+
+// DeleteChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.DeleteChecklistItemRequest
+func (_e *TodoUsecase_Expecter) DeleteChecklistItem(ctx interface{}, request interface{}) *TodoUsecase_DeleteChecklistItem_Call {
+	return &TodoUsecase_DeleteChecklistItem_Call{Call: _e.mock.On("DeleteChecklistItem", ctx, request)}
+}
+
+func (_c *TodoUsecase_DeleteChecklistItem_Call) Run(run func(ctx context.Context, request todo.DeleteChecklistItemRequest)) *TodoUsecase_DeleteChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.DeleteChecklistItemRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_DeleteChecklistItem_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_DeleteChecklistItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_DeleteChecklistItem_Call) RunAndReturn(run func(context.Context, todo.DeleteChecklistItemRequest) (*todo.TodoResponse, error)) *TodoUsecase_DeleteChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) Get(ctx context.Context, request todo.GetTodoRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.GetTodoRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.GetTodoRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.GetTodoRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type TodoUsecase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.GetTodoRequest
+func (_e *TodoUsecase_Expecter) Get(ctx interface{}, request interface{}) *TodoUsecase_Get_Call {
+	return &TodoUsecase_Get_Call{Call: _e.mock.On("Get", ctx, request)}
+}
+
+func (_c *TodoUsecase_Get_Call) Run(run func(ctx context.Context, request todo.GetTodoRequest)) *TodoUsecase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.GetTodoRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_Get_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_Get_Call) RunAndReturn(run func(context.Context, todo.GetTodoRequest) (*todo.TodoResponse, error)) *TodoUsecase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDependencies provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) GetDependencies(ctx context.Context, request todo.GetDependenciesRequest) (*todo.DependencyGraphResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDependencies")
+	}
+
+	var r0 *todo.DependencyGraphResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.GetDependenciesRequest) (*todo.DependencyGraphResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.GetDependenciesRequest) *todo.DependencyGraphResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.DependencyGraphResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.GetDependenciesRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_GetDependencies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDependencies'
+type TodoUsecase_GetDependencies_Call struct {
+	*mock.Call
+}
+
+// GetDependencies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.GetDependenciesRequest
+func (_e *TodoUsecase_Expecter) GetDependencies(ctx interface{}, request interface{}) *TodoUsecase_GetDependencies_Call {
+	return &TodoUsecase_GetDependencies_Call{Call: _e.mock.On("GetDependencies", ctx, request)}
+}
+
+func (_c *TodoUsecase_GetDependencies_Call) Run(run func(ctx context.Context, request todo.GetDependenciesRequest)) *TodoUsecase_GetDependencies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.GetDependenciesRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_GetDependencies_Call) Return(_a0 *todo.DependencyGraphResponse, _a1 error) *TodoUsecase_GetDependencies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_GetDependencies_Call) RunAndReturn(run func(context.Context, todo.GetDependenciesRequest) (*todo.DependencyGraphResponse, error)) *TodoUsecase_GetDependencies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) List(ctx context.Context, request todo.ListTodosRequest) (*todo.TodoListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *todo.TodoListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListTodosRequest) (*todo.TodoListResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListTodosRequest) *todo.TodoListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.ListTodosRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type TodoUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.ListTodosRequest
+func (_e *TodoUsecase_Expecter) List(ctx interface{}, request interface{}) *TodoUsecase_List_Call {
+	return &TodoUsecase_List_Call{Call: _e.mock.On("List", ctx, request)}
+}
+
+func (_c *TodoUsecase_List_Call) Run(run func(ctx context.Context, request todo.ListTodosRequest)) *TodoUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.ListTodosRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_List_Call) Return(_a0 *todo.TodoListResponse, _a1 error) *TodoUsecase_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_List_Call) RunAndReturn(run func(context.Context, todo.ListTodosRequest) (*todo.TodoListResponse, error)) *TodoUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByContext provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) ListByContext(ctx context.Context, request todo.ListByContextRequest) (*todo.TodosByContextResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByContext")
+	}
+
+	var r0 *todo.TodosByContextResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListByContextRequest) (*todo.TodosByContextResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListByContextRequest) *todo.TodosByContextResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodosByContextResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.ListByContextRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_ListByContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByContext'
+type TodoUsecase_ListByContext_Call struct {
+	*mock.Call
+}
+
+// ListByContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.ListByContextRequest
+func (_e *TodoUsecase_Expecter) ListByContext(ctx interface{}, request interface{}) *TodoUsecase_ListByContext_Call {
+	return &TodoUsecase_ListByContext_Call{Call: _e.mock.On("ListByContext", ctx, request)}
+}
+
+func (_c *TodoUsecase_ListByContext_Call) Run(run func(ctx context.Context, request todo.ListByContextRequest)) *TodoUsecase_ListByContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.ListByContextRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_ListByContext_Call) Return(_a0 *todo.TodosByContextResponse, _a1 error) *TodoUsecase_ListByContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_ListByContext_Call) RunAndReturn(run func(context.Context, todo.ListByContextRequest) (*todo.TodosByContextResponse, error)) *TodoUsecase_ListByContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListChanged provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) ListChanged(ctx context.Context, request todo.ListChangedTodosRequest) ([]todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChanged")
+	}
+
+	var r0 []todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListChangedTodosRequest) ([]todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListChangedTodosRequest) []todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.ListChangedTodosRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_ListChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChanged'
+type TodoUsecase_ListChanged_Call struct {
+	*mock.Call
+}
+
+// ListChanged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.ListChangedTodosRequest
+func (_e *TodoUsecase_Expecter) ListChanged(ctx interface{}, request interface{}) *TodoUsecase_ListChanged_Call {
+	return &TodoUsecase_ListChanged_Call{Call: _e.mock.On("ListChanged", ctx, request)}
+}
+
+func (_c *TodoUsecase_ListChanged_Call) Run(run func(ctx context.Context, request todo.ListChangedTodosRequest)) *TodoUsecase_ListChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.ListChangedTodosRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_ListChanged_Call) Return(_a0 []todo.TodoResponse, _a1 error) *TodoUsecase_ListChanged_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_ListChanged_Call) RunAndReturn(run func(context.Context, todo.ListChangedTodosRequest) ([]todo.TodoResponse, error)) *TodoUsecase_ListChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWaiting provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) ListWaiting(ctx context.Context, request todo.ListWaitingRequest) (*todo.WaitingListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWaiting")
+	}
+
+	var r0 *todo.WaitingListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListWaitingRequest) (*todo.WaitingListResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ListWaitingRequest) *todo.WaitingListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.WaitingListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.ListWaitingRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_ListWaiting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWaiting'
+type TodoUsecase_ListWaiting_Call struct {
+	*mock.Call
+}
+
+// ListWaiting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.ListWaitingRequest
+func (_e *TodoUsecase_Expecter) ListWaiting(ctx interface{}, request interface{}) *TodoUsecase_ListWaiting_Call {
+	return &TodoUsecase_ListWaiting_Call{Call: _e.mock.On("ListWaiting", ctx, request)}
+}
+
+func (_c *TodoUsecase_ListWaiting_Call) Run(run func(ctx context.Context, request todo.ListWaitingRequest)) *TodoUsecase_ListWaiting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.ListWaitingRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_ListWaiting_Call) Return(_a0 *todo.WaitingListResponse, _a1 error) *TodoUsecase_ListWaiting_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_ListWaiting_Call) RunAndReturn(run func(context.Context, todo.ListWaitingRequest) (*todo.WaitingListResponse, error)) *TodoUsecase_ListWaiting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDependency provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) RemoveDependency(ctx context.Context, request todo.RemoveDependencyRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDependency")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.RemoveDependencyRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.RemoveDependencyRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.RemoveDependencyRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_RemoveDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDependency'
+type TodoUsecase_RemoveDependency_Call struct {
+	*mock.Call
+}
+
+// RemoveDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.RemoveDependencyRequest
+func (_e *TodoUsecase_Expecter) RemoveDependency(ctx interface{}, request interface{}) *TodoUsecase_RemoveDependency_Call {
+	return &TodoUsecase_RemoveDependency_Call{Call: _e.mock.On("RemoveDependency", ctx, request)}
+}
+
+func (_c *TodoUsecase_RemoveDependency_Call) Run(run func(ctx context.Context, request todo.RemoveDependencyRequest)) *TodoUsecase_RemoveDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.RemoveDependencyRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_RemoveDependency_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_RemoveDependency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_RemoveDependency_Call) RunAndReturn(run func(context.Context, todo.RemoveDependencyRequest) (*todo.TodoResponse, error)) *TodoUsecase_RemoveDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReorderChecklist provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) ReorderChecklist(ctx context.Context, request todo.ReorderChecklistRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderChecklist")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ReorderChecklistRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.ReorderChecklistRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.ReorderChecklistRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_ReorderChecklist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderChecklist'
+type TodoUsecase_ReorderChecklist_Call struct {
+	*mock.Call
+}
+
+// ReorderChecklist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.ReorderChecklistRequest
+func (_e *TodoUsecase_Expecter) ReorderChecklist(ctx interface{}, request interface{}) *TodoUsecase_ReorderChecklist_Call {
+	return &TodoUsecase_ReorderChecklist_Call{Call: _e.mock.On("ReorderChecklist", ctx, request)}
+}
+
+func (_c *TodoUsecase_ReorderChecklist_Call) Run(run func(ctx context.Context, request todo.ReorderChecklistRequest)) *TodoUsecase_ReorderChecklist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.ReorderChecklistRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_ReorderChecklist_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_ReorderChecklist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_ReorderChecklist_Call) RunAndReturn(run func(context.Context, todo.ReorderChecklistRequest) (*todo.TodoResponse, error)) *TodoUsecase_ReorderChecklist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) Update(ctx context.Context, request todo.UpdateTodoRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.UpdateTodoRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.UpdateTodoRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.UpdateTodoRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TodoUsecase_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.UpdateTodoRequest
+func (_e *TodoUsecase_Expecter) Update(ctx interface{}, request interface{}) *TodoUsecase_Update_Call {
+	return &TodoUsecase_Update_Call{Call: _e.mock.On("Update", ctx, request)}
+}
+
+func (_c *TodoUsecase_Update_Call) Run(run func(ctx context.Context, request todo.UpdateTodoRequest)) *TodoUsecase_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.UpdateTodoRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_Update_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_Update_Call) RunAndReturn(run func(context.Context, todo.UpdateTodoRequest) (*todo.TodoResponse, error)) *TodoUsecase_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateChecklistItem provides a mock function with given fields: ctx, request
+func (_m *TodoUsecase) UpdateChecklistItem(ctx context.Context, request todo.UpdateChecklistItemRequest) (*todo.TodoResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChecklistItem")
+	}
+
+	var r0 *todo.TodoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, todo.UpdateChecklistItemRequest) (*todo.TodoResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, todo.UpdateChecklistItemRequest) *todo.TodoResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*todo.TodoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, todo.UpdateChecklistItemRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TodoUsecase_UpdateChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChecklistItem'
+type TodoUsecase_UpdateChecklistItem_Call struct {
+	*mock.Call
+}
+
+// UpdateChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request todo.UpdateChecklistItemRequest
+func (_e *TodoUsecase_Expecter) UpdateChecklistItem(ctx interface{}, request interface{}) *TodoUsecase_UpdateChecklistItem_Call {
+	return &TodoUsecase_UpdateChecklistItem_Call{Call: _e.mock.On("UpdateChecklistItem", ctx, request)}
+}
+
+func (_c *TodoUsecase_UpdateChecklistItem_Call) Run(run func(ctx context.Context, request todo.UpdateChecklistItemRequest)) *TodoUsecase_UpdateChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(todo.UpdateChecklistItemRequest))
+	})
+	return _c
+}
+
+func (_c *TodoUsecase_UpdateChecklistItem_Call) Return(_a0 *todo.TodoResponse, _a1 error) *TodoUsecase_UpdateChecklistItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TodoUsecase_UpdateChecklistItem_Call) RunAndReturn(run func(context.Context, todo.UpdateChecklistItemRequest) (*todo.TodoResponse, error)) *TodoUsecase_UpdateChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTodoUsecase creates a new instance of TodoUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTodoUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TodoUsecase {
+	mock := &TodoUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
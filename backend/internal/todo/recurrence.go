@@ -0,0 +1,137 @@
+package todo
+
+import (
+	"context"
+	"time"
+	"yangdongju/gtd_todo/internal/recurrence"
+)
+
+// applyRecurrence sets the rule and mode a request sends, storing the rule in canonical
+// form. An empty rule stops the todo repeating. It runs after applyDue, since a todo
+// repeating on a schedule needs a due date to count from.
+func applyRecurrence(todo *Todo, rule *string, mode *string) error {
+	if rule != nil {
+		if *rule == "" {
+			todo.Recurrence = nil
+		} else {
+			parsed, err := recurrence.Parse(*rule)
+			if err != nil {
+				return NewInvalidRecurrenceError(err.Error())
+			}
+			canonical := parsed.String()
+			todo.Recurrence = &canonical
+		}
+	}
+	if mode != nil {
+		todo.RecurrenceMode = *mode
+	}
+	if todo.Recurrence != nil && todo.RecurrenceMode != RecurrenceAfterCompletion && todo.DueDate == nil {
+		return NewInvalidRecurrenceError("a todo repeating on a schedule needs a due_date")
+	}
+	return nil
+}
+
+// nextInstance builds the instance that follows todo in its series, or returns nil when
+// the series ends with todo. It lands in status at position, where todo was before it was
+// completed, and keeps todo's project and time of day. Dates are read in the user's
+// timezone, or in UTC when the runtime does not know it.
+func (s *todoService) nextInstance(ctx context.Context, todo *Todo, status string, position int) (*Todo, error) {
+	rule, err := recurrence.Parse(*todo.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.location(ctx, todo.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := dueClock(todo.DueTime)
+	var day time.Time
+	var ok bool
+	if todo.RecurrenceMode == RecurrenceAfterCompletion {
+		day, ok = rule.NextAfterCompletion(s.now(), todo.Occurrence, clock, loc)
+	} else {
+		day, ok = rule.Next(*todo.DueDate, todo.Occurrence, clock, loc)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	instance := &Todo{
+		UserID:                todo.UserID,
+		ProjectID:             todo.ProjectID,
+		Title:                 todo.Title,
+		Description:           todo.Description,
+		Status:                status,
+		Position:              position,
+		DueDate:               &day,
+		DueTime:               todo.DueTime,
+		Recurrence:            todo.Recurrence,
+		RecurrenceMode:        todo.RecurrenceMode,
+		Occurrence:            todo.Occurrence + 1,
+		Tags:                  todo.Tags,
+		Reminders:             todo.Reminders,
+		ChecklistAutoComplete: todo.ChecklistAutoComplete,
+		EstimateMinutes:       todo.EstimateMinutes,
+		Energy:                todo.Energy,
+	}
+	// The next instance runs through the same steps from the start.
+	for _, item := range todo.Checklist {
+		instance.Checklist = append(instance.Checklist, ChecklistItem{Title: item.Title, Position: item.Position})
+	}
+	// An instance still waiting on someone waits on the same person, from today.
+	if status == StatusWaitingFor {
+		today := dateIn(s.now(), loc)
+		instance.DelegatedTo, instance.DelegateContact, instance.DelegatedDate = todo.DelegatedTo, todo.DelegateContact, &today
+	}
+	return instance, nil
+}
+
+// saveInstance stores an instance built by nextInstance along with the tags, reminders and
+// checklist it carries over.
+func (s *todoService) saveInstance(ctx context.Context, instance *Todo) (*Todo, error) {
+	saved, err := s.todoRepository.Save(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	if len(instance.Tags) > 0 {
+		tagIDs := make([]int, len(instance.Tags))
+		for i, tag := range instance.Tags {
+			tagIDs[i] = tag.ID
+		}
+		if saved.Tags, err = s.todoRepository.ReplaceTags(ctx, saved.ID, tagIDs); err != nil {
+			return nil, err
+		}
+	}
+	if len(instance.Reminders) > 0 {
+		minutesBefore := make([]int, len(instance.Reminders))
+		for i, reminder := range instance.Reminders {
+			minutesBefore[i] = reminder.MinutesBefore
+		}
+		if saved.Reminders, err = s.todoRepository.ReplaceReminders(ctx, saved.ID, minutesBefore); err != nil {
+			return nil, err
+		}
+	}
+	if len(instance.Checklist) > 0 {
+		for _, item := range instance.Checklist {
+			item.TodoID = saved.ID
+			if err := s.todoRepository.SaveChecklistItem(ctx, &item); err != nil {
+				return nil, err
+			}
+		}
+		return s.todoRepository.TouchChecklist(ctx, saved.ID)
+	}
+	return saved, nil
+}
+
+// dueClock returns the time of day of a due time, or midnight for a todo due on a day.
+func dueClock(dueTime *string) time.Duration {
+	if dueTime == nil {
+		return 0
+	}
+	clock, err := time.Parse(dueTimeLayout, formatDueTime(*dueTime))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+}
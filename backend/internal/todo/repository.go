@@ -0,0 +1,793 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/query"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	StatusInbox       = "inbox"
+	StatusNextActions = "next_actions"
+	StatusInProgress  = "in_progress"
+	StatusDone        = "done"
+	StatusSomeday     = "someday"
+	StatusWaitingFor  = "waiting_for"
+)
+
+const (
+	RecurrenceSchedule        = "schedule"
+	RecurrenceAfterCompletion = "after_completion"
+)
+
+// TagKindContext marks GTD context tags such as @home, which group the Next Actions view.
+const TagKindContext = "context"
+
+type TodoRepository interface {
+	Save(ctx context.Context, todo *Todo) (*Todo, error)
+	FindByID(ctx context.Context, userID int, id int) (*Todo, error)
+	FindByClientID(ctx context.Context, userID int, clientID string) (*Todo, error)
+	FindChangedSince(ctx context.Context, userID int, cursor int64) ([]Todo, error)
+	FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Todo, int, error)
+	FindByStatus(ctx context.Context, userID int, status string) ([]Todo, error)
+	Update(ctx context.Context, todo *Todo) (*Todo, error)
+	Delete(ctx context.Context, todo *Todo) (bool, error)
+	NextPosition(ctx context.Context, userID int, status string) (int, error)
+	ProjectExists(ctx context.Context, userID int, projectID int) (bool, error)
+	TagsExist(ctx context.Context, userID int, tagIDs []int) (bool, error)
+	ReplaceTags(ctx context.Context, todoID int, tagIDs []int) ([]TodoTag, error)
+	ReplaceReminders(ctx context.Context, todoID int, minutesBefore []int) ([]TodoReminder, error)
+	RescheduleReminders(ctx context.Context, todoID int) ([]TodoReminder, error)
+	ScheduleFollowUp(ctx context.Context, todoID int) error
+	FindWaiting(ctx context.Context, userID int, delegatedTo *string) ([]Todo, error)
+	UserTimezone(ctx context.Context, userID int) (string, error)
+	FindChecklistItem(ctx context.Context, todoID int, id int) (*ChecklistItem, error)
+	SaveChecklistItem(ctx context.Context, item *ChecklistItem) error
+	UpdateChecklistItem(ctx context.Context, item *ChecklistItem) error
+	DeleteChecklistItem(ctx context.Context, item *ChecklistItem) error
+	ReorderChecklist(ctx context.Context, todoID int, itemIDs []int) error
+	TouchChecklist(ctx context.Context, todoID int) (*Todo, error)
+	AddDependency(ctx context.Context, todoID int, blockerID int) error
+	RemoveDependency(ctx context.Context, todoID int, blockerID int) (bool, error)
+	CreatesCycle(ctx context.Context, todoID int, blockerID int) (bool, error)
+	TouchDependents(ctx context.Context, blockerID int) ([]Todo, error)
+	FindDependencyGraph(ctx context.Context, userID int, id int) (*DependencyGraph, error)
+}
+
+type todoRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+type Todo struct {
+	ID                    int             `db:"id"`
+	UserID                int             `db:"user_id"`
+	ClientID              *string         `db:"client_id"`
+	ProjectID             *int            `db:"project_id"`
+	Title                 string          `db:"title"`
+	Description           *string         `db:"description"`
+	Status                string          `db:"status"`
+	Position              int             `db:"position"`
+	DueDate               *time.Time      `db:"due_date"`
+	DueTime               *string         `db:"due_time"`
+	Recurrence            *string         `db:"recurrence"`
+	RecurrenceMode        string          `db:"recurrence_mode"`
+	Occurrence            int             `db:"occurrence"`
+	DelegatedTo           *string         `db:"delegated_to"`
+	DelegateContact       *string         `db:"delegate_contact"`
+	DelegatedDate         *time.Time      `db:"delegated_date"`
+	FollowUpDate          *time.Time      `db:"follow_up_date"`
+	ChecklistAutoComplete bool            `db:"checklist_auto_complete"`
+	EstimateMinutes       *int            `db:"estimate_minutes"`
+	Energy                *string         `db:"energy"`
+	Version               int             `db:"version"`
+	CreatedAt             time.Time       `db:"created_at"`
+	UpdatedAt             time.Time       `db:"updated_at"`
+	Tags                  []TodoTag       `db:"-"`
+	Reminders             []TodoReminder  `db:"-"`
+	Checklist             []ChecklistItem `db:"-"`
+	Blockers              []TodoBlocker   `db:"-"`
+}
+
+// TodoTag is a tag as carried by a todo. Every method returning todos fills in their tags.
+type TodoTag struct {
+	TodoID int    `db:"todo_id"`
+	ID     int    `db:"id"`
+	Name   string `db:"name"`
+	Color  string `db:"color"`
+	Kind   string `db:"kind"`
+}
+
+// TodoReminder fires minutesBefore the todo is due. Every method returning todos fills
+// in their reminders; the follow-up reminder of a waiting_for todo is not one of them.
+type TodoReminder struct {
+	TodoID        int       `db:"todo_id"`
+	MinutesBefore int       `db:"minutes_before"`
+	RemindAt      time.Time `db:"remind_at"`
+}
+
+// ChecklistItem is one step of a todo's checklist. Every method returning todos fills in
+// their checklists in order.
+type ChecklistItem struct {
+	ID        int        `db:"id"`
+	TodoID    int        `db:"todo_id"`
+	Title     string     `db:"title"`
+	Checked   bool       `db:"checked"`
+	Position  int        `db:"position"`
+	CheckedAt *time.Time `db:"checked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+// TodoBlocker is a todo that has to be done before the todo carrying it can start. Every
+// method returning todos fills in their blockers.
+type TodoBlocker struct {
+	TodoID int    `db:"todo_id"`
+	ID     int    `db:"id"`
+	Status string `db:"status"`
+}
+
+// DependencyGraph is every todo a todo waits on or holds up, directly or through others,
+// and the blocked-by edges between them.
+type DependencyGraph struct {
+	Nodes []DependencyNode
+	Edges []Dependency
+}
+
+type DependencyNode struct {
+	ID        int    `db:"id"`
+	Title     string `db:"title"`
+	Status    string `db:"status"`
+	IsBlocked bool   `db:"is_blocked"`
+}
+
+type Dependency struct {
+	TodoID    int `db:"todo_id"`
+	BlockerID int `db:"blocker_id"`
+}
+
+const checklistColumns = "id, todo_id, title, checked, position, checked_at, created_at, updated_at"
+
+const todoColumns = "id, user_id, client_id, project_id, title, description, status, position, due_date, due_time, " +
+	"recurrence, recurrence_mode, occurrence, delegated_to, delegate_contact, delegated_date, follow_up_date, " +
+	"checklist_auto_complete, estimate_minutes, energy, version, created_at, updated_at"
+
+func NewTodoRepository(db *sqlx.DB) *todoRepositoryImpl {
+	return &todoRepositoryImpl{db: db}
+}
+
+// Save inserts the todo. A todo that leaves the recurrence fields unset starts a series in
+// schedule mode, which only matters once it gets a rule.
+func (r *todoRepositoryImpl) Save(ctx context.Context, todo *Todo) (*Todo, error) {
+	mode := todo.RecurrenceMode
+	if mode == "" {
+		mode = RecurrenceSchedule
+	}
+	var saved Todo
+	err := database.Conn(ctx, r.db).GetContext(ctx, &saved, `
+		INSERT INTO todos (user_id, client_id, project_id, title, description, status, position, due_date, due_time,
+			recurrence, recurrence_mode, occurrence, delegated_to, delegate_contact, delegated_date, follow_up_date,
+			checklist_auto_complete, estimate_minutes, energy)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8::date, $9, $10, $11, $12, $13, $14, $15::date, $16::date, $17, $18, $19)
+		RETURNING `+todoColumns,
+		todo.UserID, todo.ClientID, todo.ProjectID, todo.Title, todo.Description, todo.Status, todo.Position,
+		todo.DueDate, todo.DueTime, todo.Recurrence, mode, max(todo.Occurrence, 1),
+		todo.DelegatedTo, todo.DelegateContact, todo.DelegatedDate, todo.FollowUpDate, todo.ChecklistAutoComplete,
+		todo.EstimateMinutes, todo.Energy)
+	if err != nil {
+		return nil, err
+	}
+	saved.Tags = []TodoTag{}
+	saved.Reminders = []TodoReminder{}
+	saved.Checklist = []ChecklistItem{}
+	saved.Blockers = []TodoBlocker{}
+	return &saved, nil
+}
+
+func (r *todoRepositoryImpl) FindByID(ctx context.Context, userID int, id int) (*Todo, error) {
+	var todo Todo
+	err := database.Conn(ctx, r.db).GetContext(ctx, &todo,
+		"SELECT "+todoColumns+" FROM todos WHERE id = $1 AND user_id = $2", id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+func (r *todoRepositoryImpl) FindByClientID(ctx context.Context, userID int, clientID string) (*Todo, error) {
+	var todo Todo
+	err := database.Conn(ctx, r.db).GetContext(ctx, &todo,
+		"SELECT "+todoColumns+" FROM todos WHERE client_id = $1 AND user_id = $2", clientID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// FindChangedSince returns the todos created or modified after the sync cursor, oldest change first.
+func (r *todoRepositoryImpl) FindChangedSince(ctx context.Context, userID int, cursor int64) ([]Todo, error) {
+	todos := []Todo{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &todos,
+		"SELECT "+todoColumns+" FROM todos WHERE user_id = $1 AND change_seq > $2 ORDER BY change_seq", userID, cursor)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, pointers(todos)...); err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// FindPage returns the user's todos matching spec, one row past its limit when there are
+// more, and how many match in total.
+func (r *todoRepositoryImpl) FindPage(ctx context.Context, userID int, spec *query.Spec) ([]Todo, int, error) {
+	page, count := spec.Build(todoColumns, "todos", query.Equal("user_id", userID))
+	conn := database.Conn(ctx, r.db)
+
+	todos := []Todo{}
+	if err := conn.SelectContext(ctx, &todos, page.SQL, page.Args...); err != nil {
+		return nil, 0, err
+	}
+	var total int
+	if err := conn.GetContext(ctx, &total, count.SQL, count.Args...); err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadRelations(ctx, pointers(todos)...); err != nil {
+		return nil, 0, err
+	}
+	return todos, total, nil
+}
+
+// FindByStatus returns one status column of the user's board in order.
+func (r *todoRepositoryImpl) FindByStatus(ctx context.Context, userID int, status string) ([]Todo, error) {
+	todos := []Todo{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &todos,
+		"SELECT "+todoColumns+" FROM todos WHERE user_id = $1 AND status = $2 ORDER BY position, id", userID, status)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, pointers(todos)...); err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// Update writes the todo only if its version is still the one that was read, and bumps
+// the version. It returns nil when another writer got there first.
+func (r *todoRepositoryImpl) Update(ctx context.Context, todo *Todo) (*Todo, error) {
+	var updated Todo
+	err := database.Conn(ctx, r.db).GetContext(ctx, &updated, `
+		UPDATE todos
+		SET project_id = $4, title = $5, description = $6, status = $7, position = $8,
+			due_date = $9::date, due_time = $10, recurrence = $11, recurrence_mode = $12,
+			delegated_to = $13, delegate_contact = $14, delegated_date = $15::date, follow_up_date = $16::date,
+			checklist_auto_complete = $17, estimate_minutes = $18, energy = $19,
+			version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND version = $3
+		RETURNING `+todoColumns,
+		todo.ID, todo.UserID, todo.Version, todo.ProjectID, todo.Title, todo.Description, todo.Status, todo.Position,
+		todo.DueDate, todo.DueTime, todo.Recurrence, todo.RecurrenceMode,
+		todo.DelegatedTo, todo.DelegateContact, todo.DelegatedDate, todo.FollowUpDate, todo.ChecklistAutoComplete,
+		todo.EstimateMinutes, todo.Energy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *todoRepositoryImpl) Delete(ctx context.Context, todo *Todo) (bool, error) {
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM todos WHERE id = $1 AND user_id = $2 AND version = $3",
+		todo.ID, todo.UserID, todo.Version)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	return deleted == 1, err
+}
+
+// NextPosition returns the position after the last todo in the status column.
+func (r *todoRepositoryImpl) NextPosition(ctx context.Context, userID int, status string) (int, error) {
+	var position int
+	err := database.Conn(ctx, r.db).GetContext(ctx, &position,
+		"SELECT COALESCE(MAX(position) + 1, 0) FROM todos WHERE user_id = $1 AND status = $2", userID, status)
+	return position, err
+}
+
+func (r *todoRepositoryImpl) ProjectExists(ctx context.Context, userID int, projectID int) (bool, error) {
+	var exists bool
+	err := database.Conn(ctx, r.db).GetContext(ctx, &exists,
+		"SELECT EXISTS (SELECT 1 FROM projects WHERE id = $1 AND user_id = $2)", projectID, userID)
+	return exists, err
+}
+
+// TagsExist reports whether every tag id belongs to the user.
+func (r *todoRepositoryImpl) TagsExist(ctx context.Context, userID int, tagIDs []int) (bool, error) {
+	var missing bool
+	err := database.Conn(ctx, r.db).GetContext(ctx, &missing, `
+		SELECT EXISTS (
+			SELECT 1 FROM UNNEST($2::integer[]) AS wanted(id)
+			WHERE NOT EXISTS (SELECT 1 FROM tags WHERE tags.id = wanted.id AND tags.user_id = $1)
+		)`,
+		userID, pq.Array(tagIDs))
+	return !missing, err
+}
+
+// ReplaceTags sets the todo's tags to exactly tagIDs and returns them.
+func (r *todoRepositoryImpl) ReplaceTags(ctx context.Context, todoID int, tagIDs []int) ([]TodoTag, error) {
+	conn := database.Conn(ctx, r.db)
+	if _, err := conn.ExecContext(ctx, "DELETE FROM todo_tags WHERE todo_id = $1", todoID); err != nil {
+		return nil, err
+	}
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO todo_tags (todo_id, tag_id)
+		SELECT $1, UNNEST($2::integer[])
+		ON CONFLICT DO NOTHING`,
+		todoID, pq.Array(tagIDs))
+	if err != nil {
+		return nil, err
+	}
+	todo := Todo{ID: todoID}
+	if err := r.loadTags(ctx, &todo); err != nil {
+		return nil, err
+	}
+	return todo.Tags, nil
+}
+
+// ReplaceReminders keeps the todo's reminders at exactly minutesBefore. Reminders that
+// stay keep their delivery state, so resending the same list does not fire them again.
+// A todo without a due date gets none.
+func (r *todoRepositoryImpl) ReplaceReminders(ctx context.Context, todoID int, minutesBefore []int) ([]TodoReminder, error) {
+	conn := database.Conn(ctx, r.db)
+	_, err := conn.ExecContext(ctx,
+		"DELETE FROM reminders WHERE todo_id = $1 AND kind = 'due' AND minutes_before <> ALL(COALESCE($2::integer[], '{}'))",
+		todoID, pq.Array(minutesBefore))
+	if err != nil {
+		return nil, err
+	}
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO reminders (todo_id, minutes_before, remind_at)
+		SELECT t.id, m.minutes, reminder_at(t.due_date, t.due_time, u.timezone, m.minutes)
+		FROM todos t
+		JOIN users u ON u.id = t.user_id
+		CROSS JOIN UNNEST($2::integer[]) AS m(minutes)
+		WHERE t.id = $1 AND t.due_date IS NOT NULL
+		ON CONFLICT (todo_id, kind, minutes_before) DO NOTHING`,
+		todoID, pq.Array(minutesBefore))
+	if err != nil {
+		return nil, err
+	}
+	todo := Todo{ID: todoID}
+	if err := r.loadReminders(ctx, &todo); err != nil {
+		return nil, err
+	}
+	return todo.Reminders, nil
+}
+
+// RescheduleReminders recomputes when the todo's reminders fire after its due date moved,
+// and arms them again, including ones already sent for the old date.
+func (r *todoRepositoryImpl) RescheduleReminders(ctx context.Context, todoID int) ([]TodoReminder, error) {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE reminders r
+		SET remind_at = reminder_at(t.due_date, t.due_time, u.timezone, r.minutes_before),
+			scheduled_at = CURRENT_TIMESTAMP, sent_at = NULL, attempts = 0, last_error = NULL, claimed_until = NULL
+		FROM todos t JOIN users u ON u.id = t.user_id
+		WHERE r.todo_id = t.id AND t.id = $1 AND r.kind = 'due'`,
+		todoID)
+	if err != nil {
+		return nil, err
+	}
+	todo := Todo{ID: todoID}
+	if err := r.loadReminders(ctx, &todo); err != nil {
+		return nil, err
+	}
+	return todo.Reminders, nil
+}
+
+// ScheduleFollowUp gives a waiting_for todo with a follow-up date a reminder at 09:00 that
+// day. The todo's follow-up reminder is deleted once it leaves waiting_for, loses its
+// follow-up date or moves to another date. A reminder for the same date is kept as it is,
+// so saving the todo again does not fire it twice.
+func (r *todoRepositoryImpl) ScheduleFollowUp(ctx context.Context, todoID int) error {
+	conn := database.Conn(ctx, r.db)
+	_, err := conn.ExecContext(ctx, `
+		DELETE FROM reminders r
+		USING todos t JOIN users u ON u.id = t.user_id
+		WHERE r.todo_id = t.id AND t.id = $1 AND r.kind = 'follow_up'
+			AND (t.status <> 'waiting_for' OR t.follow_up_date IS NULL
+				OR r.remind_at <> reminder_at(t.follow_up_date, NULL, u.timezone, 0))`,
+		todoID)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO reminders (todo_id, kind, minutes_before, remind_at)
+		SELECT t.id, 'follow_up', 0, reminder_at(t.follow_up_date, NULL, u.timezone, 0)
+		FROM todos t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.id = $1 AND t.status = 'waiting_for' AND t.follow_up_date IS NOT NULL
+		ON CONFLICT (todo_id, kind, minutes_before) DO NOTHING`,
+		todoID)
+	return err
+}
+
+// FindWaiting returns the user's waiting_for todos, earliest follow-up first and those
+// without one last. delegatedTo narrows them to one delegate, ignoring case.
+func (r *todoRepositoryImpl) FindWaiting(ctx context.Context, userID int, delegatedTo *string) ([]Todo, error) {
+	todos := []Todo{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &todos, `
+		SELECT `+todoColumns+` FROM todos
+		WHERE user_id = $1 AND status = 'waiting_for' AND ($2::text IS NULL OR LOWER(delegated_to) = LOWER($2))
+		ORDER BY follow_up_date NULLS LAST, delegated_date NULLS LAST, id`,
+		userID, delegatedTo)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, pointers(todos)...); err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// UserTimezone returns the IANA timezone the user reads due dates in.
+func (r *todoRepositoryImpl) UserTimezone(ctx context.Context, userID int) (string, error) {
+	var timezone string
+	err := database.Conn(ctx, r.db).GetContext(ctx, &timezone, "SELECT timezone FROM users WHERE id = $1", userID)
+	return timezone, err
+}
+
+// FindChecklistItem returns the item if it belongs to the todo.
+func (r *todoRepositoryImpl) FindChecklistItem(ctx context.Context, todoID int, id int) (*ChecklistItem, error) {
+	var item ChecklistItem
+	err := database.Conn(ctx, r.db).GetContext(ctx, &item,
+		"SELECT "+checklistColumns+" FROM checklist_items WHERE id = $1 AND todo_id = $2", id, todoID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// SaveChecklistItem inserts the item at its position, moving the items from there on
+// down one, and fills in its id.
+func (r *todoRepositoryImpl) SaveChecklistItem(ctx context.Context, item *ChecklistItem) error {
+	conn := database.Conn(ctx, r.db)
+	_, err := conn.ExecContext(ctx,
+		"UPDATE checklist_items SET position = position + 1 WHERE todo_id = $1 AND position >= $2",
+		item.TodoID, item.Position)
+	if err != nil {
+		return err
+	}
+	return conn.GetContext(ctx, item, `
+		INSERT INTO checklist_items (todo_id, title, checked, position, checked_at)
+		VALUES ($1, $2, $3, $4, CASE WHEN $3 THEN CURRENT_TIMESTAMP END)
+		RETURNING `+checklistColumns,
+		item.TodoID, item.Title, item.Checked, item.Position)
+}
+
+// UpdateChecklistItem writes the item's title and checked state. checked_at keeps the
+// moment the item was first checked until it is unchecked.
+func (r *todoRepositoryImpl) UpdateChecklistItem(ctx context.Context, item *ChecklistItem) error {
+	return database.Conn(ctx, r.db).GetContext(ctx, item, `
+		UPDATE checklist_items
+		SET title = $3, checked = $4,
+			checked_at = CASE WHEN NOT $4 THEN NULL ELSE COALESCE(checked_at, CURRENT_TIMESTAMP) END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND todo_id = $2
+		RETURNING `+checklistColumns,
+		item.ID, item.TodoID, item.Title, item.Checked)
+}
+
+// DeleteChecklistItem removes the item and closes the gap it leaves.
+func (r *todoRepositoryImpl) DeleteChecklistItem(ctx context.Context, item *ChecklistItem) error {
+	conn := database.Conn(ctx, r.db)
+	_, err := conn.ExecContext(ctx, "DELETE FROM checklist_items WHERE id = $1 AND todo_id = $2", item.ID, item.TodoID)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx,
+		"UPDATE checklist_items SET position = position - 1 WHERE todo_id = $1 AND position > $2",
+		item.TodoID, item.Position)
+	return err
+}
+
+// ReorderChecklist puts the todo's items in the order of itemIDs, which must name each of
+// them once.
+func (r *todoRepositoryImpl) ReorderChecklist(ctx context.Context, todoID int, itemIDs []int) error {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE checklist_items c
+		SET position = ordered.position - 1
+		FROM UNNEST($2::integer[]) WITH ORDINALITY AS ordered(id, position)
+		WHERE c.id = ordered.id AND c.todo_id = $1 AND c.position <> ordered.position - 1`,
+		todoID, pq.Array(itemIDs))
+	return err
+}
+
+// TouchChecklist records a checklist change on the todo: its search text follows the
+// items, and its version moves on so cached copies and sync clients see the change.
+func (r *todoRepositoryImpl) TouchChecklist(ctx context.Context, todoID int) (*Todo, error) {
+	var todo Todo
+	err := database.Conn(ctx, r.db).GetContext(ctx, &todo, `
+		UPDATE todos
+		SET checklist_text = (
+				SELECT string_agg(title, E'\n' ORDER BY position, id) FROM checklist_items WHERE todo_id = $1
+			),
+			version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+todoColumns,
+		todoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(ctx, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// AddDependency records that the todo is blocked by blockerID and moves the todo's version
+// on. Adding a dependency that exists changes nothing.
+func (r *todoRepositoryImpl) AddDependency(ctx context.Context, todoID int, blockerID int) error {
+	_, err := database.Conn(ctx, r.db).ExecContext(ctx, `
+		WITH added AS (
+			INSERT INTO todo_dependencies (todo_id, blocker_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			RETURNING todo_id
+		)
+		UPDATE todos SET version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT todo_id FROM added)`,
+		todoID, blockerID)
+	return err
+}
+
+// RemoveDependency deletes the dependency and moves the todo's version on. It reports
+// whether there was one.
+func (r *todoRepositoryImpl) RemoveDependency(ctx context.Context, todoID int, blockerID int) (bool, error) {
+	var removed bool
+	err := database.Conn(ctx, r.db).GetContext(ctx, &removed, `
+		WITH removed AS (
+			DELETE FROM todo_dependencies WHERE todo_id = $1 AND blocker_id = $2
+			RETURNING todo_id
+		), touched AS (
+			UPDATE todos SET version = version + 1, updated_at = CURRENT_TIMESTAMP
+			WHERE id IN (SELECT todo_id FROM removed)
+			RETURNING id
+		)
+		SELECT EXISTS (SELECT 1 FROM touched)`,
+		todoID, blockerID)
+	return removed, err
+}
+
+// CreatesCycle reports whether blockerID already waits on the todo, directly or through
+// other todos, so that the todo waiting on it would close a loop.
+func (r *todoRepositoryImpl) CreatesCycle(ctx context.Context, todoID int, blockerID int) (bool, error) {
+	var cycle bool
+	err := database.Conn(ctx, r.db).GetContext(ctx, &cycle, `
+		WITH RECURSIVE upstream(id) AS (
+			SELECT $2::integer
+			UNION
+			SELECT d.blocker_id FROM todo_dependencies d JOIN upstream u ON d.todo_id = u.id
+		)
+		SELECT EXISTS (SELECT 1 FROM upstream WHERE id = $1)`,
+		todoID, blockerID)
+	return cycle, err
+}
+
+// TouchDependents moves on the version of every todo blockerID blocks, after a change that
+// may have blocked or unblocked them, and returns them.
+func (r *todoRepositoryImpl) TouchDependents(ctx context.Context, blockerID int) ([]Todo, error) {
+	todos := []Todo{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &todos, `
+		UPDATE todos SET version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (SELECT todo_id FROM todo_dependencies WHERE blocker_id = $1)
+		RETURNING `+todoColumns,
+		blockerID)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(todos, func(a, b Todo) int { return a.ID - b.ID })
+	if err := r.loadRelations(ctx, pointers(todos)...); err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// FindDependencyGraph walks the blocked-by edges from the todo in both directions.
+func (r *todoRepositoryImpl) FindDependencyGraph(ctx context.Context, userID int, id int) (*DependencyGraph, error) {
+	conn := database.Conn(ctx, r.db)
+	graph := &DependencyGraph{Nodes: []DependencyNode{}, Edges: []Dependency{}}
+	err := conn.SelectContext(ctx, &graph.Nodes, `
+		WITH RECURSIVE upstream(id) AS (
+			SELECT $1::integer
+			UNION
+			SELECT d.blocker_id FROM todo_dependencies d JOIN upstream u ON d.todo_id = u.id
+		), downstream(id) AS (
+			SELECT $1::integer
+			UNION
+			SELECT d.todo_id FROM todo_dependencies d JOIN downstream w ON d.blocker_id = w.id
+		)
+		SELECT t.id, t.title, t.status, EXISTS (
+				SELECT 1 FROM todo_dependencies d JOIN todos b ON b.id = d.blocker_id
+				WHERE d.todo_id = t.id AND b.status <> 'done'
+			) AS is_blocked
+		FROM todos t
+		WHERE t.user_id = $2 AND t.id IN (SELECT id FROM upstream UNION SELECT id FROM downstream)
+		ORDER BY t.id`,
+		id, userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		ids[i] = node.ID
+	}
+	err = conn.SelectContext(ctx, &graph.Edges, `
+		SELECT todo_id, blocker_id FROM todo_dependencies
+		WHERE todo_id = ANY($1) AND blocker_id = ANY($1)
+		ORDER BY todo_id, blocker_id`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// loadRelations fills in everything a todo carries besides its own columns.
+func (r *todoRepositoryImpl) loadRelations(ctx context.Context, todos ...*Todo) error {
+	if err := r.loadTags(ctx, todos...); err != nil {
+		return err
+	}
+	if err := r.loadReminders(ctx, todos...); err != nil {
+		return err
+	}
+	if err := r.loadChecklists(ctx, todos...); err != nil {
+		return err
+	}
+	return r.loadBlockers(ctx, todos...)
+}
+
+// loadTags fills in the tags of todos with one query, contexts first, then by name.
+func (r *todoRepositoryImpl) loadTags(ctx context.Context, todos ...*Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(todos))
+	byID := make(map[int]*Todo, len(todos))
+	for _, todo := range todos {
+		todo.Tags = []TodoTag{}
+		ids = append(ids, todo.ID)
+		byID[todo.ID] = todo
+	}
+
+	tags := []TodoTag{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &tags, `
+		SELECT todo_tags.todo_id, tags.id, tags.name, tags.color, tags.kind
+		FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id
+		WHERE todo_tags.todo_id = ANY($1)
+		ORDER BY tags.kind, LOWER(tags.name), tags.id`,
+		pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		byID[tag.TodoID].Tags = append(byID[tag.TodoID].Tags, tag)
+	}
+	return nil
+}
+
+// loadReminders fills in the reminders of todos with one query, earliest first.
+func (r *todoRepositoryImpl) loadReminders(ctx context.Context, todos ...*Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(todos))
+	byID := make(map[int]*Todo, len(todos))
+	for _, todo := range todos {
+		todo.Reminders = []TodoReminder{}
+		ids = append(ids, todo.ID)
+		byID[todo.ID] = todo
+	}
+
+	reminders := []TodoReminder{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &reminders, `
+		SELECT todo_id, minutes_before, remind_at FROM reminders
+		WHERE todo_id = ANY($1) AND kind = 'due'
+		ORDER BY remind_at, minutes_before`,
+		pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, reminder := range reminders {
+		byID[reminder.TodoID].Reminders = append(byID[reminder.TodoID].Reminders, reminder)
+	}
+	return nil
+}
+
+// loadChecklists fills in the checklists of todos with one query, in order.
+func (r *todoRepositoryImpl) loadChecklists(ctx context.Context, todos ...*Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(todos))
+	byID := make(map[int]*Todo, len(todos))
+	for _, todo := range todos {
+		todo.Checklist = []ChecklistItem{}
+		ids = append(ids, todo.ID)
+		byID[todo.ID] = todo
+	}
+
+	items := []ChecklistItem{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &items,
+		"SELECT "+checklistColumns+" FROM checklist_items WHERE todo_id = ANY($1) ORDER BY position, id",
+		pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		byID[item.TodoID].Checklist = append(byID[item.TodoID].Checklist, item)
+	}
+	return nil
+}
+
+// loadBlockers fills in the blockers of todos with one query, by id.
+func (r *todoRepositoryImpl) loadBlockers(ctx context.Context, todos ...*Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(todos))
+	byID := make(map[int]*Todo, len(todos))
+	for _, todo := range todos {
+		todo.Blockers = []TodoBlocker{}
+		ids = append(ids, todo.ID)
+		byID[todo.ID] = todo
+	}
+
+	blockers := []TodoBlocker{}
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &blockers, `
+		SELECT d.todo_id, b.id, b.status
+		FROM todo_dependencies d JOIN todos b ON b.id = d.blocker_id
+		WHERE d.todo_id = ANY($1)
+		ORDER BY b.id`,
+		pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	for _, blocker := range blockers {
+		byID[blocker.TodoID].Blockers = append(byID[blocker.TodoID].Blockers, blocker)
+	}
+	return nil
+}
+
+func pointers(todos []Todo) []*Todo {
+	refs := make([]*Todo, len(todos))
+	for i := range todos {
+		refs[i] = &todos[i]
+	}
+	return refs
+}
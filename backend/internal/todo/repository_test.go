@@ -0,0 +1,281 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/todo"
+	"yangdongju/gtd_todo/testhelper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func insertUser(t *testing.T) int {
+	var id int
+	err := testhelper.GetTestDB().Get(&id,
+		"INSERT INTO users (email, password_hash) VALUES ('hello@example.com', 'hash') RETURNING id")
+	assert.NoError(t, err)
+	return id
+}
+
+func TestTodoRepository_UpdateBumpsVersion(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	saved, err := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Buy milk", Status: todo.StatusInbox})
+	assert.NoError(t, err)
+
+	// when
+	saved.Title = "Buy oat milk"
+	updated, err := repository.Update(ctx, saved)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, saved.Version)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, "Buy oat milk", updated.Title)
+}
+
+func TestTodoRepository_StaleUpdateAndDeleteAreRejected(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	saved, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Buy milk", Status: todo.StatusInbox})
+	stale := *saved
+	_, _ = repository.Update(ctx, saved)
+
+	// when
+	updated, updateErr := repository.Update(ctx, &stale)
+	deleted, deleteErr := repository.Delete(ctx, &stale)
+
+	// then
+	assert.NoError(t, updateErr)
+	assert.NoError(t, deleteErr)
+	assert.Nil(t, updated)
+	assert.False(t, deleted)
+}
+
+func TestTodoRepository_NextPositionIsPerStatus(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	_, _ = repository.Save(ctx, &todo.Todo{UserID: userID, Title: "a", Status: todo.StatusInbox, Position: 0})
+	_, _ = repository.Save(ctx, &todo.Todo{UserID: userID, Title: "b", Status: todo.StatusInbox, Position: 1})
+
+	// when
+	inbox, _ := repository.NextPosition(ctx, userID, todo.StatusInbox)
+	done, _ := repository.NextPosition(ctx, userID, todo.StatusDone)
+
+	// then
+	assert.Equal(t, 2, inbox)
+	assert.Equal(t, 0, done)
+}
+
+func TestTodoRepository_FindPageFiltersAndResumesAfterCursor(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	service := todo.NewTodoService(repository, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+	ctx := context.Background()
+	for _, title := range []string{"d", "a", "c", "b"} {
+		_, err := repository.Save(ctx, &todo.Todo{UserID: userID, Title: title, Status: todo.StatusInbox})
+		assert.NoError(t, err)
+	}
+	_, _ = repository.Save(ctx, &todo.Todo{UserID: userID, Title: "done", Status: todo.StatusDone})
+	req := todo.ListTodosRequest{UserID: userID, Status: []string{todo.StatusInbox}, Sort: "title", Limit: 3}
+
+	// when
+	first, firstErr := service.List(ctx, req)
+	req.Cursor = *first.NextCursor
+	second, secondErr := service.List(ctx, req)
+
+	// then
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, 4, first.Total)
+	assert.Equal(t, []string{"a", "b", "c"}, []string{first.Todos[0].Title, first.Todos[1].Title, first.Todos[2].Title})
+	assert.Len(t, second.Todos, 1)
+	assert.Equal(t, "d", second.Todos[0].Title)
+	assert.Nil(t, second.NextCursor)
+}
+
+func TestTodoRepository_RemindersFollowTheUsersTimezoneAcrossDST(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	testhelper.GetTestDB().MustExec("UPDATE users SET timezone = 'America/New_York' WHERE id = $1", userID)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	dstStarts := time.Date(2027, 3, 14, 0, 0, 0, 0, time.UTC)
+	timed, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Dentist", Status: todo.StatusInbox, DueDate: &dstStarts, DueTime: ptr("10:00")})
+	dateOnly, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Taxes", Status: todo.StatusInbox, DueDate: &dstStarts})
+
+	// when
+	timedReminders, timedErr := repository.ReplaceReminders(ctx, timed.ID, []int{60 * 12, 60})
+	dateOnlyReminders, dateOnlyErr := repository.ReplaceReminders(ctx, dateOnly.ID, []int{0})
+	kept, keptErr := repository.ReplaceReminders(ctx, timed.ID, []int{60})
+
+	// then
+	assert.NoError(t, timedErr)
+	assert.NoError(t, dateOnlyErr)
+	assert.NoError(t, keptErr)
+	assert.Equal(t, time.Date(2027, 3, 14, 2, 0, 0, 0, time.UTC), timedReminders[0].RemindAt.UTC(), "12 hours before 10:00 EDT is 21:00 EST the day before")
+	assert.Equal(t, time.Date(2027, 3, 14, 13, 0, 0, 0, time.UTC), timedReminders[1].RemindAt.UTC())
+	assert.Equal(t, time.Date(2027, 3, 14, 13, 0, 0, 0, time.UTC), dateOnlyReminders[0].RemindAt.UTC(), "09:00 EDT")
+	assert.Len(t, kept, 1)
+	assert.Equal(t, 60, kept[0].MinutesBefore)
+}
+
+func TestTodoRepository_RecurrenceRoundTrips(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	testhelper.GetTestDB().MustExec("UPDATE users SET timezone = 'Asia/Seoul' WHERE id = $1", userID)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	due := time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	// when
+	saved, saveErr := repository.Save(ctx, &todo.Todo{
+		UserID: userID, Title: "Weekly report", Status: todo.StatusNextActions, DueDate: &due,
+		Recurrence: ptr("FREQ=WEEKLY;BYDAY=MO"), Occurrence: 3,
+	})
+	plain, plainErr := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Once", Status: todo.StatusInbox})
+	timezone, timezoneErr := repository.UserTimezone(ctx, userID)
+
+	// then
+	assert.NoError(t, saveErr)
+	assert.NoError(t, plainErr)
+	assert.NoError(t, timezoneErr)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", *saved.Recurrence)
+	assert.Equal(t, todo.RecurrenceSchedule, saved.RecurrenceMode)
+	assert.Equal(t, 3, saved.Occurrence)
+	assert.Nil(t, plain.Recurrence)
+	assert.Equal(t, 1, plain.Occurrence)
+	assert.Equal(t, "Asia/Seoul", timezone)
+}
+
+func TestTodoRepository_FollowUpReminderTracksTheFollowUpDate(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	testhelper.GetTestDB().MustExec("UPDATE users SET timezone = 'Asia/Seoul' WHERE id = $1", userID)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	followUp := time.Now().AddDate(0, 0, 7).UTC().Truncate(24 * time.Hour)
+	waiting, _ := repository.Save(ctx, &todo.Todo{
+		UserID: userID, Title: "Hear back from landlord", Status: todo.StatusWaitingFor,
+		DelegatedTo: ptr("Landlord"), FollowUpDate: &followUp,
+	})
+	remindAt := func() []time.Time {
+		var at []time.Time
+		_ = testhelper.GetTestDB().Select(&at, "SELECT remind_at FROM reminders WHERE todo_id = $1 AND kind = 'follow_up'", waiting.ID)
+		return at
+	}
+
+	// when
+	scheduleErr := repository.ScheduleFollowUp(ctx, waiting.ID)
+	scheduled := remindAt()
+	found, findErr := repository.FindWaiting(ctx, userID, ptr("landlord"))
+	waiting.Status = todo.StatusNextActions
+	moved, _ := repository.Update(ctx, waiting)
+	_ = repository.ScheduleFollowUp(ctx, moved.ID)
+
+	// then
+	assert.NoError(t, scheduleErr)
+	assert.NoError(t, findErr)
+	assert.Equal(t, []time.Time{followUp.Add(-9 * time.Hour)}, []time.Time{scheduled[0].UTC()}, "09:00 in Seoul")
+	assert.Len(t, found, 1)
+	assert.Empty(t, found[0].Reminders, "the follow-up is not one of the todo's own reminders")
+	assert.Empty(t, remindAt())
+}
+
+func TestTodoRepository_ChecklistKeepsItsOrderAndBumpsTheTodo(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	saved, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Prepare release", Status: todo.StatusNextActions})
+	first := &todo.ChecklistItem{TodoID: saved.ID, Title: "Write notes", Position: 0}
+	second := &todo.ChecklistItem{TodoID: saved.ID, Title: "Tag the commit", Position: 0}
+	third := &todo.ChecklistItem{TodoID: saved.ID, Title: "Announce", Checked: true, Position: 2}
+	_ = repository.SaveChecklistItem(ctx, first)
+	_ = repository.SaveChecklistItem(ctx, second)
+	_ = repository.SaveChecklistItem(ctx, third)
+	titles := func(items []todo.ChecklistItem) []string {
+		names := make([]string, len(items))
+		for i, item := range items {
+			names[i] = item.Title
+		}
+		return names
+	}
+
+	// when
+	inserted, _ := repository.TouchChecklist(ctx, saved.ID)
+	reorderErr := repository.ReorderChecklist(ctx, saved.ID, []int{first.ID, third.ID, second.ID})
+	deleteErr := repository.DeleteChecklistItem(ctx, third)
+	touched, touchErr := repository.TouchChecklist(ctx, saved.ID)
+	var checklistText string
+	_ = testhelper.GetTestDB().Get(&checklistText, "SELECT checklist_text FROM todos WHERE id = $1", saved.ID)
+
+	// then
+	assert.NoError(t, reorderErr)
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, touchErr)
+	assert.Equal(t, []string{"Tag the commit", "Write notes", "Announce"}, titles(inserted.Checklist), "inserting at 0 moves the rest down")
+	assert.NotNil(t, inserted.Checklist[2].CheckedAt)
+	assert.Equal(t, []string{"Write notes", "Tag the commit"}, titles(touched.Checklist))
+	assert.Equal(t, 1, touched.Checklist[1].Position, "deleting closes the gap")
+	assert.Equal(t, saved.Version+2, touched.Version)
+	assert.Equal(t, "Write notes\nTag the commit", checklistText)
+}
+
+func TestTodoRepository_DependenciesFormAnAcyclicGraph(t *testing.T) {
+	// given
+	testhelper.CleanUp()
+	userID := insertUser(t)
+	repository := todo.NewTodoRepository(testhelper.GetTestDB())
+	ctx := context.Background()
+	design, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Design", Status: todo.StatusNextActions})
+	build, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Build", Status: todo.StatusSomeday})
+	ship, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Ship", Status: todo.StatusSomeday})
+	unrelated, _ := repository.Save(ctx, &todo.Todo{UserID: userID, Title: "Unrelated", Status: todo.StatusInbox})
+
+	// when
+	_ = repository.AddDependency(ctx, build.ID, design.ID)
+	_ = repository.AddDependency(ctx, ship.ID, build.ID)
+	_ = repository.AddDependency(ctx, ship.ID, build.ID)
+	cycle, cycleErr := repository.CreatesCycle(ctx, design.ID, ship.ID)
+	fine, _ := repository.CreatesCycle(ctx, unrelated.ID, ship.ID)
+	graph, graphErr := repository.FindDependencyGraph(ctx, userID, build.ID)
+	blocked, _ := repository.FindByID(ctx, userID, build.ID)
+	design.Status = todo.StatusDone
+	_, _ = repository.Update(ctx, design)
+	dependents, touchErr := repository.TouchDependents(ctx, design.ID)
+	removed, _ := repository.RemoveDependency(ctx, ship.ID, build.ID)
+	removedAgain, _ := repository.RemoveDependency(ctx, ship.ID, build.ID)
+
+	// then
+	assert.NoError(t, cycleErr)
+	assert.NoError(t, graphErr)
+	assert.NoError(t, touchErr)
+	assert.True(t, cycle)
+	assert.False(t, fine)
+	assert.Len(t, graph.Nodes, 3)
+	assert.Equal(t, []todo.Dependency{{TodoID: build.ID, BlockerID: design.ID}, {TodoID: ship.ID, BlockerID: build.ID}}, graph.Edges)
+	assert.True(t, blocked.IsBlocked())
+	assert.Equal(t, 2, blocked.Version, "adding the same dependency twice bumps once")
+	assert.Len(t, dependents, 1)
+	assert.False(t, dependents[0].IsBlocked())
+	assert.Equal(t, 3, dependents[0].Version)
+	assert.True(t, removed)
+	assert.False(t, removedAgain)
+}
@@ -0,0 +1,195 @@
+package todo
+
+import (
+	"time"
+	"yangdongju/gtd_todo/internal/etag"
+)
+
+type TodoResponse struct {
+	ID                    int                       `json:"id"`
+	ClientID              *string                   `json:"client_id"`
+	ProjectID             *int                      `json:"project_id"`
+	Title                 string                    `json:"title"`
+	Description           *string                   `json:"description"`
+	Status                string                    `json:"status"`
+	Position              int                       `json:"position"`
+	DueDate               *string                   `json:"due_date"`
+	DueTime               *string                   `json:"due_time"`
+	Recurrence            *string                   `json:"recurrence"`
+	RecurrenceMode        string                    `json:"recurrence_mode"`
+	Occurrence            int                       `json:"occurrence"`
+	DelegatedTo           *string                   `json:"delegated_to"`
+	DelegateContact       *string                   `json:"delegate_contact"`
+	DelegatedDate         *string                   `json:"delegated_date"`
+	FollowUpDate          *string                   `json:"follow_up_date"`
+	Checklist             []ChecklistItemResponse   `json:"checklist"`
+	ChecklistProgress     ChecklistProgressResponse `json:"checklist_progress"`
+	ChecklistAutoComplete bool                      `json:"checklist_auto_complete"`
+	EstimateMinutes       *int                      `json:"estimate_minutes"`
+	Energy                *string                   `json:"energy"`
+	BlockedBy             []int                     `json:"blocked_by"`
+	IsBlocked             bool                      `json:"is_blocked"`
+	Version               int                       `json:"version"`
+	Tags                  []TodoTagResponse         `json:"tags"`
+	Reminders             []ReminderResponse        `json:"reminders"`
+	CreatedAt             time.Time                 `json:"created_at"`
+	UpdatedAt             time.Time                 `json:"updated_at"`
+}
+
+type TodoTagResponse struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+	Kind  string `json:"kind"`
+}
+
+// ReminderResponse is a reminder with the instant it fires, already resolved in the
+// user's timezone.
+type ReminderResponse struct {
+	MinutesBefore int       `json:"minutes_before"`
+	RemindAt      time.Time `json:"remind_at"`
+}
+
+type ChecklistItemResponse struct {
+	ID        int        `json:"id"`
+	Title     string     `json:"title"`
+	Checked   bool       `json:"checked"`
+	Position  int        `json:"position"`
+	CheckedAt *time.Time `json:"checked_at"`
+}
+
+// ChecklistProgressResponse counts the checked steps, as in 3 of 6 done.
+type ChecklistProgressResponse struct {
+	Checked int `json:"checked"`
+	Total   int `json:"total"`
+}
+
+func (r TodoResponse) ETag() string {
+	return etag.FromVersion(r.Version)
+}
+
+// StatusChangeResponse is the moved todo and, when completing it continued a recurring
+// series, the instance created next. Unblocked lists the todos its completion surfaced to
+// next_actions. Its ETag is the moved todo's.
+type StatusChangeResponse struct {
+	Todo      *TodoResponse  `json:"todo"`
+	Next      *TodoResponse  `json:"next"`
+	Unblocked []TodoResponse `json:"unblocked"`
+}
+
+func (r StatusChangeResponse) ETag() string {
+	return r.Todo.ETag()
+}
+
+type TodoListResponse struct {
+	Todos      []TodoResponse `json:"todos"`
+	Total      int            `json:"total"`
+	NextCursor *string        `json:"next_cursor"`
+}
+
+// WaitingListResponse is the Waiting For list. Overdue counts every overdue follow-up,
+// including ones an overdue=false filter leaves out.
+type WaitingListResponse struct {
+	Items   []WaitingItemResponse `json:"items"`
+	Total   int                   `json:"total"`
+	Overdue int                   `json:"overdue"`
+}
+
+// WaitingItemResponse is a waiting_for todo with how long it has waited and how far past
+// its follow-up date it is.
+type WaitingItemResponse struct {
+	Todo        TodoResponse `json:"todo"`
+	DaysWaiting *int         `json:"days_waiting"`
+	Overdue     bool         `json:"overdue"`
+	DaysOverdue int          `json:"days_overdue"`
+}
+
+// ContextGroupResponse holds the todos carrying one context, or no context when Context is nil.
+type ContextGroupResponse struct {
+	Context *TodoTagResponse `json:"context"`
+	Todos   []TodoResponse   `json:"todos"`
+}
+
+type TodosByContextResponse struct {
+	Status string                 `json:"status"`
+	Groups []ContextGroupResponse `json:"groups"`
+}
+
+func toTodoResponse(todo *Todo) *TodoResponse {
+	tags := make([]TodoTagResponse, 0, len(todo.Tags))
+	for _, tag := range todo.Tags {
+		tags = append(tags, toTodoTagResponse(tag))
+	}
+	reminders := make([]ReminderResponse, 0, len(todo.Reminders))
+	for _, reminder := range todo.Reminders {
+		reminders = append(reminders, ReminderResponse{
+			MinutesBefore: reminder.MinutesBefore,
+			RemindAt:      reminder.RemindAt,
+		})
+	}
+	checklist := make([]ChecklistItemResponse, 0, len(todo.Checklist))
+	progress := ChecklistProgressResponse{Total: len(todo.Checklist)}
+	for _, item := range todo.Checklist {
+		checklist = append(checklist, ChecklistItemResponse{
+			ID:        item.ID,
+			Title:     item.Title,
+			Checked:   item.Checked,
+			Position:  item.Position,
+			CheckedAt: item.CheckedAt,
+		})
+		if item.Checked {
+			progress.Checked++
+		}
+	}
+	blockedBy := make([]int, 0, len(todo.Blockers))
+	for _, blocker := range todo.Blockers {
+		blockedBy = append(blockedBy, blocker.ID)
+	}
+	var dueTime *string
+	if todo.DueTime != nil {
+		formatted := formatDueTime(*todo.DueTime)
+		dueTime = &formatted
+	}
+	return &TodoResponse{
+		ID:                    todo.ID,
+		ClientID:              todo.ClientID,
+		ProjectID:             todo.ProjectID,
+		Title:                 todo.Title,
+		Description:           todo.Description,
+		Status:                todo.Status,
+		Position:              todo.Position,
+		DueDate:               formatDate(todo.DueDate),
+		DueTime:               dueTime,
+		Recurrence:            todo.Recurrence,
+		RecurrenceMode:        todo.RecurrenceMode,
+		Occurrence:            todo.Occurrence,
+		DelegatedTo:           todo.DelegatedTo,
+		DelegateContact:       todo.DelegateContact,
+		DelegatedDate:         formatDate(todo.DelegatedDate),
+		FollowUpDate:          formatDate(todo.FollowUpDate),
+		Checklist:             checklist,
+		ChecklistProgress:     progress,
+		ChecklistAutoComplete: todo.ChecklistAutoComplete,
+		EstimateMinutes:       todo.EstimateMinutes,
+		Energy:                todo.Energy,
+		BlockedBy:             blockedBy,
+		IsBlocked:             todo.IsBlocked(),
+		Version:               todo.Version,
+		Tags:                  tags,
+		Reminders:             reminders,
+		CreatedAt:             todo.CreatedAt,
+		UpdatedAt:             todo.UpdatedAt,
+	}
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(dueDateLayout)
+	return &formatted
+}
+
+func toTodoTagResponse(tag TodoTag) TodoTagResponse {
+	return TodoTagResponse{ID: tag.ID, Name: tag.Name, Color: tag.Color, Kind: tag.Kind}
+}
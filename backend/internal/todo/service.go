@@ -0,0 +1,87 @@
+package todo
+
+import (
+	"context"
+	"time"
+	database "yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+type TodoUsecase interface {
+	Create(ctx context.Context, request CreateTodoRequest) (*TodoResponse, error)
+	Get(ctx context.Context, request GetTodoRequest) (*TodoResponse, error)
+	List(ctx context.Context, request ListTodosRequest) (*TodoListResponse, error)
+	ListChanged(ctx context.Context, request ListChangedTodosRequest) ([]TodoResponse, error)
+	ListByContext(ctx context.Context, request ListByContextRequest) (*TodosByContextResponse, error)
+	ListWaiting(ctx context.Context, request ListWaitingRequest) (*WaitingListResponse, error)
+	Update(ctx context.Context, request UpdateTodoRequest) (*TodoResponse, error)
+	ChangeStatus(ctx context.Context, request ChangeStatusRequest) (*StatusChangeResponse, error)
+	Delete(ctx context.Context, request DeleteTodoRequest) (*DeleteTodoResponse, error)
+	AddChecklistItem(ctx context.Context, request AddChecklistItemRequest) (*TodoResponse, error)
+	UpdateChecklistItem(ctx context.Context, request UpdateChecklistItemRequest) (*TodoResponse, error)
+	DeleteChecklistItem(ctx context.Context, request DeleteChecklistItemRequest) (*TodoResponse, error)
+	ReorderChecklist(ctx context.Context, request ReorderChecklistRequest) (*TodoResponse, error)
+	GetDependencies(ctx context.Context, request GetDependenciesRequest) (*DependencyGraphResponse, error)
+	AddDependency(ctx context.Context, request AddDependencyRequest) (*TodoResponse, error)
+	RemoveDependency(ctx context.Context, request RemoveDependencyRequest) (*TodoResponse, error)
+}
+
+type todoService struct {
+	todoRepository TodoRepository
+	txManager      database.TxManager
+	publisher      event.Publisher
+	now            func() time.Time
+}
+
+func NewTodoService(repository TodoRepository, txManager database.TxManager, publisher event.Publisher, now func() time.Time) *todoService {
+	return &todoService{
+		todoRepository: repository,
+		txManager:      txManager,
+		publisher:      publisher,
+		now:            now,
+	}
+}
+
+// findForWrite loads the todo a conditional write targets and checks the version
+// the client based its change on.
+func (s *todoService) findForWrite(ctx context.Context, userID int, id int, expectedVersion int) (*Todo, error) {
+	todo, err := s.todoRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		return nil, NewTodoNotFoundError(id)
+	}
+	if todo.Version != expectedVersion {
+		return nil, NewVersionConflictError(todo, expectedVersion)
+	}
+	return todo, nil
+}
+
+func (s *todoService) checkProject(ctx context.Context, userID int, projectID *int) error {
+	if projectID == nil {
+		return nil
+	}
+	exists, err := s.todoRepository.ProjectExists(ctx, userID, *projectID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return NewInvalidProjectError(*projectID)
+	}
+	return nil
+}
+
+func (s *todoService) checkTags(ctx context.Context, userID int, tagIDs []int) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	exists, err := s.todoRepository.TagsExist(ctx, userID, tagIDs)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return NewInvalidTagError(tagIDs)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package todo
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// ChangeStatus moves the todo to another status column, to the end of it unless a position
+// is given. Completing an instance of a recurring todo creates the next instance where the
+// completed one was, and the rule moves on to it, so completing the same instance again
+// does not repeat it twice. Completing the last open blocker of todos parked in inbox or
+// someday moves them to next_actions. A todo moved to waiting_for can be given its delegation in
+// the same request, and one moved out of it loses its delegation.
+func (s *todoService) ChangeStatus(ctx context.Context, req ChangeStatusRequest) (*StatusChangeResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated, next *Todo
+	var unblocked []*Todo
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		todo, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		previousStatus, previousPosition := todo.Status, todo.Position
+
+		if req.Status != todo.Status {
+			todo.Status = req.Status
+			if req.Position == nil {
+				todo.Position, err = s.todoRepository.NextPosition(ctx, req.UserID, todo.Status)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if req.Position != nil {
+			todo.Position = *req.Position
+		}
+		if todo.Status == StatusDone && previousStatus != StatusDone && todo.Recurrence != nil {
+			if !req.EndRecurrence {
+				if next, err = s.nextInstance(ctx, todo, previousStatus, previousPosition); err != nil {
+					return err
+				}
+			}
+			todo.Recurrence = nil
+		}
+		followUpChanged, err := s.applyDelegation(ctx, todo, previousStatus, req.DelegatedTo, req.DelegateContact, req.DelegatedDate, req.FollowUpDate)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.todoRepository.Update(ctx, todo)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return NewVersionConflictError(todo, expectedVersion)
+		}
+		if followUpChanged {
+			if err := s.todoRepository.ScheduleFollowUp(ctx, updated.ID); err != nil {
+				return err
+			}
+		}
+		eventType := event.TodoUpdated
+		if updated.Status != previousStatus || updated.Position != previousPosition {
+			eventType = event.TodoMoved
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, eventType, updated.ID, toTodoResponse(updated)); err != nil {
+			return err
+		}
+		if unblocked, err = s.refreshDependents(ctx, updated, previousStatus); err != nil {
+			return err
+		}
+
+		if next == nil {
+			return nil
+		}
+		if next, err = s.saveInstance(ctx, next); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, req.UserID, event.TodoCreated, next.ID, toTodoResponse(next))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &StatusChangeResponse{Todo: toTodoResponse(updated), Unblocked: []TodoResponse{}}
+	for _, todo := range unblocked {
+		res.Unblocked = append(res.Unblocked, *toTodoResponse(todo))
+	}
+	if next != nil {
+		res.Next = toTodoResponse(next)
+	}
+	return res, nil
+}
+
+type ChangeStatusRequest struct {
+	UserID          int     `json:"-" auth:"user_id"`
+	ID              int     `json:"-" uri:"id"`
+	IfMatch         string  `json:"-" header:"If-Match"`
+	Status          string  `json:"status" binding:"required,oneof=inbox next_actions in_progress done someday waiting_for"`
+	Position        *int    `json:"position" binding:"omitempty,gte=0"`
+	DelegatedTo     *string `json:"delegated_to" binding:"omitempty,max=255"`
+	DelegateContact *string `json:"delegate_contact" binding:"omitempty,max=255"`
+	DelegatedDate   *string `json:"delegated_date" binding:"omitempty,datetime=2006-01-02"`
+	FollowUpDate    *string `json:"follow_up_date" binding:"omitempty,datetime=2006-01-02"`
+	// EndRecurrence completes a recurring todo without continuing the series, for todos
+	// completed along with their project.
+	EndRecurrence bool `json:"-"`
+}
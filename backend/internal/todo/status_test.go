@@ -0,0 +1,270 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChangeStatus_CompletingARecurringTodoCreatesTheNextInstance(t *testing.T) {
+	// given
+	monday := time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)
+	current := &todo.Todo{
+		ID: 1, UserID: 7, ProjectID: ptr(5), Title: "Weekly report", Status: todo.StatusNextActions, Position: 3,
+		DueDate: &monday, DueTime: ptr("10:00:00"), Recurrence: ptr("FREQ=WEEKLY;BYDAY=MO,TH"),
+		RecurrenceMode: todo.RecurrenceSchedule, Occurrence: 4, Version: 2,
+		Tags:      []todo.TodoTag{{TodoID: 1, ID: 11, Name: "@office", Kind: todo.TagKindContext}},
+		Reminders: []todo.TodoReminder{{TodoID: 1, MinutesBefore: 60}},
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{}, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(9, nil)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("Asia/Seoul", nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.Status == todo.StatusDone && t.Position == 9 && t.Recurrence == nil
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		updated := *t
+		updated.Version++
+		return &updated, nil
+	})
+	mockRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.Status == todo.StatusNextActions && t.Position == 3 && *t.ProjectID == 5 &&
+			t.DueDate.Format(time.DateOnly) == "2027-01-07" && *t.DueTime == "10:00:00" &&
+			*t.Recurrence == "FREQ=WEEKLY;BYDAY=MO,TH" && t.Occurrence == 5
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		saved := *t
+		saved.ID, saved.Version = 2, 1
+		return &saved, nil
+	})
+	mockRepo.EXPECT().ReplaceTags(mock.Anything, 2, []int{11}).Return([]todo.TodoTag{{TodoID: 2, ID: 11, Name: "@office"}}, nil)
+	mockRepo.EXPECT().ReplaceReminders(mock.Anything, 2, []int{60}).Return([]todo.TodoReminder{{TodoID: 2, MinutesBefore: 60}}, nil)
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"2"`, Status: todo.StatusDone,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, todo.StatusDone, res.Todo.Status)
+	assert.Nil(t, res.Todo.Recurrence)
+	assert.Equal(t, `"3"`, res.ETag())
+	assert.Equal(t, "2027-01-07", *res.Next.DueDate)
+	assert.Equal(t, "10:00", *res.Next.DueTime)
+	assert.Len(t, res.Next.Tags, 1)
+	assert.Len(t, res.Next.Reminders, 1)
+	assert.Equal(t, []string{event.TodoMoved, event.TodoCreated}, publisher.types)
+}
+
+func TestChangeStatus_AfterCompletionCountsFromTheLocalDay(t *testing.T) {
+	// given
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Title: "Water plants", Status: todo.StatusInbox, Position: 0,
+		Recurrence: ptr("FREQ=DAILY;INTERVAL=2"), RecurrenceMode: todo.RecurrenceAfterCompletion, Occurrence: 1, Version: 1,
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{}, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(0, nil)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("America/New_York", nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+	mockRepo.EXPECT().Save(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+
+	// 22:30 on 2027-03-13 in New York, already the 14th in UTC.
+	now := func() time.Time { return time.Date(2027, 3, 14, 3, 30, 0, 0, time.UTC) }
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, now)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: todo.StatusDone,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "2027-03-15", *res.Next.DueDate)
+	assert.Nil(t, res.Next.DueTime)
+	assert.Equal(t, todo.StatusInbox, res.Next.Status)
+	assert.Equal(t, 2, res.Next.Occurrence)
+}
+
+func TestChangeStatus_TheLastInstanceEndsTheSeries(t *testing.T) {
+	// given
+	due := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Status: todo.StatusNextActions, DueDate: &due,
+		Recurrence: ptr("FREQ=MONTHLY;COUNT=3"), RecurrenceMode: todo.RecurrenceSchedule, Occurrence: 3, Version: 1,
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{}, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(0, nil)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("UTC", nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.Recurrence == nil
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: todo.StatusDone,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, res.Next)
+	assert.Equal(t, []string{event.TodoMoved}, publisher.types)
+}
+
+func TestChangeStatus_AnUnknownTimezoneReadsTheSeriesInUTC(t *testing.T) {
+	// given
+	due := time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Status: todo.StatusNextActions, Position: 2, DueDate: &due,
+		Recurrence: ptr("FREQ=DAILY"), RecurrenceMode: todo.RecurrenceSchedule, Occurrence: 1, Version: 1,
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{}, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(0, nil)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("Mars/Olympus_Mons", nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+	mockRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.DueDate.Format(time.DateOnly) == "2027-01-05" && t.Occurrence == 2
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		saved := *t
+		saved.ID, saved.Version = 2, 1
+		return &saved, nil
+	})
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: todo.StatusDone,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "2027-01-05", *res.Next.DueDate)
+	assert.Equal(t, []string{event.TodoMoved, event.TodoCreated}, publisher.types)
+}
+
+func TestChangeStatus_EndRecurrenceCompletesWithoutANextInstance(t *testing.T) {
+	// given
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Status: todo.StatusNextActions,
+		Recurrence: ptr("FREQ=DAILY"), RecurrenceMode: todo.RecurrenceSchedule, Occurrence: 1, Version: 1,
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{}, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(0, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.Status == todo.StatusDone && t.Recurrence == nil
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: todo.StatusDone, EndRecurrence: true,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, res.Next)
+	assert.Equal(t, []string{event.TodoMoved}, publisher.types)
+}
+
+func TestChangeStatus_OnlyCompletingContinuesTheSeries(t *testing.T) {
+	due := time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		moveTo int
+	}{
+		{"moving between open columns", todo.StatusInbox, todo.StatusNextActions, 2},
+		{"reordering within done", todo.StatusDone, todo.StatusDone, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			current := &todo.Todo{
+				ID: 1, UserID: 7, Status: tt.from, Position: 1, DueDate: &due,
+				Recurrence: ptr("FREQ=DAILY"), RecurrenceMode: todo.RecurrenceSchedule, Occurrence: 1, Version: 1,
+			}
+			mockRepo := todomocks.NewTodoRepository(t)
+			mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+			mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+				return t.Recurrence != nil
+			})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+				return t, nil
+			})
+			service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+			// when
+			res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+				UserID: 7, ID: 1, IfMatch: `"1"`, Status: tt.to, Position: ptr(tt.moveTo),
+			})
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, res.Todo.Status)
+			assert.Equal(t, tt.moveTo, res.Todo.Position)
+			assert.Nil(t, res.Next)
+		})
+	}
+}
+
+func TestChangeStatus_LeavingWaitingForDropsTheDelegation(t *testing.T) {
+	// given
+	delegated, followUp := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	current := &todo.Todo{
+		ID: 1, UserID: 7, Status: todo.StatusWaitingFor, Version: 1,
+		DelegatedTo: ptr("Mina"), DelegatedDate: &delegated, FollowUpDate: &followUp,
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusNextActions).Return(0, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+	mockRepo.EXPECT().ScheduleFollowUp(mock.Anything, 1).Return(nil)
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.ChangeStatus(context.Background(), todo.ChangeStatusRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: todo.StatusNextActions,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, res.Todo.DelegatedTo)
+	assert.Nil(t, res.Todo.DelegatedDate)
+	assert.Nil(t, res.Todo.FollowUpDate)
+}
@@ -0,0 +1,138 @@
+package todo
+
+import (
+	"context"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+)
+
+// Update applies a partial change on top of the version named in If-Match. A todo that
+// moves to another status without an explicit position goes to the end of that column.
+// TagIDs and Reminders, when sent, replace the todo's tags and reminders. An empty
+// due_date, due_time, recurrence or delegation field clears it.
+func (s *todoService) Update(ctx context.Context, req UpdateTodoRequest) (*TodoResponse, error) {
+	expectedVersion, err := etag.ExpectedVersion(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Todo
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		todo, err := s.findForWrite(ctx, req.UserID, req.ID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		previousStatus, previousPosition := todo.Status, todo.Position
+		if err := s.checkProject(ctx, req.UserID, req.ProjectID); err != nil {
+			return err
+		}
+		if req.TagIDs != nil {
+			if err := s.checkTags(ctx, req.UserID, *req.TagIDs); err != nil {
+				return err
+			}
+		}
+
+		if req.Title != nil {
+			todo.Title = *req.Title
+		}
+		if req.Description != nil {
+			todo.Description = req.Description
+		}
+		if req.ProjectID != nil {
+			todo.ProjectID = req.ProjectID
+		}
+		if req.Status != nil && *req.Status != todo.Status {
+			todo.Status = *req.Status
+			if req.Position == nil {
+				todo.Position, err = s.todoRepository.NextPosition(ctx, req.UserID, todo.Status)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if req.Position != nil {
+			todo.Position = *req.Position
+		}
+		if req.ChecklistAutoComplete != nil {
+			todo.ChecklistAutoComplete = *req.ChecklistAutoComplete
+		}
+		// Zero minutes and an empty energy clear the estimate.
+		if req.EstimateMinutes != nil {
+			todo.EstimateMinutes = req.EstimateMinutes
+			if *req.EstimateMinutes == 0 {
+				todo.EstimateMinutes = nil
+			}
+		}
+		setText(&todo.Energy, req.Energy)
+		dueChanged, err := applyDue(todo, req.DueDate, req.DueTime)
+		if err != nil {
+			return err
+		}
+		if err := applyRecurrence(todo, req.Recurrence, req.RecurrenceMode); err != nil {
+			return err
+		}
+		followUpChanged, err := s.applyDelegation(ctx, todo, previousStatus, req.DelegatedTo, req.DelegateContact, req.DelegatedDate, req.FollowUpDate)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.todoRepository.Update(ctx, todo)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return NewVersionConflictError(todo, expectedVersion)
+		}
+		if req.TagIDs != nil {
+			if updated.Tags, err = s.todoRepository.ReplaceTags(ctx, updated.ID, *req.TagIDs); err != nil {
+				return err
+			}
+		}
+		if err := s.scheduleReminders(ctx, updated, dueChanged, req.Reminders); err != nil {
+			return err
+		}
+		if followUpChanged {
+			if err := s.todoRepository.ScheduleFollowUp(ctx, updated.ID); err != nil {
+				return err
+			}
+		}
+
+		eventType := event.TodoUpdated
+		if updated.Status != previousStatus || updated.Position != previousPosition {
+			eventType = event.TodoMoved
+		}
+		if err := s.publisher.Publish(ctx, req.UserID, eventType, updated.ID, toTodoResponse(updated)); err != nil {
+			return err
+		}
+		_, err = s.refreshDependents(ctx, updated, previousStatus)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTodoResponse(updated), nil
+}
+
+type UpdateTodoRequest struct {
+	UserID                int     `json:"-" auth:"user_id"`
+	ID                    int     `json:"-" uri:"id"`
+	IfMatch               string  `json:"-" header:"If-Match"`
+	Title                 *string `json:"title" binding:"omitempty,min=1,max=500"`
+	Description           *string `json:"description"`
+	ProjectID             *int    `json:"project_id"`
+	Status                *string `json:"status" binding:"omitempty,oneof=inbox next_actions in_progress done someday waiting_for"`
+	Position              *int    `json:"position" binding:"omitempty,gte=0"`
+	TagIDs                *[]int  `json:"tag_ids" binding:"omitempty,max=20"`
+	DueDate               *string `json:"due_date" binding:"omitempty,datetime=2006-01-02|eq="`
+	DueTime               *string `json:"due_time" binding:"omitempty,datetime=15:04|eq="`
+	Reminders             *[]int  `json:"reminders" binding:"omitempty,max=10,dive,gte=0,lte=40320"`
+	Recurrence            *string `json:"recurrence" binding:"omitempty,max=255"`
+	RecurrenceMode        *string `json:"recurrence_mode" binding:"omitempty,oneof=schedule after_completion"`
+	DelegatedTo           *string `json:"delegated_to" binding:"omitempty,max=255"`
+	DelegateContact       *string `json:"delegate_contact" binding:"omitempty,max=255"`
+	DelegatedDate         *string `json:"delegated_date" binding:"omitempty,datetime=2006-01-02|eq="`
+	FollowUpDate          *string `json:"follow_up_date" binding:"omitempty,datetime=2006-01-02|eq="`
+	ChecklistAutoComplete *bool   `json:"checklist_auto_complete"`
+	EstimateMinutes       *int    `json:"estimate_minutes" binding:"omitempty,min=0,max=1440"`
+	Energy                *string `json:"energy" binding:"omitempty,oneof=low med high|eq="`
+}
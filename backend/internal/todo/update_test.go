@@ -0,0 +1,361 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/etag"
+	"yangdongju/gtd_todo/internal/event"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type recordingPublisher struct {
+	types []string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, userID int, eventType string, entityID int, payload any) error {
+	p.types = append(p.types, eventType)
+	return nil
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// ============ Test Cases ============
+
+func TestUpdate_Success(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	current := &todo.Todo{ID: 1, UserID: 7, Title: "old", Status: todo.StatusInbox, Version: 2}
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.Title == "new" && t.Version == 2
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		updated := *t
+		updated.Version++
+		return &updated, nil
+	})
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"2"`, Title: ptr("new"),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "new", res.Title)
+	assert.Equal(t, 3, res.Version)
+	assert.Equal(t, `"3"`, res.ETag())
+	assert.Equal(t, []string{event.TodoUpdated}, publisher.types)
+}
+
+func TestUpdate_StatusChangeMovesToEndOfColumn(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	current := &todo.Todo{ID: 1, UserID: 7, Status: todo.StatusInbox, Position: 0, Version: 1}
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusDone).Return(4, nil)
+	mockRepo.EXPECT().TouchDependents(mock.Anything, 1).Return([]todo.Todo{}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+
+	publisher := &recordingPublisher{}
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, publisher, time.Now)
+
+	// when
+	res, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: ptr(todo.StatusDone),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, todo.StatusDone, res.Status)
+	assert.Equal(t, 4, res.Position)
+	assert.Equal(t, []string{event.TodoMoved}, publisher.types)
+}
+
+func TestUpdate_StaleVersion(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	current := &todo.Todo{ID: 1, UserID: 7, Title: "theirs", Version: 3}
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"2"`, Title: ptr("mine"),
+	})
+
+	// then
+	var conflict *todo.VersionConflictError
+	assert.Nil(t, res)
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "theirs", conflict.Current.Title)
+}
+
+func TestUpdate_ConcurrentWriterWins(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7, Version: 2}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil, nil)
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	_, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"2"`, Title: ptr("mine"),
+	})
+
+	// then
+	var conflict *todo.VersionConflictError
+	assert.ErrorAs(t, err, &conflict)
+}
+
+func TestUpdate_RequiresIfMatch(t *testing.T) {
+	// given
+	service := todo.NewTodoService(todomocks.NewTodoRepository(t), passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	_, missing := service.Update(context.Background(), todo.UpdateTodoRequest{UserID: 7, ID: 1})
+	_, invalid := service.Update(context.Background(), todo.UpdateTodoRequest{UserID: 7, ID: 1, IfMatch: "2"})
+
+	// then
+	assert.ErrorIs(t, missing, etag.ErrMissingIfMatch)
+	assert.ErrorIs(t, invalid, etag.ErrInvalidVersion)
+}
+
+func TestUpdate_UnknownProject(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7, Version: 1}, nil)
+	mockRepo.EXPECT().ProjectExists(mock.Anything, 7, 99).Return(false, nil)
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	_, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, ProjectID: ptr(99),
+	})
+
+	// then
+	var invalidProject *todo.InvalidProjectError
+	assert.ErrorAs(t, err, &invalidProject)
+}
+
+func TestUpdate_MovingTheDueDateReschedulesReminders(t *testing.T) {
+	// given
+	due := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7, Title: "File taxes", Status: todo.StatusInbox, DueDate: &due, Version: 2}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(t *todo.Todo) bool {
+		return t.DueDate.Format("2006-01-02") == "2026-10-30" && *t.DueTime == "14:30"
+	})).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		updated := *t
+		updated.Version++
+		return &updated, nil
+	})
+	mockRepo.EXPECT().RescheduleReminders(mock.Anything, 1).Return([]todo.TodoReminder{
+		{TodoID: 1, MinutesBefore: 60, RemindAt: time.Date(2026, 10, 30, 13, 30, 0, 0, time.UTC)},
+	}, nil)
+
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"2"`, DueDate: ptr("2026-10-30"), DueTime: ptr("14:30"),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-30", *res.DueDate)
+	assert.Equal(t, "14:30", *res.DueTime)
+	assert.Len(t, res.Reminders, 1)
+}
+
+func TestUpdate_DueRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		current todo.Todo
+		req     todo.UpdateTodoRequest
+		message string
+	}{
+		{"time without date", todo.Todo{}, todo.UpdateTodoRequest{DueTime: ptr("14:30")}, "Invalid due date. due_time needs a due_date"},
+		{"clearing the date keeps no time", todo.Todo{DueDate: ptr(time.Now())}, todo.UpdateTodoRequest{DueDate: ptr(""), DueTime: ptr("09:00")}, "Invalid due date. due_time needs a due_date"},
+		{"reminders without date", todo.Todo{}, todo.UpdateTodoRequest{Reminders: &[]int{30}}, "Invalid due date. reminders need a due_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			current := tt.current
+			current.ID, current.UserID, current.Status, current.Version = 1, 7, todo.StatusInbox, 1
+			mockRepo := todomocks.NewTodoRepository(t)
+			mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&current, nil)
+			mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+				return t, nil
+			}).Maybe()
+			service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+			req := tt.req
+			req.UserID, req.ID, req.IfMatch = 7, 1, `"1"`
+
+			// when
+			res, err := service.Update(context.Background(), req)
+
+			// then
+			var invalidDueDate *todo.InvalidDueDateError
+			assert.Nil(t, res)
+			assert.ErrorAs(t, err, &invalidDueDate)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestUpdate_RecurrenceRules(t *testing.T) {
+	due := time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		current todo.Todo
+		req     todo.UpdateTodoRequest
+		message string
+	}{
+		{"unsupported rule", todo.Todo{DueDate: &due}, todo.UpdateTodoRequest{Recurrence: ptr("FREQ=HOURLY")}, "Invalid recurrence. FREQ=HOURLY is not supported"},
+		{"schedule without date", todo.Todo{}, todo.UpdateTodoRequest{Recurrence: ptr("FREQ=WEEKLY")}, "Invalid recurrence. a todo repeating on a schedule needs a due_date"},
+		{"clearing the date of a scheduled series", todo.Todo{DueDate: &due, Recurrence: ptr("FREQ=WEEKLY"), RecurrenceMode: todo.RecurrenceSchedule}, todo.UpdateTodoRequest{DueDate: ptr("")}, "Invalid recurrence. a todo repeating on a schedule needs a due_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			current := tt.current
+			current.ID, current.UserID, current.Status, current.Version = 1, 7, todo.StatusInbox, 1
+			mockRepo := todomocks.NewTodoRepository(t)
+			mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&current, nil)
+			service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+			req := tt.req
+			req.UserID, req.ID, req.IfMatch = 7, 1, `"1"`
+
+			// when
+			res, err := service.Update(context.Background(), req)
+
+			// then
+			var invalidRecurrence *todo.InvalidRecurrenceError
+			assert.Nil(t, res)
+			assert.ErrorAs(t, err, &invalidRecurrence)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestUpdate_StoresTheRuleCanonically(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7, Status: todo.StatusInbox, RecurrenceMode: todo.RecurrenceSchedule, Version: 1}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`,
+		Recurrence: ptr("RRULE:byday=mo;freq=weekly"), RecurrenceMode: ptr(todo.RecurrenceAfterCompletion),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", *res.Recurrence)
+	assert.Equal(t, todo.RecurrenceAfterCompletion, res.RecurrenceMode)
+}
+
+func TestUpdate_ZeroMinutesAndEmptyEnergyClearTheEstimate(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{
+		ID: 1, UserID: 7, Status: todo.StatusNextActions, Version: 1, EstimateMinutes: ptr(30), Energy: ptr("high"),
+	}, nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, EstimateMinutes: ptr(0), Energy: ptr(""),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Nil(t, res.EstimateMinutes)
+	assert.Nil(t, res.Energy)
+}
+
+func TestUpdate_DelegatingRecordsTodayAndSchedulesTheFollowUp(t *testing.T) {
+	// given
+	mockRepo := todomocks.NewTodoRepository(t)
+	current := &todo.Todo{ID: 1, UserID: 7, Status: todo.StatusInbox, Version: 1}
+	mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(current, nil)
+	mockRepo.EXPECT().NextPosition(mock.Anything, 7, todo.StatusWaitingFor).Return(0, nil)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("Asia/Seoul", nil)
+	mockRepo.EXPECT().Update(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, t *todo.Todo) (*todo.Todo, error) {
+		return t, nil
+	})
+	mockRepo.EXPECT().ScheduleFollowUp(mock.Anything, 1).Return(nil)
+	lateEvening := func() time.Time { return time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC) }
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, lateEvening)
+
+	// when
+	res, err := service.Update(context.Background(), todo.UpdateTodoRequest{
+		UserID: 7, ID: 1, IfMatch: `"1"`, Status: ptr(todo.StatusWaitingFor),
+		DelegatedTo: ptr("Mina"), DelegateContact: ptr("mina@example.com"), FollowUpDate: ptr("2026-10-27"),
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "Mina", *res.DelegatedTo)
+	assert.Equal(t, "2026-10-20", *res.DelegatedDate, "already the 20th in Seoul")
+	assert.Equal(t, "2026-10-27", *res.FollowUpDate)
+}
+
+func TestUpdate_DelegationRules(t *testing.T) {
+	tests := []struct {
+		name string
+		req  todo.UpdateTodoRequest
+	}{
+		{"delegating a todo that is not waiting", todo.UpdateTodoRequest{DelegatedTo: ptr("Mina")}},
+		{"following up before delegating", todo.UpdateTodoRequest{
+			Status: ptr(todo.StatusWaitingFor), DelegatedDate: ptr("2026-10-19"), FollowUpDate: ptr("2026-10-18"),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockRepo := todomocks.NewTodoRepository(t)
+			mockRepo.EXPECT().FindByID(mock.Anything, 7, 1).Return(&todo.Todo{ID: 1, UserID: 7, Status: todo.StatusInbox, Version: 1}, nil)
+			mockRepo.EXPECT().NextPosition(mock.Anything, 7, mock.Anything).Return(0, nil).Maybe()
+			service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+			req := tt.req
+			req.UserID, req.ID, req.IfMatch = 7, 1, `"1"`
+
+			// when
+			_, err := service.Update(context.Background(), req)
+
+			// then
+			var invalidDelegationError *todo.InvalidDelegationError
+			assert.ErrorAs(t, err, &invalidDelegationError)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package todo
+
+import "context"
+
+// ListWaiting is the Waiting For list: todos delegated to someone, those whose follow-up
+// date has passed first, most overdue first, then upcoming follow-ups and finally the ones
+// without a follow-up date. Overdue is read on the user's own clock.
+func (s *todoService) ListWaiting(ctx context.Context, req ListWaitingRequest) (*WaitingListResponse, error) {
+	today, err := s.today(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	todos, err := s.todoRepository.FindWaiting(ctx, req.UserID, req.DelegatedTo)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &WaitingListResponse{Items: []WaitingItemResponse{}}
+	for i := range todos {
+		item := WaitingItemResponse{Todo: *toTodoResponse(&todos[i])}
+		if todos[i].DelegatedDate != nil {
+			days := int(today.Sub(*todos[i].DelegatedDate).Hours() / 24)
+			item.DaysWaiting = &days
+		}
+		if followUp := todos[i].FollowUpDate; followUp != nil && followUp.Before(today) {
+			item.Overdue = true
+			item.DaysOverdue = int(today.Sub(*followUp).Hours() / 24)
+			res.Overdue++
+		}
+		if req.Overdue != nil && item.Overdue != *req.Overdue {
+			continue
+		}
+		res.Items = append(res.Items, item)
+	}
+	res.Total = len(res.Items)
+	return res, nil
+}
+
+type ListWaitingRequest struct {
+	UserID      int     `json:"-" auth:"user_id"`
+	DelegatedTo *string `json:"-" form:"delegated_to" binding:"omitempty,max=255"`
+	Overdue     *bool   `json:"-" form:"overdue"`
+}
@@ -0,0 +1,82 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"yangdongju/gtd_todo/internal/todo"
+	todomocks "yangdongju/gtd_todo/internal/todo/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListWaiting_FlagsFollowUpsPastOnTheUsersClock(t *testing.T) {
+	// given
+	date := func(day int) *time.Time {
+		value := time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+		return &value
+	}
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("Asia/Seoul", nil)
+	mockRepo.EXPECT().FindWaiting(mock.Anything, 7, (*string)(nil)).Return([]todo.Todo{
+		{ID: 1, Status: todo.StatusWaitingFor, DelegatedTo: ptr("Mina"), DelegatedDate: date(5), FollowUpDate: date(12)},
+		{ID: 2, Status: todo.StatusWaitingFor, DelegatedTo: ptr("Joon"), DelegatedDate: date(15), FollowUpDate: date(20)},
+		{ID: 3, Status: todo.StatusWaitingFor, DelegatedTo: ptr("Joon")},
+	}, nil)
+	lateEvening := func() time.Time { return time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC) }
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, lateEvening)
+
+	// when
+	res, err := service.ListWaiting(context.Background(), todo.ListWaitingRequest{UserID: 7})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res.Total)
+	assert.Equal(t, 1, res.Overdue)
+	assert.True(t, res.Items[0].Overdue)
+	assert.Equal(t, 8, res.Items[0].DaysOverdue, "the 20th in Seoul")
+	assert.Equal(t, 15, *res.Items[0].DaysWaiting)
+	assert.False(t, res.Items[1].Overdue, "following up today is not overdue yet")
+	assert.Nil(t, res.Items[2].DaysWaiting)
+}
+
+func TestListWaiting_OverdueFilterKeepsTheCount(t *testing.T) {
+	// given
+	past := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("UTC", nil)
+	mockRepo.EXPECT().FindWaiting(mock.Anything, 7, ptr("mina")).Return([]todo.Todo{
+		{ID: 1, Status: todo.StatusWaitingFor, FollowUpDate: &past},
+		{ID: 2, Status: todo.StatusWaitingFor},
+	}, nil)
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, time.Now)
+
+	// when
+	res, err := service.ListWaiting(context.Background(), todo.ListWaitingRequest{UserID: 7, DelegatedTo: ptr("mina"), Overdue: ptr(false)})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+	assert.Equal(t, 2, res.Items[0].Todo.ID)
+	assert.Equal(t, 1, res.Overdue)
+}
+
+func TestListWaiting_UnknownTimezoneFallsBackToUTC(t *testing.T) {
+	// given
+	followUp := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	mockRepo := todomocks.NewTodoRepository(t)
+	mockRepo.EXPECT().UserTimezone(mock.Anything, 7).Return("Mars/Olympus_Mons", nil)
+	mockRepo.EXPECT().FindWaiting(mock.Anything, 7, (*string)(nil)).Return([]todo.Todo{
+		{ID: 1, Status: todo.StatusWaitingFor, FollowUpDate: &followUp},
+	}, nil)
+	lateEvening := func() time.Time { return time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC) }
+	service := todo.NewTodoService(mockRepo, passThroughTxManager{}, &recordingPublisher{}, lateEvening)
+
+	// when
+	res, err := service.ListWaiting(context.Background(), todo.ListWaitingRequest{UserID: 7})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.Overdue, "still the 19th in UTC")
+}
@@ -18,8 +18,16 @@ type Issuer interface {
 	Issue(userId int, email string, duration time.Duration) (string, error)
 }
 
+// TicketIssuer issues tokens limited to one audience, for places a bearer token cannot go.
+type TicketIssuer interface {
+	IssueTicket(userID int, audience string, duration time.Duration) (string, error)
+}
+
 type Parser interface {
+	// Parse accepts session tokens only; tickets are refused.
 	Parse(token string) (*Claims, error)
+	// ParseTicket accepts only tickets issued for audience.
+	ParseTicket(token string, audience string) (*Claims, error)
 }
 
 type tokenService struct {
@@ -42,16 +50,21 @@ func NewTokenService(secretKey string, now func() time.Time) (*tokenService, err
 }
 
 func (service *tokenService) Issue(userID int, email string, duration time.Duration) (string, error) {
+	return service.sign(&Claims{UserID: userID, Email: email}, duration)
+}
+
+func (service *tokenService) IssueTicket(userID int, audience string, duration time.Duration) (string, error) {
+	return service.sign(&Claims{
+		UserID:           userID,
+		RegisteredClaims: jwt.RegisteredClaims{Audience: jwt.ClaimStrings{audience}},
+	}, duration)
+}
+
+func (service *tokenService) sign(claims *Claims, duration time.Duration) (string, error) {
 	now := service.now()
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			Issuer:    "gtd-todo-app",
-		},
-	}
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(duration))
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.Issuer = "gtd-todo-app"
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -64,11 +77,26 @@ func (service *tokenService) Issue(userID int, email string, duration time.Durat
 }
 
 func (service *tokenService) Parse(tokenString string) (*Claims, error) {
-	parser := jwt.NewParser(
+	claims, err := service.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if len(claims.Audience) > 0 {
+		return nil, errors.New("ticket cannot be used as a session token")
+	}
+	return claims, nil
+}
+
+func (service *tokenService) ParseTicket(tokenString string, audience string) (*Claims, error) {
+	return service.parse(tokenString, jwt.WithAudience(audience))
+}
+
+func (service *tokenService) parse(tokenString string, options ...jwt.ParserOption) (*Claims, error) {
+	parser := jwt.NewParser(append([]jwt.ParserOption{
 		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
 		jwt.WithIssuer("gtd-todo-app"),
 		jwt.WithTimeFunc(service.now), // IssuedAt/ExpiresAt 검증 시 기준 시간
-	)
+	}, options...)...)
 	token, err := parser.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (any, error) {
 		return service.secretKey, nil
 	})
@@ -276,3 +276,38 @@ func TestParse_TokenExactlyAtExpiration(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, claims)
 }
+
+// ============ Ticket Tests ============
+
+func TestParseTicket_AcceptsOnlyItsAudience(t *testing.T) {
+	// given
+	fixedTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service, _ := user.NewTokenService("test-secret-key", func() time.Time { return fixedTime })
+	ticket, _ := service.IssueTicket(100, user.StreamAudience, time.Minute)
+	session, _ := service.Issue(100, "valid@example.com", time.Hour)
+
+	// when
+	claims, err := service.ParseTicket(ticket, user.StreamAudience)
+	_, otherAudienceErr := service.ParseTicket(ticket, "collab")
+	_, sessionErr := service.ParseTicket(session, user.StreamAudience)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 100, claims.UserID)
+	assert.Error(t, otherAudienceErr)
+	assert.Error(t, sessionErr)
+}
+
+func TestParse_RefusesTicket(t *testing.T) {
+	// given
+	fixedTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service, _ := user.NewTokenService("test-secret-key", func() time.Time { return fixedTime })
+	ticket, _ := service.IssueTicket(100, user.StreamAudience, time.Minute)
+
+	// when
+	claims, err := service.Parse(ticket)
+
+	// then
+	assert.Error(t, err)
+	assert.Nil(t, claims)
+}
@@ -23,6 +23,14 @@ func NewUserAlreadyExistsError(id int, email string) *UserAlreadyExistsError {
 	}
 }
 
+func NewDuplicateEmailError(email string) *UserAlreadyExistsError {
+	return &UserAlreadyExistsError{
+		Code:      http.StatusBadRequest,
+		Message:   fmt.Sprintf("User already exists. email=%v", email),
+		NestedErr: nil,
+	}
+}
+
 type InvalidCredentialsError struct {
 	Code      int
 	Message   string
@@ -40,3 +48,21 @@ func NewInvalidCredentialsError() *InvalidCredentialsError {
 		NestedErr: nil,
 	}
 }
+
+type UserNotFoundError struct {
+	Code      int
+	Message   string
+	NestedErr error
+}
+
+func (e UserNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewUserNotFoundError(id int) *UserNotFoundError {
+	return &UserNotFoundError{
+		Code:      http.StatusNotFound,
+		Message:   fmt.Sprintf("User not found. id=%v", id),
+		NestedErr: nil,
+	}
+}
@@ -1,8 +1,10 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"yangdongju/gtd_todo/internal/apperror"
 )
 
 type UserHandler struct {
@@ -17,26 +19,49 @@ func NewUserHandler(loginUsecase LoginUsecase, signUpUsecase SignUpUsecase) *Use
 	}
 }
 
-func (h *UserHandler) HandleSignUp(req SignUpRequest) (int, any) {
-	res, err := h.signUpUsecase.SignUp(req)
+func (h *UserHandler) HandleSignUp(ctx context.Context, req SignUpRequest) (int, any) {
+	res, err := h.signUpUsecase.SignUp(ctx, req)
 	if err != nil {
 		return handleError(err)
 	}
 	return http.StatusCreated, res
 }
 
-func (h *UserHandler) HandleLogin(req LoginRequest) (int, any) {
-	res, err := h.loginUsecase.Login(req)
+func (h *UserHandler) HandleLogin(ctx context.Context, req LoginRequest) (int, any) {
+	res, err := h.loginUsecase.Login(ctx, req)
 	if err != nil {
 		return handleError(err)
 	}
 	return http.StatusOK, res
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+// SettingsHandler serves the signed-in user's own settings.
+type SettingsHandler struct {
+	settingsUsecase SettingsUsecase
 }
 
+func NewSettingsHandler(settingsUsecase SettingsUsecase) *SettingsHandler {
+	return &SettingsHandler{settingsUsecase: settingsUsecase}
+}
+
+func (h *SettingsHandler) HandleGet(ctx context.Context, req GetSettingsRequest) (int, any) {
+	res, err := h.settingsUsecase.GetSettings(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+func (h *SettingsHandler) HandleUpdate(ctx context.Context, req UpdateSettingsRequest) (int, any) {
+	res, err := h.settingsUsecase.UpdateSettings(ctx, req)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, res
+}
+
+type ErrorResponse = apperror.ErrorResponse
+
 func handleError(err error) (int, ErrorResponse) {
 	if err == nil {
 		return 0, ErrorResponse{}
@@ -44,12 +69,15 @@ func handleError(err error) (int, ErrorResponse) {
 
 	var userAlreadyExistsError *UserAlreadyExistsError
 	var invalidCredentialsError *InvalidCredentialsError
+	var userNotFoundError *UserNotFoundError
 
 	switch {
 	case errors.As(err, &userAlreadyExistsError):
 		return http.StatusBadRequest, ErrorResponse{Error: err.Error()}
 	case errors.As(err, &invalidCredentialsError):
 		return http.StatusUnauthorized, ErrorResponse{Error: err.Error()}
+	case errors.As(err, &userNotFoundError):
+		return http.StatusNotFound, ErrorResponse{Error: err.Error()}
 	default:
 		return http.StatusInternalServerError, ErrorResponse{Error: err.Error()}
 	}
@@ -1,6 +1,7 @@
 package user_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -11,23 +12,23 @@ import (
 
 // ============ Mock SignUpUsecase ============
 type mockSignUpUsecase struct {
-	signUpFuncStub func(request user.SignUpRequest) (*user.SignUpResponse, error)
+	signUpFuncStub func(ctx context.Context, request user.SignUpRequest) (*user.SignUpResponse, error)
 }
 
-func (m *mockSignUpUsecase) SignUp(request user.SignUpRequest) (*user.SignUpResponse, error) {
+func (m *mockSignUpUsecase) SignUp(ctx context.Context, request user.SignUpRequest) (*user.SignUpResponse, error) {
 	if m.signUpFuncStub != nil {
-		return m.signUpFuncStub(request)
+		return m.signUpFuncStub(ctx, request)
 	}
 	return nil, errors.New("signUpFuncStub not implemented")
 }
 
 type mockLoginUsecase struct {
-	loginFuncStub func(request user.LoginRequest) (*user.LoginResponse, error)
+	loginFuncStub func(ctx context.Context, request user.LoginRequest) (*user.LoginResponse, error)
 }
 
-func (m *mockLoginUsecase) Login(request user.LoginRequest) (*user.LoginResponse, error) {
+func (m *mockLoginUsecase) Login(ctx context.Context, request user.LoginRequest) (*user.LoginResponse, error) {
 	if m.loginFuncStub != nil {
-		return m.loginFuncStub(request)
+		return m.loginFuncStub(ctx, request)
 	}
 	return nil, errors.New("loginStub not implemented")
 }
@@ -37,7 +38,7 @@ func (m *mockLoginUsecase) Login(request user.LoginRequest) (*user.LoginResponse
 func TestHandleSignUp_Success(t *testing.T) {
 	// given
 	mockUsecase := &mockSignUpUsecase{
-		signUpFuncStub: func(request user.SignUpRequest) (*user.SignUpResponse, error) {
+		signUpFuncStub: func(ctx context.Context, request user.SignUpRequest) (*user.SignUpResponse, error) {
 			return &user.SignUpResponse{
 				ID:    1,
 				Email: request.Email,
@@ -52,7 +53,7 @@ func TestHandleSignUp_Success(t *testing.T) {
 	}
 
 	// when
-	code, res := handler.HandleSignUp(request)
+	code, res := handler.HandleSignUp(context.Background(), request)
 	signUpRes, ok := res.(*user.SignUpResponse)
 
 	// then
@@ -66,7 +67,7 @@ func TestHandleSignUp_Success(t *testing.T) {
 func TestHandleSignUp_UserAlreadyExists(t *testing.T) {
 	// given
 	mockUsecase := &mockSignUpUsecase{
-		signUpFuncStub: func(request user.SignUpRequest) (*user.SignUpResponse, error) {
+		signUpFuncStub: func(ctx context.Context, request user.SignUpRequest) (*user.SignUpResponse, error) {
 			return nil, user.NewUserAlreadyExistsError(1, request.Email)
 		},
 	}
@@ -78,7 +79,7 @@ func TestHandleSignUp_UserAlreadyExists(t *testing.T) {
 	}
 
 	// when
-	code, res := handler.HandleSignUp(request)
+	code, res := handler.HandleSignUp(context.Background(), request)
 	errRes, ok := res.(user.ErrorResponse)
 
 	// then
@@ -91,7 +92,7 @@ func TestHandleSignUp_UserAlreadyExists(t *testing.T) {
 func TestHandleSignUp_InternalServerError(t *testing.T) {
 	// given
 	mockUsecase := &mockSignUpUsecase{
-		signUpFuncStub: func(request user.SignUpRequest) (*user.SignUpResponse, error) {
+		signUpFuncStub: func(ctx context.Context, request user.SignUpRequest) (*user.SignUpResponse, error) {
 			return nil, errors.New("database connection failed")
 		},
 	}
@@ -102,7 +103,7 @@ func TestHandleSignUp_InternalServerError(t *testing.T) {
 	}
 
 	// when
-	code, res := handler.HandleSignUp(request)
+	code, res := handler.HandleSignUp(context.Background(), request)
 	errRes, ok := res.(user.ErrorResponse)
 
 	// then
@@ -114,7 +115,7 @@ func TestHandleSignUp_InternalServerError(t *testing.T) {
 func TestHandleLogIn_Success(t *testing.T) {
 	// given
 	mockLoginUsecase := mockLoginUsecase{
-		loginFuncStub: func(request user.LoginRequest) (*user.LoginResponse, error) {
+		loginFuncStub: func(ctx context.Context, request user.LoginRequest) (*user.LoginResponse, error) {
 			return &user.LoginResponse{"example_token"}, nil
 		},
 	}
@@ -126,7 +127,7 @@ func TestHandleLogIn_Success(t *testing.T) {
 	}
 
 	// when
-	code, res := handler.HandleLogin(request)
+	code, res := handler.HandleLogin(context.Background(), request)
 	logInResponse, ok := res.(*user.LoginResponse)
 
 	// then
@@ -139,7 +140,7 @@ func TestHandleLogIn_Success(t *testing.T) {
 func TestHandleLogIn_InvalidCredentials(t *testing.T) {
 	// given
 	mockLoginUsecase := &mockLoginUsecase{
-		loginFuncStub: func(request user.LoginRequest) (*user.LoginResponse, error) {
+		loginFuncStub: func(ctx context.Context, request user.LoginRequest) (*user.LoginResponse, error) {
 			return nil, user.NewInvalidCredentialsError()
 		},
 	}
@@ -151,7 +152,7 @@ func TestHandleLogIn_InvalidCredentials(t *testing.T) {
 	}
 
 	// when
-	code, res := handler.HandleLogin(request)
+	code, res := handler.HandleLogin(context.Background(), request)
 	errRes, ok := res.(user.ErrorResponse)
 
 	// then
@@ -163,7 +164,7 @@ func TestHandleLogIn_InvalidCredentials(t *testing.T) {
 func TestHandleLogIn_InternalServerError(t *testing.T) {
 	// given
 	mockLoginUsecase := &mockLoginUsecase{
-		loginFuncStub: func(request user.LoginRequest) (*user.LoginResponse, error) {
+		loginFuncStub: func(ctx context.Context, request user.LoginRequest) (*user.LoginResponse, error) {
 			return nil, errors.New("database connection failed")
 		},
 	}
@@ -175,7 +176,7 @@ func TestHandleLogIn_InternalServerError(t *testing.T) {
 	}
 
 	// when
-	code, res := handler.HandleLogin(request)
+	code, res := handler.HandleLogin(context.Background(), request)
 	errRes, ok := res.(user.ErrorResponse)
 
 	// then
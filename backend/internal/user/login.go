@@ -1,13 +1,14 @@
 package user
 
 import (
+	"context"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
-func (s *userService) Login(request LoginRequest) (*LoginResponse, error) {
-	user, err := s.userRepository.FindUserByEmail(request.Email)
+func (s *userService) Login(ctx context.Context, request LoginRequest) (*LoginResponse, error) {
+	user, err := s.userRepository.FindUserByEmail(ctx, request.Email)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func (s *userService) Login(request LoginRequest) (*LoginResponse, error) {
 }
 
 type LoginUsecase interface {
-	Login(request LoginRequest) (*LoginResponse, error)
+	Login(ctx context.Context, request LoginRequest) (*LoginResponse, error)
 }
 
 type LoginRequest struct {
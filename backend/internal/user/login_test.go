@@ -1,6 +1,7 @@
 package user_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	usermocks "yangdongju/gtd_todo/internal/user/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -33,17 +35,17 @@ func TestLogin_Success(t *testing.T) {
 	expectedToken := "generated.jwt.token"
 
 	// Mock 설정
-	mockRepo.EXPECT().FindUserByEmail(email).Return(expectedUser, nil)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, email).Return(expectedUser, nil)
 	mockIssuer.EXPECT().Issue(expectedUser.ID, expectedUser.Email, 24*time.Hour).Return(expectedToken, nil)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 	request := user.LoginRequest{
 		Email:    email,
 		Password: password,
 	}
 
 	// when
-	response, err := service.Login(request)
+	response, err := service.Login(context.Background(), request)
 
 	// then
 	assert.NoError(t, err)
@@ -61,16 +63,16 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockParser := usermocks.NewParser(t)
 
 	// Mock 설정 - 사용자를 찾지 못함
-	mockRepo.EXPECT().FindUserByEmail(email).Return(nil, nil)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, email).Return(nil, nil)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 	request := user.LoginRequest{
 		Email:    email,
 		Password: password,
 	}
 
 	// when
-	response, err := service.Login(request)
+	response, err := service.Login(context.Background(), request)
 
 	// then
 	assert.Error(t, err)
@@ -92,16 +94,16 @@ func TestLogin_RepositoryError(t *testing.T) {
 	mockParser := usermocks.NewParser(t)
 
 	// Mock 설정 - Repository에서 에러 발생
-	mockRepo.EXPECT().FindUserByEmail(email).Return(nil, repositoryError)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, email).Return(nil, repositoryError)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 	request := user.LoginRequest{
 		Email:    email,
 		Password: password,
 	}
 
 	// when
-	response, err := service.Login(request)
+	response, err := service.Login(context.Background(), request)
 
 	// then
 	assert.Error(t, err)
@@ -127,16 +129,16 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	}
 
 	// Mock 설정
-	mockRepo.EXPECT().FindUserByEmail(email).Return(expectedUser, nil)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, email).Return(expectedUser, nil)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 	request := user.LoginRequest{
 		Email:    email,
 		Password: wrongPassword,
 	}
 
 	// when
-	response, err := service.Login(request)
+	response, err := service.Login(context.Background(), request)
 
 	// then
 	assert.Error(t, err)
@@ -165,17 +167,17 @@ func TestLogin_TokenIssueError(t *testing.T) {
 	}
 
 	// Mock 설정
-	mockRepo.EXPECT().FindUserByEmail(email).Return(expectedUser, nil)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, email).Return(expectedUser, nil)
 	mockIssuer.EXPECT().Issue(expectedUser.ID, expectedUser.Email, 24*time.Hour).Return("", tokenError)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 	request := user.LoginRequest{
 		Email:    email,
 		Password: password,
 	}
 
 	// when
-	response, err := service.Login(request)
+	response, err := service.Login(context.Background(), request)
 
 	// then
 	assert.Error(t, err)
@@ -5,19 +5,32 @@ import (
 	"log"
 	"os"
 	"time"
+	database "yangdongju/gtd_todo/internal/db"
 
 	"github.com/jmoiron/sqlx"
 )
 
 func IntializeHandler(pool *sqlx.DB) *UserHandler {
 	tokenService := initTokenService()
-	userService := NewUserService(NewUserRepository(pool), tokenService, tokenService)
+	userService := NewUserService(NewUserRepository(pool), database.NewTxManager(pool), tokenService, tokenService)
 	return &UserHandler{
 		signUpUsecase: userService,
 		loginUsecase:  userService,
 	}
 }
 
+func InitializeSettingsHandler(pool *sqlx.DB) *SettingsHandler {
+	return NewSettingsHandler(NewUserService(NewUserRepository(pool), database.NewTxManager(pool), nil, nil))
+}
+
+func InitializeTicketHandler() *TicketHandler {
+	return NewTicketHandler(initTokenService(), time.Now)
+}
+
+func InitializeTokenParser() Parser {
+	return initTokenService()
+}
+
 func initTokenService() *tokenService {
 	JWTSecretKey := os.Getenv("JWT_SECRET_KEY")
 	timeFunc := func() time.Time { return time.Now() }
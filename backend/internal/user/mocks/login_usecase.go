@@ -3,6 +3,7 @@
 package usermocks
 
 import (
+	context "context"
 	user "yangdongju/gtd_todo/internal/user"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,9 +22,9 @@ func (_m *LoginUsecase) EXPECT() *LoginUsecase_Expecter {
 	return &LoginUsecase_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function with given fields: request
-func (_m *LoginUsecase) Login(request user.LoginRequest) (*user.LoginResponse, error) {
-	ret := _m.Called(request)
+// Login provides a mock function with given fields: ctx, request
+func (_m *LoginUsecase) Login(ctx context.Context, request user.LoginRequest) (*user.LoginResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -31,19 +32,19 @@ func (_m *LoginUsecase) Login(request user.LoginRequest) (*user.LoginResponse, e
 
 	var r0 *user.LoginResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(user.LoginRequest) (*user.LoginResponse, error)); ok {
-		return rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, user.LoginRequest) (*user.LoginResponse, error)); ok {
+		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(user.LoginRequest) *user.LoginResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, user.LoginRequest) *user.LoginResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*user.LoginResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(user.LoginRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, user.LoginRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +58,15 @@ type LoginUsecase_Login_Call struct {
 }
 
 // Login is a helper method to define mock.On call
+//   - ctx context.Context
 //   - request user.LoginRequest
-func (_e *LoginUsecase_Expecter) Login(request interface{}) *LoginUsecase_Login_Call {
-	return &LoginUsecase_Login_Call{Call: _e.mock.On("Login", request)}
+func (_e *LoginUsecase_Expecter) Login(ctx interface{}, request interface{}) *LoginUsecase_Login_Call {
+	return &LoginUsecase_Login_Call{Call: _e.mock.On("Login", ctx, request)}
 }
 
-func (_c *LoginUsecase_Login_Call) Run(run func(request user.LoginRequest)) *LoginUsecase_Login_Call {
+func (_c *LoginUsecase_Login_Call) Run(run func(ctx context.Context, request user.LoginRequest)) *LoginUsecase_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(user.LoginRequest))
+		run(args[0].(context.Context), args[1].(user.LoginRequest))
 	})
 	return _c
 }
@@ -74,7 +76,7 @@ func (_c *LoginUsecase_Login_Call) Return(_a0 *user.LoginResponse, _a1 error) *L
 	return _c
 }
 
-func (_c *LoginUsecase_Login_Call) RunAndReturn(run func(user.LoginRequest) (*user.LoginResponse, error)) *LoginUsecase_Login_Call {
+func (_c *LoginUsecase_Login_Call) RunAndReturn(run func(context.Context, user.LoginRequest) (*user.LoginResponse, error)) *LoginUsecase_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
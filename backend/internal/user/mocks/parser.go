@@ -79,6 +79,65 @@ func (_c *Parser_Parse_Call) RunAndReturn(run func(string) (*user.Claims, error)
 	return _c
 }
 
+// ParseTicket provides a mock function with given fields: token, audience
+func (_m *Parser) ParseTicket(token string, audience string) (*user.Claims, error) {
+	ret := _m.Called(token, audience)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseTicket")
+	}
+
+	var r0 *user.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*user.Claims, error)); ok {
+		return rf(token, audience)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *user.Claims); ok {
+		r0 = rf(token, audience)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.Claims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(token, audience)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Parser_ParseTicket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseTicket'
+type Parser_ParseTicket_Call struct {
+	*mock.Call
+}
+
+// ParseTicket is a helper method to define mock.On call
+//   - token string
+//   - audience string
+func (_e *Parser_Expecter) ParseTicket(token interface{}, audience interface{}) *Parser_ParseTicket_Call {
+	return &Parser_ParseTicket_Call{Call: _e.mock.On("ParseTicket", token, audience)}
+}
+
+func (_c *Parser_ParseTicket_Call) Run(run func(token string, audience string)) *Parser_ParseTicket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Parser_ParseTicket_Call) Return(_a0 *user.Claims, _a1 error) *Parser_ParseTicket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Parser_ParseTicket_Call) RunAndReturn(run func(string, string) (*user.Claims, error)) *Parser_ParseTicket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewParser creates a new instance of Parser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewParser(t interface {
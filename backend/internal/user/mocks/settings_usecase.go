@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package usermocks
+
+import (
+	context "context"
+	user "yangdongju/gtd_todo/internal/user"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SettingsUsecase is an autogenerated mock type for the SettingsUsecase type
+type SettingsUsecase struct {
+	mock.Mock
+}
+
+type SettingsUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SettingsUsecase) EXPECT() *SettingsUsecase_Expecter {
+	return &SettingsUsecase_Expecter{mock: &_m.Mock}
+}
+
+// GetSettings provides a mock function with given fields: ctx, request
+func (_m *SettingsUsecase) GetSettings(ctx context.Context, request user.GetSettingsRequest) (*user.SettingsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *user.SettingsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.GetSettingsRequest) (*user.SettingsResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.GetSettingsRequest) *user.SettingsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.SettingsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.GetSettingsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsUsecase_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
+type SettingsUsecase_GetSettings_Call struct {
+	*mock.Call
+}
+
+// GetSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request user.GetSettingsRequest
+func (_e *SettingsUsecase_Expecter) GetSettings(ctx interface{}, request interface{}) *SettingsUsecase_GetSettings_Call {
+	return &SettingsUsecase_GetSettings_Call{Call: _e.mock.On("GetSettings", ctx, request)}
+}
+
+func (_c *SettingsUsecase_GetSettings_Call) Run(run func(ctx context.Context, request user.GetSettingsRequest)) *SettingsUsecase_GetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.GetSettingsRequest))
+	})
+	return _c
+}
+
+func (_c *SettingsUsecase_GetSettings_Call) Return(_a0 *user.SettingsResponse, _a1 error) *SettingsUsecase_GetSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsUsecase_GetSettings_Call) RunAndReturn(run func(context.Context, user.GetSettingsRequest) (*user.SettingsResponse, error)) *SettingsUsecase_GetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSettings provides a mock function with given fields: ctx, request
+func (_m *SettingsUsecase) UpdateSettings(ctx context.Context, request user.UpdateSettingsRequest) (*user.SettingsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSettings")
+	}
+
+	var r0 *user.SettingsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UpdateSettingsRequest) (*user.SettingsResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.UpdateSettingsRequest) *user.SettingsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.SettingsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.UpdateSettingsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsUsecase_UpdateSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSettings'
+type SettingsUsecase_UpdateSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request user.UpdateSettingsRequest
+func (_e *SettingsUsecase_Expecter) UpdateSettings(ctx interface{}, request interface{}) *SettingsUsecase_UpdateSettings_Call {
+	return &SettingsUsecase_UpdateSettings_Call{Call: _e.mock.On("UpdateSettings", ctx, request)}
+}
+
+func (_c *SettingsUsecase_UpdateSettings_Call) Run(run func(ctx context.Context, request user.UpdateSettingsRequest)) *SettingsUsecase_UpdateSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(user.UpdateSettingsRequest))
+	})
+	return _c
+}
+
+func (_c *SettingsUsecase_UpdateSettings_Call) Return(_a0 *user.SettingsResponse, _a1 error) *SettingsUsecase_UpdateSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsUsecase_UpdateSettings_Call) RunAndReturn(run func(context.Context, user.UpdateSettingsRequest) (*user.SettingsResponse, error)) *SettingsUsecase_UpdateSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSettingsUsecase creates a new instance of SettingsUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSettingsUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SettingsUsecase {
+	mock := &SettingsUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
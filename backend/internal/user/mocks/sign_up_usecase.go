@@ -3,6 +3,7 @@
 package usermocks
 
 import (
+	context "context"
 	user "yangdongju/gtd_todo/internal/user"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,29 +22,29 @@ func (_m *SignUpUsecase) EXPECT() *SignUpUsecase_Expecter {
 	return &SignUpUsecase_Expecter{mock: &_m.Mock}
 }
 
-// signUp provides a mock function with given fields: request
-func (_m *SignUpUsecase) signUp(request user.SignUpRequest) (*user.SignUpResponse, error) {
-	ret := _m.Called(request)
+// SignUp provides a mock function with given fields: ctx, request
+func (_m *SignUpUsecase) SignUp(ctx context.Context, request user.SignUpRequest) (*user.SignUpResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for signUp")
+		panic("no return value specified for SignUp")
 	}
 
 	var r0 *user.SignUpResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(user.SignUpRequest) (*user.SignUpResponse, error)); ok {
-		return rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, user.SignUpRequest) (*user.SignUpResponse, error)); ok {
+		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(user.SignUpRequest) *user.SignUpResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, user.SignUpRequest) *user.SignUpResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*user.SignUpResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(user.SignUpRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, user.SignUpRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,30 +52,31 @@ func (_m *SignUpUsecase) signUp(request user.SignUpRequest) (*user.SignUpRespons
 	return r0, r1
 }
 
-// SignUpUsecase_signUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'signUp'
-type SignUpUsecase_signUp_Call struct {
+// SignUpUsecase_SignUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignUp'
+type SignUpUsecase_SignUp_Call struct {
 	*mock.Call
 }
 
-// signUp is a helper method to define mock.On call
+// SignUp is a helper method to define mock.On call
+//   - ctx context.Context
 //   - request user.SignUpRequest
-func (_e *SignUpUsecase_Expecter) signUp(request interface{}) *SignUpUsecase_signUp_Call {
-	return &SignUpUsecase_signUp_Call{Call: _e.mock.On("signUp", request)}
+func (_e *SignUpUsecase_Expecter) SignUp(ctx interface{}, request interface{}) *SignUpUsecase_SignUp_Call {
+	return &SignUpUsecase_SignUp_Call{Call: _e.mock.On("SignUp", ctx, request)}
 }
 
-func (_c *SignUpUsecase_signUp_Call) Run(run func(request user.SignUpRequest)) *SignUpUsecase_signUp_Call {
+func (_c *SignUpUsecase_SignUp_Call) Run(run func(ctx context.Context, request user.SignUpRequest)) *SignUpUsecase_SignUp_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(user.SignUpRequest))
+		run(args[0].(context.Context), args[1].(user.SignUpRequest))
 	})
 	return _c
 }
 
-func (_c *SignUpUsecase_signUp_Call) Return(_a0 *user.SignUpResponse, _a1 error) *SignUpUsecase_signUp_Call {
+func (_c *SignUpUsecase_SignUp_Call) Return(_a0 *user.SignUpResponse, _a1 error) *SignUpUsecase_SignUp_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SignUpUsecase_signUp_Call) RunAndReturn(run func(user.SignUpRequest) (*user.SignUpResponse, error)) *SignUpUsecase_signUp_Call {
+func (_c *SignUpUsecase_SignUp_Call) RunAndReturn(run func(context.Context, user.SignUpRequest) (*user.SignUpResponse, error)) *SignUpUsecase_SignUp_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package usermocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TicketIssuer is an autogenerated mock type for the TicketIssuer type
+type TicketIssuer struct {
+	mock.Mock
+}
+
+type TicketIssuer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TicketIssuer) EXPECT() *TicketIssuer_Expecter {
+	return &TicketIssuer_Expecter{mock: &_m.Mock}
+}
+
+// IssueTicket provides a mock function with given fields: userID, audience, duration
+func (_m *TicketIssuer) IssueTicket(userID int, audience string, duration time.Duration) (string, error) {
+	ret := _m.Called(userID, audience, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueTicket")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, time.Duration) (string, error)); ok {
+		return rf(userID, audience, duration)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, time.Duration) string); ok {
+		r0 = rf(userID, audience, duration)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, time.Duration) error); ok {
+		r1 = rf(userID, audience, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TicketIssuer_IssueTicket_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueTicket'
+type TicketIssuer_IssueTicket_Call struct {
+	*mock.Call
+}
+
+// IssueTicket is a helper method to define mock.On call
+//   - userID int
+//   - audience string
+//   - duration time.Duration
+func (_e *TicketIssuer_Expecter) IssueTicket(userID interface{}, audience interface{}, duration interface{}) *TicketIssuer_IssueTicket_Call {
+	return &TicketIssuer_IssueTicket_Call{Call: _e.mock.On("IssueTicket", userID, audience, duration)}
+}
+
+func (_c *TicketIssuer_IssueTicket_Call) Run(run func(userID int, audience string, duration time.Duration)) *TicketIssuer_IssueTicket_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *TicketIssuer_IssueTicket_Call) Return(_a0 string, _a1 error) *TicketIssuer_IssueTicket_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TicketIssuer_IssueTicket_Call) RunAndReturn(run func(int, string, time.Duration) (string, error)) *TicketIssuer_IssueTicket_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTicketIssuer creates a new instance of TicketIssuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTicketIssuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TicketIssuer {
+	mock := &TicketIssuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,7 @@
 package usermocks
 
 import (
+	context "context"
 	user "yangdongju/gtd_todo/internal/user"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,9 +22,68 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
-// FindUserByEmail provides a mock function with given fields: email
-func (_m *UserRepository) FindUserByEmail(email string) (*user.User, error) {
-	ret := _m.Called(email)
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *UserRepository) FindByID(ctx context.Context, id int) (*user.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*user.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *user.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type UserRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *UserRepository_Expecter) FindByID(ctx interface{}, id interface{}) *UserRepository_FindByID_Call {
+	return &UserRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *UserRepository_FindByID_Call) Run(run func(ctx context.Context, id int)) *UserRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *UserRepository_FindByID_Call) Return(_a0 *user.User, _a1 error) *UserRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_FindByID_Call) RunAndReturn(run func(context.Context, int) (*user.User, error)) *UserRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUserByEmail provides a mock function with given fields: ctx, email
+func (_m *UserRepository) FindUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	ret := _m.Called(ctx, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindUserByEmail")
@@ -31,19 +91,19 @@ func (_m *UserRepository) FindUserByEmail(email string) (*user.User, error) {
 
 	var r0 *user.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*user.User, error)); ok {
-		return rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*user.User, error)); ok {
+		return rf(ctx, email)
 	}
-	if rf, ok := ret.Get(0).(func(string) *user.User); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *user.User); ok {
+		r0 = rf(ctx, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*user.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +117,15 @@ type UserRepository_FindUserByEmail_Call struct {
 }
 
 // FindUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
 //   - email string
-func (_e *UserRepository_Expecter) FindUserByEmail(email interface{}) *UserRepository_FindUserByEmail_Call {
-	return &UserRepository_FindUserByEmail_Call{Call: _e.mock.On("FindUserByEmail", email)}
+func (_e *UserRepository_Expecter) FindUserByEmail(ctx interface{}, email interface{}) *UserRepository_FindUserByEmail_Call {
+	return &UserRepository_FindUserByEmail_Call{Call: _e.mock.On("FindUserByEmail", ctx, email)}
 }
 
-func (_c *UserRepository_FindUserByEmail_Call) Run(run func(email string)) *UserRepository_FindUserByEmail_Call {
+func (_c *UserRepository_FindUserByEmail_Call) Run(run func(ctx context.Context, email string)) *UserRepository_FindUserByEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -74,14 +135,14 @@ func (_c *UserRepository_FindUserByEmail_Call) Return(_a0 *user.User, _a1 error)
 	return _c
 }
 
-func (_c *UserRepository_FindUserByEmail_Call) RunAndReturn(run func(string) (*user.User, error)) *UserRepository_FindUserByEmail_Call {
+func (_c *UserRepository_FindUserByEmail_Call) RunAndReturn(run func(context.Context, string) (*user.User, error)) *UserRepository_FindUserByEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function with given fields: _a0
-func (_m *UserRepository) Save(_a0 *user.User) (*user.User, error) {
-	ret := _m.Called(_a0)
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *UserRepository) Save(ctx context.Context, _a1 *user.User) (*user.User, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
@@ -89,19 +150,19 @@ func (_m *UserRepository) Save(_a0 *user.User) (*user.User, error) {
 
 	var r0 *user.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*user.User) (*user.User, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *user.User) (*user.User, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(*user.User) *user.User); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *user.User) *user.User); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*user.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*user.User) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *user.User) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,14 +176,15 @@ type UserRepository_Save_Call struct {
 }
 
 // Save is a helper method to define mock.On call
-//   - _a0 *user.User
-func (_e *UserRepository_Expecter) Save(_a0 interface{}) *UserRepository_Save_Call {
-	return &UserRepository_Save_Call{Call: _e.mock.On("Save", _a0)}
+//   - ctx context.Context
+//   - _a1 *user.User
+func (_e *UserRepository_Expecter) Save(ctx interface{}, _a1 interface{}) *UserRepository_Save_Call {
+	return &UserRepository_Save_Call{Call: _e.mock.On("Save", ctx, _a1)}
 }
 
-func (_c *UserRepository_Save_Call) Run(run func(_a0 *user.User)) *UserRepository_Save_Call {
+func (_c *UserRepository_Save_Call) Run(run func(ctx context.Context, _a1 *user.User)) *UserRepository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*user.User))
+		run(args[0].(context.Context), args[1].(*user.User))
 	})
 	return _c
 }
@@ -132,7 +194,67 @@ func (_c *UserRepository_Save_Call) Return(_a0 *user.User, _a1 error) *UserRepos
 	return _c
 }
 
-func (_c *UserRepository_Save_Call) RunAndReturn(run func(*user.User) (*user.User, error)) *UserRepository_Save_Call {
+func (_c *UserRepository_Save_Call) RunAndReturn(run func(context.Context, *user.User) (*user.User, error)) *UserRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTimezone provides a mock function with given fields: ctx, id, timezone
+func (_m *UserRepository) UpdateTimezone(ctx context.Context, id int, timezone string) (*user.User, error) {
+	ret := _m.Called(ctx, id, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTimezone")
+	}
+
+	var r0 *user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*user.User, error)); ok {
+		return rf(ctx, id, timezone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *user.User); ok {
+		r0 = rf(ctx, id, timezone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_UpdateTimezone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTimezone'
+type UserRepository_UpdateTimezone_Call struct {
+	*mock.Call
+}
+
+// UpdateTimezone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - timezone string
+func (_e *UserRepository_Expecter) UpdateTimezone(ctx interface{}, id interface{}, timezone interface{}) *UserRepository_UpdateTimezone_Call {
+	return &UserRepository_UpdateTimezone_Call{Call: _e.mock.On("UpdateTimezone", ctx, id, timezone)}
+}
+
+func (_c *UserRepository_UpdateTimezone_Call) Run(run func(ctx context.Context, id int, timezone string)) *UserRepository_UpdateTimezone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateTimezone_Call) Return(_a0 *user.User, _a1 error) *UserRepository_UpdateTimezone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_UpdateTimezone_Call) RunAndReturn(run func(context.Context, int, string) (*user.User, error)) *UserRepository_UpdateTimezone_Call {
 	_c.Call.Return(run)
 	return _c
 }
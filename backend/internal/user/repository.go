@@ -1,15 +1,22 @@
 package user
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"time"
+	database "yangdongju/gtd_todo/internal/db"
 
 	"github.com/jmoiron/sqlx"
 )
 
+const emailUniqueConstraint = "users_email_key"
+
 type UserRepository interface {
-	FindUserByEmail(email string) (*User, error)
-	Save(user *User) (*User, error)
+	FindUserByEmail(ctx context.Context, email string) (*User, error)
+	FindByID(ctx context.Context, id int) (*User, error)
+	Save(ctx context.Context, user *User) (*User, error)
+	UpdateTimezone(ctx context.Context, id int, timezone string) (*User, error)
 }
 
 type userRepositoryImpl struct {
@@ -20,19 +27,22 @@ type User struct {
 	ID           int       `db:"id"`
 	Email        string    `db:"email"`
 	PasswordHash string    `db:"password_hash"`
+	Timezone     string    `db:"timezone"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
 }
 
+const userColumns = "id, email, password_hash, timezone, created_at, updated_at"
+
 func NewUserRepository(db *sqlx.DB) *userRepositoryImpl {
 	return &userRepositoryImpl{db: db}
 }
 
-func (r *userRepositoryImpl) FindUserByEmail(email string) (*User, error) {
+func (r *userRepositoryImpl) FindUserByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
 
-	err := r.db.Get(&user, "SELECT * FROM users WHERE email = $1", email)
-	if err == sql.ErrNoRows {
+	err := database.Conn(ctx, r.db).GetContext(ctx, &user, "SELECT "+userColumns+" FROM users WHERE email = $1", email)
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 
@@ -43,16 +53,74 @@ func (r *userRepositoryImpl) FindUserByEmail(email string) (*User, error) {
 	return &user, nil
 }
 
-func (r *userRepositoryImpl) Save(user *User) (*User, error) {
+// Save translates a unique violation on email into UserAlreadyExistsError,
+// so concurrent sign ups with the same email do not surface as internal errors.
+func (r *userRepositoryImpl) Save(ctx context.Context, user *User) (*User, error) {
 	var id int
-	err := r.db.QueryRow(`
-		INSERT INTO users (email, password_hash, created_at)
-		VALUES ($1, $2, $3)
+	err := database.Conn(ctx, r.db).QueryRowxContext(ctx, `
+		INSERT INTO users (email, password_hash, timezone, created_at)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id`,
-		user.Email, user.PasswordHash, user.CreatedAt).Scan(&id)
+		user.Email, user.PasswordHash, user.Timezone, user.CreatedAt).Scan(&id)
+	if database.IsUniqueViolation(err, emailUniqueConstraint) {
+		return nil, NewDuplicateEmailError(user.Email)
+	}
 	if err != nil {
 		return nil, err
 	}
 	user.ID = id
 	return user, nil
 }
+
+func (r *userRepositoryImpl) FindByID(ctx context.Context, id int) (*User, error) {
+	var user User
+	err := database.Conn(ctx, r.db).GetContext(ctx, &user, "SELECT "+userColumns+" FROM users WHERE id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateTimezone moves the reminders that have not fired yet, follow-ups included, to the
+// same wall-clock time in the new timezone. Todos carrying them get a new version, since they show when their
+// reminders fire. It returns nil when the user does not exist.
+func (r *userRepositoryImpl) UpdateTimezone(ctx context.Context, id int, timezone string) (*User, error) {
+	conn := database.Conn(ctx, r.db)
+	var user User
+	err := conn.GetContext(ctx, &user, `
+		UPDATE users SET timezone = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+userColumns,
+		id, timezone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		UPDATE reminders r
+		SET remind_at = CASE WHEN r.kind = 'follow_up'
+				THEN reminder_at(t.follow_up_date, NULL, $2, 0)
+				ELSE reminder_at(t.due_date, t.due_time, $2, r.minutes_before)
+			END,
+			scheduled_at = CURRENT_TIMESTAMP, claimed_until = NULL
+		FROM todos t
+		WHERE r.todo_id = t.id AND t.user_id = $1 AND r.sent_at IS NULL`,
+		id, timezone)
+	if err != nil {
+		return nil, err
+	}
+	_, err = conn.ExecContext(ctx, `
+		UPDATE todos SET version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND id IN (SELECT todo_id FROM reminders WHERE sent_at IS NULL)`,
+		id)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -1,6 +1,8 @@
 package user_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -20,7 +22,7 @@ func TestFindUserByEmail(t *testing.T) {
 
 	userRepository := user.NewUserRepository(testDB)
 
-	foundUser, err := userRepository.FindUserByEmail(email)
+	foundUser, err := userRepository.FindUserByEmail(context.Background(), email)
 
 	assert.Nil(t, err)
 	assert.Equal(t, foundUser.ID, 1)
@@ -33,7 +35,7 @@ func TestFindUserByEmail_NotFound(t *testing.T) {
 	email := "hello@example.com"
 	userRepository := user.NewUserRepository(testDB)
 
-	foundUser, err := userRepository.FindUserByEmail(email)
+	foundUser, err := userRepository.FindUserByEmail(context.Background(), email)
 
 	assert.Nil(t, err)
 	assert.Nil(t, foundUser)
@@ -50,9 +52,30 @@ func TestSave(t *testing.T) {
 		CreatedAt:    time.Now(),
 	}
 
-	savedUser, _ := userRepository.Save(&createdUser)
+	savedUser, _ := userRepository.Save(context.Background(), &createdUser)
 
 	assert.Equal(t, 1, savedUser.ID)
 	assert.Equal(t, savedUser.Email, createdUser.Email)
 	assert.Equal(t, savedUser.PasswordHash, createdUser.PasswordHash)
 }
+
+func TestSave_DuplicateEmail(t *testing.T) {
+	testDB := testhelper.GetTestDB()
+	testhelper.CleanUp()
+	userRepository := user.NewUserRepository(testDB)
+
+	createdUser := user.User{
+		Email:        "hello@example.com",
+		PasswordHash: "password_hash",
+		CreatedAt:    time.Now(),
+	}
+	_, _ = userRepository.Save(context.Background(), &createdUser)
+
+	duplicatedUser := createdUser
+	savedUser, err := userRepository.Save(context.Background(), &duplicatedUser)
+
+	var userAlreadyExistsErr *user.UserAlreadyExistsError
+	assert.Nil(t, savedUser)
+	assert.True(t, errors.As(err, &userAlreadyExistsErr))
+	assert.Contains(t, err.Error(), "hello@example.com")
+}
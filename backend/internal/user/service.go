@@ -1,14 +1,18 @@
 package user
 
+import database "yangdongju/gtd_todo/internal/db"
+
 type userService struct {
 	userRepository UserRepository
+	txManager      database.TxManager
 	tokenIssuer    Issuer
 	tokenParser    Parser
 }
 
-func NewUserService(repository UserRepository, tokenIssuer Issuer, tokenParser Parser) *userService {
+func NewUserService(repository UserRepository, txManager database.TxManager, tokenIssuer Issuer, tokenParser Parser) *userService {
 	return &userService{
 		userRepository: repository,
+		txManager:      txManager,
 		tokenIssuer:    tokenIssuer,
 		tokenParser:    tokenParser,
 	}
@@ -0,0 +1,70 @@
+package user
+
+import "context"
+
+// DefaultTimezone applies to users who did not pick one at sign up.
+const DefaultTimezone = "UTC"
+
+type SettingsUsecase interface {
+	GetSettings(ctx context.Context, request GetSettingsRequest) (*SettingsResponse, error)
+	UpdateSettings(ctx context.Context, request UpdateSettingsRequest) (*SettingsResponse, error)
+}
+
+func (s *userService) GetSettings(ctx context.Context, req GetSettingsRequest) (*SettingsResponse, error) {
+	user, err := s.userRepository.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, NewUserNotFoundError(req.UserID)
+	}
+	return toSettingsResponse(user), nil
+}
+
+// UpdateSettings changes the timezone due dates and reminders are read in. Pending
+// reminders keep their wall-clock time in the new timezone.
+func (s *userService) UpdateSettings(ctx context.Context, req UpdateSettingsRequest) (*SettingsResponse, error) {
+	var user *User
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if req.Timezone == nil {
+			user, err = s.userRepository.FindByID(ctx, req.UserID)
+		} else {
+			user, err = s.userRepository.UpdateTimezone(ctx, req.UserID, *req.Timezone)
+		}
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return NewUserNotFoundError(req.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toSettingsResponse(user), nil
+}
+
+func toSettingsResponse(user *User) *SettingsResponse {
+	return &SettingsResponse{
+		ID:       user.ID,
+		Email:    user.Email,
+		Timezone: user.Timezone,
+	}
+}
+
+type GetSettingsRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+}
+
+type UpdateSettingsRequest struct {
+	UserID   int     `json:"-" auth:"user_id"`
+	Timezone *string `json:"timezone" binding:"omitempty,timezone"`
+}
+
+type SettingsResponse struct {
+	ID       int    `json:"id"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
+}
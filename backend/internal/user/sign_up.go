@@ -1,37 +1,46 @@
 package user
 
 import (
+	"context"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type SignUpUsecase interface {
-	SignUp(request SignUpRequest) (*SignUpResponse, error)
+	SignUp(ctx context.Context, request SignUpRequest) (*SignUpResponse, error)
 }
 
-func (s *userService) SignUp(req SignUpRequest) (*SignUpResponse, error) {
-	foundUser, err := s.userRepository.FindUserByEmail(req.Email)
+func (s *userService) SignUp(ctx context.Context, req SignUpRequest) (*SignUpResponse, error) {
+	passwordHash, err := hashPassword(req.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	if foundUser != nil {
-		return nil, NewUserAlreadyExistsError(foundUser.ID, foundUser.Email)
-	}
+	var savedUser *User
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		foundUser, err := s.userRepository.FindUserByEmail(ctx, req.Email)
+		if err != nil {
+			return err
+		}
 
-	passwordHash, err := hashPassword(req.Password)
-	if err != nil {
-		return nil, err
-	}
+		if foundUser != nil {
+			return NewUserAlreadyExistsError(foundUser.ID, foundUser.Email)
+		}
 
-	newUser := User{
-		Email:        req.Email,
-		PasswordHash: passwordHash,
-		CreatedAt:    time.Now(),
-	}
+		newUser := User{
+			Email:        req.Email,
+			PasswordHash: passwordHash,
+			Timezone:     DefaultTimezone,
+			CreatedAt:    time.Now(),
+		}
+		if req.Timezone != nil {
+			newUser.Timezone = *req.Timezone
+		}
 
-	savedUser, err := s.userRepository.Save(&newUser)
+		savedUser, err = s.userRepository.Save(ctx, &newUser)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +59,9 @@ func hashPassword(password string) (string, error) {
 }
 
 type SignUpRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8"`
+	Email    string  `json:"email" binding:"required,email"`
+	Password string  `json:"password" binding:"required,min=8"`
+	Timezone *string `json:"timezone" binding:"omitempty,timezone"`
 }
 
 type SignUpResponse struct {
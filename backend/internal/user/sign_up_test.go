@@ -1,6 +1,7 @@
 package user_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -12,6 +13,12 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 // ============ Test Cases ============
 
 func TestSignUp_Success(t *testing.T) {
@@ -27,13 +34,13 @@ func TestSignUp_Success(t *testing.T) {
 	}
 
 	// Mock 설정
-	mockRepo.EXPECT().FindUserByEmail(request.Email).Return(nil, nil)
-	mockRepo.EXPECT().Save(mock.MatchedBy(func(u *user.User) bool {
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, request.Email).Return(nil, nil)
+	mockRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(u *user.User) bool {
 		capturedUser = u
 		return u.Email == request.Email &&
 			bcrypt.CompareHashAndPassword([]byte(u.PasswordHash),
 				[]byte(request.Password)) == nil
-	})).RunAndReturn(func(u *user.User) (*user.User, error) {
+	})).RunAndReturn(func(ctx context.Context, u *user.User) (*user.User, error) {
 		return &user.User{
 			ID:           1,
 			Email:        u.Email,
@@ -42,10 +49,10 @@ func TestSignUp_Success(t *testing.T) {
 		}, nil
 	})
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 
 	// when
-	response, err := service.SignUp(request)
+	response, err := service.SignUp(context.Background(), request)
 
 	// then
 	assert.Nil(t, err)
@@ -80,12 +87,12 @@ func TestSignUp_UserAlreadyExists(t *testing.T) {
 	}
 
 	// Mock 설정
-	mockRepo.EXPECT().FindUserByEmail(request.Email).Return(existingUser, nil)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, request.Email).Return(existingUser, nil)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 
 	// when
-	response, err := service.SignUp(request)
+	response, err := service.SignUp(context.Background(), request)
 
 	// then
 	assert.Nil(t, response)
@@ -111,12 +118,12 @@ func TestSignUp_FindUserByEmail_RepositoryError(t *testing.T) {
 	}
 
 	// Mock 설정
-	mockRepo.EXPECT().FindUserByEmail(request.Email).Return(nil, repositoryError)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, request.Email).Return(nil, repositoryError)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 
 	// when
-	response, err := service.SignUp(request)
+	response, err := service.SignUp(context.Background(), request)
 
 	// then
 	assert.Nil(t, response)
@@ -138,16 +145,43 @@ func TestSignUp_Save_RepositoryError(t *testing.T) {
 	}
 
 	// Mock 설정
-	mockRepo.EXPECT().FindUserByEmail(request.Email).Return(nil, nil)
-	mockRepo.EXPECT().Save(mock.Anything).Return(nil, saveError)
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, request.Email).Return(nil, nil)
+	mockRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil, saveError)
 
-	service := user.NewUserService(mockRepo, mockIssuer, mockParser)
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
 
 	// when
-	response, err := service.SignUp(request)
+	response, err := service.SignUp(context.Background(), request)
 
 	// then
 	assert.Nil(t, response)
 	assert.NotNil(t, err)
 	assert.Equal(t, "failed to insert user", err.Error())
 }
+
+func TestSignUp_Save_DuplicateEmail(t *testing.T) {
+	// given
+	mockRepo := usermocks.NewUserRepository(t)
+	mockIssuer := usermocks.NewIssuer(t)
+	mockParser := usermocks.NewParser(t)
+
+	request := user.SignUpRequest{
+		Email:    "race@example.com",
+		Password: "password1234",
+	}
+
+	// 동시 가입으로 조회 이후 다른 요청이 먼저 저장한 경우
+	mockRepo.EXPECT().FindUserByEmail(mock.Anything, request.Email).Return(nil, nil)
+	mockRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil, user.NewDuplicateEmailError(request.Email))
+
+	service := user.NewUserService(mockRepo, passThroughTxManager{}, mockIssuer, mockParser)
+
+	// when
+	response, err := service.SignUp(context.Background(), request)
+
+	// then
+	assert.Nil(t, response)
+	var userAlreadyExistsErr *user.UserAlreadyExistsError
+	assert.True(t, errors.As(err, &userAlreadyExistsErr), "Error should be userAlreadyExistsError type")
+	assert.Contains(t, err.Error(), "race@example.com")
+}
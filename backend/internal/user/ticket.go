@@ -0,0 +1,44 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	// StreamAudience is the audience of tickets that open the event stream. A browser
+	// EventSource cannot send an Authorization header, so it passes a ticket in the URL.
+	StreamAudience = "events"
+	// ticketLifetime keeps a ticket that leaks through a URL, e.g. into an access log,
+	// useless soon after. Reconnects ask for a new one.
+	ticketLifetime = time.Minute
+)
+
+// TicketHandler hands the signed-in user short-lived tickets.
+type TicketHandler struct {
+	ticketIssuer TicketIssuer
+	now          func() time.Time
+}
+
+func NewTicketHandler(ticketIssuer TicketIssuer, now func() time.Time) *TicketHandler {
+	return &TicketHandler{ticketIssuer: ticketIssuer, now: now}
+}
+
+func (h *TicketHandler) HandleIssueStreamTicket(ctx context.Context, req IssueTicketRequest) (int, any) {
+	expiresAt := h.now().Add(ticketLifetime)
+	ticket, err := h.ticketIssuer.IssueTicket(req.UserID, StreamAudience, ticketLifetime)
+	if err != nil {
+		return handleError(err)
+	}
+	return http.StatusOK, TicketResponse{Ticket: ticket, ExpiresAt: expiresAt}
+}
+
+type IssueTicketRequest struct {
+	UserID int `json:"-" auth:"user_id"`
+}
+
+type TicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 	"yangdongju/gtd_todo/internal/config"
 	"yangdongju/gtd_todo/internal/db"
+	"yangdongju/gtd_todo/internal/migrate"
 	"yangdongju/gtd_todo/internal/server"
+	"yangdongju/gtd_todo/migrations"
 )
 
 func main() {
@@ -13,6 +17,20 @@ func main() {
 	if err != nil {
 		log.Fatalf("Database connection failed.\n%v", err)
 	}
-	server.SetupRouter(pool)
-}
+	defer pool.Close()
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrator, err := migrate.NewMigrator(pool, migrations.FS)
+		if err != nil {
+			log.Fatalf("Migrator init failed.\n%v", err)
+		}
+		if err := migrate.RunCommand(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Migration failed.\n%v", err)
+		}
+		return
+	}
 
+	if err := server.NewServer(cfg, pool).Run(); err != nil {
+		log.Fatalf("Server stopped with error.\n%v", err)
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_log_horizon;
+DROP TABLE IF EXISTS events;
@@ -0,0 +1,20 @@
+CREATE TABLE events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_events_user_id_id ON events(user_id, id);
+CREATE INDEX idx_events_created_at ON events(created_at);
+
+-- pruned_through is the highest event id removed by retention. Clients resuming from an
+-- older id have missed events and must refetch.
+CREATE TABLE event_log_horizon (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    pruned_through BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO event_log_horizon DEFAULT VALUES;
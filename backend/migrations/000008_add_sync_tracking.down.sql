@@ -0,0 +1,17 @@
+DROP TRIGGER IF EXISTS todos_tombstone ON todos;
+DROP TRIGGER IF EXISTS todos_change_seq ON todos;
+DROP TRIGGER IF EXISTS projects_tombstone ON projects;
+DROP TRIGGER IF EXISTS projects_change_seq ON projects;
+DROP FUNCTION IF EXISTS record_tombstone();
+DROP FUNCTION IF EXISTS stamp_change_seq();
+DROP FUNCTION IF EXISTS next_sync_cursor(INTEGER);
+DROP TABLE IF EXISTS tombstones;
+DROP INDEX IF EXISTS idx_todos_user_change_seq;
+DROP INDEX IF EXISTS idx_todos_user_client_id;
+DROP INDEX IF EXISTS idx_projects_user_change_seq;
+DROP INDEX IF EXISTS idx_projects_user_client_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE todos DROP COLUMN IF EXISTS client_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE projects DROP COLUMN IF EXISTS client_id;
+ALTER TABLE users DROP COLUMN IF EXISTS sync_cursor;
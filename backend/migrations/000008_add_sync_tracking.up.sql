@@ -0,0 +1,64 @@
+-- sync_cursor is a per-user change counter. Taking the next value locks the user row until
+-- the writing transaction commits, so cursors become visible in the order they were issued.
+ALTER TABLE users ADD COLUMN sync_cursor BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE projects ADD COLUMN client_id UUID;
+ALTER TABLE projects ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE todos ADD COLUMN client_id UUID;
+ALTER TABLE todos ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX idx_projects_user_client_id ON projects(user_id, client_id);
+CREATE INDEX idx_projects_user_change_seq ON projects(user_id, change_seq);
+CREATE UNIQUE INDEX idx_todos_user_client_id ON todos(user_id, client_id);
+CREATE INDEX idx_todos_user_change_seq ON todos(user_id, change_seq);
+
+CREATE TABLE tombstones (
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id UUID,
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+CREATE INDEX idx_tombstones_user_change_seq ON tombstones(user_id, change_seq);
+
+-- Returns NULL when the user is gone, which happens while a user delete cascades.
+CREATE FUNCTION next_sync_cursor(owner INTEGER) RETURNS BIGINT AS $$
+    UPDATE users SET sync_cursor = sync_cursor + 1 WHERE id = owner RETURNING sync_cursor;
+$$ LANGUAGE sql;
+
+-- Stamps every write, including ones the application does not issue itself such as
+-- ON DELETE SET NULL, which also get a new version so conditional writes notice them.
+CREATE FUNCTION stamp_change_seq() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_seq := COALESCE(next_sync_cursor(NEW.user_id), 0);
+    IF TG_OP = 'UPDATE' AND NEW.version = OLD.version THEN
+        NEW.version := OLD.version + 1;
+        NEW.updated_at := CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION record_tombstone() RETURNS TRIGGER AS $$
+DECLARE
+    seq BIGINT := next_sync_cursor(OLD.user_id);
+BEGIN
+    IF seq IS NOT NULL THEN
+        INSERT INTO tombstones (entity_type, entity_id, user_id, client_id, change_seq)
+        VALUES (TG_ARGV[0], OLD.id, OLD.user_id, OLD.client_id, seq);
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER projects_change_seq BEFORE INSERT OR UPDATE ON projects
+    FOR EACH ROW EXECUTE FUNCTION stamp_change_seq();
+CREATE TRIGGER projects_tombstone AFTER DELETE ON projects
+    FOR EACH ROW EXECUTE FUNCTION record_tombstone('project');
+CREATE TRIGGER todos_change_seq BEFORE INSERT OR UPDATE ON todos
+    FOR EACH ROW EXECUTE FUNCTION stamp_change_seq();
+CREATE TRIGGER todos_tombstone AFTER DELETE ON todos
+    FOR EACH ROW EXECUTE FUNCTION record_tombstone('todo');
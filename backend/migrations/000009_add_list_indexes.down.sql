@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_projects_user_updated_at;
+DROP INDEX IF EXISTS idx_projects_user_created_at;
+DROP INDEX IF EXISTS idx_todos_user_updated_at;
+DROP INDEX IF EXISTS idx_todos_user_created_at;
+DROP INDEX IF EXISTS idx_todos_user_board;
@@ -0,0 +1,7 @@
+-- Keyset pagination walks (sort columns, id) within one user, so each default sort gets an
+-- index in exactly that order.
+CREATE INDEX idx_todos_user_board ON todos(user_id, status, position, id);
+CREATE INDEX idx_todos_user_created_at ON todos(user_id, created_at, id);
+CREATE INDEX idx_todos_user_updated_at ON todos(user_id, updated_at, id);
+CREATE INDEX idx_projects_user_created_at ON projects(user_id, created_at, id);
+CREATE INDEX idx_projects_user_updated_at ON projects(user_id, updated_at, id);
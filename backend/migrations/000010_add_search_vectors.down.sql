@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_projects_search_vector;
+DROP INDEX IF EXISTS idx_todos_search_vector;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE todos DROP COLUMN IF EXISTS search_vector;
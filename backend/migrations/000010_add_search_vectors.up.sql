@@ -0,0 +1,14 @@
+-- The 'simple' configuration lowercases without stemming, which suits mixed Korean and
+-- English text; searches match words by prefix, so "우유" still finds "우유를".
+ALTER TABLE todos ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+) STORED;
+
+ALTER TABLE projects ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_todos_search_vector ON todos USING GIN (search_vector);
+CREATE INDEX idx_projects_search_vector ON projects USING GIN (search_vector);
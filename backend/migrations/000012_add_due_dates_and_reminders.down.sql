@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS reminders;
+DROP FUNCTION IF EXISTS reminder_at(DATE, TIME, TEXT, INTEGER);
+DROP FUNCTION IF EXISTS todo_due_at(DATE, TIME, TEXT);
+DROP INDEX IF EXISTS idx_todos_user_due_date;
+ALTER TABLE todos DROP CONSTRAINT IF EXISTS todos_due_time_needs_date;
+ALTER TABLE todos DROP COLUMN IF EXISTS due_time;
+ALTER TABLE todos DROP COLUMN IF EXISTS due_date;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,35 @@
+-- Due dates are wall-clock values in the owner's timezone, so moving to another timezone
+-- keeps "Friday 14:00" meaning Friday 14:00 where the user is.
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE todos ADD COLUMN due_date DATE;
+ALTER TABLE todos ADD COLUMN due_time TIME;
+ALTER TABLE todos ADD CONSTRAINT todos_due_time_needs_date CHECK (due_time IS NULL OR due_date IS NOT NULL);
+
+CREATE INDEX idx_todos_user_due_date ON todos(user_id, due_date) WHERE due_date IS NOT NULL;
+
+-- A todo without a time is due at the end of its day. TIME '24:00' is midnight of the next day.
+CREATE FUNCTION todo_due_at(due_date DATE, due_time TIME, tz TEXT) RETURNS TIMESTAMPTZ AS $$
+    SELECT (due_date + COALESCE(due_time, TIME '24:00')) AT TIME ZONE tz;
+$$ LANGUAGE sql STABLE;
+
+-- Reminders for a todo without a time count back from 09:00 on its day.
+CREATE FUNCTION reminder_at(due_date DATE, due_time TIME, tz TEXT, minutes_before INTEGER) RETURNS TIMESTAMPTZ AS $$
+    SELECT (due_date + COALESCE(due_time, TIME '09:00')) AT TIME ZONE tz - make_interval(mins => minutes_before);
+$$ LANGUAGE sql STABLE;
+
+-- remind_at is recomputed whenever the due date or the owner's timezone changes, and
+-- scheduled_at records when. A reminder whose time had already passed by then never fires.
+CREATE TABLE reminders (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    minutes_before INTEGER NOT NULL CHECK (minutes_before >= 0),
+    remind_at TIMESTAMPTZ NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMPTZ,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    UNIQUE (todo_id, minutes_before)
+);
+
+CREATE INDEX idx_reminders_pending ON reminders(remind_at) WHERE sent_at IS NULL;
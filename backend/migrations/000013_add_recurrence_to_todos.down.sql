@@ -0,0 +1,4 @@
+ALTER TABLE todos DROP CONSTRAINT IF EXISTS todos_scheduled_recurrence_needs_due_date;
+ALTER TABLE todos DROP COLUMN IF EXISTS occurrence;
+ALTER TABLE todos DROP COLUMN IF EXISTS recurrence_mode;
+ALTER TABLE todos DROP COLUMN IF EXISTS recurrence;
@@ -0,0 +1,9 @@
+-- recurrence is an RFC 5545 RRULE. In 'schedule' mode the next instance falls on the rule's
+-- next date after this one's due date; in 'after_completion' mode it counts from the day
+-- this one was completed. occurrence numbers the instances of a series, for COUNT.
+ALTER TABLE todos ADD COLUMN recurrence VARCHAR(255);
+ALTER TABLE todos ADD COLUMN recurrence_mode VARCHAR(20) NOT NULL DEFAULT 'schedule'
+    CHECK (recurrence_mode IN ('schedule', 'after_completion'));
+ALTER TABLE todos ADD COLUMN occurrence INTEGER NOT NULL DEFAULT 1 CHECK (occurrence >= 1);
+ALTER TABLE todos ADD CONSTRAINT todos_scheduled_recurrence_needs_due_date
+    CHECK (recurrence IS NULL OR recurrence_mode = 'after_completion' OR due_date IS NOT NULL);
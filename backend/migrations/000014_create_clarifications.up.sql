@@ -0,0 +1,18 @@
+-- One row per inbox item clarified. The source is copied because trashing the item or
+-- turning it into a project deletes the todo.
+CREATE TABLE clarifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_todo_id INTEGER NOT NULL,
+    source_title VARCHAR(500) NOT NULL,
+    source_description TEXT,
+    decision VARCHAR(20) NOT NULL
+        CHECK (decision IN ('action', 'project', 'someday', 'delegate', 'trash', 'do_now')),
+    todo_id INTEGER REFERENCES todos(id) ON DELETE SET NULL,
+    project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    context VARCHAR(50),
+    delegated_to VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_clarifications_user_created_at ON clarifications(user_id, created_at);
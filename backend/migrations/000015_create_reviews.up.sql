@@ -0,0 +1,28 @@
+-- A review walks through fixed steps in order. completed_at stays null while it is open,
+-- and a user has at most one open review.
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL DEFAULT 'weekly' CHECK (kind IN ('daily', 'weekly', 'monthly')),
+    step VARCHAR(20) NOT NULL DEFAULT 'inbox'
+        CHECK (step IN ('inbox', 'next_actions', 'waiting_for', 'projects', 'someday')),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_reviews_user_open ON reviews(user_id) WHERE completed_at IS NULL;
+CREATE INDEX idx_reviews_user_started_at ON reviews(user_id, started_at);
+
+-- One decision per item and step. The item is not a foreign key, since a decision may
+-- delete it.
+CREATE TABLE review_decisions (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    step VARCHAR(20) NOT NULL,
+    item_type VARCHAR(10) NOT NULL CHECK (item_type IN ('todo', 'project')),
+    item_id INTEGER NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    note TEXT,
+    decided_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (review_id, step, item_type, item_id)
+);
@@ -0,0 +1,18 @@
+-- Per-user thresholds for what counts as drifting, and when the attention digest goes
+-- out. Users without a row get the defaults.
+CREATE TABLE attention_settings (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    inbox_days INTEGER NOT NULL DEFAULT 2 CHECK (inbox_days BETWEEN 1 AND 365),
+    in_progress_days INTEGER NOT NULL DEFAULT 7 CHECK (in_progress_days BETWEEN 1 AND 365),
+    waiting_for_days INTEGER NOT NULL DEFAULT 7 CHECK (waiting_for_days BETWEEN 1 AND 365),
+    project_days INTEGER NOT NULL DEFAULT 7 CHECK (project_days BETWEEN 1 AND 365),
+    digest VARCHAR(10) NOT NULL DEFAULT 'off' CHECK (digest IN ('off', 'daily', 'weekly')),
+    digest_hour INTEGER NOT NULL DEFAULT 8 CHECK (digest_hour BETWEEN 0 AND 23),
+    next_digest_at TIMESTAMPTZ,
+    digest_sent_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT attention_settings_digest_scheduled CHECK ((digest = 'off') = (next_digest_at IS NULL))
+);
+
+CREATE INDEX idx_attention_settings_next_digest_at ON attention_settings(next_digest_at)
+    WHERE next_digest_at IS NOT NULL;
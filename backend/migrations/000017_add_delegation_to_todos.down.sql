@@ -0,0 +1,9 @@
+DELETE FROM reminders WHERE kind = 'follow_up';
+ALTER TABLE reminders DROP CONSTRAINT IF EXISTS reminders_todo_id_kind_minutes_before_key;
+ALTER TABLE reminders ADD CONSTRAINT reminders_todo_id_minutes_before_key UNIQUE (todo_id, minutes_before);
+ALTER TABLE reminders DROP COLUMN IF EXISTS kind;
+DROP INDEX IF EXISTS idx_todos_user_follow_up_date;
+ALTER TABLE todos DROP COLUMN IF EXISTS follow_up_date;
+ALTER TABLE todos DROP COLUMN IF EXISTS delegated_date;
+ALTER TABLE todos DROP COLUMN IF EXISTS delegate_contact;
+ALTER TABLE todos DROP COLUMN IF EXISTS delegated_to;
@@ -0,0 +1,15 @@
+-- Who a waiting_for todo waits on and when to chase them. The dates are wall-clock dates
+-- in the owner's timezone, like due_date.
+ALTER TABLE todos ADD COLUMN delegated_to VARCHAR(255);
+ALTER TABLE todos ADD COLUMN delegate_contact VARCHAR(255);
+ALTER TABLE todos ADD COLUMN delegated_date DATE;
+ALTER TABLE todos ADD COLUMN follow_up_date DATE;
+
+CREATE INDEX idx_todos_user_follow_up_date ON todos(user_id, follow_up_date) WHERE status = 'waiting_for';
+
+-- A follow_up reminder fires at 09:00 on the follow-up date. A todo has at most one, kept
+-- with minutes_before 0.
+ALTER TABLE reminders ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'due'
+    CHECK (kind IN ('due', 'follow_up'));
+ALTER TABLE reminders DROP CONSTRAINT reminders_todo_id_minutes_before_key;
+ALTER TABLE reminders ADD CONSTRAINT reminders_todo_id_kind_minutes_before_key UNIQUE (todo_id, kind, minutes_before);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_todos_search_vector;
+ALTER TABLE todos DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE todos ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+) STORED;
+CREATE INDEX idx_todos_search_vector ON todos USING GIN (search_vector);
+
+ALTER TABLE todos DROP COLUMN IF EXISTS checklist_text;
+ALTER TABLE todos DROP COLUMN IF EXISTS checklist_auto_complete;
+DROP TABLE IF EXISTS checklist_items;
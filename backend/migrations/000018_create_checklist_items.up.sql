@@ -0,0 +1,30 @@
+-- Ordered steps inside a todo. Positions run from 0 without gaps.
+CREATE TABLE checklist_items (
+    id SERIAL PRIMARY KEY,
+    todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    title VARCHAR(500) NOT NULL,
+    checked BOOLEAN NOT NULL DEFAULT false,
+    position INTEGER NOT NULL DEFAULT 0,
+    checked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT checklist_items_checked_at CHECK (checked = (checked_at IS NOT NULL))
+);
+
+CREATE INDEX idx_checklist_items_todo_id_position ON checklist_items(todo_id, position);
+
+-- Whether checking the last open item completes the todo.
+ALTER TABLE todos ADD COLUMN checklist_auto_complete BOOLEAN NOT NULL DEFAULT false;
+
+-- The item titles, one per line, kept with the todo so search matches them like the
+-- description, one weight lower.
+ALTER TABLE todos ADD COLUMN checklist_text TEXT;
+
+DROP INDEX idx_todos_search_vector;
+ALTER TABLE todos DROP COLUMN search_vector;
+ALTER TABLE todos ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(checklist_text, '')), 'C')
+) STORED;
+CREATE INDEX idx_todos_search_vector ON todos USING GIN (search_vector);
@@ -0,0 +1,11 @@
+-- todo_id cannot start until blocker_id is done. Both todos belong to the same user and
+-- the graph stays acyclic; the application checks both before inserting.
+CREATE TABLE todo_dependencies (
+    todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    blocker_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (todo_id, blocker_id),
+    CONSTRAINT todo_dependencies_not_self CHECK (todo_id <> blocker_id)
+);
+
+CREATE INDEX idx_todo_dependencies_blocker_id ON todo_dependencies(blocker_id);
@@ -0,0 +1,3 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS priority;
+ALTER TABLE todos DROP COLUMN IF EXISTS energy;
+ALTER TABLE todos DROP COLUMN IF EXISTS estimate_minutes;
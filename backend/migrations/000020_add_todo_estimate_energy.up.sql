@@ -0,0 +1,10 @@
+-- What a todo takes, for picking one that fits the time and energy at hand. Both are
+-- optional; a todo without them is never filtered out.
+ALTER TABLE todos ADD COLUMN estimate_minutes INTEGER
+    CONSTRAINT todos_estimate_minutes_range CHECK (estimate_minutes BETWEEN 1 AND 1440);
+ALTER TABLE todos ADD COLUMN energy VARCHAR(10)
+    CONSTRAINT todos_energy_level CHECK (energy IN ('low', 'med', 'high'));
+
+-- How much a project's todos weigh when ranking next actions.
+ALTER TABLE projects ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'normal'
+    CONSTRAINT projects_priority_level CHECK (priority IN ('high', 'normal', 'low'));
@@ -0,0 +1,3 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS parent_project_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS area_id;
+DROP TABLE IF EXISTS areas;
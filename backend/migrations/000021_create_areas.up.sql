@@ -0,0 +1,22 @@
+-- Areas of responsibility, such as Work, Health or Family, that group a user's projects.
+CREATE TABLE areas (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#6B7280',
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_areas_user_name ON areas(user_id, LOWER(name));
+
+-- A sub-project lives in the area of its parent. Nesting stays acyclic and at most three
+-- levels deep; the application checks both. Removing an area or a parent leaves the
+-- projects in place, which the change_seq trigger stamps with a new version.
+ALTER TABLE projects ADD COLUMN area_id INTEGER REFERENCES areas(id) ON DELETE SET NULL;
+ALTER TABLE projects ADD COLUMN parent_project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL;
+ALTER TABLE projects ADD CONSTRAINT projects_not_own_parent CHECK (parent_project_id <> id);
+
+CREATE INDEX idx_projects_area_id ON projects(area_id);
+CREATE INDEX idx_projects_parent_project_id ON projects(parent_project_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_projects_user_status;
+ALTER TABLE projects DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS status;
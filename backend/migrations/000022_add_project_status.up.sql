@@ -0,0 +1,8 @@
+-- Where a project is in its life. completed_at is set while the project is done and kept
+-- when a done project is archived; archived_at is set while it is archived.
+ALTER TABLE projects ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'todo'
+    CONSTRAINT projects_status_value CHECK (status IN ('todo', 'in_progress', 'done', 'archived'));
+ALTER TABLE projects ADD COLUMN completed_at TIMESTAMPTZ;
+ALTER TABLE projects ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE INDEX idx_projects_user_status ON projects(user_id, status);
@@ -0,0 +1,2 @@
+-- Headers replayed along with the stored response, such as the ETag of a created todo.
+ALTER TABLE idempotency_keys ADD COLUMN response_headers JSONB;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tombstones_deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS tombstones_pruned_through;
@@ -0,0 +1,6 @@
+-- tombstones_pruned_through is the highest change_seq among the user's tombstones removed
+-- by retention. A client syncing from an older cursor may have missed deletes and is sent
+-- everything again instead.
+ALTER TABLE users ADD COLUMN tombstones_pruned_through BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_tombstones_deleted_at ON tombstones(deleted_at);
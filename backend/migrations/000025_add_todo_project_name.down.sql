@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS idx_todos_search_vector;
+ALTER TABLE todos DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE todos ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(checklist_text, '')), 'C')
+) STORED;
+CREATE INDEX idx_todos_search_vector ON todos USING GIN (search_vector);
+
+DROP TRIGGER IF EXISTS projects_todo_project_names ON projects;
+DROP TRIGGER IF EXISTS todos_project_name ON todos;
+DROP FUNCTION IF EXISTS refresh_todo_project_names();
+DROP FUNCTION IF EXISTS copy_todo_project_name();
+
+DROP TRIGGER todos_change_seq ON todos;
+CREATE TRIGGER todos_change_seq BEFORE INSERT OR UPDATE ON todos
+    FOR EACH ROW EXECUTE FUNCTION stamp_change_seq();
+
+ALTER TABLE todos DROP COLUMN IF EXISTS project_name;
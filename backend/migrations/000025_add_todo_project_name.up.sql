@@ -0,0 +1,47 @@
+-- The name of the todo's project, copied so search can match a todo by its project at the
+-- lowest weight. Triggers keep it current: one on the todo when its project changes, one
+-- on the project when it is renamed.
+ALTER TABLE todos ADD COLUMN project_name VARCHAR(255);
+
+-- Refreshing the copied name is invisible to clients, so it must not move versions or sync
+-- cursors. The flag is local to the transaction that renames the project.
+DROP TRIGGER todos_change_seq ON todos;
+CREATE TRIGGER todos_change_seq BEFORE INSERT OR UPDATE ON todos
+    FOR EACH ROW WHEN (current_setting('gtd.refreshing_project_name', true) IS DISTINCT FROM 'on')
+    EXECUTE FUNCTION stamp_change_seq();
+
+CREATE FUNCTION copy_todo_project_name() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.project_name := (SELECT name FROM projects WHERE id = NEW.project_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION refresh_todo_project_names() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM set_config('gtd.refreshing_project_name', 'on', true);
+    UPDATE todos SET project_name = NEW.name WHERE project_id = NEW.id;
+    PERFORM set_config('gtd.refreshing_project_name', '', true);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todos_project_name BEFORE INSERT OR UPDATE OF project_id ON todos
+    FOR EACH ROW EXECUTE FUNCTION copy_todo_project_name();
+CREATE TRIGGER projects_todo_project_names AFTER UPDATE OF name ON projects
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION refresh_todo_project_names();
+
+SELECT set_config('gtd.refreshing_project_name', 'on', true);
+UPDATE todos t SET project_name = p.name FROM projects p WHERE p.id = t.project_id;
+SELECT set_config('gtd.refreshing_project_name', '', true);
+
+DROP INDEX idx_todos_search_vector;
+ALTER TABLE todos DROP COLUMN search_vector;
+ALTER TABLE todos ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(checklist_text, '')), 'C') ||
+    setweight(to_tsvector('simple', COALESCE(project_name, '')), 'D')
+) STORED;
+CREATE INDEX idx_todos_search_vector ON todos USING GIN (search_vector);
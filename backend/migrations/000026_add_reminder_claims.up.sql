@@ -0,0 +1,4 @@
+-- claimed_until is the lease a scheduler takes on a reminder before delivering it outside
+-- any transaction. Until it runs out no other instance claims the reminder; a scheduler
+-- that dies mid-delivery leaves it to be claimed again once it has.
+ALTER TABLE reminders ADD COLUMN claimed_until TIMESTAMPTZ;
@@ -0,0 +1,4 @@
+-- locked_until bounds how long an unfinished key stays claimed. Past it, a retry of the
+-- same request takes the key over from an owner that died before completing or releasing it.
+ALTER TABLE idempotency_keys ADD COLUMN locked_until TIMESTAMPTZ;
+UPDATE idempotency_keys SET locked_until = created_at + INTERVAL '1 minute' WHERE status_code IS NULL;
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS holds every versioned migration so the binary can migrate without the source tree.
+//
+//go:embed *.sql
+var FS embed.FS
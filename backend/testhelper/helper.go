@@ -1,12 +1,12 @@
 package testhelper
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
-	"runtime"
-	"sort"
 	"strings"
+	"yangdongju/gtd_todo/internal/migrate"
+	"yangdongju/gtd_todo/migrations"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -29,12 +29,13 @@ func GetTestDB() *sqlx.DB {
 }
 
 func CleanUp() {
-	var tables = []string{"users", "todos", "projects"}
+	var tables = []string{"users", "todos", "projects", "rate_limit_buckets", "idempotency_keys", "events", "tombstones", "tags", "todo_tags", "reminders", "clarifications", "reviews", "review_decisions", "attention_settings", "checklist_items", "todo_dependencies", "areas"}
 	var builder = strings.Builder{}
 	for _, tableName := range tables {
 		builder.WriteString("TRUNCATE TABLE ")
 		builder.WriteString(tableName + " RESTART IDENTITY CASCADE;")
 	}
+	builder.WriteString("UPDATE event_log_horizon SET pruned_through = 0;")
 	_, err := testDB.Exec(builder.String())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "DB clean up failed:\n%v", err)
@@ -44,37 +45,17 @@ func CleanUp() {
 }
 
 func runMigrates(db *sqlx.DB) error {
-	_, filename, _, _ := runtime.Caller(0)
-	dir := filepath.Dir(filename)
-	migrationPath := filepath.Join(dir, "../..", "migrations")
-	migrationEntries, err := os.ReadDir(migrationPath)
+	migrator, err := migrate.NewMigrator(db, migrations.FS)
 	if err != nil {
 		return err
 	}
 
-	var sqlFileNames []string
-	for _, entry := range migrationEntries {
-		if strings.HasSuffix(entry.Name(), ".up.sql") {
-			sqlFileNames = append(sqlFileNames, entry.Name())
-		}
-	}
-
-	sort.Strings(sqlFileNames)
-
-	var builder strings.Builder
-	for _, fileName := range sqlFileNames {
-		sqlFilePath := filepath.Join(migrationPath, fileName)
-		bytes, err := os.ReadFile(sqlFilePath)
-		if err != nil {
-			return err
-		}
-		builder.Write(bytes)
-		fmt.Printf("✓ Loaded: %s\n", fileName)
-	}
-
-	_, err = db.Exec(builder.String())
+	applied, err := migrator.Up(context.Background())
 	if err != nil {
 		return err
 	}
+	for _, migration := range applied {
+		fmt.Printf("✓ Applied: %06d_%s\n", migration.Version, migration.Name)
+	}
 	return nil
 }